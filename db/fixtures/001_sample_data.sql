@@ -52,33 +52,33 @@ ON CONFLICT (id) DO NOTHING;
 -- =========================================
 -- Drivers
 -- =========================================
-INSERT INTO drivers (id, full_name, phone, license_no, license_classes, photo, created_at, updated_at) VALUES
-('550e8400-e29b-41d4-a716-446655440401', 'Петров Иван Сергеевич', '+7 (495) 111-22-33', '77АА123456', '["B", "C", "CE"]', NULL, now(), now()),
-('550e8400-e29b-41d4-a716-446655440402', 'Сидоров Алексей Петрович', '+7 (495) 222-33-44', '77ББ654321', '["B", "C"]', NULL, now(), now()),
-('550e8400-e29b-41d4-a716-446655440403', 'Козлов Дмитрий Иванович', '+7 (495) 333-44-55', '77ВВ789012', '["B", "C", "CE", "D"]', NULL, now(), now()),
-('550e8400-e29b-41d4-a716-446655440404', 'Морозов Сергей Александрович', '+7 (495) 444-55-66', '77ГГ345678', '["B", "C"]', NULL, now(), now())
+INSERT INTO drivers (id, full_name, phone, license_no, license_classes, created_at, updated_at) VALUES
+('550e8400-e29b-41d4-a716-446655440401', 'Петров Иван Сергеевич', '+7 (495) 111-22-33', '77АА123456', '["B", "C", "CE"]', now(), now()),
+('550e8400-e29b-41d4-a716-446655440402', 'Сидоров Алексей Петрович', '+7 (495) 222-33-44', '77ББ654321', '["B", "C"]', now(), now()),
+('550e8400-e29b-41d4-a716-446655440403', 'Козлов Дмитрий Иванович', '+7 (495) 333-44-55', '77ВВ789012', '["B", "C", "CE", "D"]', now(), now()),
+('550e8400-e29b-41d4-a716-446655440404', 'Морозов Сергей Александрович', '+7 (495) 444-55-66', '77ГГ345678', '["B", "C"]', now(), now())
 ON CONFLICT (id) DO NOTHING;
 
 -- =========================================
 -- Equipment
 -- =========================================
-INSERT INTO equipment (id, number, type, volume_l, condition, photo, client_object_id, warehouse_id, created_at, updated_at, deleted_at, transport_id) VALUES
+INSERT INTO equipment (id, number, type, volume_l, condition, client_object_id, warehouse_id, created_at, updated_at, deleted_at, transport_id) VALUES
 -- Equipment at client objects
-('550e8400-e29b-41d4-a716-446655440501', 'EQ-001', 'CONTAINER', 1000, 'GOOD', NULL, '550e8400-e29b-41d4-a716-446655440301', NULL, now(), now(), NULL, NULL),
-('550e8400-e29b-41d4-a716-446655440502', 'EQ-002', 'BIN', 200, 'GOOD', NULL, '550e8400-e29b-41d4-a716-446655440302', NULL, now(), now(), NULL, NULL),
-('550e8400-e29b-41d4-a716-446655440503', 'EQ-003', 'CONTAINER', 800, 'GOOD', NULL, '550e8400-e29b-41d4-a716-446655440303', NULL, now(), now(), NULL, NULL),
-('550e8400-e29b-41d4-a716-446655440504', 'EQ-004', 'BIN', 150, 'DAMAGED', NULL, '550e8400-e29b-41d4-a716-446655440304', NULL, now(), now(), NULL, NULL),
-('550e8400-e29b-41d4-a716-446655440505', 'EQ-005', 'CONTAINER', 1200, 'GOOD', NULL, '550e8400-e29b-41d4-a716-446655440305', NULL, now(), now(), NULL, NULL),
+('550e8400-e29b-41d4-a716-446655440501', 'EQ-001', 'CONTAINER', 1000, 'GOOD', '550e8400-e29b-41d4-a716-446655440301', NULL, now(), now(), NULL, NULL),
+('550e8400-e29b-41d4-a716-446655440502', 'EQ-002', 'BIN', 200, 'GOOD', '550e8400-e29b-41d4-a716-446655440302', NULL, now(), now(), NULL, NULL),
+('550e8400-e29b-41d4-a716-446655440503', 'EQ-003', 'CONTAINER', 800, 'GOOD', '550e8400-e29b-41d4-a716-446655440303', NULL, now(), now(), NULL, NULL),
+('550e8400-e29b-41d4-a716-446655440504', 'EQ-004', 'BIN', 150, 'DAMAGED', '550e8400-e29b-41d4-a716-446655440304', NULL, now(), now(), NULL, NULL),
+('550e8400-e29b-41d4-a716-446655440505', 'EQ-005', 'CONTAINER', 1200, 'GOOD', '550e8400-e29b-41d4-a716-446655440305', NULL, now(), now(), NULL, NULL),
 
 -- Equipment at warehouses
-('550e8400-e29b-41d4-a716-446655440506', 'EQ-006', 'BIN', 100, 'GOOD', NULL, NULL, '550e8400-e29b-41d4-a716-446655440201', now(), now(), NULL, NULL),
-('550e8400-e29b-41d4-a716-446655440507', 'EQ-007', 'CONTAINER', 600, 'GOOD', NULL, NULL, '550e8400-e29b-41d4-a716-446655440201', now(), now(), NULL, NULL),
-('550e8400-e29b-41d4-a716-446655440508', 'EQ-008', 'BIN', 300, 'GOOD', NULL, NULL, '550e8400-e29b-41d4-a716-446655440202', now(), now(), NULL, NULL),
-('550e8400-e29b-41d4-a716-446655440509', 'EQ-009', 'CONTAINER', 900, 'OUT_OF_SERVICE', NULL, NULL, '550e8400-e29b-41d4-a716-446655440203', now(), now(), NULL, NULL),
+('550e8400-e29b-41d4-a716-446655440506', 'EQ-006', 'BIN', 100, 'GOOD', NULL, '550e8400-e29b-41d4-a716-446655440201', now(), now(), NULL, NULL),
+('550e8400-e29b-41d4-a716-446655440507', 'EQ-007', 'CONTAINER', 600, 'GOOD', NULL, '550e8400-e29b-41d4-a716-446655440201', now(), now(), NULL, NULL),
+('550e8400-e29b-41d4-a716-446655440508', 'EQ-008', 'BIN', 300, 'GOOD', NULL, '550e8400-e29b-41d4-a716-446655440202', now(), now(), NULL, NULL),
+('550e8400-e29b-41d4-a716-446655440509', 'EQ-009', 'CONTAINER', 900, 'OUT_OF_SERVICE', NULL, '550e8400-e29b-41d4-a716-446655440203', now(), now(), NULL, NULL),
 
 -- Equipment that will be assigned to transport (initially at warehouse)
-('550e8400-e29b-41d4-a716-446655440510', 'EQ-010', 'CONTAINER', 1500, 'GOOD', NULL, NULL, '550e8400-e29b-41d4-a716-446655440201', now(), now(), NULL, NULL),
-('550e8400-e29b-41d4-a716-446655440511', 'EQ-011', 'BIN', 250, 'GOOD', NULL, NULL, '550e8400-e29b-41d4-a716-446655440201', now(), now(), NULL, NULL)
+('550e8400-e29b-41d4-a716-446655440510', 'EQ-010', 'CONTAINER', 1500, 'GOOD', NULL, '550e8400-e29b-41d4-a716-446655440201', now(), now(), NULL, NULL),
+('550e8400-e29b-41d4-a716-446655440511', 'EQ-011', 'BIN', 250, 'GOOD', NULL, '550e8400-e29b-41d4-a716-446655440201', now(), now(), NULL, NULL)
 ON CONFLICT (id) DO NOTHING;
 
 -- =========================================
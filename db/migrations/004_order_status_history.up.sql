@@ -0,0 +1,14 @@
+-- =========================================
+-- Order status history (audit trail of status transitions)
+-- =========================================
+CREATE TABLE IF NOT EXISTS order_status_history (
+  id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  order_id    UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+  from_status TEXT NOT NULL CHECK (from_status IN ('DRAFT','SCHEDULED','IN_PROGRESS','COMPLETED','CANCELED')),
+  to_status   TEXT NOT NULL CHECK (to_status IN ('DRAFT','SCHEDULED','IN_PROGRESS','COMPLETED','CANCELED')),
+  changed_by  UUID REFERENCES users(id) ON DELETE SET NULL,
+  reason      TEXT,
+  changed_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_status_history_order ON order_status_history(order_id, changed_at);
@@ -0,0 +1,10 @@
+-- Remove structured cancellation data from orders
+DROP INDEX IF EXISTS idx_orders_canceled_at;
+DROP INDEX IF EXISTS idx_orders_cancellation_reason;
+ALTER TABLE orders DROP COLUMN IF EXISTS canceled_at;
+ALTER TABLE orders DROP COLUMN IF EXISTS cancellation_note;
+ALTER TABLE orders DROP COLUMN IF EXISTS cancellation_reason_code;
+
+-- Remove cancellation reasons
+DROP TRIGGER IF EXISTS trg_cancellation_reasons_updated_at ON cancellation_reasons;
+DROP TABLE IF EXISTS cancellation_reasons;
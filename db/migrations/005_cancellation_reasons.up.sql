@@ -0,0 +1,35 @@
+-- =========================================
+-- Cancellation reasons (admin-managed taxonomy)
+-- =========================================
+CREATE TABLE IF NOT EXISTS cancellation_reasons (
+  code       TEXT PRIMARY KEY CHECK (code ~ '^[A-Z][A-Z0-9_]*$'),
+  label      TEXT NOT NULL,
+  is_active  BOOLEAN NOT NULL DEFAULT true,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+DO $$
+BEGIN
+  IF NOT EXISTS (SELECT 1 FROM pg_trigger WHERE tgname = 'trg_cancellation_reasons_updated_at') THEN
+    CREATE TRIGGER trg_cancellation_reasons_updated_at BEFORE UPDATE ON cancellation_reasons
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+  END IF;
+END$$;
+
+INSERT INTO cancellation_reasons (code, label) VALUES
+  ('CLIENT_REQUEST',    'Client request'),
+  ('NO_ACCESS',         'No access to site'),
+  ('VEHICLE_BREAKDOWN', 'Vehicle breakdown'),
+  ('WEATHER',           'Weather'),
+  ('DUPLICATE',         'Duplicate order')
+ON CONFLICT (code) DO NOTHING;
+
+-- Structured cancellation data on orders
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS cancellation_reason_code TEXT REFERENCES cancellation_reasons(code);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS cancellation_note TEXT;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS canceled_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_orders_cancellation_reason ON orders(cancellation_reason_code)
+  WHERE cancellation_reason_code IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_orders_canceled_at ON orders(canceled_at) WHERE canceled_at IS NOT NULL;
@@ -0,0 +1,9 @@
+-- Remove schedule reference from orders
+DROP INDEX IF EXISTS uq_orders_schedule_date;
+ALTER TABLE orders DROP COLUMN IF EXISTS schedule_id;
+
+-- Remove recurring order schedules
+DROP TRIGGER IF EXISTS trg_order_schedules_updated_at ON order_schedules;
+DROP INDEX IF EXISTS idx_order_schedules_object;
+DROP INDEX IF EXISTS idx_order_schedules_due;
+DROP TABLE IF EXISTS order_schedules;
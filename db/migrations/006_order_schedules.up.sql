@@ -0,0 +1,44 @@
+-- =========================================
+-- Recurring order schedules
+-- =========================================
+CREATE TABLE IF NOT EXISTS order_schedules (
+  id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  object_id       UUID NOT NULL REFERENCES client_objects(id),
+  rrule           TEXT NOT NULL,
+  start_date      DATE NOT NULL,
+  end_date        DATE,
+  window_from     TIME,
+  window_to       TIME,
+  priority        TEXT NOT NULL DEFAULT 'MEDIUM' CHECK (priority IN ('LOW','MEDIUM','HIGH')),
+  transport_id    UUID REFERENCES transport(id) ON DELETE SET NULL,
+  order_status    TEXT NOT NULL DEFAULT 'DRAFT' CHECK (order_status IN ('DRAFT','SCHEDULED')),
+  notes           TEXT,
+  is_active       BOOLEAN NOT NULL DEFAULT true,
+  generated_until DATE,
+  created_by      UUID,
+  created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+  deleted_at      TIMESTAMPTZ,
+  CONSTRAINT order_schedules_date_range CHECK (end_date IS NULL OR end_date >= start_date)
+);
+
+-- Generator scan: active schedules that still have dates to materialize
+CREATE INDEX IF NOT EXISTS idx_order_schedules_due
+  ON order_schedules(generated_until) WHERE deleted_at IS NULL AND is_active;
+
+CREATE INDEX IF NOT EXISTS idx_order_schedules_object
+  ON order_schedules(object_id) WHERE deleted_at IS NULL;
+
+DO $$
+BEGIN
+  IF NOT EXISTS (SELECT 1 FROM pg_trigger WHERE tgname = 'trg_order_schedules_updated_at') THEN
+    CREATE TRIGGER trg_order_schedules_updated_at BEFORE UPDATE ON order_schedules
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+  END IF;
+END$$;
+
+-- Orders generated from a schedule; at most one live order per schedule and date
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS schedule_id UUID REFERENCES order_schedules(id) ON DELETE SET NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_orders_schedule_date
+  ON orders(schedule_id, scheduled_date) WHERE schedule_id IS NOT NULL AND deleted_at IS NULL;
@@ -0,0 +1,4 @@
+-- Remove order items
+DROP INDEX IF EXISTS uq_order_items_order_equipment;
+DROP INDEX IF EXISTS idx_order_items_order;
+DROP TABLE IF EXISTS order_items;
@@ -0,0 +1,19 @@
+-- =========================================
+-- Order items (equipment operations performed by an order)
+-- =========================================
+CREATE TABLE IF NOT EXISTS order_items (
+  id                       UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  order_id                 UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+  equipment_id             UUID NOT NULL REFERENCES equipment(id),
+  operation                TEXT NOT NULL CHECK (operation IN ('DELIVER','PICKUP','SWAP','EMPTY')),
+  replacement_equipment_id UUID REFERENCES equipment(id),
+  warehouse_id             UUID REFERENCES warehouses(id),
+  created_at               TIMESTAMPTZ NOT NULL DEFAULT now(),
+  -- Only a swap brings a replacement; only equipment taken away can go to a warehouse
+  CONSTRAINT order_items_replacement CHECK ((operation = 'SWAP') = (replacement_equipment_id IS NOT NULL)),
+  CONSTRAINT order_items_warehouse CHECK (warehouse_id IS NULL OR operation IN ('PICKUP','SWAP')),
+  CONSTRAINT order_items_distinct_equipment CHECK (replacement_equipment_id IS NULL OR replacement_equipment_id <> equipment_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_items_order ON order_items(order_id);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_order_items_order_equipment ON order_items(order_id, equipment_id);
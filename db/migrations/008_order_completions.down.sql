@@ -0,0 +1,4 @@
+-- Remove order completion records
+DROP INDEX IF EXISTS idx_order_completions_category;
+DROP INDEX IF EXISTS idx_order_completions_completed_at;
+DROP TABLE IF EXISTS order_completions;
@@ -0,0 +1,16 @@
+-- =========================================
+-- Order completion records (collected volume, weight and waste category)
+-- =========================================
+CREATE TABLE IF NOT EXISTS order_completions (
+  order_id       UUID PRIMARY KEY REFERENCES orders(id) ON DELETE CASCADE,
+  volume_l       INT NOT NULL CHECK (volume_l > 0),
+  weight_kg      NUMERIC(10,2) CHECK (weight_kg > 0),
+  waste_category TEXT NOT NULL CHECK (waste_category IN (
+    'MIXED','PAPER','PLASTIC','GLASS','METAL','ORGANIC','CONSTRUCTION','BULKY','HAZARDOUS'
+  )),
+  completed_by   UUID REFERENCES users(id) ON DELETE SET NULL,
+  completed_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_completions_completed_at ON order_completions(completed_at);
+CREATE INDEX IF NOT EXISTS idx_order_completions_category ON order_completions(waste_category, completed_at);
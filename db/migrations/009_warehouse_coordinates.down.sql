@@ -0,0 +1,3 @@
+-- Remove warehouse coordinates
+ALTER TABLE warehouses DROP COLUMN IF EXISTS geo_lng;
+ALTER TABLE warehouses DROP COLUMN IF EXISTS geo_lat;
@@ -0,0 +1,5 @@
+-- =========================================
+-- Warehouse coordinates (route planning depots)
+-- =========================================
+ALTER TABLE warehouses ADD COLUMN IF NOT EXISTS geo_lat NUMERIC(9,6);
+ALTER TABLE warehouses ADD COLUMN IF NOT EXISTS geo_lng NUMERIC(9,6);
@@ -0,0 +1,3 @@
+-- Remove driver user accounts
+DROP INDEX IF EXISTS uniq_drivers_user_not_deleted;
+ALTER TABLE drivers DROP COLUMN IF EXISTS user_id;
@@ -0,0 +1,8 @@
+-- =========================================
+-- Driver user accounts (driver self-service)
+-- =========================================
+ALTER TABLE drivers ADD COLUMN IF NOT EXISTS user_id UUID REFERENCES users(id) ON DELETE SET NULL;
+
+-- A user account belongs to at most one non-deleted driver
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_drivers_user_not_deleted
+  ON drivers(user_id) WHERE user_id IS NOT NULL AND deleted_at IS NULL;
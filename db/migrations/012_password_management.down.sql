@@ -0,0 +1,3 @@
+-- Remove password reset tokens and forced rotation
+DROP TABLE IF EXISTS password_reset_tokens;
+ALTER TABLE users DROP COLUMN IF EXISTS must_change_password;
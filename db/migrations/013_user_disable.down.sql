@@ -0,0 +1,2 @@
+-- Remove disabled user accounts
+ALTER TABLE users DROP COLUMN IF EXISTS disabled_at;
@@ -0,0 +1,5 @@
+-- =========================================
+-- Disabled user accounts
+-- =========================================
+-- A disabled user keeps their history (orders.created_by, audit) but can no longer authenticate
+ALTER TABLE users ADD COLUMN IF NOT EXISTS disabled_at TIMESTAMPTZ;
@@ -0,0 +1,3 @@
+-- Remove login brute-force protection and attempt log
+DROP TABLE IF EXISTS login_attempts;
+DROP TABLE IF EXISTS login_throttles;
@@ -0,0 +1,26 @@
+-- =========================================
+-- Login brute-force protection and attempt log
+-- =========================================
+-- Failure counters shared by all replicas; keyed by "account:<email>" or "ip:<address>"
+CREATE TABLE IF NOT EXISTS login_throttles (
+  key              TEXT PRIMARY KEY,
+  failures         INT NOT NULL,
+  last_failure_at  TIMESTAMPTZ NOT NULL,
+  locked_until     TIMESTAMPTZ
+);
+
+-- Every login attempt, for security review
+CREATE TABLE IF NOT EXISTS login_attempts (
+  id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  email           CITEXT NOT NULL,
+  user_id         UUID REFERENCES users(id) ON DELETE SET NULL,
+  ip              TEXT NOT NULL,
+  user_agent      TEXT NOT NULL DEFAULT '',
+  success         BOOLEAN NOT NULL,
+  failure_reason  TEXT,
+  created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_attempts_created ON login_attempts(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_login_attempts_email ON login_attempts(email, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_login_attempts_ip ON login_attempts(ip, created_at DESC);
@@ -0,0 +1,18 @@
+-- =========================================
+-- API keys for machine-to-machine integrations
+-- =========================================
+CREATE TABLE IF NOT EXISTS api_keys (
+  id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  name          TEXT NOT NULL,
+  key_prefix    TEXT NOT NULL,                      -- leading characters shown to identify the key
+  key_hash      TEXT NOT NULL UNIQUE,               -- SHA-256 of the key; the key itself is never stored
+  role          TEXT NOT NULL CHECK (role IN ('ADMIN','DISPATCHER','DRIVER','VIEWER')),
+  scopes        TEXT[] NOT NULL,                    -- resource:action grants, '*' allowed
+  created_by    UUID REFERENCES users(id) ON DELETE SET NULL,
+  expires_at    TIMESTAMPTZ,
+  last_used_at  TIMESTAMPTZ,
+  revoked_at    TIMESTAMPTZ,
+  created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_created ON api_keys(created_at DESC);
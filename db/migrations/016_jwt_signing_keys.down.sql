@@ -0,0 +1,2 @@
+-- Remove JWT signing keys
+DROP TABLE IF EXISTS jwt_signing_keys;
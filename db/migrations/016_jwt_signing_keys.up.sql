@@ -0,0 +1,14 @@
+-- =========================================
+-- Rotating JWT signing keys (RS256 / EdDSA)
+-- =========================================
+-- Public halves are published at /.well-known/jwks.json; a key keeps verifying tokens until expires_at
+CREATE TABLE IF NOT EXISTS jwt_signing_keys (
+  kid           TEXT PRIMARY KEY,
+  algorithm     TEXT NOT NULL CHECK (algorithm IN ('RS256','EdDSA')),
+  private_key   TEXT NOT NULL,                      -- PKCS#8 PEM
+  activates_at  TIMESTAMPTZ NOT NULL,               -- signing starts; published in JWKS before that
+  expires_at    TIMESTAMPTZ,                        -- set when a successor is created
+  created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_jwt_signing_keys_activates ON jwt_signing_keys(activates_at DESC);
@@ -0,0 +1,3 @@
+-- Remove OpenID Connect single sign-on
+DROP TABLE IF EXISTS oidc_login_states;
+DROP TABLE IF EXISTS user_identities;
@@ -0,0 +1,24 @@
+-- =========================================
+-- OpenID Connect single sign-on
+-- =========================================
+-- Identity provider accounts linked to users; created on first SSO login
+CREATE TABLE IF NOT EXISTS user_identities (
+  issuer      TEXT NOT NULL,
+  subject     TEXT NOT NULL,                        -- the provider's stable user ID (sub claim)
+  user_id     UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (issuer, subject)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_identities_user ON user_identities(user_id);
+
+-- Pending authorization requests; each state is consumed by a single callback
+CREATE TABLE IF NOT EXISTS oidc_login_states (
+  state_hash     TEXT PRIMARY KEY,                  -- SHA-256 of the state handed to the client
+  nonce          TEXT NOT NULL,
+  code_verifier  TEXT NOT NULL,                     -- PKCE verifier
+  expires_at     TIMESTAMPTZ NOT NULL,
+  created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_oidc_login_states_expires ON oidc_login_states(expires_at);
@@ -0,0 +1,22 @@
+-- =========================================
+-- TOTP two-factor authentication
+-- =========================================
+-- TOTP secret of a user; enrollment is pending until the first code is confirmed.
+-- last_used_step rejects a code that was already used within its time step.
+CREATE TABLE IF NOT EXISTS user_totp (
+  user_id         UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+  secret          TEXT NOT NULL,
+  confirmed_at    TIMESTAMPTZ,
+  last_used_step  BIGINT,
+  created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- One-time recovery codes for a lost authenticator; only the SHA-256 hash of a code is stored
+CREATE TABLE IF NOT EXISTS user_recovery_codes (
+  id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id     UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  code_hash   TEXT NOT NULL,
+  created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  used_at     TIMESTAMPTZ,
+  UNIQUE (user_id, code_hash)
+);
@@ -0,0 +1,10 @@
+-- =========================================
+-- Rate limit token buckets shared by all API replicas
+-- =========================================
+-- A bucket is stored as the time it is full again; buckets past that time are equivalent to a missing row
+CREATE TABLE IF NOT EXISTS rate_limit_buckets (
+  key      TEXT PRIMARY KEY,
+  full_at  TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_rate_limit_buckets_full_at ON rate_limit_buckets(full_at);
@@ -0,0 +1,4 @@
+-- Remove order versions
+DROP TRIGGER IF EXISTS trg_orders_version ON orders;
+DROP FUNCTION IF EXISTS increment_version_column();
+ALTER TABLE orders DROP COLUMN IF EXISTS version;
@@ -0,0 +1,22 @@
+-- =========================================
+-- Order versions for optimistic concurrency
+-- =========================================
+-- Every update of an order bumps its version; clients send it back in If-Match so that an edit based on a
+-- stale copy is rejected instead of overwriting a concurrent one
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+
+CREATE OR REPLACE FUNCTION increment_version_column()
+RETURNS TRIGGER AS $$
+BEGIN
+  NEW.version = OLD.version + 1;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DO $$
+BEGIN
+  IF NOT EXISTS (SELECT 1 FROM pg_trigger WHERE tgname = 'trg_orders_version') THEN
+    CREATE TRIGGER trg_orders_version BEFORE UPDATE ON orders
+    FOR EACH ROW EXECUTE FUNCTION increment_version_column();
+  END IF;
+END$$;
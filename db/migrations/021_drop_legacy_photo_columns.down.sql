@@ -0,0 +1,13 @@
+-- Restore legacy photo columns with their archived values
+ALTER TABLE equipment ADD COLUMN IF NOT EXISTS photo TEXT;
+ALTER TABLE drivers ADD COLUMN IF NOT EXISTS photo TEXT;
+
+UPDATE equipment e SET photo = l.photo
+FROM legacy_photo_values l
+WHERE l.entity_type = 'equipment' AND l.entity_id = e.id;
+
+UPDATE drivers d SET photo = l.photo
+FROM legacy_photo_values l
+WHERE l.entity_type = 'drivers' AND l.entity_id = d.id;
+
+DROP TABLE IF EXISTS legacy_photo_values;
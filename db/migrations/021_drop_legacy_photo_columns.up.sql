@@ -0,0 +1,42 @@
+-- =========================================
+-- Drop legacy photo columns
+-- =========================================
+-- Equipment and driver photos live in the photos table; responses derive photoId and photo from its latest row.
+-- The legacy columns held free-form references rather than stored files, so they cannot become photos rows;
+-- their values are archived in legacy_photo_values for review before the columns go away.
+CREATE TABLE IF NOT EXISTS legacy_photo_values (
+  entity_type TEXT NOT NULL CHECK (entity_type IN ('equipment','drivers')),
+  entity_id   UUID NOT NULL,
+  photo       TEXT NOT NULL,
+  archived_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (entity_type, entity_id)
+);
+
+DO $$
+DECLARE
+  archived INTEGER := 0;
+  n        INTEGER;
+BEGIN
+  IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'equipment' AND column_name = 'photo') THEN
+    INSERT INTO legacy_photo_values (entity_type, entity_id, photo)
+    SELECT 'equipment', id, photo FROM equipment WHERE photo IS NOT NULL AND photo <> ''
+    ON CONFLICT (entity_type, entity_id) DO UPDATE SET photo = EXCLUDED.photo;
+    GET DIAGNOSTICS n = ROW_COUNT;
+    archived := archived + n;
+  END IF;
+
+  IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'drivers' AND column_name = 'photo') THEN
+    INSERT INTO legacy_photo_values (entity_type, entity_id, photo)
+    SELECT 'drivers', id, photo FROM drivers WHERE photo IS NOT NULL AND photo <> ''
+    ON CONFLICT (entity_type, entity_id) DO UPDATE SET photo = EXCLUDED.photo;
+    GET DIAGNOSTICS n = ROW_COUNT;
+    archived := archived + n;
+  END IF;
+
+  IF archived > 0 THEN
+    RAISE NOTICE '% legacy photo values archived in legacy_photo_values; upload them via /photos', archived;
+  END IF;
+END$$;
+
+ALTER TABLE equipment DROP COLUMN IF EXISTS photo;
+ALTER TABLE drivers DROP COLUMN IF EXISTS photo;
@@ -14,10 +14,14 @@ const (
 	// Token types
 	TokenTypeAccess  = "access"
 	TokenTypeRefresh = "refresh"
+	// TokenTypeTwoFactor identifies a login whose password was accepted and that awaits its second factor
+	TokenTypeTwoFactor = "2fa"
 
 	// Default TTLs
 	AccessTokenTTL  = 15 * time.Minute
 	RefreshTokenTTL = 30 * 24 * time.Hour // 30 days
+	// TwoFactorTokenTTL is how long the second login step can be completed
+	TwoFactorTokenTTL = 5 * time.Minute
 )
 
 // Claims represents the JWT claims
@@ -25,12 +29,20 @@ type Claims struct {
 	UserID uuid.UUID       `json:"sub"`
 	Role   models.UserRole `json:"role"`
 	Type   string          `json:"typ"`
+	// FamilyID links refresh tokens rotated from the same login; the token ID is the jti claim
+	FamilyID uuid.UUID `json:"fam,omitempty"`
+	// PasswordChange marks a restricted access token that only allows changing the password
+	PasswordChange bool `json:"pwc,omitempty"`
+	// TwoFactorSetup marks a restricted access token that only allows enrolling in two-factor authentication
+	TwoFactorSetup bool `json:"tfs,omitempty"`
 	jwt.RegisteredClaims
 }
 
 // JWTManager handles JWT token operations
 type JWTManager struct {
-	secretKey  []byte
+	secretKey []byte
+	// keys signs with rotating key pairs instead of the shared secret when set
+	keys       *KeySet
 	accessTTL  time.Duration
 	refreshTTL time.Duration
 }
@@ -44,6 +56,16 @@ func NewJWTManager(secretKey string, accessTTL, refreshTTL time.Duration) *JWTMa
 	}
 }
 
+// NewKeySetJWTManager creates a JWT manager that signs with the active key of the key set and
+// verifies tokens by their kid header, so other services can validate them with the published JWKS
+func NewKeySetJWTManager(keys *KeySet, accessTTL, refreshTTL time.Duration) *JWTManager {
+	return &JWTManager{
+		keys:       keys,
+		accessTTL:  accessTTL,
+		refreshTTL: refreshTTL,
+	}
+}
+
 // NewDefaultJWTManager creates a JWT manager with default TTLs
 func NewDefaultJWTManager(secretKey string) *JWTManager {
 	return NewJWTManager(secretKey, AccessTokenTTL, RefreshTokenTTL)
@@ -62,36 +84,123 @@ func (j *JWTManager) GenerateAccessToken(user *models.User) (string, error) {
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(j.secretKey)
+	return j.sign(claims)
 }
 
-// GenerateRefreshToken generates a new refresh token
-func (j *JWTManager) GenerateRefreshToken(user *models.User) (string, error) {
+// GeneratePasswordChangeToken generates a restricted access token for a user who must change their password
+func (j *JWTManager) GeneratePasswordChangeToken(user *models.User) (string, error) {
+	claims := &Claims{
+		UserID:         user.ID,
+		Role:           user.Role,
+		Type:           TokenTypeAccess,
+		PasswordChange: true,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.accessTTL)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	return j.sign(claims)
+}
+
+// GenerateTwoFactorSetupToken generates a restricted access token for a user who must enroll in two-factor authentication
+func (j *JWTManager) GenerateTwoFactorSetupToken(user *models.User) (string, error) {
+	claims := &Claims{
+		UserID:         user.ID,
+		Role:           user.Role,
+		Type:           TokenTypeAccess,
+		TwoFactorSetup: true,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.accessTTL)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	return j.sign(claims)
+}
+
+// GenerateTwoFactorToken generates the short-lived token that identifies a login in its second step
+func (j *JWTManager) GenerateTwoFactorToken(user *models.User) (string, error) {
 	claims := &Claims{
 		UserID: user.ID,
 		Role:   user.Role,
-		Type:   TokenTypeRefresh,
+		Type:   TokenTypeTwoFactor,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(TwoFactorTokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	return j.sign(claims)
+}
+
+// GenerateRefreshToken generates a new refresh token starting a new token family
+func (j *JWTManager) GenerateRefreshToken(user *models.User) (string, error) {
+	return j.GenerateFamilyRefreshToken(user, uuid.New(), uuid.New())
+}
+
+// GenerateFamilyRefreshToken generates a refresh token with the given token ID within a token family
+func (j *JWTManager) GenerateFamilyRefreshToken(user *models.User, tokenID, familyID uuid.UUID) (string, error) {
+	claims := &Claims{
+		UserID:   user.ID,
+		Role:     user.Role,
+		Type:     TokenTypeRefresh,
+		FamilyID: familyID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID.String(),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.refreshTTL)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(j.secretKey)
+	return j.sign(claims)
 }
 
-// ValidateToken validates and parses a JWT token
-func (j *JWTManager) ValidateToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+// sign signs the claims with the shared secret or, with a key set, the active key named in the kid header
+func (j *JWTManager) sign(claims *Claims) (string, error) {
+	if j.keys == nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		return token.SignedString(j.secretKey)
+	}
+
+	key, err := j.keys.SigningKey(time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(key.Algorithm), claims)
+	token.Header["kid"] = key.KID
+	return token.SignedString(key.PrivateKey)
+}
+
+// verificationKey resolves the key that must have signed the token
+func (j *JWTManager) verificationKey(token *jwt.Token) (interface{}, error) {
+	if j.keys == nil {
 		// Validate the signing method
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
 		return j.secretKey, nil
-	})
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key, ok := j.keys.VerificationKey(kid)
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key: %q", kid)
+	}
+	if token.Method.Alg() != key.Algorithm {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return key.PrivateKey.Public(), nil
+}
+
+// ValidateToken validates and parses a JWT token
+func (j *JWTManager) ValidateToken(tokenString string) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, j.verificationKey)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse token: %w", err)
@@ -137,6 +246,20 @@ func (j *JWTManager) ValidateRefreshToken(tokenString string) (*Claims, error) {
 	return claims, nil
 }
 
+// ValidateTwoFactorToken validates a two-factor login token specifically
+func (j *JWTManager) ValidateTwoFactorToken(tokenString string) (*Claims, error) {
+	claims, err := j.ValidateToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Type != TokenTypeTwoFactor {
+		return nil, fmt.Errorf("invalid token type: expected two-factor token")
+	}
+
+	return claims, nil
+}
+
 // GetTokenExpiration returns the expiration time for a token
 func (j *JWTManager) GetTokenExpiration(tokenType string) time.Duration {
 	switch tokenType {
@@ -144,11 +267,21 @@ func (j *JWTManager) GetTokenExpiration(tokenType string) time.Duration {
 		return j.accessTTL
 	case TokenTypeRefresh:
 		return j.refreshTTL
+	case TokenTypeTwoFactor:
+		return TwoFactorTokenTTL
 	default:
 		return 0
 	}
 }
 
+// JWKS returns the public signing keys; it is empty when tokens are signed with the shared secret
+func (j *JWTManager) JWKS() JWKS {
+	if j.keys == nil {
+		return JWKS{Keys: []JWK{}}
+	}
+	return j.keys.JWKS()
+}
+
 // GetAccessTokenTTL returns the access token TTL in seconds
 func (j *JWTManager) GetAccessTokenTTL() int {
 	return int(j.accessTTL.Seconds())
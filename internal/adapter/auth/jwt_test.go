@@ -127,6 +127,35 @@ func TestGenerateRefreshToken(t *testing.T) {
 	}
 }
 
+func TestGenerateFamilyRefreshToken(t *testing.T) {
+	manager := NewDefaultJWTManager(testSecretKey)
+
+	user := &models.User{
+		ID:    uuid.New(),
+		Email: testEmail,
+		Role:  models.UserRoleAdmin,
+	}
+	tokenID, familyID := uuid.New(), uuid.New()
+
+	token, err := manager.GenerateFamilyRefreshToken(user, tokenID, familyID)
+	if err != nil {
+		t.Fatalf("Failed to generate refresh token: %v", err)
+	}
+
+	claims, err := manager.ValidateRefreshToken(token)
+	if err != nil {
+		t.Fatalf("Failed to validate refresh token: %v", err)
+	}
+
+	if claims.ID != tokenID.String() {
+		t.Errorf("Expected token ID %s, got %s", tokenID, claims.ID)
+	}
+
+	if claims.FamilyID != familyID {
+		t.Errorf("Expected family ID %s, got %s", familyID, claims.FamilyID)
+	}
+}
+
 func TestValidateToken_InvalidToken(t *testing.T) {
 	secretKey := testSecretKey
 	manager := NewDefaultJWTManager(secretKey)
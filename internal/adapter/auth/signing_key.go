@@ -0,0 +1,203 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"eco-van-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// rsaKeyBits is the modulus size of generated RS256 keys
+const rsaKeyBits = 2048
+
+// pemBlockPrivateKey is the PEM block type of PKCS#8 private keys
+const pemBlockPrivateKey = "PRIVATE KEY"
+
+// SigningKey is an asymmetric key pair that signs tokens carrying its KID in the kid header
+type SigningKey struct {
+	KID         string
+	Algorithm   string
+	PrivateKey  crypto.Signer
+	ActivatesAt time.Time
+}
+
+// GenerateSigningKey generates a new key pair for the algorithm that starts signing at activatesAt
+func GenerateSigningKey(algorithm string, activatesAt time.Time) (*SigningKey, error) {
+	var privateKey crypto.Signer
+	switch algorithm {
+	case models.JWTAlgorithmRS256:
+		key, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate RSA key: %w", err)
+		}
+		privateKey = key
+	case models.JWTAlgorithmEdDSA:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate Ed25519 key: %w", err)
+		}
+		privateKey = key
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm: %s", algorithm)
+	}
+
+	return &SigningKey{
+		KID:         uuid.New().String(),
+		Algorithm:   algorithm,
+		PrivateKey:  privateKey,
+		ActivatesAt: activatesAt,
+	}, nil
+}
+
+// ParseSigningKey restores a key pair from its PKCS#8 PEM encoding
+func ParseSigningKey(kid, algorithm, privateKeyPEM string, activatesAt time.Time) (*SigningKey, error) {
+	block, _ := pem.Decode([]byte(privateKeyPEM))
+	if block == nil || block.Type != pemBlockPrivateKey {
+		return nil, fmt.Errorf("signing key %s: invalid PEM", kid)
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("signing key %s: %w", kid, err)
+	}
+
+	var privateKey crypto.Signer
+	switch key := parsed.(type) {
+	case *rsa.PrivateKey:
+		if algorithm == models.JWTAlgorithmRS256 {
+			privateKey = key
+		}
+	case ed25519.PrivateKey:
+		if algorithm == models.JWTAlgorithmEdDSA {
+			privateKey = key
+		}
+	}
+	if privateKey == nil {
+		return nil, fmt.Errorf("signing key %s: key type %T does not match algorithm %s", kid, parsed, algorithm)
+	}
+
+	return &SigningKey{
+		KID:         kid,
+		Algorithm:   algorithm,
+		PrivateKey:  privateKey,
+		ActivatesAt: activatesAt,
+	}, nil
+}
+
+// MarshalPrivateKey returns the PKCS#8 PEM encoding of the private key
+func (k *SigningKey) MarshalPrivateKey() (string, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(k.PrivateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode signing key: %w", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: pemBlockPrivateKey, Bytes: der})), nil
+}
+
+// JWK is the public half of a signing key as published in the JWKS (RFC 7517)
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	// RSA modulus and exponent
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Ed25519 curve and public key
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+}
+
+// JWKS is the JSON Web Key Set served at /.well-known/jwks.json
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// PublicJWK returns the public key in JWK form
+func (k *SigningKey) PublicJWK() JWK {
+	jwk := JWK{Use: "sig", Algorithm: k.Algorithm, KeyID: k.KID}
+	switch publicKey := k.PrivateKey.Public().(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+	}
+	return jwk
+}
+
+// KeySet holds the signing keys known to this instance; it is refreshed from the database while tokens are issued
+type KeySet struct {
+	mu   sync.RWMutex
+	keys []*SigningKey // ordered by activation time
+}
+
+// NewKeySet creates an empty key set
+func NewKeySet() *KeySet {
+	return &KeySet{}
+}
+
+// Replace swaps in the current keys: the signing key, its pending successor and retired keys still verifying tokens
+func (s *KeySet) Replace(keys []*SigningKey) {
+	ordered := make([]*SigningKey, len(keys))
+	copy(ordered, keys)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].ActivatesAt.Before(ordered[j].ActivatesAt)
+	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = ordered
+}
+
+// SigningKey returns the most recently activated key, so a pending key takes over without a reload
+func (s *KeySet) SigningKey(now time.Time) (*SigningKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for i := len(s.keys) - 1; i >= 0; i-- {
+		if !s.keys[i].ActivatesAt.After(now) {
+			return s.keys[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no active signing key")
+}
+
+// VerificationKey returns the key with the given kid
+func (s *KeySet) VerificationKey(kid string) (*SigningKey, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, key := range s.keys {
+		if key.KID == kid {
+			return key, true
+		}
+	}
+	return nil, false
+}
+
+// JWKS returns the public keys of every key in the set, including pending and retired keys
+func (s *KeySet) JWKS() JWKS {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	jwks := JWKS{Keys: make([]JWK, 0, len(s.keys))}
+	for _, key := range s.keys {
+		jwks.Keys = append(jwks.Keys, key.PublicJWK())
+	}
+	return jwks
+}
@@ -0,0 +1,154 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"eco-van-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func mustGenerateSigningKey(t *testing.T, algorithm string, activatesAt time.Time) *SigningKey {
+	t.Helper()
+	key, err := GenerateSigningKey(algorithm, activatesAt)
+	if err != nil {
+		t.Fatalf("Failed to generate %s key: %v", algorithm, err)
+	}
+	return key
+}
+
+func TestKeySetJWTManager_SignAndValidate(t *testing.T) {
+	user := &models.User{ID: uuid.New(), Email: testEmail, Role: models.UserRoleDispatcher}
+
+	for _, algorithm := range []string{models.JWTAlgorithmRS256, models.JWTAlgorithmEdDSA} {
+		t.Run(algorithm, func(t *testing.T) {
+			key := mustGenerateSigningKey(t, algorithm, time.Now().Add(-time.Minute))
+			keys := NewKeySet()
+			keys.Replace([]*SigningKey{key})
+			manager := NewKeySetJWTManager(keys, AccessTokenTTL, RefreshTokenTTL)
+
+			token, err := manager.GenerateAccessToken(user)
+			if err != nil {
+				t.Fatalf("Failed to generate access token: %v", err)
+			}
+
+			claims, err := manager.ValidateAccessToken(token)
+			if err != nil {
+				t.Fatalf("Failed to validate access token: %v", err)
+			}
+			if claims.UserID != user.ID {
+				t.Errorf("Expected user ID %s, got %s", user.ID, claims.UserID)
+			}
+
+			// Tokens signed with the shared secret are not accepted once key pairs are used
+			hsToken, err := NewDefaultJWTManager(testSecretKey).GenerateAccessToken(user)
+			if err != nil {
+				t.Fatalf("Failed to generate HS256 token: %v", err)
+			}
+			if _, err := manager.ValidateAccessToken(hsToken); err == nil {
+				t.Error("Expected HS256 token to be rejected")
+			}
+		})
+	}
+}
+
+func TestKeySetJWTManager_Rotation(t *testing.T) {
+	user := &models.User{ID: uuid.New(), Email: testEmail, Role: models.UserRoleAdmin}
+	now := time.Now()
+
+	oldKey := mustGenerateSigningKey(t, models.JWTAlgorithmEdDSA, now.Add(-time.Hour))
+	keys := NewKeySet()
+	keys.Replace([]*SigningKey{oldKey})
+	manager := NewKeySetJWTManager(keys, AccessTokenTTL, RefreshTokenTTL)
+
+	oldToken, err := manager.GenerateRefreshToken(user)
+	if err != nil {
+		t.Fatalf("Failed to generate refresh token: %v", err)
+	}
+
+	// A pending key is published but does not sign yet
+	newKey := mustGenerateSigningKey(t, models.JWTAlgorithmRS256, now.Add(time.Hour))
+	keys.Replace([]*SigningKey{newKey, oldKey})
+	if signing, _ := keys.SigningKey(now); signing.KID != oldKey.KID {
+		t.Errorf("Expected old key %s to sign until the new one activates, got %s", oldKey.KID, signing.KID)
+	}
+	if signing, _ := keys.SigningKey(now.Add(2 * time.Hour)); signing.KID != newKey.KID {
+		t.Errorf("Expected new key %s to sign after activation, got %s", newKey.KID, signing.KID)
+	}
+
+	// The previous key still verifies the tokens it signed
+	if _, err := manager.ValidateRefreshToken(oldToken); err != nil {
+		t.Errorf("Expected token signed with the previous key to validate, got %v", err)
+	}
+
+	// Once the previous key is dropped its tokens are rejected
+	keys.Replace([]*SigningKey{newKey})
+	if _, err := manager.ValidateRefreshToken(oldToken); err == nil || !strings.Contains(err.Error(), "unknown signing key") {
+		t.Errorf("Expected unknown signing key error, got %v", err)
+	}
+}
+
+func TestKeySet_SigningKeyMissing(t *testing.T) {
+	keys := NewKeySet()
+	keys.Replace([]*SigningKey{mustGenerateSigningKey(t, models.JWTAlgorithmEdDSA, time.Now().Add(time.Hour))})
+
+	manager := NewKeySetJWTManager(keys, AccessTokenTTL, RefreshTokenTTL)
+	if _, err := manager.GenerateAccessToken(&models.User{ID: uuid.New()}); err == nil {
+		t.Error("Expected error when no key is active yet")
+	}
+}
+
+func TestParseSigningKey(t *testing.T) {
+	for _, algorithm := range []string{models.JWTAlgorithmRS256, models.JWTAlgorithmEdDSA} {
+		t.Run(algorithm, func(t *testing.T) {
+			key := mustGenerateSigningKey(t, algorithm, time.Now())
+			encoded, err := key.MarshalPrivateKey()
+			if err != nil {
+				t.Fatalf("Failed to encode key: %v", err)
+			}
+
+			parsed, err := ParseSigningKey(key.KID, algorithm, encoded, key.ActivatesAt)
+			if err != nil {
+				t.Fatalf("Failed to parse key: %v", err)
+			}
+			if parsed.PublicJWK() != key.PublicJWK() {
+				t.Error("Expected parsed key to have the same public key")
+			}
+		})
+	}
+
+	rsaKey := mustGenerateSigningKey(t, models.JWTAlgorithmRS256, time.Now())
+	encoded, err := rsaKey.MarshalPrivateKey()
+	if err != nil {
+		t.Fatalf("Failed to encode key: %v", err)
+	}
+	if _, err := ParseSigningKey(rsaKey.KID, models.JWTAlgorithmEdDSA, encoded, time.Now()); err == nil {
+		t.Error("Expected error for an RSA key stored as EdDSA")
+	}
+}
+
+func TestKeySet_JWKS(t *testing.T) {
+	rsaKey := mustGenerateSigningKey(t, models.JWTAlgorithmRS256, time.Now().Add(-time.Hour))
+	edKey := mustGenerateSigningKey(t, models.JWTAlgorithmEdDSA, time.Now())
+	keys := NewKeySet()
+	keys.Replace([]*SigningKey{edKey, rsaKey})
+
+	jwks := keys.JWKS()
+	if len(jwks.Keys) != 2 {
+		t.Fatalf("Expected 2 keys, got %d", len(jwks.Keys))
+	}
+
+	rsaJWK, edJWK := jwks.Keys[0], jwks.Keys[1]
+	if rsaJWK.KeyID != rsaKey.KID || rsaJWK.KeyType != "RSA" || rsaJWK.Algorithm != "RS256" || rsaJWK.E != "AQAB" || rsaJWK.N == "" {
+		t.Errorf("Unexpected RSA JWK: %+v", rsaJWK)
+	}
+	if edJWK.KeyID != edKey.KID || edJWK.KeyType != "OKP" || edJWK.Curve != "Ed25519" || edJWK.X == "" || edJWK.Use != "sig" {
+		t.Errorf("Unexpected Ed25519 JWK: %+v", edJWK)
+	}
+
+	if got := NewDefaultJWTManager(testSecretKey).JWKS(); got.Keys == nil || len(got.Keys) != 0 {
+		t.Errorf("Expected an empty key set for HS256, got %+v", got)
+	}
+}
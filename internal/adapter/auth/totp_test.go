@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"encoding/base32"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA-1 key of the RFC 6238 test vectors
+var rfc6238Secret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestTOTPCode_RFC6238Vectors(t *testing.T) {
+	// The RFC lists 8-digit codes; a 6-digit code is their last six digits
+	tests := []struct {
+		unix     int64
+		expected string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		code, err := TOTPCode(rfc6238Secret, TOTPStep(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatalf("Failed to compute code: %v", err)
+		}
+		if code != tt.expected {
+			t.Errorf("TOTPCode at %d = %s, expected %s", tt.unix, code, tt.expected)
+		}
+	}
+}
+
+func TestVerifyTOTP(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+	step := TOTPStep(now)
+
+	previous, _ := TOTPCode(rfc6238Secret, step-1)
+	if matched, ok := VerifyTOTP(rfc6238Secret, previous, now); !ok || matched != step-1 {
+		t.Errorf("Expected the previous period to be accepted with its step, got %d, %v", matched, ok)
+	}
+
+	if _, ok := VerifyTOTP(rfc6238Secret, "081 804", now); !ok {
+		t.Error("Expected a code with a space to be accepted")
+	}
+
+	stale, _ := TOTPCode(rfc6238Secret, step-2)
+	if _, ok := VerifyTOTP(rfc6238Secret, stale, now); ok {
+		t.Error("Expected a code two periods old to be rejected")
+	}
+
+	for _, code := range []string{"", "08180", "0818040"} {
+		if _, ok := VerifyTOTP(rfc6238Secret, code, now); ok {
+			t.Errorf("Expected code %q to be rejected", code)
+		}
+	}
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatalf("Failed to generate secret: %v", err)
+	}
+
+	uri, err := url.Parse(TOTPProvisioningURI("Eco-Van", "jane@example.com", secret))
+	if err != nil {
+		t.Fatalf("Failed to parse URI: %v", err)
+	}
+	if uri.Scheme != "otpauth" || uri.Host != "totp" || uri.Path != "/Eco-Van:jane@example.com" {
+		t.Errorf("Unexpected URI %s", uri)
+	}
+	params := uri.Query()
+	if params.Get("secret") != secret || params.Get("issuer") != "Eco-Van" || params.Get("digits") != "6" || params.Get("period") != "30" {
+		t.Errorf("Unexpected URI parameters %v", params)
+	}
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes()
+	if err != nil {
+		t.Fatalf("Failed to generate recovery codes: %v", err)
+	}
+	if len(codes) != RecoveryCodeCount {
+		t.Fatalf("Expected %d codes, got %d", RecoveryCodeCount, len(codes))
+	}
+
+	seen := map[string]bool{}
+	for _, code := range codes {
+		if len(code) != recoveryCodeLength+1 || code[recoveryCodeLength/2] != '-' {
+			t.Errorf("Unexpected recovery code format %q", code)
+		}
+		if seen[code] {
+			t.Errorf("Duplicate recovery code %q", code)
+		}
+		seen[code] = true
+	}
+
+	typed := strings.ToUpper(strings.ReplaceAll(codes[0], "-", " "))
+	if HashRecoveryCode(typed) != HashRecoveryCode(codes[0]) {
+		t.Error("Expected case, spaces and dashes to be ignored when hashing a recovery code")
+	}
+}
@@ -0,0 +1,89 @@
+package http
+
+import (
+	"net/http"
+
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/port"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// APIKeyHandler handles HTTP requests for API key management
+type APIKeyHandler struct {
+	apiKeyService port.APIKeyService
+}
+
+// NewAPIKeyHandler creates a new API key handler
+func NewAPIKeyHandler(apiKeyService port.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+	}
+}
+
+// ListAPIKeys handles GET /api/v1/api-keys
+func (h *APIKeyHandler) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	response, err := h.apiKeyService.List(r.Context())
+	if err != nil {
+		WriteError(w, err, "Failed to list API keys")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, response)
+}
+
+// GetAPIKey handles GET /api/v1/api-keys/{id}
+func (h *APIKeyHandler) GetAPIKey(w http.ResponseWriter, r *http.Request) {
+	id, err := models.ParseUUID(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid API key ID")
+		return
+	}
+
+	apiKey, err := h.apiKeyService.Get(r.Context(), id)
+	if err != nil {
+		WriteError(w, err, "Failed to get API key")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, apiKey)
+}
+
+// CreateAPIKey handles POST /api/v1/api-keys
+func (h *APIKeyHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteUnauthorized(w, "User ID not found in context")
+		return
+	}
+
+	var req models.CreateAPIKeyRequest
+	if err := ParseJSON(r, &req); err != nil {
+		WriteBadRequest(w, "Invalid request body")
+		return
+	}
+
+	response, err := h.apiKeyService.Create(r.Context(), req, adminID)
+	if err != nil {
+		WriteError(w, err, "Failed to create API key")
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, response)
+}
+
+// RevokeAPIKey handles DELETE /api/v1/api-keys/{id}
+func (h *APIKeyHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	id, err := models.ParseUUID(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid API key ID")
+		return
+	}
+
+	if err := h.apiKeyService.Revoke(r.Context(), id); err != nil {
+		WriteError(w, err, "Failed to revoke API key")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
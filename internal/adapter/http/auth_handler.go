@@ -2,6 +2,8 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
+	"net"
 	"net/http"
 	"strconv"
 
@@ -34,13 +36,19 @@ func (h *AuthHandler) handleLoginRequest(w http.ResponseWriter, r *http.Request)
 	}
 
 	if err := req.Validate(); err != nil {
-		WriteValidationError(w, err.Error())
+		WriteError(w, err, "Validation failed")
 		return
 	}
 
-	response, err := h.authService.Login(r.Context(), &req)
+	response, err := h.authService.Login(r.Context(), &req, clientInfo(r))
 	if err != nil {
-		WriteUnauthorized(w, "Invalid credentials")
+		var throttled *models.LoginThrottledError
+		switch {
+		case errors.As(err, &throttled):
+			WriteTooManyRequests(w, "Too many failed login attempts, try again later", throttled.RetryAfterSeconds())
+		default:
+			WriteError(w, err, "Failed to log in")
+		}
 		return
 	}
 
@@ -62,13 +70,13 @@ func (h *AuthHandler) handleRefreshRequest(w http.ResponseWriter, r *http.Reques
 	}
 
 	if err := req.Validate(); err != nil {
-		WriteValidationError(w, err.Error())
+		WriteError(w, err, "Validation failed")
 		return
 	}
 
 	response, err := h.authService.Refresh(r.Context(), &req)
 	if err != nil {
-		WriteUnauthorized(w, "Invalid refresh token")
+		WriteError(w, err, "Failed to refresh token")
 		return
 	}
 
@@ -90,6 +98,149 @@ func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
 	h.handleRefreshRequest(w, r)
 }
 
+// Logout handles logout requests by revoking the session of the refresh token
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	var req models.LogoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteBadRequest(w, "Invalid request body")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteError(w, err, "Validation failed")
+		return
+	}
+
+	if err := h.authService.Logout(r.Context(), &req); err != nil {
+		WriteError(w, err, "Failed to log out")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ResetPassword handles setting a new password with a one-time reset token
+func (h *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req models.ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteBadRequest(w, "Invalid request body")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteError(w, err, "Validation failed")
+		return
+	}
+
+	if err := h.authService.ResetPassword(r.Context(), &req); err != nil {
+		WriteError(w, err, "Failed to reset password")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ChangePassword handles password change requests of the current user
+func (h *AuthHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteUnauthorized(w, "User ID not found in context")
+		return
+	}
+
+	var req models.ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteBadRequest(w, "Invalid request body")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteError(w, err, "Validation failed")
+		return
+	}
+
+	response, err := h.authService.ChangePassword(r.Context(), userID, &req)
+	if err != nil {
+		WriteError(w, err, "Failed to change password")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, response)
+}
+
+// IssuePasswordReset handles admin requests to issue a one-time password reset token for a user
+func (h *AuthHandler) IssuePasswordReset(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteUnauthorized(w, "User ID not found in context")
+		return
+	}
+
+	response, err := h.authService.IssuePasswordReset(r.Context(), chi.URLParam(r, "id"), adminID)
+	if err != nil {
+		WriteError(w, err, "Failed to issue password reset")
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, response)
+}
+
+// UnlockUser handles clearing the failed-login lockout of a user's account
+func (h *AuthHandler) UnlockUser(w http.ResponseWriter, r *http.Request) {
+	if err := h.authService.UnlockUser(r.Context(), chi.URLParam(r, "id")); err != nil {
+		WriteError(w, err, "Failed to unlock user")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListLoginAttempts handles retrieval of the login attempt log
+func (h *AuthHandler) ListLoginAttempts(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	page, _ := strconv.Atoi(query.Get("page"))
+	pageSize, _ := strconv.Atoi(query.Get("pageSize"))
+
+	req := models.LoginAttemptListRequest{
+		Page:     page,
+		PageSize: pageSize,
+	}
+
+	if email := query.Get("email"); email != "" {
+		req.Email = &email
+	}
+
+	if ip := query.Get("ip"); ip != "" {
+		req.IP = &ip
+	}
+
+	if userIDStr := query.Get("userId"); userIDStr != "" {
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			WriteBadRequest(w, "Invalid user ID format")
+			return
+		}
+		req.UserID = &userID
+	}
+
+	if successStr := query.Get("success"); successStr != "" {
+		success, err := strconv.ParseBool(successStr)
+		if err != nil {
+			WriteBadRequest(w, "Invalid success value. Expected true or false")
+			return
+		}
+		req.Success = &success
+	}
+
+	response, err := h.authService.ListLoginAttempts(r.Context(), req)
+	if err != nil {
+		WriteError(w, err, "Failed to retrieve login attempts")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, response)
+}
+
 // CreateUser handles user creation requests
 func (h *AuthHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	var req models.CreateUserRequest
@@ -100,14 +251,14 @@ func (h *AuthHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 
 	// Validate request
 	if err := req.Validate(); err != nil {
-		WriteValidationError(w, err.Error())
+		WriteError(w, err, "Validation failed")
 		return
 	}
 
 	// Create user
 	user, err := h.authService.CreateUser(r.Context(), &req)
 	if err != nil {
-		WriteConflict(w, err.Error())
+		WriteError(w, err, "Failed to create user")
 		return
 	}
 
@@ -131,7 +282,7 @@ func (h *AuthHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 	// Get user
 	user, err := h.authService.GetUser(r.Context(), userID)
 	if err != nil {
-		WriteNotFound(w, "User not found")
+		WriteError(w, err, "Failed to get user")
 		return
 	}
 
@@ -168,7 +319,7 @@ func (h *AuthHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
 	// Get users
 	users, total, err := h.authService.ListUsers(r.Context(), page, pageSize)
 	if err != nil {
-		WriteInternalError(w, "Failed to retrieve users")
+		WriteError(w, err, "Failed to retrieve users")
 		return
 	}
 
@@ -188,6 +339,39 @@ func (h *AuthHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// ReplaceUser handles PUT /users/{id}, which requires email and role
+func (h *AuthHandler) ReplaceUser(w http.ResponseWriter, r *http.Request) {
+	h.updateUser(w, r, true)
+}
+
+// PatchUser handles PATCH /users/{id}, which updates only the fields present
+func (h *AuthHandler) PatchUser(w http.ResponseWriter, r *http.Request) {
+	h.updateUser(w, r, false)
+}
+
+// updateUser handles user update requests
+func (h *AuthHandler) updateUser(w http.ResponseWriter, r *http.Request, replace bool) {
+	actorID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteUnauthorized(w, "User ID not found in context")
+		return
+	}
+
+	var req models.UpdateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteBadRequest(w, "Invalid request body")
+		return
+	}
+
+	user, err := h.authService.UpdateUser(r.Context(), chi.URLParam(r, "id"), actorID, &req, replace)
+	if err != nil {
+		WriteError(w, err, "Failed to update user")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, user)
+}
+
 // DeleteUser handles user deletion requests
 func (h *AuthHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	userID := chi.URLParam(r, "id")
@@ -199,7 +383,7 @@ func (h *AuthHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	// Delete user
 	err := h.authService.DeleteUser(r.Context(), userID)
 	if err != nil {
-		WriteNotFound(w, "User not found")
+		WriteError(w, err, "Failed to delete user")
 		return
 	}
 
@@ -219,7 +403,7 @@ func (h *AuthHandler) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 	// Get user
 	user, err := h.authService.GetUser(r.Context(), userID.String())
 	if err != nil {
-		WriteNotFound(w, "User not found")
+		WriteError(w, err, "Failed to get user")
 		return
 	}
 
@@ -231,3 +415,17 @@ func (h *AuthHandler) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// clientInfo identifies the client of a request for login throttling and the attempt log.
+// RemoteAddr holds the proxy-supplied address when the RealIP middleware is enabled.
+func clientInfo(r *http.Request) models.ClientInfo {
+	ip := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		ip = host
+	}
+
+	return models.ClientInfo{
+		IP:        ip,
+		UserAgent: r.UserAgent(),
+	}
+}
@@ -6,26 +6,89 @@ import (
 	"strings"
 
 	"eco-van-api/internal/adapter/auth"
+	"eco-van-api/internal/domainerr"
 	"eco-van-api/internal/models"
 
 	"github.com/google/uuid"
 )
 
+// APIKeyHeader carries the API key of machine-to-machine requests
+const APIKeyHeader = "X-API-Key"
+
+// tokenRestriction names the kind of restricted access token an endpoint accepts
+type tokenRestriction int
+
+const (
+	// noRestrictedTokens rejects every restricted access token
+	noRestrictedTokens tokenRestriction = iota
+	// allowPasswordChangeTokens accepts tokens of users who must change their password
+	allowPasswordChangeTokens
+	// allowTwoFactorSetupTokens accepts tokens of users who must enroll in two-factor authentication
+	allowTwoFactorSetupTokens
+)
+
+// UserStateReader loads the current state of a user so that every request sees disabled accounts and role changes
+type UserStateReader interface {
+	Get(ctx context.Context, id uuid.UUID) (*models.User, error)
+}
+
+// APIKeyAuthenticator resolves an active API key from the value presented by a client
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, key string) (*models.APIKey, error)
+}
+
 // AuthMiddleware provides authentication for protected routes
 type AuthMiddleware struct {
 	jwtManager *auth.JWTManager
+	users      UserStateReader
+	apiKeys    APIKeyAuthenticator
 }
 
-// NewAuthMiddleware creates a new authentication middleware
-func NewAuthMiddleware(jwtManager *auth.JWTManager) *AuthMiddleware {
+// NewAuthMiddleware creates a new authentication middleware.
+// With a nil users reader the token claims are trusted until the token expires; with nil apiKeys
+// API keys are not accepted.
+func NewAuthMiddleware(jwtManager *auth.JWTManager, users UserStateReader, apiKeys APIKeyAuthenticator) *AuthMiddleware {
 	return &AuthMiddleware{
 		jwtManager: jwtManager,
+		users:      users,
+		apiKeys:    apiKeys,
 	}
 }
 
-// RequireAuth middleware that requires a valid access token
+// RequireAuth middleware that requires a valid access token or API key; restricted password change tokens are rejected
 func (m *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
+	return m.authenticate(next, noRestrictedTokens, true)
+}
+
+// RequireUserAuth middleware that requires a user access token; API keys are rejected
+func (m *AuthMiddleware) RequireUserAuth(next http.Handler) http.Handler {
+	return m.authenticate(next, noRestrictedTokens, false)
+}
+
+// RequireAuthAllowingPasswordChange middleware that also accepts restricted password change tokens,
+// for the endpoints a user who must change their password can still reach. API keys are rejected.
+func (m *AuthMiddleware) RequireAuthAllowingPasswordChange(next http.Handler) http.Handler {
+	return m.authenticate(next, allowPasswordChangeTokens, false)
+}
+
+// RequireAuthAllowingTwoFactorSetup middleware that also accepts restricted two-factor setup tokens,
+// for the endpoints a user who must enroll in two-factor authentication can still reach. API keys are rejected.
+func (m *AuthMiddleware) RequireAuthAllowingTwoFactorSetup(next http.Handler) http.Handler {
+	return m.authenticate(next, allowTwoFactorSetupTokens, false)
+}
+
+// authenticate validates the API key or bearer access token and stores the caller in the request context
+func (m *AuthMiddleware) authenticate(next http.Handler, restriction tokenRestriction, allowAPIKey bool) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if key := r.Header.Get(APIKeyHeader); key != "" {
+			if !allowAPIKey || m.apiKeys == nil {
+				WriteUnauthorized(w, "API keys are not accepted for this endpoint")
+				return
+			}
+			m.authenticateAPIKey(next, w, r, key)
+			return
+		}
+
 		// Extract token from Authorization header
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
@@ -53,15 +116,61 @@ func (m *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
 			return
 		}
 
+		if claims.PasswordChange && restriction != allowPasswordChangeTokens {
+			WriteForbidden(w, "Password change required")
+			return
+		}
+		if claims.TwoFactorSetup && restriction != allowTwoFactorSetupTokens {
+			WriteForbidden(w, "Two-factor authentication setup required")
+			return
+		}
+
+		// The current role wins over the one in the token so role changes apply immediately
+		role := claims.Role
+		if m.users != nil {
+			user, err := m.users.Get(r.Context(), claims.UserID)
+			if err != nil {
+				if domainerr.IsKind(err, domainerr.KindNotFound) {
+					WriteUnauthorized(w, "User not found")
+					return
+				}
+				WriteInternalError(w, "Failed to load user")
+				return
+			}
+			if user.IsDisabled() {
+				WriteUnauthorized(w, "User account is disabled")
+				return
+			}
+			role = user.Role
+		}
+
 		// Add user information to request context
 		ctx := context.WithValue(r.Context(), UserIDKey, claims.UserID)
-		ctx = context.WithValue(ctx, UserRoleKey, claims.Role)
+		ctx = context.WithValue(ctx, UserRoleKey, role)
 
 		// Call the next handler with the updated context
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// authenticateAPIKey resolves the API key and stores its role and scopes in the request context.
+// API key requests carry no user ID.
+func (m *AuthMiddleware) authenticateAPIKey(next http.Handler, w http.ResponseWriter, r *http.Request, key string) {
+	apiKey, err := m.apiKeys.Authenticate(r.Context(), key)
+	if err != nil {
+		if domainerr.IsKind(err, domainerr.KindUnauthorized) {
+			WriteUnauthorized(w, "Invalid, expired or revoked API key")
+			return
+		}
+		WriteInternalError(w, "Failed to authenticate API key")
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), UserRoleKey, apiKey.Role)
+	ctx = context.WithValue(ctx, APIKeyKey, apiKey)
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // RequireRole middleware that requires a specific user role
 func (m *AuthMiddleware) RequireRole(requiredRole models.UserRole) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -127,3 +236,9 @@ func GetUserRoleFromContext(ctx context.Context) (models.UserRole, bool) {
 	userRole, ok := ctx.Value(UserRoleKey).(models.UserRole)
 	return userRole, ok
 }
+
+// GetAPIKeyFromContext extracts the API key of a machine-to-machine request from the request context
+func GetAPIKeyFromContext(ctx context.Context) (*models.APIKey, bool) {
+	apiKey, ok := ctx.Value(APIKeyKey).(*models.APIKey)
+	return apiKey, ok
+}
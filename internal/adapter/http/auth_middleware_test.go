@@ -0,0 +1,312 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"eco-van-api/internal/adapter/auth"
+	"eco-van-api/internal/domainerr"
+	"eco-van-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func TestAuthMiddleware_PasswordChangeToken(t *testing.T) {
+	jwtManager := auth.NewDefaultJWTManager("test-secret")
+	middleware := NewAuthMiddleware(jwtManager, nil, nil)
+	user := &models.User{ID: uuid.New(), Role: models.UserRoleAdmin}
+
+	restricted, err := jwtManager.GeneratePasswordChangeToken(user)
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+	full, err := jwtManager.GenerateAccessToken(user)
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		token          string
+		middlewareFunc func(http.Handler) http.Handler
+		expectedStatus int
+	}{
+		{
+			name:           "RequireAuth rejects restricted token",
+			token:          restricted,
+			middlewareFunc: middleware.RequireAuth,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "RequireAuthAllowingPasswordChange accepts restricted token",
+			token:          restricted,
+			middlewareFunc: middleware.RequireAuthAllowingPasswordChange,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "RequireAuth accepts full token",
+			token:          full,
+			middlewareFunc: middleware.RequireAuth,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "RequireAuthAllowingPasswordChange accepts full token",
+			token:          full,
+			middlewareFunc: middleware.RequireAuthAllowingPasswordChange,
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/test", http.NoBody)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			tt.middlewareFunc(handler).ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_TwoFactorTokens(t *testing.T) {
+	jwtManager := auth.NewDefaultJWTManager("test-secret")
+	middleware := NewAuthMiddleware(jwtManager, nil, nil)
+	user := &models.User{ID: uuid.New(), Role: models.UserRoleAdmin}
+
+	setup, err := jwtManager.GenerateTwoFactorSetupToken(user)
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+	challenge, err := jwtManager.GenerateTwoFactorToken(user)
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+	passwordChange, err := jwtManager.GeneratePasswordChangeToken(user)
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		token          string
+		middlewareFunc func(http.Handler) http.Handler
+		expectedStatus int
+	}{
+		{"RequireAuth rejects setup token", setup, middleware.RequireAuth, http.StatusForbidden},
+		{"RequireAuthAllowingPasswordChange rejects setup token", setup, middleware.RequireAuthAllowingPasswordChange, http.StatusForbidden},
+		{"RequireAuthAllowingTwoFactorSetup accepts setup token", setup, middleware.RequireAuthAllowingTwoFactorSetup, http.StatusOK},
+		{
+			"RequireAuthAllowingTwoFactorSetup rejects password change token",
+			passwordChange, middleware.RequireAuthAllowingTwoFactorSetup, http.StatusForbidden,
+		},
+		{"second login step token is not an access token", challenge, middleware.RequireAuthAllowingTwoFactorSetup, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/test", http.NoBody)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			tt.middlewareFunc(handler).ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
+
+// stubUserStateReader returns a fixed user or error
+type stubUserStateReader struct {
+	user *models.User
+	err  error
+}
+
+func (s *stubUserStateReader) Get(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	return s.user, s.err
+}
+
+func TestAuthMiddleware_UserState(t *testing.T) {
+	jwtManager := auth.NewDefaultJWTManager("test-secret")
+	user := &models.User{ID: uuid.New(), Role: models.UserRoleDispatcher}
+	token, err := jwtManager.GenerateAccessToken(user)
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+	disabledAt := time.Now()
+
+	tests := []struct {
+		name           string
+		reader         *stubUserStateReader
+		expectedStatus int
+		expectedRole   models.UserRole
+	}{
+		{
+			name:           "Active user passes with token role",
+			reader:         &stubUserStateReader{user: &models.User{ID: user.ID, Role: models.UserRoleDispatcher}},
+			expectedStatus: http.StatusOK,
+			expectedRole:   models.UserRoleDispatcher,
+		},
+		{
+			name:           "Role change applies before the token expires",
+			reader:         &stubUserStateReader{user: &models.User{ID: user.ID, Role: models.UserRoleDriver}},
+			expectedStatus: http.StatusOK,
+			expectedRole:   models.UserRoleDriver,
+		},
+		{
+			name:           "Disabled user is rejected with a valid token",
+			reader:         &stubUserStateReader{user: &models.User{ID: user.ID, Role: models.UserRoleDispatcher, DisabledAt: &disabledAt}},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Deleted user is rejected",
+			reader:         &stubUserStateReader{err: domainerr.NotFound(domainerr.CodeUserNotFound, "user not found")},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Lookup failure is an internal error",
+			reader:         &stubUserStateReader{err: errors.New("failed to get user: connection refused")},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			middleware := NewAuthMiddleware(jwtManager, tt.reader, nil)
+			req := httptest.NewRequest("GET", "/test", http.NoBody)
+			req.Header.Set("Authorization", "Bearer "+token)
+			rr := httptest.NewRecorder()
+
+			var role models.UserRole
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				role, _ = GetUserRoleFromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			})
+
+			middleware.RequireAuth(handler).ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+			if tt.expectedStatus == http.StatusOK && role != tt.expectedRole {
+				t.Errorf("expected role %s in context, got %s", tt.expectedRole, role)
+			}
+		})
+	}
+}
+
+// stubAPIKeyAuthenticator returns a fixed API key or error and counts the recorded uses
+type stubAPIKeyAuthenticator struct {
+	apiKey *models.APIKey
+	err    error
+	uses   int
+}
+
+func (s *stubAPIKeyAuthenticator) Authenticate(ctx context.Context, key string) (*models.APIKey, error) {
+	s.uses++
+	return s.apiKey, s.err
+}
+
+func (s *stubAPIKeyAuthenticator) Resolve(ctx context.Context, key string) (*models.APIKey, error) {
+	return s.apiKey, s.err
+}
+
+func TestAuthMiddleware_APIKey(t *testing.T) {
+	jwtManager := auth.NewDefaultJWTManager("test-secret")
+	apiKey := &models.APIKey{ID: uuid.New(), Role: models.UserRoleDispatcher, Scopes: []string{"orders:read"}}
+
+	tests := []struct {
+		name           string
+		authenticator  *stubAPIKeyAuthenticator
+		userOnly       bool
+		expectedStatus int
+	}{
+		{
+			name:           "Valid key authenticates with its role",
+			authenticator:  &stubAPIKeyAuthenticator{apiKey: apiKey},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid key is rejected",
+			authenticator:  &stubAPIKeyAuthenticator{err: domainerr.Unauthorized(domainerr.CodeInvalidAPIKey, "invalid API key")},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Lookup failure is an internal error",
+			authenticator:  &stubAPIKeyAuthenticator{err: errors.New("failed to get API key: connection refused")},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "User-only endpoints reject keys",
+			authenticator:  &stubAPIKeyAuthenticator{apiKey: apiKey},
+			userOnly:       true,
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			middleware := NewAuthMiddleware(jwtManager, nil, tt.authenticator)
+			req := httptest.NewRequest("GET", "/test", http.NoBody)
+			req.Header.Set(APIKeyHeader, "evk_test")
+			rr := httptest.NewRecorder()
+
+			var role models.UserRole
+			var hasUserID, hasAPIKey bool
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				role, _ = GetUserRoleFromContext(r.Context())
+				_, hasUserID = GetUserIDFromContext(r.Context())
+				_, hasAPIKey = GetAPIKeyFromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			})
+
+			protect := middleware.RequireAuth
+			if tt.userOnly {
+				protect = middleware.RequireUserAuth
+			}
+			protect(handler).ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+			if tt.expectedStatus == http.StatusOK {
+				if role != apiKey.Role || !hasAPIKey {
+					t.Errorf("expected API key with role %s in context, got role %s", apiKey.Role, role)
+				}
+				if hasUserID {
+					t.Error("expected no user ID in context for an API key request")
+				}
+			}
+		})
+	}
+
+	t.Run("Keys are rejected when not configured", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/test", http.NoBody)
+		req.Header.Set(APIKeyHeader, "evk_test")
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		NewAuthMiddleware(jwtManager, nil, nil).RequireAuth(handler).ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusUnauthorized {
+			t.Errorf("expected status %d, got %d", http.StatusUnauthorized, rr.Code)
+		}
+	})
+}
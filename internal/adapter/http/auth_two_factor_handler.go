@@ -0,0 +1,164 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"eco-van-api/internal/models"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// LoginTwoFactor handles the second login step of users with two-factor authentication
+func (h *AuthHandler) LoginTwoFactor(w http.ResponseWriter, r *http.Request) {
+	var req models.TwoFactorLoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteBadRequest(w, "Invalid request body")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteError(w, err, "Validation failed")
+		return
+	}
+
+	response, err := h.authService.LoginTwoFactor(r.Context(), &req, clientInfo(r))
+	if err != nil {
+		var throttled *models.LoginThrottledError
+		switch {
+		case errors.As(err, &throttled):
+			WriteTooManyRequests(w, "Too many failed login attempts, try again later", throttled.RetryAfterSeconds())
+		default:
+			WriteError(w, err, "Failed to log in")
+		}
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, response)
+}
+
+// GetTwoFactorStatus handles retrieval of the two-factor authentication state of the current user
+func (h *AuthHandler) GetTwoFactorStatus(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteUnauthorized(w, "User ID not found in context")
+		return
+	}
+
+	response, err := h.authService.GetTwoFactorStatus(r.Context(), userID)
+	if err != nil {
+		WriteError(w, err, "Failed to get two-factor authentication status")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, response)
+}
+
+// EnrollTwoFactor handles starting a TOTP enrollment of the current user
+func (h *AuthHandler) EnrollTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteUnauthorized(w, "User ID not found in context")
+		return
+	}
+
+	response, err := h.authService.EnrollTwoFactor(r.Context(), userID)
+	if err != nil {
+		WriteError(w, err, "Failed to start two-factor enrollment")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, response)
+}
+
+// ConfirmTwoFactor handles enabling two-factor authentication with a code of the pending enrollment
+func (h *AuthHandler) ConfirmTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteUnauthorized(w, "User ID not found in context")
+		return
+	}
+
+	req, ok := decodeTwoFactorCodeRequest(w, r)
+	if !ok {
+		return
+	}
+
+	response, err := h.authService.ConfirmTwoFactor(r.Context(), userID, req)
+	if err != nil {
+		WriteError(w, err, "Failed to enable two-factor authentication")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, response)
+}
+
+// RegenerateRecoveryCodes handles replacing the recovery codes of the current user
+func (h *AuthHandler) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteUnauthorized(w, "User ID not found in context")
+		return
+	}
+
+	req, ok := decodeTwoFactorCodeRequest(w, r)
+	if !ok {
+		return
+	}
+
+	response, err := h.authService.RegenerateRecoveryCodes(r.Context(), userID, req)
+	if err != nil {
+		WriteError(w, err, "Failed to regenerate recovery codes")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, response)
+}
+
+// DisableTwoFactor handles turning off two-factor authentication of the current user
+func (h *AuthHandler) DisableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteUnauthorized(w, "User ID not found in context")
+		return
+	}
+
+	req, ok := decodeTwoFactorCodeRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.authService.DisableTwoFactor(r.Context(), userID, req); err != nil {
+		WriteError(w, err, "Failed to disable two-factor authentication")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ResetTwoFactor handles admin requests to remove the two-factor authentication of a user
+func (h *AuthHandler) ResetTwoFactor(w http.ResponseWriter, r *http.Request) {
+	if err := h.authService.ResetTwoFactor(r.Context(), chi.URLParam(r, "id")); err != nil {
+		WriteError(w, err, "Failed to reset two-factor authentication")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodeTwoFactorCodeRequest decodes and validates a request carrying a two-factor code
+func decodeTwoFactorCodeRequest(w http.ResponseWriter, r *http.Request) (*models.TwoFactorCodeRequest, bool) {
+	var req models.TwoFactorCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteBadRequest(w, "Invalid request body")
+		return nil, false
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteError(w, err, "Validation failed")
+		return nil, false
+	}
+
+	return &req, true
+}
@@ -0,0 +1,84 @@
+package http
+
+import (
+	"net/http"
+
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/port"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+// CancellationReasonHandler handles HTTP requests for the cancellation reason taxonomy
+type CancellationReasonHandler struct {
+	reasonService port.CancellationReasonService
+	validate      *validator.Validate
+}
+
+// NewCancellationReasonHandler creates a new cancellation reason handler
+func NewCancellationReasonHandler(reasonService port.CancellationReasonService) *CancellationReasonHandler {
+	return &CancellationReasonHandler{
+		reasonService: reasonService,
+		validate:      newValidator(),
+	}
+}
+
+// ListCancellationReasons handles GET /api/v1/cancellation-reasons
+func (h *CancellationReasonHandler) ListCancellationReasons(w http.ResponseWriter, r *http.Request) {
+	includeInactive := r.URL.Query().Get("includeInactive") == "true"
+
+	response, err := h.reasonService.List(r.Context(), includeInactive)
+	if err != nil {
+		WriteError(w, err, "Failed to list cancellation reasons")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, response)
+}
+
+// CreateCancellationReason handles POST /api/v1/cancellation-reasons
+func (h *CancellationReasonHandler) CreateCancellationReason(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateCancellationReasonRequest
+	if err := ParseJSON(r, &req); err != nil {
+		WriteBadRequest(w, "Invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		WriteInvalidRequest(w, err)
+		return
+	}
+
+	reason, err := h.reasonService.Create(r.Context(), req)
+	if err != nil {
+		WriteError(w, err, "Failed to create cancellation reason")
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, reason)
+}
+
+// UpdateCancellationReason handles PUT /api/v1/cancellation-reasons/{code}
+func (h *CancellationReasonHandler) UpdateCancellationReason(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+
+	var req models.UpdateCancellationReasonRequest
+	if err := ParseJSON(r, &req); err != nil {
+		WriteBadRequest(w, "Invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		WriteInvalidRequest(w, err)
+		return
+	}
+
+	reason, err := h.reasonService.Update(r.Context(), code, req)
+	if err != nil {
+		WriteError(w, err, "Failed to update cancellation reason")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, reason)
+}
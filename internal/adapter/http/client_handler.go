@@ -23,7 +23,7 @@ type clientHandler struct {
 func NewClientHandler(clientService port.ClientService) *clientHandler {
 	return &clientHandler{
 		clientService: clientService,
-		validate:      validator.New(),
+		validate:      newValidator(),
 	}
 }
 
@@ -64,7 +64,7 @@ func (h *clientHandler) ListClients(w http.ResponseWriter, r *http.Request) {
 	// Get clients from service
 	response, err := h.clientService.List(r.Context(), req)
 	if err != nil {
-		WriteInternalError(w, "Failed to list clients")
+		WriteError(w, err, "Failed to list clients")
 		return
 	}
 
@@ -86,18 +86,14 @@ func (h *clientHandler) CreateClient(w http.ResponseWriter, r *http.Request) {
 
 	// Validate request
 	if err := h.validate.Struct(req); err != nil {
-		WriteValidationError(w, "Validation failed")
+		WriteInvalidRequest(w, err)
 		return
 	}
 
 	// Create client via service
 	response, err := h.clientService.Create(r.Context(), req)
 	if err != nil {
-		if strings.Contains(err.Error(), "already exists") {
-			WriteConflict(w, "Client creation failed")
-			return
-		}
-		WriteInternalError(w, "Failed to create client")
+		WriteError(w, err, "Failed to create client")
 		return
 	}
 
@@ -119,11 +115,7 @@ func (h *clientHandler) GetClient(w http.ResponseWriter, r *http.Request) {
 	// Get client from service
 	response, err := h.clientService.GetByID(r.Context(), id)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			WriteNotFound(w, "Client not found")
-			return
-		}
-		WriteInternalError(w, "Failed to get client")
+		WriteError(w, err, "Failed to get client")
 		return
 	}
 
@@ -154,22 +146,14 @@ func (h *clientHandler) UpdateClient(w http.ResponseWriter, r *http.Request) {
 
 	// Validate request
 	if err := h.validate.Struct(req); err != nil {
-		WriteValidationError(w, "Validation failed")
+		WriteInvalidRequest(w, err)
 		return
 	}
 
 	// Update client via service
 	response, err := h.clientService.Update(r.Context(), id, req)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			WriteNotFound(w, "Client not found")
-			return
-		}
-		if strings.Contains(err.Error(), "already exists") {
-			WriteConflict(w, "Client update failed")
-			return
-		}
-		WriteInternalError(w, "Failed to update client")
+		WriteError(w, err, "Failed to update client")
 		return
 	}
 
@@ -191,11 +175,7 @@ func (h *clientHandler) DeleteClient(w http.ResponseWriter, r *http.Request) {
 	// Delete client via service
 	err = h.clientService.Delete(r.Context(), id)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			WriteNotFound(w, "Client not found")
-			return
-		}
-		WriteInternalError(w, "Failed to delete client")
+		WriteError(w, err, "Failed to delete client")
 		return
 	}
 
@@ -219,19 +199,7 @@ func (h *clientHandler) RestoreClient(w http.ResponseWriter, r *http.Request) {
 	// Restore client via service
 	response, err := h.clientService.Restore(r.Context(), id)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			WriteNotFound(w, "Client not found")
-			return
-		}
-		if strings.Contains(err.Error(), "not deleted") {
-			WriteBadRequest(w, "Client is not deleted")
-			return
-		}
-		if strings.Contains(err.Error(), "already taken") {
-			WriteConflict(w, "Cannot restore client")
-			return
-		}
-		WriteInternalError(w, "Failed to restore client")
+		WriteError(w, err, "Failed to restore client")
 		return
 	}
 
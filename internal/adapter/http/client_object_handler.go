@@ -21,7 +21,7 @@ type clientObjectHandler struct {
 func NewClientObjectHandler(clientObjectService port.ClientObjectService) *clientObjectHandler {
 	return &clientObjectHandler{
 		clientObjectService: clientObjectService,
-		validate:            validator.New(),
+		validate:            newValidator(),
 	}
 }
 
@@ -66,7 +66,7 @@ func (h *clientObjectHandler) ListClientObjects(w http.ResponseWriter, r *http.R
 	// Get client objects
 	response, err := h.clientObjectService.List(r.Context(), clientID, req)
 	if err != nil {
-		WriteInternalError(w, "Failed to list client objects")
+		WriteError(w, err, "Failed to list client objects")
 		return
 	}
 
@@ -92,22 +92,14 @@ func (h *clientObjectHandler) CreateClientObject(w http.ResponseWriter, r *http.
 
 	// Validate request
 	if err := h.validate.Struct(req); err != nil {
-		WriteValidationError(w, "Validation failed")
+		WriteInvalidRequest(w, err)
 		return
 	}
 
 	// Create client object
 	response, err := h.clientObjectService.Create(r.Context(), clientID, req)
 	if err != nil {
-		if err.Error() == ErrClientNotFound {
-			WriteNotFound(w, "Client not found")
-			return
-		}
-		if err.Error()[:len(ErrClientObjectNamePrefix)] == ErrClientObjectNamePrefix {
-			WriteConflict(w, "Client object with this name already exists for this client")
-			return
-		}
-		WriteInternalError(w, "Failed to create client object")
+		WriteError(w, err, "Failed to create client object")
 		return
 	}
 
@@ -137,19 +129,7 @@ func (h *clientObjectHandler) GetClientObject(w http.ResponseWriter, r *http.Req
 	// Get client object
 	response, err := h.clientObjectService.GetByID(r.Context(), clientID, id, includeDeleted)
 	if err != nil {
-		if err.Error() == ErrClientNotFound {
-			WriteNotFound(w, "Client not found")
-			return
-		}
-		if err.Error() == ErrClientObjectNotFound {
-			WriteNotFound(w, "Client object not found")
-			return
-		}
-		if err.Error()[:len(ErrClientObjectNamePrefix)] == ErrClientObjectNamePrefix {
-			WriteConflict(w, "Client object with this name already exists for this client")
-			return
-		}
-		WriteInternalError(w, "Failed to get client object")
+		WriteError(w, err, "Failed to get client object")
 		return
 	}
 
@@ -182,26 +162,14 @@ func (h *clientObjectHandler) UpdateClientObject(w http.ResponseWriter, r *http.
 
 	// Validate request
 	if err := h.validate.Struct(req); err != nil {
-		WriteValidationError(w, "Validation failed")
+		WriteInvalidRequest(w, err)
 		return
 	}
 
 	// Update client object
 	response, err := h.clientObjectService.Update(r.Context(), clientID, id, req)
 	if err != nil {
-		if err.Error() == ErrClientNotFound {
-			WriteNotFound(w, "Client not found")
-			return
-		}
-		if err.Error() == ErrClientObjectNotFound {
-			WriteNotFound(w, "Client object not found")
-			return
-		}
-		if err.Error()[:len(ErrClientObjectNamePrefix)] == ErrClientObjectNamePrefix {
-			WriteConflict(w, err.Error())
-			return
-		}
-		WriteInternalError(w, "Failed to update client object")
+		WriteError(w, err, "Failed to update client object")
 		return
 	}
 
@@ -228,19 +196,7 @@ func (h *clientObjectHandler) DeleteClientObject(w http.ResponseWriter, r *http.
 	// Delete client object
 	err = h.clientObjectService.Delete(r.Context(), clientID, id)
 	if err != nil {
-		if err.Error() == ErrClientNotFound {
-			WriteNotFound(w, "Client not found")
-			return
-		}
-		if err.Error() == ErrClientObjectNotFound {
-			WriteNotFound(w, "Client object not found")
-			return
-		}
-		if err.Error()[:len(ErrCannotDeleteClientObject)] == ErrCannotDeleteClientObject {
-			WriteConflict(w, err.Error())
-			return
-		}
-		WriteInternalError(w, "Failed to delete client object")
+		WriteError(w, err, "Failed to delete client object")
 		return
 	}
 
@@ -267,19 +223,7 @@ func (h *clientObjectHandler) RestoreClientObject(w http.ResponseWriter, r *http
 	// Restore client object
 	response, err := h.clientObjectService.Restore(r.Context(), clientID, id)
 	if err != nil {
-		if err.Error() == ErrClientNotFound {
-			WriteNotFound(w, "Client not found")
-			return
-		}
-		if err.Error() == ErrClientObjectNotFound {
-			WriteNotFound(w, "Client object not found")
-			return
-		}
-		if err.Error()[:len(ErrClientObjectNamePrefix)] == ErrClientObjectNamePrefix {
-			WriteConflict(w, err.Error())
-			return
-		}
-		WriteInternalError(w, "Failed to restore client object")
+		WriteError(w, err, "Failed to restore client object")
 		return
 	}
 
@@ -0,0 +1,43 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"eco-van-api/internal/port"
+)
+
+// DispatchHandler handles HTTP requests for the dispatch board
+type DispatchHandler struct {
+	dispatchService port.DispatchService
+}
+
+// NewDispatchHandler creates a new dispatch board handler
+func NewDispatchHandler(dispatchService port.DispatchService) *DispatchHandler {
+	return &DispatchHandler{
+		dispatchService: dispatchService,
+	}
+}
+
+// GetBoard handles GET /api/v1/dispatch?date=YYYY-MM-DD
+func (h *DispatchHandler) GetBoard(w http.ResponseWriter, r *http.Request) {
+	dateParam := r.URL.Query().Get("date")
+	if dateParam == "" {
+		WriteBadRequest(w, "date query parameter is required")
+		return
+	}
+
+	date, err := time.Parse("2006-01-02", dateParam)
+	if err != nil {
+		WriteBadRequest(w, "Invalid date format, expected YYYY-MM-DD")
+		return
+	}
+
+	board, err := h.dispatchService.GetBoard(r.Context(), date)
+	if err != nil {
+		WriteError(w, err, "Failed to get dispatch board")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, board)
+}
@@ -5,7 +5,6 @@ import (
 	"eco-van-api/internal/port"
 	"net/http"
 	"strconv"
-	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
@@ -21,7 +20,7 @@ type DriverHandler struct {
 func NewDriverHandler(driverService port.DriverService) *DriverHandler {
 	return &DriverHandler{
 		driverService: driverService,
-		validate:      validator.New(),
+		validate:      newValidator(),
 	}
 }
 
@@ -69,7 +68,7 @@ func (h *DriverHandler) ListDrivers(w http.ResponseWriter, r *http.Request) {
 	// Call service
 	drivers, err := h.driverService.List(r.Context(), req)
 	if err != nil {
-		WriteInternalError(w, "Failed to list drivers")
+		WriteError(w, err, "Failed to list drivers")
 		return
 	}
 
@@ -90,11 +89,7 @@ func (h *DriverHandler) GetDriver(w http.ResponseWriter, r *http.Request) {
 	// Call service
 	driver, err := h.driverService.GetByID(r.Context(), id)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			WriteNotFound(w, "Driver not found")
-			return
-		}
-		WriteInternalError(w, "Failed to get driver")
+		WriteError(w, err, "Failed to get driver")
 		return
 	}
 
@@ -115,18 +110,14 @@ func (h *DriverHandler) CreateDriver(w http.ResponseWriter, r *http.Request) {
 
 	// Validate request
 	if err := h.validate.Struct(req); err != nil {
-		WriteValidationError(w, "Validation failed")
+		WriteInvalidRequest(w, err)
 		return
 	}
 
 	// Call service
 	driver, err := h.driverService.Create(r.Context(), req)
 	if err != nil {
-		if strings.Contains(err.Error(), "already exists") {
-			WriteConflict(w, "Driver with this license number already exists")
-			return
-		}
-		WriteInternalError(w, "Failed to create driver")
+		WriteError(w, err, "Failed to create driver")
 		return
 	}
 
@@ -155,22 +146,14 @@ func (h *DriverHandler) UpdateDriver(w http.ResponseWriter, r *http.Request) {
 
 	// Validate request
 	if err := h.validate.Struct(req); err != nil {
-		WriteValidationError(w, "Validation failed")
+		WriteInvalidRequest(w, err)
 		return
 	}
 
 	// Call service
 	driver, err := h.driverService.Update(r.Context(), id, req)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			WriteNotFound(w, "Driver not found")
-			return
-		}
-		if strings.Contains(err.Error(), "already exists") {
-			WriteConflict(w, "Driver with this license number already exists")
-			return
-		}
-		WriteInternalError(w, "Failed to update driver")
+		WriteError(w, err, "Failed to update driver")
 		return
 	}
 
@@ -191,15 +174,7 @@ func (h *DriverHandler) DeleteDriver(w http.ResponseWriter, r *http.Request) {
 	// Call service
 	err = h.driverService.Delete(r.Context(), id)
 	if err != nil {
-		if strings.Contains(err.Error(), "while assigned to transport") {
-			WriteConflict(w, "Cannot delete driver while assigned to transport")
-			return
-		}
-		if strings.Contains(err.Error(), "not found") {
-			WriteNotFound(w, "Driver not found")
-			return
-		}
-		WriteInternalError(w, "Failed to delete driver")
+		WriteError(w, err, "Failed to delete driver")
 		return
 	}
 
@@ -220,15 +195,7 @@ func (h *DriverHandler) RestoreDriver(w http.ResponseWriter, r *http.Request) {
 	// Call service
 	driver, err := h.driverService.Restore(r.Context(), id)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			WriteNotFound(w, "Driver not found")
-			return
-		}
-		if strings.Contains(err.Error(), "not soft-deleted") {
-			WriteBadRequest(w, "Driver is not soft-deleted")
-			return
-		}
-		WriteInternalError(w, "Failed to restore driver")
+		WriteError(w, err, "Failed to restore driver")
 		return
 	}
 
@@ -280,10 +247,65 @@ func (h *DriverHandler) ListAvailableDrivers(w http.ResponseWriter, r *http.Requ
 	// Call service
 	drivers, err := h.driverService.ListAvailable(r.Context(), req)
 	if err != nil {
-		WriteInternalError(w, "Failed to list available drivers")
+		WriteError(w, err, "Failed to list available drivers")
 		return
 	}
 
 	// Return response
 	WriteJSON(w, http.StatusOK, drivers)
 }
+
+// LinkUser handles PUT /v1/drivers/{id}/link-user
+func (h *DriverHandler) LinkUser(w http.ResponseWriter, r *http.Request) {
+	// Parse driver ID
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		WriteBadRequest(w, "Invalid driver ID")
+		return
+	}
+
+	// Parse request body
+	var req models.LinkDriverUserRequest
+	if err := ParseJSON(r, &req); err != nil {
+		WriteBadRequest(w, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	if err := h.validate.Struct(req); err != nil {
+		WriteInvalidRequest(w, err)
+		return
+	}
+
+	// Call service
+	driver, err := h.driverService.LinkUser(r.Context(), id, req)
+	if err != nil {
+		WriteError(w, err, "Failed to link user to driver")
+		return
+	}
+
+	// Return response
+	WriteJSON(w, http.StatusOK, driver)
+}
+
+// UnlinkUser handles DELETE /v1/drivers/{id}/user
+func (h *DriverHandler) UnlinkUser(w http.ResponseWriter, r *http.Request) {
+	// Parse driver ID
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		WriteBadRequest(w, "Invalid driver ID")
+		return
+	}
+
+	// Call service
+	driver, err := h.driverService.UnlinkUser(r.Context(), id)
+	if err != nil {
+		WriteError(w, err, "Failed to unlink user from driver")
+		return
+	}
+
+	// Return response
+	WriteJSON(w, http.StatusOK, driver)
+}
@@ -0,0 +1,129 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/port"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// DriverSelfHandler handles HTTP requests of DRIVER users for their own driver profile
+type DriverSelfHandler struct {
+	driverSelfService port.DriverSelfService
+	validate          *validator.Validate
+}
+
+// NewDriverSelfHandler creates a new driver self-service handler
+func NewDriverSelfHandler(driverSelfService port.DriverSelfService) *DriverSelfHandler {
+	return &DriverSelfHandler{
+		driverSelfService: driverSelfService,
+		validate:          newValidator(),
+	}
+}
+
+// GetProfile handles GET /api/v1/me/driver
+func (h *DriverSelfHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteUnauthorized(w, "User ID not found in context")
+		return
+	}
+
+	profile, err := h.driverSelfService.GetProfile(r.Context(), userID)
+	if err != nil {
+		WriteError(w, err, "Failed to get driver profile")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, profile)
+}
+
+// ListOrders handles GET /api/v1/me/driver/orders?date=YYYY-MM-DD, defaulting to today
+func (h *DriverSelfHandler) ListOrders(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteUnauthorized(w, "User ID not found in context")
+		return
+	}
+
+	now := time.Now()
+	date := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if dateParam := r.URL.Query().Get("date"); dateParam != "" {
+		parsed, err := time.Parse("2006-01-02", dateParam)
+		if err != nil {
+			WriteBadRequest(w, "Invalid date format. Expected YYYY-MM-DD")
+			return
+		}
+		date = parsed
+	}
+
+	orders, err := h.driverSelfService.ListOrders(r.Context(), userID, date)
+	if err != nil {
+		WriteError(w, err, "Failed to list driver orders")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, orders)
+}
+
+// StartOrder handles POST /api/v1/me/driver/orders/{id}/start
+func (h *DriverSelfHandler) StartOrder(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteUnauthorized(w, "User ID not found in context")
+		return
+	}
+
+	orderID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid order ID")
+		return
+	}
+
+	order, err := h.driverSelfService.StartOrder(r.Context(), userID, orderID)
+	if err != nil {
+		WriteError(w, err, "Failed to start order")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, order)
+}
+
+// CompleteOrder handles POST /api/v1/me/driver/orders/{id}/complete
+func (h *DriverSelfHandler) CompleteOrder(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteUnauthorized(w, "User ID not found in context")
+		return
+	}
+
+	orderID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid order ID")
+		return
+	}
+
+	var req models.OrderCompletionRequest
+	if err := ParseJSON(r, &req); err != nil {
+		WriteBadRequest(w, "Invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		WriteInvalidRequest(w, err)
+		return
+	}
+
+	order, err := h.driverSelfService.CompleteOrder(r.Context(), userID, orderID, req)
+	if err != nil {
+		WriteError(w, err, "Failed to complete order")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, order)
+}
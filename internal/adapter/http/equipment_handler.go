@@ -22,7 +22,7 @@ type EquipmentHandler struct {
 func NewEquipmentHandler(equipmentService port.EquipmentService) *EquipmentHandler {
 	return &EquipmentHandler{
 		equipmentService: equipmentService,
-		validate:         validator.New(),
+		validate:         newValidator(),
 	}
 }
 
@@ -86,7 +86,7 @@ func (h *EquipmentHandler) ListEquipment(w http.ResponseWriter, r *http.Request)
 	// Call service
 	response, err := h.equipmentService.List(r.Context(), req)
 	if err != nil {
-		WriteInternalError(w, "Failed to list equipment")
+		WriteError(w, err, "Failed to list equipment")
 		return
 	}
 
@@ -107,11 +107,7 @@ func (h *EquipmentHandler) GetEquipment(w http.ResponseWriter, r *http.Request)
 	// Call service
 	equipment, err := h.equipmentService.GetByID(r.Context(), id)
 	if err != nil {
-		if err.Error() == ErrEquipmentNotFound {
-			WriteNotFound(w, "Equipment not found")
-			return
-		}
-		WriteInternalError(w, "Failed to get equipment")
+		WriteError(w, err, "Failed to get equipment")
 		return
 	}
 
@@ -130,24 +126,14 @@ func (h *EquipmentHandler) CreateEquipment(w http.ResponseWriter, r *http.Reques
 
 	// Validate request
 	if err := h.validate.Struct(req); err != nil {
-		WriteValidationError(w, "Validation failed")
+		WriteInvalidRequest(w, err)
 		return
 	}
 
 	// Call service
 	equipment, err := h.equipmentService.Create(r.Context(), req)
 	if err != nil {
-		if err.Error() == "validation failed: equipment must be assigned to exactly one of: transport, client object, or warehouse" {
-			WriteProblemWithType(w, http.StatusUnprocessableEntity,
-				"/errors/unprocessable-entity",
-				"Equipment must be assigned to exactly one of: transport, client object, or warehouse")
-			return
-		}
-		if err.Error() == "equipment with number '"+*req.Number+"' already exists" {
-			WriteConflict(w, "Equipment with this number already exists")
-			return
-		}
-		WriteInternalError(w, "Failed to create equipment")
+		WriteError(w, err, "Failed to create equipment")
 		return
 	}
 
@@ -174,34 +160,14 @@ func (h *EquipmentHandler) UpdateEquipment(w http.ResponseWriter, r *http.Reques
 
 	// Validate request
 	if err := h.validate.Struct(req); err != nil {
-		WriteValidationError(w, "Validation failed")
+		WriteInvalidRequest(w, err)
 		return
 	}
 
 	// Call service
 	equipment, err := h.equipmentService.Update(r.Context(), id, req)
 	if err != nil {
-		if err.Error() == "validation failed: equipment must be assigned to exactly one of: transport, client object, or warehouse" {
-			WriteProblemWithType(w, http.StatusUnprocessableEntity,
-				"/errors/unprocessable-entity",
-				"Equipment must be assigned to exactly one of: transport, client object, or warehouse")
-			return
-		}
-		if err.Error() == ErrEquipmentNotFound {
-			WriteNotFound(w, "Equipment not found")
-			return
-		}
-		if err.Error() == "cannot change equipment placement while attached to transport" {
-			WriteProblemWithType(w, http.StatusConflict,
-				"/errors/conflict",
-				"Cannot change equipment placement while attached to transport")
-			return
-		}
-		if err.Error() == "equipment with number '"+*req.Number+"' already exists" {
-			WriteConflict(w, "Equipment with this number already exists")
-			return
-		}
-		WriteInternalError(w, "Failed to update equipment")
+		WriteError(w, err, "Failed to update equipment")
 		return
 	}
 
@@ -222,17 +188,7 @@ func (h *EquipmentHandler) DeleteEquipment(w http.ResponseWriter, r *http.Reques
 	// Call service
 	err = h.equipmentService.Delete(r.Context(), id)
 	if err != nil {
-		if err.Error() == ErrEquipmentNotFound {
-			WriteNotFound(w, "Equipment not found")
-			return
-		}
-		if err.Error() == "cannot delete equipment while attached to transport" {
-			WriteProblemWithType(w, http.StatusConflict,
-				"/errors/conflict",
-				"Cannot delete equipment while attached to transport")
-			return
-		}
-		WriteInternalError(w, "Failed to delete equipment")
+		WriteError(w, err, "Failed to delete equipment")
 		return
 	}
 
@@ -253,19 +209,7 @@ func (h *EquipmentHandler) RestoreEquipment(w http.ResponseWriter, r *http.Reque
 	// Call service
 	equipment, err := h.equipmentService.Restore(r.Context(), id)
 	if err != nil {
-		if err.Error() == ErrEquipmentNotFound {
-			WriteNotFound(w, "Equipment not found")
-			return
-		}
-		if err.Error() == "equipment is not deleted" {
-			WriteBadRequest(w, "Equipment is not deleted")
-			return
-		}
-		if err.Error() == "cannot restore equipment: number '"+*equipment.Number+"' conflicts with existing equipment" {
-			WriteConflict(w, "Cannot restore equipment: number conflicts with existing equipment")
-			return
-		}
-		WriteInternalError(w, "Failed to restore equipment")
+		WriteError(w, err, "Failed to restore equipment")
 		return
 	}
 
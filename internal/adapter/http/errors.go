@@ -0,0 +1,81 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"unicode"
+	"unicode/utf8"
+
+	"eco-van-api/internal/domainerr"
+)
+
+// kindProblems maps each domain error kind to the problem it is reported as
+var kindProblems = map[domainerr.Kind]Problem{
+	domainerr.KindNotFound:   CommonProblems[http.StatusNotFound],
+	domainerr.KindConflict:   CommonProblems[http.StatusConflict],
+	domainerr.KindValidation: CommonProblems[http.StatusUnprocessableEntity],
+	domainerr.KindInvalidTransition: {
+		Type:   ProblemTypeInvalidTransition,
+		Title:  "Invalid State Transition",
+		Status: http.StatusConflict,
+		Code:   "INVALID_TRANSITION",
+	},
+	domainerr.KindForbidden:    CommonProblems[http.StatusForbidden],
+	domainerr.KindUnauthorized: CommonProblems[http.StatusUnauthorized],
+	domainerr.KindInvalidInput: {
+		Type:   ProblemTypeInvalidInput,
+		Title:  "Bad Request",
+		Status: http.StatusBadRequest,
+		Code:   "INVALID_INPUT",
+	},
+	domainerr.KindPreconditionFailed: CommonProblems[http.StatusPreconditionFailed],
+}
+
+// codeProblems overrides the kind mapping for codes that have a more specific status
+var codeProblems = map[string]Problem{
+	domainerr.CodePhotoUnsupportedType: CommonProblems[http.StatusUnsupportedMediaType],
+}
+
+// ProblemFromError builds the problem for an error returned by a service. Domain errors are mapped by kind
+// (or code) and keep their message as detail; their cause is never shown, as it may carry database or provider
+// errors. Any other error is an internal error described by fallback.
+func ProblemFromError(err error, fallback string) Problem {
+	domainErr, ok := domainerr.As(err)
+	if !ok {
+		problem := CommonProblems[http.StatusInternalServerError]
+		problem.Detail = fallback
+		return problem
+	}
+
+	problem, ok := codeProblems[domainErr.Code]
+	if !ok {
+		problem, ok = kindProblems[domainErr.Kind]
+	}
+	if !ok {
+		problem = CommonProblems[http.StatusInternalServerError]
+	}
+	problem.Code = domainErr.Code
+	problem.Detail = capitalize(domainErr.Message)
+	problem.Fields = domainErr.Fields
+	problem.Errors = domainErr.FieldErrors
+	return problem
+}
+
+// WriteError writes the problem for an error returned by a service, see ProblemFromError. The detail and field
+// errors of a domain error are translated into the language of the response. Internal errors and the causes of
+// domain errors are left to the access log instead, see Middleware.AccessLog.
+func WriteError(w http.ResponseWriter, err error, fallback string) {
+	if domainErr, ok := domainerr.As(err); !ok || errors.Unwrap(domainErr) != nil {
+		recordError(w, err)
+	}
+	WriteProblem(w, localizeError(ProblemFromError(err, fallback), responseLanguage(w)))
+}
+
+// capitalize upper-cases the first letter of a message so it reads as a sentence in the detail
+func capitalize(message string) string {
+	r, size := utf8.DecodeRuneInString(message)
+	if r == utf8.RuneError {
+		return message
+	}
+	return string(unicode.ToUpper(r)) + message[size:]
+}
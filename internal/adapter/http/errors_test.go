@@ -0,0 +1,151 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"eco-van-api/internal/domainerr"
+)
+
+func TestProblemFromError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantType   string
+		wantDetail string
+	}{
+		{
+			name:       "not found",
+			err:        domainerr.NotFound(domainerr.CodeDriverNotFound, "driver not found"),
+			wantStatus: http.StatusNotFound,
+			wantType:   ProblemTypeNotFound,
+			wantDetail: "Driver not found",
+		},
+		{
+			name:       "conflict",
+			err:        domainerr.Conflict(domainerr.CodeWarehouseNameExists, "warehouse with name 'Main' already exists"),
+			wantStatus: http.StatusConflict,
+			wantType:   ProblemTypeConflict,
+			wantDetail: "Warehouse with name 'Main' already exists",
+		},
+		{
+			name:       "validation",
+			err:        domainerr.Validation(domainerr.CodeValidationFailed, "validation failed").Wrap(errors.New("bad date")),
+			wantStatus: http.StatusUnprocessableEntity,
+			wantType:   ProblemTypeValidationError,
+			wantDetail: "Validation failed",
+		},
+		{
+			name: "cause is not shown",
+			err: domainerr.Unauthorized(domainerr.CodeInvalidOIDCLogin, "invalid OIDC login").
+				Wrap(errors.New(`token endpoint returned 400: {"error":"invalid_grant"}`)),
+			wantStatus: http.StatusUnauthorized,
+			wantType:   ProblemTypeUnauthorized,
+			wantDetail: "Invalid OIDC login",
+		},
+		{
+			name:       "invalid transition",
+			err:        domainerr.InvalidTransition(domainerr.CodeDriverNotDeleted, "driver is not soft-deleted"),
+			wantStatus: http.StatusConflict,
+			wantType:   ProblemTypeInvalidTransition,
+			wantDetail: "Driver is not soft-deleted",
+		},
+		{
+			name:       "forbidden",
+			err:        domainerr.Forbidden(domainerr.CodeOrderNotOnDriverTransport, "order is not assigned to your transport"),
+			wantStatus: http.StatusForbidden,
+			wantType:   ProblemTypeForbidden,
+			wantDetail: "Order is not assigned to your transport",
+		},
+		{
+			name:       "code override",
+			err:        domainerr.Validation(domainerr.CodePhotoUnsupportedType, "unsupported photo type: image/gif"),
+			wantStatus: http.StatusUnsupportedMediaType,
+			wantType:   ProblemTypeUnsupportedMediaType,
+			wantDetail: "Unsupported photo type: image/gif",
+		},
+		{
+			name:       "wrapped domain error keeps its own message",
+			err:        fmt.Errorf("failed to get user: %w", domainerr.NotFound(domainerr.CodeUserNotFound, "user not found")),
+			wantStatus: http.StatusNotFound,
+			wantType:   ProblemTypeNotFound,
+			wantDetail: "User not found",
+		},
+		{
+			name:       "plain error is internal",
+			err:        errors.New("failed to get driver: connection refused"),
+			wantStatus: http.StatusInternalServerError,
+			wantType:   ProblemTypeInternalError,
+			wantDetail: "Failed to get driver",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problem := ProblemFromError(tt.err, "Failed to get driver")
+			if problem.Status != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, problem.Status)
+			}
+			if problem.Type != tt.wantType {
+				t.Errorf("Expected type %s, got %s", tt.wantType, problem.Type)
+			}
+			if problem.Detail != tt.wantDetail {
+				t.Errorf("Expected detail %q, got %q", tt.wantDetail, problem.Detail)
+			}
+		})
+	}
+}
+
+func TestWriteError_RecordsCause(t *testing.T) {
+	cause := errors.New("connection refused")
+	tests := map[string]struct {
+		err  error
+		want error
+	}{
+		"internal error":        {err: cause, want: cause},
+		"domain error cause":    {err: domainerr.Unauthorized(domainerr.CodeInvalidTwoFactorToken, "invalid token").Wrap(cause), want: cause},
+		"domain error no cause": {err: domainerr.NotFound(domainerr.CodeDriverNotFound, "driver not found")},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			w := &responseWriter{ResponseWriter: httptest.NewRecorder(), statusCode: http.StatusOK}
+			WriteError(w, tt.err, "Failed to get driver")
+
+			if tt.want == nil && w.err != nil {
+				t.Errorf("Expected no recorded error, got %v", w.err)
+			}
+			if tt.want != nil && !errors.Is(w.err, tt.want) {
+				t.Errorf("Expected the error to be recorded for the access log, got %v", w.err)
+			}
+		})
+	}
+}
+
+func TestWriteError_Fields(t *testing.T) {
+	w := httptest.NewRecorder()
+	err := domainerr.Conflict(domainerr.CodeTransportPlateExists, "transport with plate number A123BC already exists").
+		With("plateNo", "A123BC")
+
+	WriteError(w, err, "Failed to create transport")
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status %d, got %d", http.StatusConflict, w.Code)
+	}
+	if contentType := w.Header().Get("Content-Type"); contentType != "application/problem+json" {
+		t.Errorf("Expected problem content type, got %s", contentType)
+	}
+
+	var problem Problem
+	if err := json.NewDecoder(w.Body).Decode(&problem); err != nil {
+		t.Fatalf("Failed to decode problem: %v", err)
+	}
+	if problem.Fields["plateNo"] != "A123BC" {
+		t.Errorf("Expected plateNo field, got %v", problem.Fields)
+	}
+}
@@ -0,0 +1,90 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"eco-van-api/internal/models"
+)
+
+// entityTag is one entry of an If-Match or If-None-Match list
+type entityTag struct {
+	weak   bool
+	opaque string // the quoted tag, e.g. "3"
+}
+
+// versionETag formats the version of a resource as a strong entity tag, e.g. "3"
+func versionETag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// parseEntityTags parses a comma-separated list of entity tags; ok is false when the list is malformed
+func parseEntityTags(header string) (tags []entityTag, ok bool) {
+	rest := header
+	for {
+		rest = strings.TrimLeft(rest, " \t,")
+		if rest == "" {
+			return tags, true
+		}
+
+		var tag entityTag
+		rest, tag.weak = strings.CutPrefix(rest, "W/")
+		if !strings.HasPrefix(rest, `"`) {
+			return nil, false
+		}
+		end := strings.IndexByte(rest[1:], '"')
+		if end < 0 {
+			return nil, false
+		}
+		tag.opaque, rest = rest[:end+2], rest[end+2:]
+		tags = append(tags, tag)
+
+		rest = strings.TrimLeft(rest, " \t")
+		if rest != "" && rest[0] != ',' {
+			return nil, false
+		}
+	}
+}
+
+// parseIfMatch returns the versions a write is conditional on. It is nil without an If-Match header or for *,
+// which only requires the resource to exist; ok is false when the header is malformed. If-Match uses strong
+// comparison, so weak tags and tags that are not versions never match and the write fails its precondition.
+func parseIfMatch(r *http.Request) (match *models.VersionMatch, ok bool) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" || header == "*" {
+		return nil, true
+	}
+
+	tags, ok := parseEntityTags(header)
+	if !ok || len(tags) == 0 {
+		return nil, false
+	}
+
+	match = &models.VersionMatch{}
+	for _, tag := range tags {
+		if tag.weak {
+			continue
+		}
+		if version, err := strconv.Atoi(strings.Trim(tag.opaque, `"`)); err == nil {
+			match.Versions = append(match.Versions, version)
+		}
+	}
+	return match, true
+}
+
+// ifNoneMatch reports whether the If-None-Match header of r names etag, so the client's copy is current.
+// Weak tags match their strong counterpart.
+func ifNoneMatch(r *http.Request, etag string) bool {
+	header := strings.TrimSpace(r.Header.Get("If-None-Match"))
+	if header == "*" {
+		return true
+	}
+	tags, _ := parseEntityTags(header)
+	for _, tag := range tags {
+		if tag.opaque == etag {
+			return true
+		}
+	}
+	return false
+}
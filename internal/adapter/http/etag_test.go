@@ -0,0 +1,78 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestParseIfMatch(t *testing.T) {
+	tests := []struct {
+		header       string
+		wantOK       bool
+		wantVersions []int // nil for an unconditional write
+	}{
+		{header: "", wantOK: true},
+		{header: "*", wantOK: true},
+		{header: `"3"`, wantOK: true, wantVersions: []int{3}},
+		{header: ` "3" `, wantOK: true, wantVersions: []int{3}},
+		{header: `"3", "4"`, wantOK: true, wantVersions: []int{3, 4}},
+		{header: `W/"3", "4"`, wantOK: true, wantVersions: []int{4}},
+		// Weak and foreign tags are valid but never match
+		{header: `W/"3"`, wantOK: true, wantVersions: []int{}},
+		{header: `"abc"`, wantOK: true, wantVersions: []int{}},
+		{header: "3"},
+		{header: `"3`},
+		{header: `"3" "4"`},
+		{header: ","},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest("PUT", "/api/v1/orders/1", http.NoBody)
+		req.Header.Set("If-Match", tt.header)
+
+		match, ok := parseIfMatch(req)
+		if ok != tt.wantOK {
+			t.Errorf("parseIfMatch(%q) ok = %v, want %v", tt.header, ok, tt.wantOK)
+			continue
+		}
+		if (match == nil) != (tt.wantVersions == nil) {
+			t.Errorf("parseIfMatch(%q) = %v, want versions %v", tt.header, match, tt.wantVersions)
+			continue
+		}
+		if match != nil && !slices.Equal(match.Versions, tt.wantVersions) && len(match.Versions)+len(tt.wantVersions) > 0 {
+			t.Errorf("parseIfMatch(%q) versions = %v, want %v", tt.header, match.Versions, tt.wantVersions)
+		}
+		if match.Matches(3) != (tt.wantVersions == nil || slices.Contains(tt.wantVersions, 3)) {
+			t.Errorf("parseIfMatch(%q) matches version 3 = %v", tt.header, match.Matches(3))
+		}
+	}
+}
+
+func TestIfNoneMatch(t *testing.T) {
+	etag := versionETag(3)
+	if etag != `"3"` {
+		t.Fatalf("Expected the ETag \"3\", got %s", etag)
+	}
+
+	tests := map[string]bool{
+		"":            false,
+		"*":           true,
+		`"3"`:         true,
+		`W/"3"`:       true,
+		`"2", "3"`:    true,
+		`"2"`:         false,
+		`"30"`:        false,
+		`"2",W/"4"`:   false,
+		` "1" , "3" `: true,
+	}
+
+	for header, want := range tests {
+		req := httptest.NewRequest("GET", "/api/v1/orders/1", http.NoBody)
+		req.Header.Set("If-None-Match", header)
+		if got := ifNoneMatch(req, etag); got != want {
+			t.Errorf("ifNoneMatch(%q) = %v, want %v", header, got, want)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"eco-van-api/internal/domainerr"
+)
+
+// Languages problems are written in. Services and the validator describe errors in English; other languages
+// translate them through a catalog keyed by error code, so a missing translation falls back to English.
+const (
+	LanguageEnglish = "en"
+	LanguageRussian = "ru"
+)
+
+// ContentLanguageHeader announces the language of a translated problem, see WriteProblem
+const ContentLanguageHeader = "Content-Language"
+
+type languageKey struct{}
+
+// LanguageFromContext returns the language negotiated for the request by Middleware.Language, English when
+// none was
+func LanguageFromContext(ctx context.Context) string {
+	if language, ok := ctx.Value(languageKey{}).(string); ok {
+		return language
+	}
+	return LanguageEnglish
+}
+
+// languageWriter carries the negotiated language to the problem writers, which get the response writer but
+// not the request
+type languageWriter struct {
+	http.ResponseWriter
+	language string
+}
+
+// Unwrap returns the wrapped writer for http.ResponseController
+func (lw *languageWriter) Unwrap() http.ResponseWriter {
+	return lw.ResponseWriter
+}
+
+// catalog holds the translations of one language
+type catalog struct {
+	// titles translate problem titles by problem type
+	titles map[string]string
+	// details translate problem details by error code; {name} placeholders are filled from the problem fields
+	details map[string]string
+	// rules translate field error messages by message ID or rule; {field} is the failing field and {param}
+	// the parameter of the rule
+	rules map[string]string
+}
+
+// catalogs holds the translations of every supported language but English
+var catalogs = map[string]catalog{
+	LanguageRussian: ruCatalog,
+}
+
+// negotiateLanguage picks the supported language with the highest quality in an Accept-Language header,
+// e.g. ru for "ru-RU,ru;q=0.9,en;q=0.8"; fallback is used when the header names no supported language
+func negotiateLanguage(acceptLanguage, fallback string) string {
+	best, bestQuality := fallback, 0.0
+	for _, entry := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(entry, ";")
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+
+		language, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if language == "*" {
+			language = fallback
+		}
+		if !isSupportedLanguage(language) || quality <= bestQuality {
+			continue
+		}
+		best, bestQuality = language, quality
+	}
+	return best
+}
+
+// isSupportedLanguage reports whether problems can be written in language
+func isSupportedLanguage(language string) bool {
+	_, ok := catalogs[language]
+	return ok || language == LanguageEnglish
+}
+
+// responseLanguage returns the language negotiated for the response, English when none was
+func responseLanguage(w http.ResponseWriter) string {
+	language, _ := negotiatedLanguage(w)
+	return language
+}
+
+// negotiatedLanguage looks for the language Middleware.Language put on the response writer, unwrapping the
+// writers of later middleware; ok is false when there is none and the language defaults to English
+func negotiatedLanguage(w http.ResponseWriter) (language string, ok bool) {
+	for {
+		switch writer := w.(type) {
+		case *languageWriter:
+			return writer.language, true
+		case interface{ Unwrap() http.ResponseWriter }:
+			w = writer.Unwrap()
+		default:
+			return LanguageEnglish, false
+		}
+	}
+}
+
+// localizeTitle translates the title of a problem
+func localizeTitle(p Problem, language string) Problem {
+	if title, ok := catalogs[language].titles[p.Type]; ok {
+		p.Title = title
+	}
+	return p
+}
+
+// localizeError translates the detail of a problem built from a domain error by its code, and the messages of
+// its field errors by their rule. Texts without a translation stay in English.
+func localizeError(p Problem, language string) Problem {
+	cat, ok := catalogs[language]
+	if !ok {
+		return p
+	}
+
+	if template, ok := cat.details[p.Code]; ok {
+		args := make(map[string]string, len(p.Fields))
+		for key, value := range p.Fields {
+			args[key] = fmt.Sprint(value)
+		}
+		if detail, ok := fillTemplate(template, args); ok {
+			p.Detail = detail
+		}
+	}
+
+	if len(p.Errors) > 0 {
+		fieldErrs := make([]domainerr.FieldError, len(p.Errors))
+		copy(fieldErrs, p.Errors)
+		for i := range fieldErrs {
+			fieldErrs[i].Message = cat.fieldMessage(fieldErrs[i])
+		}
+		p.Errors = fieldErrs
+	}
+	return p
+}
+
+// fieldMessage translates the message of a field error, looking it up by message ID and then by rule
+func (c catalog) fieldMessage(fieldErr domainerr.FieldError) string {
+	template, ok := c.rules[fieldErr.MessageID]
+	if !ok {
+		template, ok = c.rules[fieldErr.Rule]
+	}
+	if !ok {
+		return fieldErr.Message
+	}
+
+	message, ok := fillTemplate(template, map[string]string{
+		"field": fieldName(fieldErr.Pointer),
+		"param": fieldErr.Param,
+	})
+	if !ok {
+		return fieldErr.Message
+	}
+	return message
+}
+
+// fieldName returns the name of the field a JSON pointer refers to, e.g. quantity for /items/0/quantity
+func fieldName(pointer string) string {
+	segments := strings.Split(pointer, "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		if _, err := strconv.Atoi(segments[i]); err != nil && segments[i] != "" {
+			return segments[i]
+		}
+	}
+	return ""
+}
+
+// fillTemplate replaces the {name} placeholders of template; it fails when an argument is missing or empty
+func fillTemplate(template string, args map[string]string) (string, bool) {
+	var result strings.Builder
+	for {
+		start := strings.IndexByte(template, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 {
+			break
+		}
+		value := args[template[start+1:start+end]]
+		if value == "" {
+			return "", false
+		}
+		result.WriteString(template[:start])
+		result.WriteString(value)
+		template = template[start+end+1:]
+	}
+	result.WriteString(template)
+	return result.String(), true
+}
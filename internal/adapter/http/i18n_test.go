@@ -0,0 +1,334 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"eco-van-api/internal/domainerr"
+)
+
+func TestNegotiateLanguage(t *testing.T) {
+	tests := map[string]string{
+		"":                              LanguageEnglish,
+		"ru":                            LanguageRussian,
+		"ru-RU,ru;q=0.9,en-US;q=0.8":    LanguageRussian,
+		"en-US,en;q=0.9,ru;q=0.8":       LanguageEnglish,
+		"de-DE,ru;q=0.5":                LanguageRussian,
+		"de-DE,fr;q=0.5":                LanguageEnglish,
+		"en;q=0.3, RU;q=0.7":            LanguageRussian,
+		"ru;q=0":                        LanguageEnglish,
+		"*":                             LanguageEnglish,
+		"ru;q=invalid,en":               LanguageEnglish,
+		"uk-UA, ru-RU;q=0.8, en;q=0.8 ": LanguageRussian,
+	}
+
+	for header, want := range tests {
+		if got := negotiateLanguage(header, LanguageEnglish); got != want {
+			t.Errorf("negotiateLanguage(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
+
+// withLanguage wraps a response writer the way Middleware.Language does
+func withLanguage(w http.ResponseWriter, language string) http.ResponseWriter {
+	return &languageWriter{ResponseWriter: w, language: language}
+}
+
+func TestMiddleware_Language(t *testing.T) {
+	var contextLanguage string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contextLanguage = LanguageFromContext(r.Context())
+		if r.URL.Path == "/ok" {
+			WriteJSON(w, http.StatusOK, map[string]string{})
+			return
+		}
+		WriteNotFound(w, "Driver not found")
+	})
+
+	// Responses other than problems are not translated and announce no language
+	req := httptest.NewRequest("GET", "/ok", http.NoBody)
+	req.Header.Set("Accept-Language", "ru")
+	w := httptest.NewRecorder()
+	(&Middleware{}).Language()(handler).ServeHTTP(w, req)
+
+	if contextLanguage != LanguageRussian {
+		t.Errorf("Expected language %q in the request context, got %q", LanguageRussian, contextLanguage)
+	}
+	if got := w.Header().Get(ContentLanguageHeader); got != "" {
+		t.Errorf("Expected no Content-Language on a JSON response, got %q", got)
+	}
+
+	req = httptest.NewRequest("GET", "/test", http.NoBody)
+	req.Header.Set("Accept-Language", "ru-RU,ru;q=0.9,en;q=0.8")
+	w = httptest.NewRecorder()
+	(&Middleware{}).Language()(handler).ServeHTTP(w, req)
+
+	if got := w.Header().Get(ContentLanguageHeader); got != LanguageRussian {
+		t.Errorf("Expected Content-Language %q, got %q", LanguageRussian, got)
+	}
+	if got := w.Header().Get("Vary"); got != "Accept-Language" {
+		t.Errorf("Expected Vary: Accept-Language, got %q", got)
+	}
+
+	var problem Problem
+	if err := json.NewDecoder(w.Body).Decode(&problem); err != nil {
+		t.Fatalf("Failed to decode problem: %v", err)
+	}
+	if problem.Title != "Не найдено" || problem.Code != domainerr.CodeDriverNotFound {
+		t.Errorf("Expected a Russian title and the %s code, got %+v", domainerr.CodeDriverNotFound, problem)
+	}
+	if problem.Detail != "Водитель не найден" {
+		t.Errorf("Expected the detail to be translated, got %q", problem.Detail)
+	}
+}
+
+func TestWriteProblemWithDetail_Codes(t *testing.T) {
+	w := httptest.NewRecorder()
+	WriteBadRequest(withLanguage(w, LanguageRussian), "Invalid If-Match header")
+
+	var problem Problem
+	if err := json.NewDecoder(w.Body).Decode(&problem); err != nil {
+		t.Fatalf("Failed to decode problem: %v", err)
+	}
+	if problem.Code != "INVALID_IF_MATCH" || problem.Detail != "Некорректный заголовок If-Match" {
+		t.Errorf("Expected the coded and translated detail, got %+v", problem)
+	}
+
+	// Details without a code keep the code of the status and stay in English
+	w = httptest.NewRecorder()
+	WriteBadRequest(withLanguage(w, LanguageRussian), "Something unexpected")
+
+	problem = Problem{}
+	if err := json.NewDecoder(w.Body).Decode(&problem); err != nil {
+		t.Fatalf("Failed to decode problem: %v", err)
+	}
+	if problem.Code != "BAD_REQUEST" || problem.Detail != "Something unexpected" {
+		t.Errorf("Expected the detail to be kept, got %+v", problem)
+	}
+}
+
+func TestWriteProblemWithFields_Localized(t *testing.T) {
+	w := httptest.NewRecorder()
+	WriteProblemWithFields(withLanguage(w, LanguageRussian), http.StatusForbidden, CodeAPIKeyScopeNotPermitted,
+		"API key scopes do not permit write orders", map[string]interface{}{"action": "write", "resource": "orders"})
+
+	var problem Problem
+	if err := json.NewDecoder(w.Body).Decode(&problem); err != nil {
+		t.Fatalf("Failed to decode problem: %v", err)
+	}
+	if want := "Области API-ключа не разрешают действие write для orders"; problem.Detail != want {
+		t.Errorf("Expected detail %q, got %q", want, problem.Detail)
+	}
+}
+
+// problemDetailArgs maps the problem writers that take a fixed detail to the position of the detail argument
+var problemDetailArgs = map[string]int{
+	"WriteBadRequest":         1,
+	"WriteUnauthorized":       1,
+	"WriteForbidden":          1,
+	"WriteNotFound":           1,
+	"WriteConflict":           1,
+	"WriteValidationError":    1,
+	"WriteTooManyRequests":    1,
+	"WriteInternalError":      1,
+	"WriteServiceUnavailable": 1,
+	"WriteProblemWithDetail":  2,
+	"WriteProblemWithType":    3,
+}
+
+func TestDetailCodes_CoverProblemWriters(t *testing.T) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(info fs.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		t.Fatalf("Failed to parse package: %v", err)
+	}
+	server, err := parser.ParseFile(fset, "../../app/server.go", nil, 0)
+	if err != nil {
+		t.Fatalf("Failed to parse server: %v", err)
+	}
+	files := []*ast.File{server}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			files = append(files, file)
+		}
+	}
+
+	for _, file := range files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			var name string
+			switch fun := call.Fun.(type) {
+			case *ast.Ident:
+				name = fun.Name
+			case *ast.SelectorExpr:
+				name = fun.Sel.Name
+			}
+			arg, ok := problemDetailArgs[name]
+			if !ok || len(call.Args) <= arg {
+				return true
+			}
+			lit, ok := call.Args[arg].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			detail, _ := strconv.Unquote(lit.Value)
+			if _, ok := detailCodes[detail]; !ok {
+				t.Errorf("%s: detail %q has no code in detailCodes", fset.Position(lit.Pos()), detail)
+			}
+			return true
+		})
+	}
+}
+
+func TestCatalogs_CoverDetailCodes(t *testing.T) {
+	codes := []string{CodeRoleNotPermitted, CodeAPIKeyScopeNotPermitted}
+	for _, code := range detailCodes {
+		codes = append(codes, code)
+	}
+
+	for language, cat := range catalogs {
+		for _, code := range codes {
+			if _, ok := cat.details[code]; !ok {
+				t.Errorf("Catalog %s has no detail for %s", language, code)
+			}
+		}
+	}
+}
+
+func TestWriteError_Localized(t *testing.T) {
+	tests := []struct {
+		name       string
+		language   string
+		err        error
+		wantTitle  string
+		wantDetail string
+	}{
+		{
+			name:       "english keeps the message",
+			language:   LanguageEnglish,
+			err:        domainerr.Conflict(domainerr.CodeWarehouseNameExists, "warehouse with name 'Main' already exists").With("name", "Main"),
+			wantTitle:  "Conflict",
+			wantDetail: "Warehouse with name 'Main' already exists",
+		},
+		{
+			name:       "russian fills the fields",
+			language:   LanguageRussian,
+			err:        domainerr.Conflict(domainerr.CodeWarehouseNameExists, "warehouse with name 'Main' already exists").With("name", "Main"),
+			wantTitle:  "Конфликт",
+			wantDetail: "Склад с названием «Main» уже существует",
+		},
+		{
+			name:       "missing field falls back to english",
+			language:   LanguageRussian,
+			err:        domainerr.Conflict(domainerr.CodeWarehouseNameExists, "warehouse with name 'Main' already exists"),
+			wantTitle:  "Конфликт",
+			wantDetail: "Warehouse with name 'Main' already exists",
+		},
+		{
+			name:       "internal error",
+			language:   LanguageRussian,
+			err:        errors.New("failed to create warehouse: connection refused"),
+			wantTitle:  "Внутренняя ошибка сервера",
+			wantDetail: "Failed to create warehouse",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			WriteError(withLanguage(w, tt.language), tt.err, "Failed to create warehouse")
+
+			var problem Problem
+			if err := json.NewDecoder(w.Body).Decode(&problem); err != nil {
+				t.Fatalf("Failed to decode problem: %v", err)
+			}
+			if problem.Title != tt.wantTitle {
+				t.Errorf("Expected title %q, got %q", tt.wantTitle, problem.Title)
+			}
+			if problem.Detail != tt.wantDetail {
+				t.Errorf("Expected detail %q, got %q", tt.wantDetail, problem.Detail)
+			}
+		})
+	}
+}
+
+func TestWriteInvalidRequest_Localized(t *testing.T) {
+	req := testValidationRequest{
+		Status: "PENDING",
+		Items:  []testValidationItem{{Quantity: 1}, {Quantity: 0}},
+	}
+	err := newValidator().Struct(req)
+	if err == nil {
+		t.Fatal("Expected validation to fail")
+	}
+
+	w := httptest.NewRecorder()
+	WriteInvalidRequest(withLanguage(w, LanguageRussian), err)
+
+	var problem Problem
+	if err := json.NewDecoder(w.Body).Decode(&problem); err != nil {
+		t.Fatalf("Failed to decode problem: %v", err)
+	}
+	if problem.Code != domainerr.CodeValidationFailed {
+		t.Errorf("Expected code %s, got %s", domainerr.CodeValidationFailed, problem.Code)
+	}
+	if problem.Detail != "Данные запроса не прошли проверку" {
+		t.Errorf("Unexpected detail %q", problem.Detail)
+	}
+
+	want := []domainerr.FieldError{
+		{Pointer: "/name", Rule: "required", Message: "Поле name обязательно"},
+		{Pointer: "/status", Rule: "oneof", Message: "Поле status должно иметь одно из значений: OPEN, CLOSED"},
+		{Pointer: "/items/1/quantity", Rule: "min", Message: "Значение поля quantity должно быть не меньше 1"},
+	}
+	if len(problem.Errors) != len(want) {
+		t.Fatalf("Expected %d field errors, got %+v", len(want), problem.Errors)
+	}
+	for i := range want {
+		if problem.Errors[i] != want[i] {
+			t.Errorf("Expected field error %+v, got %+v", want[i], problem.Errors[i])
+		}
+	}
+}
+
+// TestCatalogs_CoverErrorCodes makes sure every error code declared in the domainerr package is translated
+func TestCatalogs_CoverErrorCodes(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "../../domainerr/codes.go", nil, 0)
+	if err != nil {
+		t.Fatalf("Failed to parse error codes: %v", err)
+	}
+
+	var codes []string
+	ast.Inspect(file, func(node ast.Node) bool {
+		if lit, ok := node.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			code, _ := strconv.Unquote(lit.Value)
+			codes = append(codes, code)
+		}
+		return true
+	})
+	if len(codes) == 0 {
+		t.Fatal("Expected error codes in codes.go")
+	}
+
+	for language, cat := range catalogs {
+		for _, code := range codes {
+			if _, ok := cat.details[code]; !ok {
+				t.Errorf("Catalog %s has no detail for %s", language, code)
+			}
+		}
+	}
+}
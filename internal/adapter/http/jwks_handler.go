@@ -0,0 +1,28 @@
+package http
+
+import (
+	"net/http"
+
+	"eco-van-api/internal/adapter/auth"
+)
+
+// jwksCacheControl lets verifiers cache the key set; new keys are published well before they sign
+const jwksCacheControl = "public, max-age=300"
+
+// JWKSHandler publishes the public token signing keys
+type JWKSHandler struct {
+	jwtManager *auth.JWTManager
+}
+
+// NewJWKSHandler creates a new JWKS handler
+func NewJWKSHandler(jwtManager *auth.JWTManager) *JWKSHandler {
+	return &JWKSHandler{
+		jwtManager: jwtManager,
+	}
+}
+
+// GetJWKS handles GET /.well-known/jwks.json
+func (h *JWKSHandler) GetJWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", jwksCacheControl)
+	WriteJSON(w, http.StatusOK, h.jwtManager.JWKS())
+}
@@ -0,0 +1,86 @@
+package http
+
+import "eco-van-api/internal/domainerr"
+
+// Codes of problems written by handlers and middleware that are built from request data rather than looked up
+// in detailCodes. Like the codes of domain errors they are part of the API contract.
+const (
+	CodeRoleNotPermitted        = "ROLE_NOT_PERMITTED"
+	CodeAPIKeyScopeNotPermitted = "API_KEY_SCOPE_NOT_PERMITTED"
+)
+
+// detailCodes gives the fixed details that handlers and middleware write without a domain error a stable code,
+// so that clients can branch on them and catalogs translate them like the details of domain errors. Details
+// that mean the same get the same code.
+var detailCodes = map[string]string{
+	// Request bodies and parameters
+	"Invalid request body":                             "INVALID_REQUEST_BODY",
+	"Invalid multipart body":                           "INVALID_MULTIPART_BODY",
+	"Request must be multipart/form-data":              "MULTIPART_REQUIRED",
+	"Missing '" + photoFormField + "' form field":      "PHOTO_FILE_MISSING",
+	"Photo exceeds the maximum upload size":            "PHOTO_TOO_LARGE",
+	"Invalid If-Match header":                          "INVALID_IF_MATCH",
+	"Invalid date format, expected YYYY-MM-DD":         "INVALID_DATE",
+	"Invalid date format. Expected YYYY-MM-DD":         "INVALID_DATE",
+	"date query parameter is required":                 "DATE_REQUIRED",
+	"Invalid 'from' format. Expected YYYY-MM":          "INVALID_FROM_MONTH",
+	"Invalid 'to' format. Expected YYYY-MM":            "INVALID_TO_MONTH",
+	"Query parameters 'from' and 'to' are required":    "MONTH_RANGE_REQUIRED",
+	"Invalid success value. Expected true or false":    "INVALID_SUCCESS_FILTER",
+	"Invalid priority value":                           "INVALID_PRIORITY",
+	"Invalid status value":                             "INVALID_STATUS",
+	"Invalid entity type":                              "INVALID_ENTITY_TYPE",
+	"The requested resource was not found":             "ROUTE_NOT_FOUND",
+	"The HTTP method is not allowed for this resource": "METHOD_NOT_ALLOWED",
+
+	// Identifiers in the path
+	"Invalid API key ID":              "INVALID_API_KEY_ID",
+	"Invalid client ID":               "INVALID_CLIENT_ID",
+	"Invalid client ID format":        "INVALID_CLIENT_ID",
+	"Invalid client object ID format": "INVALID_CLIENT_OBJECT_ID",
+	"Invalid object ID format":        "INVALID_CLIENT_OBJECT_ID",
+	"Invalid driver ID":               "INVALID_DRIVER_ID",
+	"Invalid entity ID":               "INVALID_ENTITY_ID",
+	"Invalid equipment ID":            "INVALID_EQUIPMENT_ID",
+	"Invalid order ID":                "INVALID_ORDER_ID",
+	"Invalid order schedule ID":       "INVALID_ORDER_SCHEDULE_ID",
+	"Invalid photo ID":                "INVALID_PHOTO_ID",
+	"Invalid transport ID":            "INVALID_TRANSPORT_ID",
+	"Invalid transport ID format":     "INVALID_TRANSPORT_ID",
+	"Invalid user ID format":          "INVALID_USER_ID",
+	"Invalid warehouse ID":            "INVALID_WAREHOUSE_ID",
+	"User ID is required":             "USER_ID_REQUIRED",
+	"Driver not found":                domainerr.CodeDriverNotFound,
+
+	// Authentication
+	"Authorization header is required":            "AUTHORIZATION_REQUIRED",
+	"Invalid authorization header format":         "INVALID_AUTHORIZATION_HEADER",
+	"Token is required":                           "TOKEN_REQUIRED",
+	"Invalid or expired token":                    "INVALID_TOKEN",
+	"Invalid, expired or revoked API key":         domainerr.CodeInvalidAPIKey,
+	"API keys are not accepted for this endpoint": "API_KEY_NOT_ACCEPTED",
+	"User ID not found in context":                "UNAUTHENTICATED",
+	"User role not found in context":              "UNAUTHENTICATED",
+	"User not found":                              domainerr.CodeUserNotFound,
+	"User account is disabled":                    domainerr.CodeAccountDisabled,
+
+	// Authorization
+	"Insufficient permissions":                 "INSUFFICIENT_PERMISSIONS",
+	"Admin role required for this operation":   "ADMIN_REQUIRED",
+	"Admin role required for write operations": "ADMIN_REQUIRED",
+	"Password change required":                 "PASSWORD_CHANGE_REQUIRED",
+	"Two-factor authentication setup required": "TWO_FACTOR_SETUP_REQUIRED",
+
+	// Rate limits
+	"Rate limit exceeded, try again later":            "RATE_LIMIT_EXCEEDED",
+	"Too many failed login attempts, try again later": "LOGIN_THROTTLED",
+	"Rate limit check failed, try again later":        "RATE_LIMIT_UNAVAILABLE",
+
+	// Internal errors
+	"Failed to encode response":         "RESPONSE_ENCODING_FAILED",
+	"Failed to authenticate API key":    "API_KEY_AUTHENTICATION_FAILED",
+	"Failed to load user":               "USER_LOAD_FAILED",
+	"Failed to load API documentation":  "DOCS_UNAVAILABLE",
+	"Failed to write API documentation": "DOCS_UNAVAILABLE",
+	"Failed to write Swagger UI":        "DOCS_UNAVAILABLE",
+}
@@ -0,0 +1,207 @@
+package http
+
+import "eco-van-api/internal/domainerr"
+
+// ruCatalog translates problems into Russian
+var ruCatalog = catalog{
+	titles: map[string]string{
+		ProblemTypeBadRequest:           "Некорректный запрос",
+		ProblemTypeInvalidInput:         "Некорректный запрос",
+		ProblemTypeUnauthorized:         "Требуется аутентификация",
+		ProblemTypeForbidden:            "Доступ запрещён",
+		ProblemTypeNotFound:             "Не найдено",
+		ProblemTypeMethodNotAllowed:     "Метод не поддерживается",
+		ProblemTypeConflict:             "Конфликт",
+		ProblemTypeTransportConflict:    "Транспорт занят",
+		ProblemTypeInvalidTransition:    "Недопустимая смена состояния",
+		ProblemTypePreconditionFailed:   "Данные устарели",
+		ProblemTypePayloadTooLarge:      "Слишком большой запрос",
+		ProblemTypeUnsupportedMediaType: "Неподдерживаемый тип содержимого",
+		ProblemTypeValidationError:      "Ошибка проверки данных",
+		ProblemTypeTooManyRequests:      "Слишком много запросов",
+		ProblemTypeInternalError:        "Внутренняя ошибка сервера",
+		ProblemTypeServiceUnavailable:   "Сервис недоступен",
+	},
+	details: map[string]string{
+		// Generic
+		domainerr.CodeValidationFailed: "Данные запроса не прошли проверку",
+		domainerr.CodeInvalidID:        "Некорректный идентификатор",
+		domainerr.CodeInvalidDateRange: "Конец периода не может быть раньше его начала",
+
+		// Users and authentication
+		domainerr.CodeUserNotFound:                "Пользователь не найден",
+		domainerr.CodeUserEmailExists:             "Пользователь с email {email} уже существует",
+		domainerr.CodeSelfDisable:                 "Нельзя отключить собственную учётную запись",
+		domainerr.CodeSelfRoleChange:              "Нельзя изменить собственную роль",
+		domainerr.CodeInvalidCredentials:          "Неверный email или пароль",
+		domainerr.CodeAccountDisabled:             "Учётная запись отключена",
+		domainerr.CodeInvalidRefreshToken:         "Недействительный токен обновления",
+		domainerr.CodeInvalidResetToken:           "Недействительный токен сброса пароля",
+		domainerr.CodeCurrentPasswordIncorrect:    "Текущий пароль указан неверно",
+		domainerr.CodePasswordUnchanged:           "Новый пароль должен отличаться от текущего",
+		domainerr.CodeInvalidTwoFactorToken:       "Недействительный токен двухфакторной аутентификации",
+		domainerr.CodeInvalidTwoFactorCode:        "Неверный код двухфакторной аутентификации",
+		domainerr.CodeTwoFactorAlreadyEnabled:     "Двухфакторная аутентификация уже включена",
+		domainerr.CodeTwoFactorNotEnabled:         "Двухфакторная аутентификация не включена",
+		domainerr.CodeTwoFactorEnrollmentRequired: "Подключение двухфакторной аутентификации не начато",
+		domainerr.CodeTwoFactorRequired:           "Для роли {role} требуется двухфакторная аутентификация",
+		domainerr.CodeInvalidOIDCLogin:            "Не удалось войти через единый вход",
+		domainerr.CodeOIDCRoleNotMapped:           "Группам пользователя в едином входе не сопоставлена ни одна роль",
+		domainerr.CodeAPIKeyNotFound:              "API-ключ не найден",
+		domainerr.CodeInvalidAPIKey:               "Недействительный API-ключ",
+
+		// Clients and client objects
+		domainerr.CodeClientNotFound:             "Клиент не найден",
+		domainerr.CodeClientNameExists:           "Клиент с названием «{name}» уже существует",
+		domainerr.CodeClientNotDeleted:           "Клиент не удалён",
+		domainerr.CodeClientObjectNotFound:       "Объект клиента не найден",
+		domainerr.CodeClientObjectNameExists:     "У клиента уже есть объект с названием «{name}»",
+		domainerr.CodeClientObjectInUse:          "Объект клиента используется и не может быть удалён",
+		domainerr.CodeClientObjectClientMismatch: "Объект не принадлежит указанному клиенту",
+
+		// Warehouses
+		domainerr.CodeWarehouseNotFound:     "Склад не найден",
+		domainerr.CodeWarehouseNameExists:   "Склад с названием «{name}» уже существует",
+		domainerr.CodeWarehouseNotDeleted:   "Склад не удалён",
+		domainerr.CodeWarehouseHasEquipment: "Нельзя удалить склад, на котором находится оборудование",
+
+		// Equipment
+		domainerr.CodeEquipmentNotFound:            "Оборудование не найдено",
+		domainerr.CodeEquipmentNumberExists:        "Оборудование с номером «{number}» уже существует",
+		domainerr.CodeEquipmentNotDeleted:          "Оборудование не удалено",
+		domainerr.CodeEquipmentInvalidPlacement:    "Оборудование должно быть ровно в одном месте: транспорт, объект клиента или склад",
+		domainerr.CodeEquipmentAttachedToTransport: "Оборудование закреплено за транспортом",
+		domainerr.CodeEquipmentNotAvailable:        "Оборудование недоступно",
+		domainerr.CodeEquipmentAlreadyAssigned:     "Оборудование уже закреплено за другим транспортом",
+		domainerr.CodeEquipmentPlacementConflict:   "Размещение оборудования изменилось, повторите операцию",
+
+		// Drivers
+		domainerr.CodeDriverNotFound:            "Водитель не найден",
+		domainerr.CodeDriverLicenseExists:       "Водитель с номером удостоверения «{licenseNo}» уже существует",
+		domainerr.CodeDriverNotDeleted:          "Водитель не удалён",
+		domainerr.CodeDriverAssigned:            "Нельзя удалить водителя, закреплённого за транспортом",
+		domainerr.CodeDriverAlreadyAssigned:     "Водитель уже закреплён за другим транспортом",
+		domainerr.CodeDriverUserRoleRequired:    "Пользователь должен иметь роль {role}",
+		domainerr.CodeDriverUserAlreadyLinked:   "Пользователь уже связан с другим водителем",
+		domainerr.CodeDriverNotLinked:           "Пользователь не связан с водителем",
+		domainerr.CodeDriverWithoutTransport:    "Водитель не закреплён за транспортом",
+		domainerr.CodeOrderNotOnDriverTransport: "Заявка не назначена на ваш транспорт",
+
+		// Transports
+		domainerr.CodeTransportNotFound:        "Транспорт не найден",
+		domainerr.CodeTransportPlateExists:     "Транспорт с госномером {plateNo} уже существует",
+		domainerr.CodeTransportNotAvailable:    "Транспорт недоступен (статус: {status})",
+		domainerr.CodeTransportHasDriver:       "Нельзя удалить транспорт, за которым закреплён водитель",
+		domainerr.CodeTransportHasEquipment:    "Нельзя удалить транспорт, за которым закреплено оборудование",
+		domainerr.CodeTransportHasActiveOrders: "Нельзя удалить транспорт с активными заявками",
+		domainerr.CodeTransportHasNoDriver:     "За транспортом не закреплён водитель",
+
+		// Orders
+		domainerr.CodeOrderNotFound:              "Заявка не найдена",
+		domainerr.CodeOrderNotDeleted:            "Заявка не удалена",
+		domainerr.CodeOrderStatusTransition:      "Недопустимая смена статуса заявки",
+		domainerr.CodeOrderNotDeletable:          "Заявку нельзя удалить",
+		domainerr.CodeOrderItemsLocked:           "В статусе {status} позиции заявки изменить нельзя",
+		domainerr.CodeOrderChangedConcurrently:   "Заявка была изменена, повторите операцию",
+		domainerr.CodeOrderVersionMismatch:       "Заявка была изменена другим пользователем, загрузите её заново",
+		domainerr.CodeOrderScheduleNotFound:      "Расписание заявок не найдено",
+		domainerr.CodeCancellationReasonNotFound: "Причина отмены не найдена",
+		domainerr.CodeCancellationReasonExists:   "Причина отмены с кодом «{code}» уже существует",
+
+		// Routes
+		domainerr.CodeRouteNoDepot: "Нет склада с координатами, который можно использовать как базу",
+
+		// Photos
+		domainerr.CodePhotoNotFound:        "Фотография не найдена",
+		domainerr.CodePhotoEntityNotFound:  "Объект фотографии не найден",
+		domainerr.CodePhotoUnsupportedType: "Неподдерживаемый тип фотографии: {contentType}",
+		domainerr.CodePhotoEmpty:           "Файл фотографии пуст",
+		domainerr.CodePhotoInvalidEntity:   "Неизвестный тип объекта фотографии: {entityType}",
+
+		// Requests rejected by handlers and middleware, see detailCodes
+		"INVALID_REQUEST_BODY":          "Некорректное тело запроса",
+		"INVALID_MULTIPART_BODY":        "Некорректное тело запроса multipart",
+		"MULTIPART_REQUIRED":            "Запрос должен иметь тип multipart/form-data",
+		"PHOTO_FILE_MISSING":            "Отсутствует поле формы '" + photoFormField + "'",
+		"PHOTO_TOO_LARGE":               "Фотография превышает максимальный размер загрузки",
+		"INVALID_IF_MATCH":              "Некорректный заголовок If-Match",
+		"INVALID_DATE":                  "Некорректный формат даты, ожидается ГГГГ-ММ-ДД",
+		"DATE_REQUIRED":                 "Параметр запроса date обязателен",
+		"INVALID_FROM_MONTH":            "Некорректный формат параметра 'from', ожидается ГГГГ-ММ",
+		"INVALID_TO_MONTH":              "Некорректный формат параметра 'to', ожидается ГГГГ-ММ",
+		"MONTH_RANGE_REQUIRED":          "Параметры запроса 'from' и 'to' обязательны",
+		"INVALID_SUCCESS_FILTER":        "Некорректное значение success, ожидается true или false",
+		"INVALID_PRIORITY":              "Некорректное значение приоритета",
+		"INVALID_STATUS":                "Некорректное значение статуса",
+		"INVALID_ENTITY_TYPE":           "Некорректный тип объекта",
+		"ROUTE_NOT_FOUND":               "Запрошенный ресурс не найден",
+		"METHOD_NOT_ALLOWED":            "HTTP-метод не поддерживается для этого ресурса",
+		"INVALID_API_KEY_ID":            "Некорректный идентификатор API-ключа",
+		"INVALID_CLIENT_ID":             "Некорректный идентификатор клиента",
+		"INVALID_CLIENT_OBJECT_ID":      "Некорректный идентификатор объекта клиента",
+		"INVALID_DRIVER_ID":             "Некорректный идентификатор водителя",
+		"INVALID_ENTITY_ID":             "Некорректный идентификатор объекта",
+		"INVALID_EQUIPMENT_ID":          "Некорректный идентификатор оборудования",
+		"INVALID_ORDER_ID":              "Некорректный идентификатор заказа",
+		"INVALID_ORDER_SCHEDULE_ID":     "Некорректный идентификатор расписания заказов",
+		"INVALID_PHOTO_ID":              "Некорректный идентификатор фотографии",
+		"INVALID_TRANSPORT_ID":          "Некорректный идентификатор транспорта",
+		"INVALID_USER_ID":               "Некорректный идентификатор пользователя",
+		"INVALID_WAREHOUSE_ID":          "Некорректный идентификатор склада",
+		"USER_ID_REQUIRED":              "Идентификатор пользователя обязателен",
+		"AUTHORIZATION_REQUIRED":        "Требуется заголовок Authorization",
+		"INVALID_AUTHORIZATION_HEADER":  "Некорректный формат заголовка Authorization",
+		"TOKEN_REQUIRED":                "Требуется токен",
+		"INVALID_TOKEN":                 "Токен недействителен или истёк",
+		"API_KEY_NOT_ACCEPTED":          "API-ключи не принимаются для этого ресурса",
+		"UNAUTHENTICATED":               "Пользователь не аутентифицирован",
+		"INSUFFICIENT_PERMISSIONS":      "Недостаточно прав",
+		"ADMIN_REQUIRED":                "Операция доступна только администратору",
+		"PASSWORD_CHANGE_REQUIRED":      "Требуется сменить пароль",
+		"TWO_FACTOR_SETUP_REQUIRED":     "Требуется настроить двухфакторную аутентификацию",
+		CodeRoleNotPermitted:            "Роли {role} не разрешено действие {action} для {resource}",
+		CodeAPIKeyScopeNotPermitted:     "Области API-ключа не разрешают действие {action} для {resource}",
+		"RATE_LIMIT_EXCEEDED":           "Превышен лимит запросов, повторите попытку позже",
+		"LOGIN_THROTTLED":               "Слишком много неудачных попыток входа, повторите попытку позже",
+		"RATE_LIMIT_UNAVAILABLE":        "Не удалось проверить лимит запросов, повторите попытку позже",
+		"RESPONSE_ENCODING_FAILED":      "Не удалось сформировать ответ",
+		"API_KEY_AUTHENTICATION_FAILED": "Не удалось проверить API-ключ",
+		"USER_LOAD_FAILED":              "Не удалось загрузить пользователя",
+		"DOCS_UNAVAILABLE":              "Документация API недоступна",
+	},
+	rules: map[string]string{
+		"required":        "Поле {field} обязательно",
+		"required_if":     "Поле {field} обязательно в этом случае",
+		"required_one_of": "Заполните хотя бы одно из полей",
+		"excluded_unless": "Поле {field} в этом случае не допускается",
+		"exactly_one":     "Укажите ровно одно место: транспорт, объект клиента или склад",
+		"exists":          "Запись из поля {field} не найдена",
+		"unique":          "Значение поля {field} повторяется",
+		"available":       "Транспорт из поля {field} недоступен",
+		"nefield":         "Значение поля {field} должно отличаться",
+		"ltfield":         "Поле {field} должно быть раньше конца интервала",
+		"gtefield":        "Поле {field} не может быть раньше начала интервала",
+		"match":           "Значение поля {field} указано неверно",
+		"self":            "Поле {field} нельзя изменить у собственной учётной записи",
+		"totp":            "Неверный код двухфакторной аутентификации",
+		"role":            "Пользователь должен иметь роль {param}",
+		"future":          "Поле {field} должно содержать дату в будущем",
+		"format":          "Поле {field} имеет неверный формат",
+		"datetime":        "Поле {field} содержит некорректную дату или время",
+		"rrule":           "Поле {field} содержит некорректное правило повторения",
+		"email":           "Поле {field} должно содержать корректный email",
+		"deprecated":      "Поле {field} устарело и больше не может быть задано",
+		"oneof":           "Поле {field} содержит недопустимое значение",
+		"oneof.values":    "Поле {field} должно иметь одно из значений: {param}",
+		"min.string":      "Поле {field} должно содержать не менее {param} символов",
+		"max.string":      "Поле {field} должно содержать не более {param} символов",
+		"min.items":       "Поле {field} должно содержать не менее {param} элементов",
+		"max.items":       "Поле {field} должно содержать не более {param} элементов",
+		"min.number":      "Значение поля {field} должно быть не меньше {param}",
+		"max.number":      "Значение поля {field} должно быть не больше {param}",
+		"gt.number":       "Значение поля {field} должно быть больше {param}",
+		"gte.number":      "Значение поля {field} должно быть не меньше {param}",
+		"lt.number":       "Значение поля {field} должно быть меньше {param}",
+		"lte.number":      "Значение поля {field} должно быть не больше {param}",
+	},
+}
@@ -57,6 +57,21 @@ func (m *Middleware) RequestID() func(http.Handler) http.Handler {
 	}
 }
 
+// Language negotiates the language of the response from the Accept-Language header and stores it in the
+// request context, see LanguageFromContext. The problem writers, which only get the response writer, find it on
+// the writer; they announce it in Content-Language on the problems they translate.
+func (m *Middleware) Language() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			language := negotiateLanguage(r.Header.Get("Accept-Language"), LanguageEnglish)
+			w.Header().Add("Vary", "Accept-Language")
+
+			ctx := context.WithValue(r.Context(), languageKey{}, language)
+			next.ServeHTTP(&languageWriter{ResponseWriter: w, language: language}, r.WithContext(ctx))
+		})
+	}
+}
+
 // Recover recovers from panics and logs the error
 func (m *Middleware) Recover() func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -103,8 +118,16 @@ func (m *Middleware) AccessLog() func(http.Handler) http.Handler {
 				requestID = "unknown"
 			}
 
-			// Log access
+			// Log access, and the error behind a failed request that is not shown to the client
 			m.logger.AccessLog(r.Method, r.URL.Path, wrapped.statusCode, duration, requestID.(string))
+			if wrapped.err != nil {
+				logger := m.logger.WithRequestID(requestID.(string))
+				if wrapped.statusCode >= http.StatusInternalServerError {
+					logger.Error("Request failed", wrapped.err)
+				} else {
+					logger.Warn("Request rejected: " + wrapped.err.Error())
+				}
+			}
 
 			// Record metrics if enabled
 			if m.metrics.IsEnabled() {
@@ -164,7 +187,7 @@ func (m *Middleware) CORS(origins []string) func(http.Handler) http.Handler {
 					w.Header().Set("Access-Control-Allow-Origin", origin)
 				}
 				w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-				w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Request-ID")
+				w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Request-ID, If-Match, If-None-Match")
 				w.Header().Set("Access-Control-Max-Age", "86400")
 				w.WriteHeader(http.StatusOK)
 				return
@@ -174,6 +197,7 @@ func (m *Middleware) CORS(origins []string) func(http.Handler) http.Handler {
 			origin := r.Header.Get("Origin")
 			if m.isAllowedOrigin(origin, origins) {
 				w.Header().Set("Access-Control-Allow-Origin", origin)
+				w.Header().Set("Access-Control-Expose-Headers", "ETag")
 			}
 
 			next.ServeHTTP(w, r)
@@ -181,11 +205,43 @@ func (m *Middleware) CORS(origins []string) func(http.Handler) http.Handler {
 	}
 }
 
-// RateLimit is a placeholder for rate limiting (no logic yet)
-func (m *Middleware) RateLimit() func(http.Handler) http.Handler {
+// RateLimit charges every request to its token bucket and rejects requests over budget with 429.
+// A nil limiter disables rate limiting. When the bucket store fails, the failure is logged and the request
+// is let through or rejected with 503 depending on its budget.
+func (m *Middleware) RateLimit(limiter *RateLimiter) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
+		if limiter == nil {
+			return next
+		}
+
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Rate limiting logic placeholder
+			budget, limited := limiter.budgetFor(r.URL.Path)
+			if !limited {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			decision, err := limiter.take(r, budget)
+			if err != nil {
+				requestID := r.Context().Value(RequestIDKey)
+				if requestID == nil {
+					requestID = "unknown"
+				}
+				m.logger.WithRequestID(requestID.(string)).Error("Rate limit check failed", err)
+				if budget.failOpen {
+					next.ServeHTTP(w, r)
+				} else {
+					WriteServiceUnavailable(w, "Rate limit check failed, try again later")
+				}
+				return
+			}
+
+			writeRateLimitHeaders(w, decision)
+			if !decision.result.Allowed {
+				WriteTooManyRequests(w, "Rate limit exceeded, try again later", max(ceilSeconds(decision.result.RetryAfter), 1))
+				return
+			}
+
 			next.ServeHTTP(w, r)
 		})
 	}
@@ -228,6 +284,28 @@ func (m *Middleware) isAllowedOrigin(origin string, allowedOrigins []string) boo
 type responseWriter struct {
 	http.ResponseWriter
 	statusCode int
+	// err is the error behind the response, recorded by WriteError for the access log
+	err error
+}
+
+// Unwrap returns the wrapped writer for http.ResponseController
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+// recordError hands err to the access log of the request, if w is wrapped by Middleware.AccessLog
+func recordError(w http.ResponseWriter, err error) {
+	for {
+		switch writer := w.(type) {
+		case *responseWriter:
+			writer.err = err
+			return
+		case interface{ Unwrap() http.ResponseWriter }:
+			w = writer.Unwrap()
+		default:
+			return
+		}
+	}
 }
 
 func (rw *responseWriter) WriteHeader(code int) {
@@ -221,8 +221,8 @@ func TestMiddleware_RateLimit(t *testing.T) {
 		w.WriteHeader(http.StatusOK)
 	})
 
-	// Test middleware (should not panic)
-	middleware := mw.RateLimit()(handler)
+	// Without a limiter requests pass through
+	middleware := mw.RateLimit(nil)(handler)
 	req := httptest.NewRequest("GET", "/test", http.NoBody)
 	w := httptest.NewRecorder()
 
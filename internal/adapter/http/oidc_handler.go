@@ -0,0 +1,54 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/service"
+)
+
+// OIDCHandler handles single sign-on HTTP requests
+type OIDCHandler struct {
+	oidcService *service.OIDCService
+}
+
+// NewOIDCHandler creates a new single sign-on handler
+func NewOIDCHandler(oidcService *service.OIDCService) *OIDCHandler {
+	return &OIDCHandler{
+		oidcService: oidcService,
+	}
+}
+
+// Authorize handles POST /api/v1/auth/oidc/authorize
+func (h *OIDCHandler) Authorize(w http.ResponseWriter, r *http.Request) {
+	response, err := h.oidcService.Authorize(r.Context())
+	if err != nil {
+		WriteError(w, err, "Failed to start single sign-on")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, response)
+}
+
+// Callback handles POST /api/v1/auth/oidc/callback
+func (h *OIDCHandler) Callback(w http.ResponseWriter, r *http.Request) {
+	var req models.OIDCCallbackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteBadRequest(w, "Invalid request body")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteError(w, err, "Validation failed")
+		return
+	}
+
+	response, err := h.oidcService.Callback(r.Context(), &req, clientInfo(r))
+	if err != nil {
+		WriteError(w, err, "Failed to complete single sign-on")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, response)
+}
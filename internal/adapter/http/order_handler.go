@@ -1,6 +1,7 @@
 package http
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -24,7 +25,7 @@ type OrderHandler struct {
 func NewOrderHandler(orderService port.OrderService) *OrderHandler {
 	return &OrderHandler{
 		orderService: orderService,
-		validate:     validator.New(),
+		validate:     newValidator(),
 	}
 }
 
@@ -38,6 +39,8 @@ func (h *OrderHandler) ListOrders(w http.ResponseWriter, r *http.Request) {
 	date := r.URL.Query().Get("date")
 	clientIDStr := r.URL.Query().Get("clientId")
 	objectIDStr := r.URL.Query().Get("objectId")
+	transportIDStr := r.URL.Query().Get("transportId")
+	cancellationReason := r.URL.Query().Get("cancellationReason")
 	includeDeleted := r.URL.Query().Get("includeDeleted") == QueryParamIncludeDeleted
 
 	// Set defaults
@@ -100,10 +103,24 @@ func (h *OrderHandler) ListOrders(w http.ResponseWriter, r *http.Request) {
 		req.ObjectID = &objectID
 	}
 
+	if transportIDStr != "" {
+		transportID, err := uuid.Parse(transportIDStr)
+		if err != nil {
+			WriteBadRequest(w, "Invalid transport ID format")
+			return
+		}
+		req.TransportID = &transportID
+	}
+
+	if cancellationReason != "" {
+		code := strings.ToUpper(cancellationReason)
+		req.CancellationReason = &code
+	}
+
 	// Get orders from service
 	response, err := h.orderService.List(r.Context(), req)
 	if err != nil {
-		WriteInternalError(w, "Failed to list orders")
+		WriteError(w, err, "Failed to list orders")
 		return
 	}
 
@@ -124,12 +141,20 @@ func (h *OrderHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
 	// Get order from service
 	order, err := h.orderService.GetByID(r.Context(), orderID)
 	if err != nil {
-		WriteNotFound(w, "Order not found")
+		WriteError(w, err, "Failed to get order")
+		return
+	}
+
+	// The client's copy is current when it names the version in If-None-Match
+	etag := versionETag(order.Version)
+	if ifNoneMatch(r, etag) {
+		w.Header().Set("ETag", etag)
+		w.WriteHeader(http.StatusNotModified)
 		return
 	}
 
 	// Return response
-	WriteJSON(w, http.StatusOK, order)
+	writeOrder(w, http.StatusOK, order)
 }
 
 // CreateOrder handles POST /api/v1/orders
@@ -143,25 +168,22 @@ func (h *OrderHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
 
 	// Validate request
 	if err := h.validate.Struct(req); err != nil {
-		WriteValidationError(w, err.Error())
+		WriteInvalidRequest(w, err)
 		return
 	}
 
 	// Get user ID from context (if available)
-	var createdBy *uuid.UUID
-	if userID, ok := r.Context().Value("user_id").(uuid.UUID); ok {
-		createdBy = &userID
-	}
+	createdBy := userIDFromContext(r)
 
 	// Create order
 	order, err := h.orderService.Create(r.Context(), &req, createdBy)
 	if err != nil {
-		WriteInternalError(w, "Failed to create order")
+		writeOrderWriteError(w, err, "Failed to create order")
 		return
 	}
 
 	// Return response
-	WriteJSON(w, http.StatusCreated, order)
+	writeOrder(w, http.StatusCreated, order)
 }
 
 // UpdateOrder handles PUT /api/v1/orders/{id}
@@ -183,19 +205,25 @@ func (h *OrderHandler) UpdateOrder(w http.ResponseWriter, r *http.Request) {
 
 	// Validate request
 	if err := h.validate.Struct(req); err != nil {
-		WriteValidationError(w, err.Error())
+		WriteInvalidRequest(w, err)
+		return
+	}
+
+	ifMatch, ok := parseIfMatch(r)
+	if !ok {
+		WriteBadRequest(w, "Invalid If-Match header")
 		return
 	}
 
 	// Update order
-	order, err := h.orderService.Update(r.Context(), orderID, req)
+	order, err := h.orderService.Update(r.Context(), orderID, req, ifMatch)
 	if err != nil {
-		WriteInternalError(w, "Failed to update order")
+		writeOrderWriteError(w, err, "Failed to update order")
 		return
 	}
 
 	// Return response
-	WriteJSON(w, http.StatusOK, order)
+	writeOrder(w, http.StatusOK, order)
 }
 
 // UpdateOrderStatus handles PUT /api/v1/orders/{id}/status
@@ -217,24 +245,81 @@ func (h *OrderHandler) UpdateOrderStatus(w http.ResponseWriter, r *http.Request)
 
 	// Validate request
 	if err := h.validate.Struct(req); err != nil {
-		WriteValidationError(w, err.Error())
+		WriteInvalidRequest(w, err)
+		return
+	}
+
+	ifMatch, ok := parseIfMatch(r)
+	if !ok {
+		WriteBadRequest(w, "Invalid If-Match header")
 		return
 	}
 
-	// Update order status
-	order, err := h.orderService.UpdateStatus(r.Context(), orderID, req)
+	// Update order status, recording the authenticated user as the actor
+	order, err := h.orderService.UpdateStatus(r.Context(), orderID, req, userIDFromContext(r), ifMatch)
 	if err != nil {
-		// Check for specific error types
-		if err.Error() == "invalid status transition" {
-			WriteConflict(w, err.Error())
-			return
-		}
-		WriteInternalError(w, "Failed to update order status")
+		WriteError(w, err, "Failed to update order status")
+		return
+	}
+
+	// Return response
+	writeOrder(w, http.StatusOK, order)
+}
+
+// GetOrderHistory handles GET /api/v1/orders/{id}/history
+func (h *OrderHandler) GetOrderHistory(w http.ResponseWriter, r *http.Request) {
+	// Parse order ID
+	orderIDStr := chi.URLParam(r, "id")
+	orderID, err := uuid.Parse(orderIDStr)
+	if err != nil {
+		WriteBadRequest(w, "Invalid order ID")
+		return
+	}
+
+	// Get status history from service
+	history, err := h.orderService.GetStatusHistory(r.Context(), orderID)
+	if err != nil {
+		WriteError(w, err, "Failed to get order history")
 		return
 	}
 
 	// Return response
-	WriteJSON(w, http.StatusOK, order)
+	WriteJSON(w, http.StatusOK, history)
+}
+
+// GetCancellationStats handles GET /api/v1/orders/stats/cancellations
+func (h *OrderHandler) GetCancellationStats(w http.ResponseWriter, r *http.Request) {
+	fromStr := r.URL.Query().Get("from")
+	toStr := r.URL.Query().Get("to")
+	if fromStr == "" || toStr == "" {
+		WriteBadRequest(w, "Query parameters 'from' and 'to' are required")
+		return
+	}
+
+	from, err := time.Parse(models.MonthLayout, fromStr)
+	if err != nil {
+		WriteBadRequest(w, "Invalid 'from' format. Expected YYYY-MM")
+		return
+	}
+	to, err := time.Parse(models.MonthLayout, toStr)
+	if err != nil {
+		WriteBadRequest(w, "Invalid 'to' format. Expected YYYY-MM")
+		return
+	}
+
+	// The 'to' month is inclusive, so the period ends at the start of the following month
+	req := models.CancellationStatsRequest{
+		From: from,
+		To:   to.AddDate(0, 1, 0),
+	}
+
+	stats, err := h.orderService.GetCancellationStats(r.Context(), req)
+	if err != nil {
+		WriteError(w, err, "Failed to get cancellation stats")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, stats)
 }
 
 // AssignTransport handles PUT /api/v1/orders/{id}/assign-transport
@@ -256,14 +341,20 @@ func (h *OrderHandler) AssignTransport(w http.ResponseWriter, r *http.Request) {
 
 	// Validate request
 	if err := h.validate.Struct(req); err != nil {
-		WriteValidationError(w, err.Error())
+		WriteInvalidRequest(w, err)
+		return
+	}
+
+	ifMatch, ok := parseIfMatch(r)
+	if !ok {
+		WriteBadRequest(w, "Invalid If-Match header")
 		return
 	}
 
 	// Assign transport
-	err = h.orderService.AssignTransport(r.Context(), orderID, req)
+	err = h.orderService.AssignTransport(r.Context(), orderID, req, ifMatch)
 	if err != nil {
-		WriteInternalError(w, "Failed to assign transport")
+		writeOrderWriteError(w, err, "Failed to assign transport")
 		return
 	}
 
@@ -281,15 +372,16 @@ func (h *OrderHandler) DeleteOrder(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	ifMatch, ok := parseIfMatch(r)
+	if !ok {
+		WriteBadRequest(w, "Invalid If-Match header")
+		return
+	}
+
 	// Delete order
-	err = h.orderService.Delete(r.Context(), orderID)
+	err = h.orderService.Delete(r.Context(), orderID, ifMatch)
 	if err != nil {
-		// Check for specific error types
-		if strings.Contains(err.Error(), "cannot be deleted") {
-			WriteConflict(w, err.Error())
-			return
-		}
-		WriteInternalError(w, "Failed to delete order")
+		WriteError(w, err, "Failed to delete order")
 		return
 	}
 
@@ -310,10 +402,36 @@ func (h *OrderHandler) RestoreOrder(w http.ResponseWriter, r *http.Request) {
 	// Restore order
 	order, err := h.orderService.Restore(r.Context(), orderID)
 	if err != nil {
-		WriteInternalError(w, "Failed to restore order")
+		WriteError(w, err, "Failed to restore order")
 		return
 	}
 
 	// Return response
-	WriteJSON(w, http.StatusOK, order)
+	writeOrder(w, http.StatusOK, order)
+}
+
+// userIDFromContext returns the authenticated user's ID, or nil if the request is anonymous
+func userIDFromContext(r *http.Request) *uuid.UUID {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		return nil
+	}
+	return &userID
+}
+
+// writeOrder writes an order with its version as the ETag, which clients send back in If-Match
+func writeOrder(w http.ResponseWriter, status int, order *models.OrderResponse) {
+	w.Header().Set("ETag", versionETag(order.Version))
+	WriteJSON(w, status, order)
+}
+
+// writeOrderWriteError maps order create, update, transport assignment and item errors to problem responses
+func writeOrderWriteError(w http.ResponseWriter, err error, fallback string) {
+	var conflict *models.TransportConflictError
+	switch {
+	case errors.As(err, &conflict):
+		WriteTransportConflict(w, conflict.Error(), conflict.ConflictingOrderIDs())
+	default:
+		WriteError(w, err, fallback)
+	}
 }
@@ -0,0 +1,79 @@
+package http
+
+import (
+	"net/http"
+
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/port"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// OrderItemHandler handles HTTP requests for order equipment items
+type OrderItemHandler struct {
+	itemService port.OrderItemService
+	validate    *validator.Validate
+}
+
+// NewOrderItemHandler creates a new order item handler
+func NewOrderItemHandler(itemService port.OrderItemService) *OrderItemHandler {
+	return &OrderItemHandler{
+		itemService: itemService,
+		validate:    newValidator(),
+	}
+}
+
+// ListOrderItems handles GET /api/v1/orders/{id}/items
+func (h *OrderItemHandler) ListOrderItems(w http.ResponseWriter, r *http.Request) {
+	orderID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid order ID")
+		return
+	}
+
+	items, err := h.itemService.List(r.Context(), orderID)
+	if err != nil {
+		WriteError(w, err, "Failed to list order items")
+		return
+	}
+
+	w.Header().Set("ETag", versionETag(items.OrderVersion))
+	WriteJSON(w, http.StatusOK, items)
+}
+
+// ReplaceOrderItems handles PUT /api/v1/orders/{id}/items
+func (h *OrderItemHandler) ReplaceOrderItems(w http.ResponseWriter, r *http.Request) {
+	orderID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid order ID")
+		return
+	}
+
+	var req models.ReplaceOrderItemsRequest
+	if err := ParseJSON(r, &req); err != nil {
+		WriteBadRequest(w, "Invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		WriteInvalidRequest(w, err)
+		return
+	}
+
+	ifMatch, ok := parseIfMatch(r)
+	if !ok {
+		WriteBadRequest(w, "Invalid If-Match header")
+		return
+	}
+
+	items, err := h.itemService.Replace(r.Context(), orderID, req, ifMatch)
+	if err != nil {
+		writeOrderWriteError(w, err, "Failed to replace order items")
+		return
+	}
+
+	w.Header().Set("ETag", versionETag(items.OrderVersion))
+	WriteJSON(w, http.StatusOK, items)
+}
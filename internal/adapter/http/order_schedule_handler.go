@@ -0,0 +1,139 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/port"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// OrderScheduleHandler handles HTTP requests for recurring order schedules
+type OrderScheduleHandler struct {
+	scheduleService port.OrderScheduleService
+	validate        *validator.Validate
+}
+
+// NewOrderScheduleHandler creates a new order schedule handler
+func NewOrderScheduleHandler(scheduleService port.OrderScheduleService) *OrderScheduleHandler {
+	return &OrderScheduleHandler{
+		scheduleService: scheduleService,
+		validate:        newValidator(),
+	}
+}
+
+// ListOrderSchedules handles GET /api/v1/order-schedules
+func (h *OrderScheduleHandler) ListOrderSchedules(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+
+	req := models.OrderScheduleListRequest{
+		Page:           page,
+		PageSize:       pageSize,
+		IncludeDeleted: r.URL.Query().Get("includeDeleted") == QueryParamIncludeDeleted,
+	}
+
+	if objectIDStr := r.URL.Query().Get("objectId"); objectIDStr != "" {
+		objectID, err := uuid.Parse(objectIDStr)
+		if err != nil {
+			WriteBadRequest(w, "Invalid object ID format")
+			return
+		}
+		req.ObjectID = &objectID
+	}
+
+	response, err := h.scheduleService.List(r.Context(), req)
+	if err != nil {
+		WriteError(w, err, "Failed to list order schedules")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, response)
+}
+
+// GetOrderSchedule handles GET /api/v1/order-schedules/{id}
+func (h *OrderScheduleHandler) GetOrderSchedule(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid order schedule ID")
+		return
+	}
+
+	schedule, err := h.scheduleService.GetByID(r.Context(), id)
+	if err != nil {
+		WriteError(w, err, "Failed to get order schedule")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, schedule)
+}
+
+// CreateOrderSchedule handles POST /api/v1/order-schedules
+func (h *OrderScheduleHandler) CreateOrderSchedule(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateOrderScheduleRequest
+	if err := ParseJSON(r, &req); err != nil {
+		WriteBadRequest(w, "Invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		WriteInvalidRequest(w, err)
+		return
+	}
+
+	schedule, err := h.scheduleService.Create(r.Context(), &req, userIDFromContext(r))
+	if err != nil {
+		WriteError(w, err, "Failed to create order schedule")
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, schedule)
+}
+
+// UpdateOrderSchedule handles PUT /api/v1/order-schedules/{id}
+func (h *OrderScheduleHandler) UpdateOrderSchedule(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid order schedule ID")
+		return
+	}
+
+	var req models.UpdateOrderScheduleRequest
+	if err := ParseJSON(r, &req); err != nil {
+		WriteBadRequest(w, "Invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		WriteInvalidRequest(w, err)
+		return
+	}
+
+	schedule, err := h.scheduleService.Update(r.Context(), id, req)
+	if err != nil {
+		WriteError(w, err, "Failed to update order schedule")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, schedule)
+}
+
+// DeleteOrderSchedule handles DELETE /api/v1/order-schedules/{id}
+func (h *OrderScheduleHandler) DeleteOrderSchedule(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid order schedule ID")
+		return
+	}
+
+	if err := h.scheduleService.Delete(r.Context(), id); err != nil {
+		WriteError(w, err, "Failed to delete order schedule")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,182 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/port"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// photoFormField is the multipart form field carrying the uploaded file
+const photoFormField = "file"
+
+// PhotoHandler handles HTTP requests for photo operations
+type PhotoHandler struct {
+	photoService port.PhotoService
+	maxBodyBytes int64
+}
+
+// NewPhotoHandler creates a new photo handler; uploads larger than maxBodyBytes are rejected
+func NewPhotoHandler(photoService port.PhotoService, maxBodyBytes int64) *PhotoHandler {
+	return &PhotoHandler{
+		photoService: photoService,
+		maxBodyBytes: maxBodyBytes,
+	}
+}
+
+// ListPhotos handles GET /api/v1/photos/{entityType}/{entityId}
+func (h *PhotoHandler) ListPhotos(w http.ResponseWriter, r *http.Request) {
+	entityType, entityID, ok := parsePhotoEntity(w, r)
+	if !ok {
+		return
+	}
+
+	photos, err := h.photoService.List(r.Context(), entityType, entityID)
+	if err != nil {
+		WriteError(w, err, "Failed to list photos")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, photos)
+}
+
+// UploadPhoto handles POST /api/v1/photos/{entityType}/{entityId}
+func (h *PhotoHandler) UploadPhoto(w http.ResponseWriter, r *http.Request) {
+	entityType, entityID, ok := parsePhotoEntity(w, r)
+	if !ok {
+		return
+	}
+
+	if r.ContentLength > h.maxBodyBytes {
+		WriteProblemWithDetail(w, http.StatusRequestEntityTooLarge, "Photo exceeds the maximum upload size")
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxBodyBytes)
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		WriteProblemWithDetail(w, http.StatusUnsupportedMediaType, "Request must be multipart/form-data")
+		return
+	}
+
+	// Stream the file part straight to the service instead of buffering the whole form
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			if isBodyTooLarge(err) {
+				WriteProblemWithDetail(w, http.StatusRequestEntityTooLarge, "Photo exceeds the maximum upload size")
+				return
+			}
+			if errors.Is(err, io.EOF) {
+				WriteBadRequest(w, "Missing '"+photoFormField+"' form field")
+				return
+			}
+			WriteBadRequest(w, "Invalid multipart body")
+			return
+		}
+
+		if part.FormName() != photoFormField {
+			_ = part.Close()
+			continue
+		}
+
+		photo, err := h.photoService.Upload(r.Context(), entityType, entityID, part.FileName(), part)
+		_ = part.Close()
+		if err != nil {
+			h.writeUploadError(w, err)
+			return
+		}
+
+		WriteJSON(w, http.StatusCreated, photo)
+		return
+	}
+}
+
+// DownloadPhoto handles GET /api/v1/photos/{entityType}/{entityId}/{photoId}
+func (h *PhotoHandler) DownloadPhoto(w http.ResponseWriter, r *http.Request) {
+	entityType, entityID, ok := parsePhotoEntity(w, r)
+	if !ok {
+		return
+	}
+
+	photoID, err := uuid.Parse(chi.URLParam(r, "photoId"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid photo ID")
+		return
+	}
+
+	photo, content, err := h.photoService.Open(r.Context(), entityType, entityID, photoID)
+	if err != nil {
+		WriteError(w, err, "Failed to get photo")
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", photo.MimeType)
+	w.Header().Set("Content-Length", strconv.FormatInt(photo.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": photo.OriginalName}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.Copy(w, content)
+}
+
+// DeletePhoto handles DELETE /api/v1/photos/{entityType}/{entityId}/{photoId}
+func (h *PhotoHandler) DeletePhoto(w http.ResponseWriter, r *http.Request) {
+	entityType, entityID, ok := parsePhotoEntity(w, r)
+	if !ok {
+		return
+	}
+
+	photoID, err := uuid.Parse(chi.URLParam(r, "photoId"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid photo ID")
+		return
+	}
+
+	if err := h.photoService.Delete(r.Context(), entityType, entityID, photoID); err != nil {
+		WriteError(w, err, "Failed to delete photo")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeUploadError maps upload service errors to problem responses
+func (h *PhotoHandler) writeUploadError(w http.ResponseWriter, err error) {
+	switch {
+	case isBodyTooLarge(err):
+		WriteProblemWithDetail(w, http.StatusRequestEntityTooLarge, "Photo exceeds the maximum upload size")
+	default:
+		WriteError(w, err, "Failed to upload photo")
+	}
+}
+
+// parsePhotoEntity extracts and validates the entity type and ID from the URL
+func parsePhotoEntity(w http.ResponseWriter, r *http.Request) (models.PhotoEntityType, uuid.UUID, bool) {
+	entityType := models.PhotoEntityType(chi.URLParam(r, "entityType"))
+	if !entityType.IsValid() {
+		WriteBadRequest(w, "Invalid entity type")
+		return "", uuid.Nil, false
+	}
+
+	entityID, err := uuid.Parse(chi.URLParam(r, "entityId"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid entity ID")
+		return "", uuid.Nil, false
+	}
+
+	return entityType, entityID, true
+}
+
+// isBodyTooLarge reports whether err was caused by http.MaxBytesReader hitting its limit
+func isBodyTooLarge(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.As(err, &maxBytesErr)
+}
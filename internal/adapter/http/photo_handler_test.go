@@ -0,0 +1,240 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"eco-van-api/internal/adapter/storage"
+	"eco-van-api/internal/domainerr"
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// pngHeader is the signature content sniffing recognizes as image/png
+const pngHeader = "\x89PNG\r\n\x1a\n"
+
+// memPhotoRepository keeps photo metadata in memory; every entity in entities exists
+type memPhotoRepository struct {
+	photos   map[uuid.UUID]models.Photo
+	entities map[uuid.UUID]bool
+}
+
+func (r *memPhotoRepository) Create(ctx context.Context, photo *models.Photo) error {
+	r.photos[photo.ID] = *photo
+	return nil
+}
+
+func (r *memPhotoRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Photo, error) {
+	photo, ok := r.photos[id]
+	if !ok {
+		return nil, nil
+	}
+	return &photo, nil
+}
+
+func (r *memPhotoRepository) ListByEntity(
+	ctx context.Context, entityType models.PhotoEntityType, entityID uuid.UUID,
+) ([]models.Photo, error) {
+	var photos []models.Photo
+	for _, photo := range r.photos {
+		if photo.EntityType == entityType && photo.EntityID == entityID {
+			photos = append(photos, photo)
+		}
+	}
+	return photos, nil
+}
+
+func (r *memPhotoRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	delete(r.photos, id)
+	return nil
+}
+
+func (r *memPhotoRepository) EntityExists(ctx context.Context, entityType models.PhotoEntityType, entityID uuid.UUID) (bool, error) {
+	return r.entities[entityID], nil
+}
+
+// newTestPhotoRouter serves the photo endpoints for one existing equipment over a local blob store
+func newTestPhotoRouter(t *testing.T, maxBodyBytes int64) (http.Handler, uuid.UUID) {
+	t.Helper()
+	equipmentID := uuid.New()
+	repo := &memPhotoRepository{
+		photos:   map[uuid.UUID]models.Photo{},
+		entities: map[uuid.UUID]bool{equipmentID: true},
+	}
+	handler := NewPhotoHandler(service.NewPhotoService(repo, storage.NewLocalStore(t.TempDir())), maxBodyBytes)
+
+	r := chi.NewRouter()
+	r.Get("/photos/{entityType}/{entityId}", handler.ListPhotos)
+	r.Post("/photos/{entityType}/{entityId}", handler.UploadPhoto)
+	r.Get("/photos/{entityType}/{entityId}/{photoId}", handler.DownloadPhoto)
+	r.Delete("/photos/{entityType}/{entityId}/{photoId}", handler.DeletePhoto)
+	return r, equipmentID
+}
+
+// newUploadRequest builds a multipart upload with content in the given form field
+func newUploadRequest(t *testing.T, path, field, content string) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile(field, "bin.png")
+	if err != nil {
+		t.Fatalf("Failed to create form file: %v", err)
+	}
+	_, _ = part.Write([]byte(content))
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Failed to close multipart writer: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, path, &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func serveTestPhotoRequest(handler http.Handler, req *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+func TestPhotoHandler_Lifecycle(t *testing.T) {
+	router, equipmentID := newTestPhotoRouter(t, 1<<20)
+	entityPath := "/photos/equipment/" + equipmentID.String()
+	content := pngHeader + "image data"
+
+	w := serveTestPhotoRequest(router, newUploadRequest(t, entityPath, photoFormField, content))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var photo models.PhotoResponse
+	if err := json.NewDecoder(w.Body).Decode(&photo); err != nil {
+		t.Fatalf("Failed to decode photo: %v", err)
+	}
+	if photo.MimeType != "image/png" || photo.Size != int64(len(content)) || photo.EntityID != equipmentID {
+		t.Errorf("Unexpected photo metadata: %+v", photo)
+	}
+
+	w = serveTestPhotoRequest(router, httptest.NewRequest(http.MethodGet, entityPath, http.NoBody))
+	var list models.PhotoListResponse
+	if err := json.NewDecoder(w.Body).Decode(&list); err != nil {
+		t.Fatalf("Failed to decode photo list: %v", err)
+	}
+	if list.Total != 1 || list.Items[0].ID != photo.ID {
+		t.Errorf("Expected the uploaded photo in the list, got %+v", list)
+	}
+
+	photoPath := entityPath + "/" + photo.ID.String()
+	w = serveTestPhotoRequest(router, httptest.NewRequest(http.MethodGet, photoPath, http.NoBody))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	if w.Header().Get("Content-Type") != "image/png" || w.Body.String() != content {
+		t.Errorf("Unexpected download: %s %q", w.Header().Get("Content-Type"), w.Body.String())
+	}
+
+	w = serveTestPhotoRequest(router, httptest.NewRequest(http.MethodDelete, photoPath, http.NoBody))
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("Expected status 204, got %d", w.Code)
+	}
+
+	w = serveTestPhotoRequest(router, httptest.NewRequest(http.MethodGet, photoPath, http.NoBody))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 after delete, got %d", w.Code)
+	}
+}
+
+func TestPhotoHandler_UploadRejected(t *testing.T) {
+	router, equipmentID := newTestPhotoRouter(t, 1024)
+	entityPath := "/photos/equipment/" + equipmentID.String()
+
+	plain := httptest.NewRequest(http.MethodPost, entityPath, strings.NewReader(pngHeader))
+	plain.Header.Set("Content-Type", "image/png")
+
+	tests := []struct {
+		name       string
+		req        *http.Request
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "unsupported content type",
+			req:        newUploadRequest(t, entityPath, photoFormField, "GIF89a not a png"),
+			wantStatus: http.StatusUnsupportedMediaType,
+			wantCode:   domainerr.CodePhotoUnsupportedType,
+		},
+		{
+			name:       "empty file",
+			req:        newUploadRequest(t, entityPath, photoFormField, ""),
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   domainerr.CodePhotoEmpty,
+		},
+		{
+			name:       "too large",
+			req:        newUploadRequest(t, entityPath, photoFormField, pngHeader+strings.Repeat("x", 2048)),
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "not multipart",
+			req:        plain,
+			wantStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:       "missing file field",
+			req:        newUploadRequest(t, entityPath, "image", pngHeader),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid entity type",
+			req:        newUploadRequest(t, "/photos/invoices/"+equipmentID.String(), photoFormField, pngHeader),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown entity",
+			req:        newUploadRequest(t, "/photos/equipment/"+uuid.New().String(), photoFormField, pngHeader),
+			wantStatus: http.StatusNotFound,
+			wantCode:   domainerr.CodePhotoEntityNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveTestPhotoRequest(router, tt.req)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantCode == "" {
+				return
+			}
+			var problem Problem
+			if err := json.NewDecoder(w.Body).Decode(&problem); err != nil {
+				t.Fatalf("Failed to decode problem: %v", err)
+			}
+			if problem.Code != tt.wantCode {
+				t.Errorf("Expected code %s, got %s", tt.wantCode, problem.Code)
+			}
+		})
+	}
+}
+
+func TestPhotoHandler_DeleteUnknownPhoto(t *testing.T) {
+	router, equipmentID := newTestPhotoRouter(t, 1024)
+	path := "/photos/equipment/" + equipmentID.String() + "/" + uuid.New().String()
+
+	w := serveTestPhotoRequest(router, httptest.NewRequest(http.MethodDelete, path, http.NoBody))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", w.Code)
+	}
+
+	w = serveTestPhotoRequest(router, httptest.NewRequest(http.MethodDelete, "/photos/equipment/"+equipmentID.String()+"/1", http.NoBody))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an invalid photo ID, got %d", w.Code)
+	}
+}
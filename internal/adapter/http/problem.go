@@ -3,6 +3,11 @@ package http
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
+
+	"eco-van-api/internal/domainerr"
+
+	"github.com/google/uuid"
 )
 
 // Problem represents an HTTP Problem Details object as defined in RFC 7807
@@ -12,6 +17,14 @@ type Problem struct {
 	Status   int    `json:"status"`
 	Detail   string `json:"detail,omitempty"`
 	Instance string `json:"instance,omitempty"`
+	// Code identifies the error for clients that localize messages themselves, e.g. WAREHOUSE_NAME_EXISTS
+	Code string `json:"code,omitempty"`
+	// ConflictingOrderIDs lists the orders that caused a transport conflict
+	ConflictingOrderIDs []uuid.UUID `json:"conflictingOrderIds,omitempty"`
+	// Fields carries the structured details of a domain error, e.g. the duplicate name
+	Fields map[string]interface{} `json:"fields,omitempty"`
+	// Errors lists the request fields that failed validation
+	Errors []domainerr.FieldError `json:"errors,omitempty"`
 }
 
 // Common problem types
@@ -27,6 +40,11 @@ const (
 	ProblemTypeBadRequest           = "/errors/bad-request"
 	ProblemTypeMethodNotAllowed     = "/errors/method-not-allowed"
 	ProblemTypeUnsupportedMediaType = "/errors/unsupported-media-type"
+	ProblemTypePayloadTooLarge      = "/errors/payload-too-large"
+	ProblemTypeTransportConflict    = "/errors/transport-conflict"
+	ProblemTypeTooManyRequests      = "/errors/too-many-requests"
+	ProblemTypeInvalidTransition    = "/errors/invalid-transition"
+	ProblemTypePreconditionFailed   = "/errors/precondition-failed"
 )
 
 // Common problems for standard HTTP status codes
@@ -35,58 +53,93 @@ var CommonProblems = map[int]Problem{
 		Type:   ProblemTypeBadRequest,
 		Title:  "Bad Request",
 		Status: http.StatusBadRequest,
+		Code:   "BAD_REQUEST",
 	},
 	http.StatusUnauthorized: {
 		Type:   ProblemTypeUnauthorized,
 		Title:  "Unauthorized",
 		Status: http.StatusUnauthorized,
+		Code:   "UNAUTHORIZED",
 	},
 	http.StatusForbidden: {
 		Type:   ProblemTypeForbidden,
 		Title:  "Forbidden",
 		Status: http.StatusForbidden,
+		Code:   "FORBIDDEN",
 	},
 	http.StatusNotFound: {
 		Type:   ProblemTypeNotFound,
 		Title:  "Not Found",
 		Status: http.StatusNotFound,
+		Code:   "NOT_FOUND",
 	},
 	http.StatusMethodNotAllowed: {
 		Type:   ProblemTypeMethodNotAllowed,
 		Title:  "Method Not Allowed",
 		Status: http.StatusMethodNotAllowed,
+		Code:   "METHOD_NOT_ALLOWED",
 	},
 	http.StatusConflict: {
 		Type:   ProblemTypeConflict,
 		Title:  "Conflict",
 		Status: http.StatusConflict,
+		Code:   "CONFLICT",
+	},
+	http.StatusPreconditionFailed: {
+		Type:   ProblemTypePreconditionFailed,
+		Title:  "Precondition Failed",
+		Status: http.StatusPreconditionFailed,
+		Code:   "PRECONDITION_FAILED",
+	},
+	http.StatusRequestEntityTooLarge: {
+		Type:   ProblemTypePayloadTooLarge,
+		Title:  "Payload Too Large",
+		Status: http.StatusRequestEntityTooLarge,
+		Code:   "PAYLOAD_TOO_LARGE",
 	},
 	http.StatusUnsupportedMediaType: {
 		Type:   ProblemTypeUnsupportedMediaType,
 		Title:  "Unsupported Media Type",
 		Status: http.StatusUnsupportedMediaType,
+		Code:   "UNSUPPORTED_MEDIA_TYPE",
 	},
 	http.StatusUnprocessableEntity: {
 		Type:   ProblemTypeValidationError,
 		Title:  "Validation Error",
 		Status: http.StatusUnprocessableEntity,
+		Code:   "VALIDATION_FAILED",
+	},
+	http.StatusTooManyRequests: {
+		Type:   ProblemTypeTooManyRequests,
+		Title:  "Too Many Requests",
+		Status: http.StatusTooManyRequests,
+		Code:   "TOO_MANY_REQUESTS",
 	},
 	http.StatusInternalServerError: {
 		Type:   ProblemTypeInternalError,
 		Title:  "Internal Server Error",
 		Status: http.StatusInternalServerError,
+		Code:   "INTERNAL_ERROR",
 	},
 	http.StatusServiceUnavailable: {
 		Type:   ProblemTypeServiceUnavailable,
 		Title:  "Service Unavailable",
 		Status: http.StatusServiceUnavailable,
+		Code:   "SERVICE_UNAVAILABLE",
 	},
 }
 
-// WriteProblem writes a Problem JSON response to the HTTP response writer
+// WriteProblem writes a Problem JSON response to the HTTP response writer, with the title in the language of
+// the response. When a language was negotiated for the request it is announced in Content-Language.
 func WriteProblem(w http.ResponseWriter, p Problem) {
+	language, negotiated := negotiatedLanguage(w)
+	p = localizeTitle(p, language)
+
 	// Set content type header
 	w.Header().Set("Content-Type", "application/problem+json")
+	if negotiated {
+		w.Header().Set(ContentLanguageHeader, language)
+	}
 
 	// Set status code
 	w.WriteHeader(p.Status)
@@ -98,18 +151,39 @@ func WriteProblem(w http.ResponseWriter, p Problem) {
 	}
 }
 
-// WriteProblemWithDetail creates and writes a problem with custom detail
+// WriteProblemWithDetail creates and writes a problem with custom detail. Fixed details listed in detailCodes
+// get their stable code and are translated into the language of the response.
 func WriteProblemWithDetail(w http.ResponseWriter, status int, detail string) {
 	problem := CommonProblems[status]
 	problem.Detail = detail
-	WriteProblem(w, problem)
+	writeDetailProblem(w, problem)
 }
 
-// WriteProblemWithType creates and writes a problem with custom type and detail
+// WriteProblemWithType creates and writes a problem with custom type and detail, coded like
+// WriteProblemWithDetail
 func WriteProblemWithType(w http.ResponseWriter, status int, problemType, detail string) {
 	problem := CommonProblems[status]
 	problem.Type = problemType
 	problem.Detail = detail
+	writeDetailProblem(w, problem)
+}
+
+// WriteProblemWithFields writes a problem whose detail is built from fields under a stable code, so that it is
+// translated like the detail of a domain error
+func WriteProblemWithFields(w http.ResponseWriter, status int, code, detail string, fields map[string]interface{}) {
+	problem := CommonProblems[status]
+	problem.Code = code
+	problem.Detail = detail
+	problem.Fields = fields
+	WriteProblem(w, localizeError(problem, responseLanguage(w)))
+}
+
+// writeDetailProblem writes a problem with the code of its detail, if it has one
+func writeDetailProblem(w http.ResponseWriter, problem Problem) {
+	if code, ok := detailCodes[problem.Detail]; ok {
+		problem.Code = code
+		problem = localizeError(problem, responseLanguage(w))
+	}
 	WriteProblem(w, problem)
 }
 
@@ -140,6 +214,16 @@ func WriteConflict(w http.ResponseWriter, detail string) {
 	WriteProblemWithDetail(w, http.StatusConflict, detail)
 }
 
+// WriteTransportConflict writes a conflict problem listing the orders that block a transport booking
+func WriteTransportConflict(w http.ResponseWriter, detail string, orderIDs []uuid.UUID) {
+	problem := CommonProblems[http.StatusConflict]
+	problem.Type = ProblemTypeTransportConflict
+	problem.Code = "TRANSPORT_CONFLICT"
+	problem.Detail = detail
+	problem.ConflictingOrderIDs = orderIDs
+	WriteProblem(w, problem)
+}
+
 // WriteUnauthorized writes an unauthorized problem
 func WriteUnauthorized(w http.ResponseWriter, detail string) {
 	WriteProblemWithDetail(w, http.StatusUnauthorized, detail)
@@ -155,6 +239,12 @@ func WriteBadRequest(w http.ResponseWriter, detail string) {
 	WriteProblemWithDetail(w, http.StatusBadRequest, detail)
 }
 
+// WriteTooManyRequests writes a too many requests problem telling the client when to retry
+func WriteTooManyRequests(w http.ResponseWriter, detail string, retryAfterSeconds int) {
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds))
+	WriteProblemWithDetail(w, http.StatusTooManyRequests, detail)
+}
+
 // WriteInternalError writes an internal server error problem
 func WriteInternalError(w http.ResponseWriter, detail string) {
 	WriteProblemWithDetail(w, http.StatusInternalServerError, detail)
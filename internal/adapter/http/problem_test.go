@@ -108,8 +108,10 @@ func TestCommonProblems_StatusCodes(t *testing.T) {
 		http.StatusNotFound,
 		http.StatusMethodNotAllowed,
 		http.StatusConflict,
+		http.StatusRequestEntityTooLarge,
 		http.StatusUnsupportedMediaType,
 		http.StatusUnprocessableEntity,
+		http.StatusTooManyRequests,
 		http.StatusInternalServerError,
 		http.StatusServiceUnavailable,
 	}
@@ -241,3 +243,24 @@ func TestHelperFunctions(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteTooManyRequests(t *testing.T) {
+	recorder := httptest.NewRecorder()
+
+	WriteTooManyRequests(recorder, customErrorDetail, 42)
+
+	if recorder.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected status code %d, got %d", http.StatusTooManyRequests, recorder.Code)
+	}
+	if retryAfter := recorder.Header().Get("Retry-After"); retryAfter != "42" {
+		t.Errorf("Expected Retry-After '42', got '%s'", retryAfter)
+	}
+
+	var response Problem
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Type != ProblemTypeTooManyRequests {
+		t.Errorf("Expected type '%s', got '%s'", ProblemTypeTooManyRequests, response.Type)
+	}
+}
@@ -0,0 +1,145 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"eco-van-api/internal/adapter/auth"
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/port"
+)
+
+// Rate limit scopes; each scope has its own buckets so endpoint budgets do not drain the default one
+const (
+	RateLimitScopeDefault = "default"
+	RateLimitScopeLogin   = "login"
+	RateLimitScopeRefresh = "refresh"
+)
+
+// APIKeyResolver resolves an active API key without recording its use
+type APIKeyResolver interface {
+	Resolve(ctx context.Context, key string) (*models.APIKey, error)
+}
+
+// rateLimitBudget is a named policy; its name is part of the bucket key
+type rateLimitBudget struct {
+	scope  string
+	policy models.RateLimitPolicy
+	// failOpen lets requests through when the bucket store fails instead of rejecting them with 503
+	failOpen bool
+}
+
+// rateLimitDecision is the outcome of charging a request to its bucket
+type rateLimitDecision struct {
+	policy models.RateLimitPolicy
+	result models.RateLimitResult
+}
+
+// RateLimiter charges requests to token buckets keyed by the authenticated user, the API key or the client IP
+type RateLimiter struct {
+	store      port.RateLimitStore
+	budget     rateLimitBudget
+	paths      map[string]rateLimitBudget
+	exempt     map[string]bool
+	jwtManager *auth.JWTManager
+	apiKeys    APIKeyResolver
+	now        func() time.Time
+}
+
+// NewRateLimiter creates a rate limiter that applies defaultPolicy to every path without a budget of its own.
+// With a nil jwtManager or apiKeys the corresponding callers are limited by their IP.
+// The default budget lets requests through when the bucket store fails, see FailClosed.
+func NewRateLimiter(
+	store port.RateLimitStore, defaultPolicy models.RateLimitPolicy, jwtManager *auth.JWTManager, apiKeys APIKeyResolver,
+) *RateLimiter {
+	return &RateLimiter{
+		store:      store,
+		budget:     rateLimitBudget{scope: RateLimitScopeDefault, policy: defaultPolicy, failOpen: true},
+		paths:      map[string]rateLimitBudget{},
+		exempt:     map[string]bool{},
+		jwtManager: jwtManager,
+		apiKeys:    apiKeys,
+		now:        time.Now,
+	}
+}
+
+// Limit gives the paths their own budget under scope instead of the default one. These budgets guard
+// credential checks, so their requests are rejected when the bucket store fails.
+func (l *RateLimiter) Limit(scope string, policy models.RateLimitPolicy, paths ...string) *RateLimiter {
+	for _, path := range paths {
+		l.paths[path] = rateLimitBudget{scope: scope, policy: policy}
+	}
+	return l
+}
+
+// Exempt turns rate limiting off for the paths, e.g. for probes and metrics scraping
+func (l *RateLimiter) Exempt(paths ...string) *RateLimiter {
+	for _, path := range paths {
+		l.exempt[path] = true
+	}
+	return l
+}
+
+// FailClosed rejects requests on the default budget too when the bucket store fails
+func (l *RateLimiter) FailClosed() *RateLimiter {
+	l.budget.failOpen = false
+	return l
+}
+
+// budgetFor returns the budget requests to the path are charged to; false for exempt paths
+func (l *RateLimiter) budgetFor(path string) (rateLimitBudget, bool) {
+	if l.exempt[path] {
+		return rateLimitBudget{}, false
+	}
+	if budget, ok := l.paths[path]; ok {
+		return budget, true
+	}
+	return l.budget, true
+}
+
+// take charges the request to its bucket in budget
+func (l *RateLimiter) take(r *http.Request, budget rateLimitBudget) (*rateLimitDecision, error) {
+	key := budget.scope + ":" + l.identity(r)
+	result, err := l.store.Take(r.Context(), key, budget.policy, l.now())
+	if err != nil {
+		return nil, err
+	}
+
+	return &rateLimitDecision{policy: budget.policy, result: result}, nil
+}
+
+// identity names the caller of a request. Only verified credentials count, so clients cannot escape their
+// IP budget by sending made-up tokens or keys. API keys are resolved without recording their use, which is
+// left to authentication; refresh, challenge and restricted tokens do not name a caller.
+func (l *RateLimiter) identity(r *http.Request) string {
+	if key := r.Header.Get(APIKeyHeader); key != "" && l.apiKeys != nil {
+		if apiKey, err := l.apiKeys.Resolve(r.Context(), key); err == nil {
+			return "apikey:" + apiKey.ID.String()
+		}
+	}
+
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok && token != "" && l.jwtManager != nil {
+		if claims, err := l.jwtManager.ValidateAccessToken(token); err == nil && !claims.PasswordChange && !claims.TwoFactorSetup {
+			return "user:" + claims.UserID.String()
+		}
+	}
+
+	return "ip:" + clientInfo(r).IP
+}
+
+// writeRateLimitHeaders sets the RateLimit-* headers describing the budget of the request
+func writeRateLimitHeaders(w http.ResponseWriter, decision *rateLimitDecision) {
+	policy := decision.policy
+	w.Header().Set("RateLimit-Policy", strconv.Itoa(policy.Requests)+";w="+strconv.Itoa(ceilSeconds(policy.Period)))
+	w.Header().Set("RateLimit-Limit", strconv.Itoa(policy.Requests))
+	w.Header().Set("RateLimit-Remaining", strconv.Itoa(decision.result.Remaining))
+	w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(decision.result.Reset)))
+}
+
+// ceilSeconds rounds a duration up to whole seconds so clients never retry too early
+func ceilSeconds(d time.Duration) int {
+	return int((d + time.Second - 1) / time.Second)
+}
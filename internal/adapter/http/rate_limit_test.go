@@ -0,0 +1,179 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"eco-van-api/internal/adapter/auth"
+	"eco-van-api/internal/adapter/ratelimit"
+	"eco-van-api/internal/adapter/telemetry"
+	"eco-van-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// failingRateLimitStore simulates an unreachable bucket store
+type failingRateLimitStore struct{}
+
+func (failingRateLimitStore) Take(context.Context, string, models.RateLimitPolicy, time.Time) (models.RateLimitResult, error) {
+	return models.RateLimitResult{}, errors.New("connection refused")
+}
+
+func newTestRateLimitHandler(limiter *RateLimiter) http.Handler {
+	mw := NewMiddleware(telemetry.NewLogger("error"), telemetry.NewTracer("test", "1.0.0"), telemetry.NewMetrics())
+	return mw.RateLimit(limiter)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func serveRateLimited(handler http.Handler, path string, header http.Header, remoteAddr string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, http.NoBody)
+	req.RemoteAddr = remoteAddr
+	for name, values := range header {
+		req.Header.Set(name, values[0])
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+func TestMiddleware_RateLimit_Budget(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(ratelimit.NewMemoryStore(), models.RateLimitPolicy{Requests: 2, Period: time.Minute}, nil, nil)
+	limiter.now = func() time.Time { return now }
+	handler := newTestRateLimitHandler(limiter)
+
+	w := serveRateLimited(handler, "/api/v1/orders", nil, "10.0.0.1:1234")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	expected := map[string]string{
+		"RateLimit-Policy":    "2;w=60",
+		"RateLimit-Limit":     "2",
+		"RateLimit-Remaining": "1",
+		"RateLimit-Reset":     "30",
+	}
+	for name, value := range expected {
+		if got := w.Header().Get(name); got != value {
+			t.Errorf("Expected %s %q, got %q", name, value, got)
+		}
+	}
+
+	serveRateLimited(handler, "/api/v1/orders", nil, "10.0.0.1:1234")
+	w = serveRateLimited(handler, "/api/v1/orders", nil, "10.0.0.1:1234")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected status 429, got %d", w.Code)
+	}
+	if w.Header().Get("Content-Type") != "application/problem+json" {
+		t.Errorf("Expected a problem document, got %q", w.Header().Get("Content-Type"))
+	}
+	if w.Header().Get("Retry-After") != "30" || w.Header().Get("RateLimit-Remaining") != "0" {
+		t.Errorf("Unexpected rejection headers: %v", w.Header())
+	}
+
+	// Another client has its own bucket
+	if w := serveRateLimited(handler, "/api/v1/orders", nil, "10.0.0.2:1234"); w.Code != http.StatusOK {
+		t.Errorf("Expected another IP to be allowed, got %d", w.Code)
+	}
+}
+
+func TestMiddleware_RateLimit_PathBudgets(t *testing.T) {
+	limiter := NewRateLimiter(ratelimit.NewMemoryStore(), models.RateLimitPolicy{Requests: 100, Period: time.Minute}, nil, nil).
+		Limit(RateLimitScopeLogin, models.RateLimitPolicy{Requests: 1, Period: time.Minute}, "/api/v1/auth/login").
+		Exempt("/healthz")
+	handler := newTestRateLimitHandler(limiter)
+
+	serveRateLimited(handler, "/api/v1/auth/login", nil, "10.0.0.1:1234")
+	if w := serveRateLimited(handler, "/api/v1/auth/login", nil, "10.0.0.1:1234"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected the login budget to be exhausted, got %d", w.Code)
+	}
+
+	// The login budget does not drain the default one
+	if w := serveRateLimited(handler, "/api/v1/orders", nil, "10.0.0.1:1234"); w.Code != http.StatusOK {
+		t.Errorf("Expected the default budget to be untouched, got %d", w.Code)
+	}
+
+	w := serveRateLimited(handler, "/healthz", nil, "10.0.0.1:1234")
+	if w.Code != http.StatusOK || w.Header().Get("RateLimit-Limit") != "" {
+		t.Errorf("Expected exempt path without rate limit headers, got %d %v", w.Code, w.Header())
+	}
+}
+
+func TestMiddleware_RateLimit_Identity(t *testing.T) {
+	jwtManager := auth.NewDefaultJWTManager("test-secret")
+	apiKeys := &stubAPIKeyAuthenticator{apiKey: &models.APIKey{ID: uuid.New()}}
+	limiter := NewRateLimiter(ratelimit.NewMemoryStore(), models.RateLimitPolicy{Requests: 1, Period: time.Minute},
+		jwtManager, apiKeys)
+	handler := newTestRateLimitHandler(limiter)
+
+	user := &models.User{ID: uuid.New(), Role: models.UserRoleViewer}
+	token, err := jwtManager.GenerateAccessToken(user)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+	bearer := http.Header{"Authorization": {"Bearer " + token}}
+
+	// A user keeps one bucket across IPs
+	serveRateLimited(handler, "/api/v1/orders", bearer, "10.0.0.1:1234")
+	if w := serveRateLimited(handler, "/api/v1/orders", bearer, "10.0.0.2:1234"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected the user budget to follow the user, got %d", w.Code)
+	}
+
+	// API keys and anonymous callers behind the same IP have separate buckets
+	if w := serveRateLimited(handler, "/api/v1/orders", http.Header{APIKeyHeader: {"evk_test"}}, "10.0.0.1:1234"); w.Code != http.StatusOK {
+		t.Errorf("Expected the API key to have its own bucket, got %d", w.Code)
+	}
+	if apiKeys.uses != 0 {
+		t.Errorf("Expected the API key use not to be recorded, got %d uses", apiKeys.uses)
+	}
+	if w := serveRateLimited(handler, "/api/v1/orders", nil, "10.0.0.1:1234"); w.Code != http.StatusOK {
+		t.Errorf("Expected the IP to have its own bucket, got %d", w.Code)
+	}
+
+	// Made-up, refresh and restricted tokens are limited by IP
+	refreshToken, err := jwtManager.GenerateRefreshToken(user)
+	if err != nil {
+		t.Fatalf("Failed to generate refresh token: %v", err)
+	}
+	setupToken, err := jwtManager.GenerateTwoFactorSetupToken(user)
+	if err != nil {
+		t.Fatalf("Failed to generate setup token: %v", err)
+	}
+	for name, token := range map[string]string{"invalid": "forged", "refresh": refreshToken, "restricted": setupToken} {
+		header := http.Header{"Authorization": {"Bearer " + token}}
+		if w := serveRateLimited(handler, "/api/v1/orders", header, "10.0.0.1:1234"); w.Code != http.StatusTooManyRequests {
+			t.Errorf("Expected the %s token to share the IP bucket, got %d", name, w.Code)
+		}
+	}
+}
+
+func TestMiddleware_RateLimit_StoreFailure(t *testing.T) {
+	newLimiter := func() *RateLimiter {
+		return NewRateLimiter(failingRateLimitStore{}, models.RateLimitPolicy{Requests: 1, Period: time.Minute}, nil, nil).
+			Limit(RateLimitScopeLogin, models.RateLimitPolicy{Requests: 1, Period: time.Minute}, "/api/v1/auth/login")
+	}
+
+	tests := []struct {
+		name       string
+		limiter    *RateLimiter
+		path       string
+		wantStatus int
+	}{
+		{"default budget fails open", newLimiter(), "/api/v1/orders", http.StatusOK},
+		{"endpoint budget fails closed", newLimiter(), "/api/v1/auth/login", http.StatusServiceUnavailable},
+		{"default budget can fail closed", newLimiter().FailClosed(), "/api/v1/orders", http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveRateLimited(newTestRateLimitHandler(tt.limiter), tt.path, nil, "10.0.0.1:1234")
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
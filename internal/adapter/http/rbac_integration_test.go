@@ -10,7 +10,11 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"eco-van-api/internal/adapter/auth"
 	"eco-van-api/internal/models"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 )
 
 func TestRBACIntegration_UserEndpoints(t *testing.T) {
@@ -151,3 +155,130 @@ func testWriteAccessDenied(t *testing.T, user *models.User) {
 
 	t.Error("Unexpected user role")
 }
+
+func TestRBACIntegration_PermissionMatrix(t *testing.T) {
+	jwtManager := auth.NewDefaultJWTManager("test-secret")
+	router := newPermissionTestRouter(jwtManager, models.DefaultPermissionMatrix())
+
+	endpoints := []struct {
+		method string
+		path   string
+	}{
+		{"GET", "/api/v1/orders"},
+		{"POST", "/api/v1/orders"},
+		{"DELETE", "/api/v1/orders/1"},
+		{"PUT", "/api/v1/transport/1/assign-driver"},
+		{"POST", "/api/v1/clients/1/objects"},
+		{"POST", "/api/v1/transport"},
+		{"POST", "/api/v1/users"},
+	}
+
+	// Expected status per endpoint, in the order of endpoints above
+	tests := []struct {
+		name     string
+		userRole models.UserRole
+		expected []int
+	}{
+		{
+			name:     "Admin user has full access",
+			userRole: models.UserRoleAdmin,
+			expected: []int{200, 200, 200, 200, 200, 200, 200},
+		},
+		{
+			name:     "Dispatcher user manages orders, assignments and client objects",
+			userRole: models.UserRoleDispatcher,
+			expected: []int{200, 200, 200, 200, 200, 403, 403},
+		},
+		{
+			name:     "Driver user has read-only access",
+			userRole: models.UserRoleDriver,
+			expected: []int{200, 403, 403, 403, 403, 403, 403},
+		},
+		{
+			name:     "Viewer user has read-only access",
+			userRole: models.UserRoleViewer,
+			expected: []int{200, 403, 403, 403, 403, 403, 403},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := jwtManager.GenerateAccessToken(&models.User{ID: uuid.New(), Role: tt.userRole})
+			if err != nil {
+				t.Fatalf("failed to generate token: %v", err)
+			}
+
+			for i, endpoint := range endpoints {
+				req := httptest.NewRequest(endpoint.method, endpoint.path, http.NoBody)
+				req.Header.Set("Authorization", "Bearer "+token)
+				rr := httptest.NewRecorder()
+
+				router.ServeHTTP(rr, req)
+
+				if rr.Code != tt.expected[i] {
+					t.Errorf("%s %s: expected status %d, got %d", endpoint.method, endpoint.path, tt.expected[i], rr.Code)
+				}
+			}
+		})
+	}
+
+	t.Run("Request without token is unauthorized", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/v1/orders", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusUnauthorized {
+			t.Errorf("expected status %d, got %d", http.StatusUnauthorized, rr.Code)
+		}
+	})
+}
+
+func TestRBACIntegration_ConfiguredPermissionMatrix(t *testing.T) {
+	permissions, err := models.ParsePermissionMatrix("DISPATCHER=*:read,orders:read")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	jwtManager := auth.NewDefaultJWTManager("test-secret")
+	router := newPermissionTestRouter(jwtManager, permissions)
+
+	token, err := jwtManager.GenerateAccessToken(&models.User{ID: uuid.New(), Role: models.UserRoleDispatcher})
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+
+	req := httptest.NewRequest("POST", "/api/v1/orders", http.NoBody)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rr := httptest.NewRecorder()
+
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected status %d, got %d", http.StatusForbidden, rr.Code)
+	}
+}
+
+// newPermissionTestRouter mounts stub handlers behind the same middleware chain as the server routes
+func newPermissionTestRouter(jwtManager *auth.JWTManager, permissions *models.PermissionMatrix) http.Handler {
+	authMiddleware := NewAuthMiddleware(jwtManager, nil, nil)
+	rbacMiddleware := NewRBACMiddlewareWithPermissions(permissions)
+	ok := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	r := chi.NewRouter()
+	r.Route("/api/v1", func(r chi.Router) {
+		r.Use(authMiddleware.RequireAuth)
+
+		r.With(rbacMiddleware.RequirePermission(models.ResourceOrders, models.PermissionActionRead)).Get("/orders", ok)
+		r.With(rbacMiddleware.RequirePermission(models.ResourceOrders, models.PermissionActionWrite)).Post("/orders", ok)
+		r.With(rbacMiddleware.RequirePermission(models.ResourceOrders, models.PermissionActionDelete)).Delete("/orders/{id}", ok)
+		r.With(rbacMiddleware.RequirePermission(models.ResourceTransportAssignments, models.PermissionActionWrite)).
+			Put("/transport/{id}/assign-driver", ok)
+		r.With(rbacMiddleware.RequirePermission(models.ResourceClientObjects, models.PermissionActionWrite)).
+			Post("/clients/{id}/objects", ok)
+		r.With(rbacMiddleware.RequirePermission(models.ResourceTransport, models.PermissionActionWrite)).Post("/transport", ok)
+		r.With(rbacMiddleware.RequirePermission(models.ResourceUsers, models.PermissionActionWrite)).Post("/users", ok)
+	})
+	return r
+}
@@ -1,17 +1,60 @@
 package http
 
 import (
+	"fmt"
 	"net/http"
 
 	"eco-van-api/internal/models"
 )
 
-// RBACMiddleware provides role-based access control for user endpoints
-type RBACMiddleware struct{}
+// RBACMiddleware provides role-based access control evaluated against a permission matrix
+type RBACMiddleware struct {
+	permissions *models.PermissionMatrix
+}
 
-// NewRBACMiddleware creates a new RBAC middleware
+// NewRBACMiddleware creates a new RBAC middleware with the default permission matrix
 func NewRBACMiddleware() *RBACMiddleware {
-	return &RBACMiddleware{}
+	return NewRBACMiddlewareWithPermissions(nil)
+}
+
+// NewRBACMiddlewareWithPermissions creates a new RBAC middleware; a nil matrix means the default one
+func NewRBACMiddlewareWithPermissions(permissions *models.PermissionMatrix) *RBACMiddleware {
+	if permissions == nil {
+		permissions = models.DefaultPermissionMatrix()
+	}
+	return &RBACMiddleware{permissions: permissions}
+}
+
+// RequirePermission middleware that requires the user's role to be granted the action on the resource.
+// Requests made with an API key additionally need a key scope covering the action.
+func (m *RBACMiddleware) RequirePermission(
+	resource models.PermissionResource, action models.PermissionAction,
+) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userRole, ok := GetUserRoleFromContext(r.Context())
+			if !ok {
+				WriteUnauthorized(w, "User role not found in context")
+				return
+			}
+
+			if !m.permissions.Allows(userRole, resource, action) {
+				WriteProblemWithFields(w, http.StatusForbidden, CodeRoleNotPermitted,
+					fmt.Sprintf("Role %s is not permitted to %s %s", userRole, action, resource),
+					map[string]interface{}{"role": userRole, "action": action, "resource": resource})
+				return
+			}
+
+			if apiKey, ok := GetAPIKeyFromContext(r.Context()); ok && !apiKey.AllowsScope(resource, action) {
+				WriteProblemWithFields(w, http.StatusForbidden, CodeAPIKeyScopeNotPermitted,
+					fmt.Sprintf("API key scopes do not permit %s %s", action, resource),
+					map[string]interface{}{"action": action, "resource": resource})
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
 }
 
 // RequireAdminRole middleware that requires ADMIN role for full access
@@ -174,6 +174,10 @@ func TestRBACMiddleware_NoRoleInContext(t *testing.T) {
 			name:           "RequireWriteAccess without role should be unauthorized",
 			middlewareFunc: middleware.RequireWriteAccess,
 		},
+		{
+			name:           "RequirePermission without role should be unauthorized",
+			middlewareFunc: middleware.RequirePermission(models.ResourceOrders, models.PermissionActionRead),
+		},
 	}
 
 	for _, tt := range tests {
@@ -192,3 +196,140 @@ func TestRBACMiddleware_NoRoleInContext(t *testing.T) {
 		})
 	}
 }
+
+func TestRBACMiddleware_RequirePermission(t *testing.T) {
+	middleware := NewRBACMiddleware()
+
+	tests := []struct {
+		name           string
+		userRole       models.UserRole
+		resource       models.PermissionResource
+		action         models.PermissionAction
+		expectedStatus int
+	}{
+		{
+			name:           "Dispatcher can create orders",
+			userRole:       models.UserRoleDispatcher,
+			resource:       models.ResourceOrders,
+			action:         models.PermissionActionWrite,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Dispatcher can assign transport",
+			userRole:       models.UserRoleDispatcher,
+			resource:       models.ResourceTransportAssignments,
+			action:         models.PermissionActionWrite,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Dispatcher cannot create transport",
+			userRole:       models.UserRoleDispatcher,
+			resource:       models.ResourceTransport,
+			action:         models.PermissionActionWrite,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Dispatcher cannot manage users",
+			userRole:       models.UserRoleDispatcher,
+			resource:       models.ResourceUsers,
+			action:         models.PermissionActionWrite,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Viewer can read orders",
+			userRole:       models.UserRoleViewer,
+			resource:       models.ResourceOrders,
+			action:         models.PermissionActionRead,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Viewer cannot delete client objects",
+			userRole:       models.UserRoleViewer,
+			resource:       models.ResourceClientObjects,
+			action:         models.PermissionActionDelete,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Admin can delete users",
+			userRole:       models.UserRoleAdmin,
+			resource:       models.ResourceUsers,
+			action:         models.PermissionActionDelete,
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/test", http.NoBody)
+			ctx := context.WithValue(req.Context(), UserRoleKey, tt.userRole)
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			middleware.RequirePermission(tt.resource, tt.action)(handler).ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestRBACMiddleware_RequirePermissionCustomMatrix(t *testing.T) {
+	permissions, err := models.ParsePermissionMatrix("VIEWER=*:read,orders:write")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	middleware := NewRBACMiddlewareWithPermissions(permissions)
+
+	req := httptest.NewRequest("POST", "/test", http.NoBody)
+	req = req.WithContext(context.WithValue(req.Context(), UserRoleKey, models.UserRoleViewer))
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	middleware.RequirePermission(models.ResourceOrders, models.PermissionActionWrite)(handler).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+}
+
+func TestRBACMiddleware_RequirePermissionAPIKeyScopes(t *testing.T) {
+	middleware := NewRBACMiddleware()
+	apiKey := &models.APIKey{Role: models.UserRoleDispatcher, Scopes: []string{"orders:read", "transport:*"}}
+
+	tests := []struct {
+		name           string
+		resource       models.PermissionResource
+		action         models.PermissionAction
+		expectedStatus int
+	}{
+		{"Scope and role allow", models.ResourceOrders, models.PermissionActionRead, http.StatusOK},
+		{"Role allows but scope does not", models.ResourceOrders, models.PermissionActionWrite, http.StatusForbidden},
+		{"Scope allows but role does not", models.ResourceTransport, models.PermissionActionWrite, http.StatusForbidden},
+		{"Wildcard scope with role permission", models.ResourceTransport, models.PermissionActionRead, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/test", http.NoBody)
+			ctx := context.WithValue(req.Context(), UserRoleKey, apiKey.Role)
+			ctx = context.WithValue(ctx, APIKeyKey, apiKey)
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			middleware.RequirePermission(tt.resource, tt.action)(handler).ServeHTTP(rr, req.WithContext(ctx))
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package http
+
+import (
+	"net/http"
+
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/port"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// RouteHandler handles HTTP requests for daily route planning
+type RouteHandler struct {
+	routeService port.RouteService
+	validate     *validator.Validate
+}
+
+// NewRouteHandler creates a new route planning handler
+func NewRouteHandler(routeService port.RouteService) *RouteHandler {
+	return &RouteHandler{
+		routeService: routeService,
+		validate:     newValidator(),
+	}
+}
+
+// OptimizeRoutes handles POST /api/v1/routes/optimize
+func (h *RouteHandler) OptimizeRoutes(w http.ResponseWriter, r *http.Request) {
+	var req models.RoutePlanRequest
+	if err := ParseJSON(r, &req); err != nil {
+		WriteBadRequest(w, "Invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		WriteInvalidRequest(w, err)
+		return
+	}
+
+	plan, err := h.routeService.Optimize(r.Context(), req)
+	if err != nil {
+		WriteError(w, err, "Failed to optimize routes")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, plan)
+}
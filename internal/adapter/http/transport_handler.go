@@ -3,7 +3,6 @@ package http
 import (
 	"net/http"
 	"strconv"
-	"strings"
 
 	"eco-van-api/internal/models"
 	"eco-van-api/internal/port"
@@ -23,7 +22,7 @@ type transportHandler struct {
 func NewTransportHandler(transportService port.TransportService) *transportHandler {
 	return &transportHandler{
 		transportService: transportService,
-		validate:         validator.New(),
+		validate:         newValidator(),
 	}
 }
 
@@ -62,7 +61,7 @@ func (h *transportHandler) ListItems(w http.ResponseWriter, r *http.Request) {
 	// Get transport list
 	response, err := h.transportService.List(r.Context(), req)
 	if err != nil {
-		WriteInternalError(w, "Failed to list transport")
+		WriteError(w, err, "Failed to list transport")
 		return
 	}
 
@@ -82,11 +81,7 @@ func (h *transportHandler) GetItem(w http.ResponseWriter, r *http.Request) {
 	// Get transport by ID
 	transport, err := h.transportService.GetByID(r.Context(), id)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			WriteNotFound(w, "Transport not found")
-			return
-		}
-		WriteInternalError(w, "Failed to get transport")
+		WriteError(w, err, "Failed to get transport")
 		return
 	}
 
@@ -106,18 +101,14 @@ func (h *transportHandler) CreateItem(w http.ResponseWriter, r *http.Request) {
 
 	// Validate request
 	if err := h.validate.Struct(req); err != nil {
-		WriteValidationError(w, "Validation failed")
+		WriteInvalidRequest(w, err)
 		return
 	}
 
 	// Create transport
 	transport, err := h.transportService.Create(r.Context(), &req)
 	if err != nil {
-		if strings.Contains(err.Error(), "already exists") {
-			WriteConflict(w, "Transport with this plate number already exists")
-			return
-		}
-		WriteInternalError(w, "Failed to create transport")
+		WriteError(w, err, "Failed to create transport")
 		return
 	}
 
@@ -144,22 +135,14 @@ func (h *transportHandler) UpdateItem(w http.ResponseWriter, r *http.Request) {
 
 	// Validate request
 	if err := h.validate.Struct(req); err != nil {
-		WriteValidationError(w, "Validation failed")
+		WriteInvalidRequest(w, err)
 		return
 	}
 
 	// Update transport
 	transport, err := h.transportService.Update(r.Context(), id, req)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			WriteNotFound(w, "Transport not found")
-			return
-		}
-		if strings.Contains(err.Error(), "already exists") {
-			WriteConflict(w, "Transport with this plate number already exists")
-			return
-		}
-		WriteInternalError(w, "Failed to update transport")
+		WriteError(w, err, "Failed to update transport")
 		return
 	}
 
@@ -179,18 +162,7 @@ func (h *transportHandler) DeleteItem(w http.ResponseWriter, r *http.Request) {
 	// Delete transport
 	err = h.transportService.Delete(r.Context(), id)
 	if err != nil {
-		// Check for specific error types
-		if strings.Contains(err.Error(), "driver is currently assigned") ||
-			strings.Contains(err.Error(), "equipment is currently assigned") ||
-			strings.Contains(err.Error(), "has active orders") {
-			WriteConflict(w, err.Error())
-			return
-		}
-		if strings.Contains(err.Error(), "not found") {
-			WriteNotFound(w, "Transport not found")
-			return
-		}
-		WriteInternalError(w, "Failed to delete transport")
+		WriteError(w, err, "Failed to delete transport")
 		return
 	}
 
@@ -212,11 +184,7 @@ func (h *transportHandler) RestoreItem(w http.ResponseWriter, r *http.Request) {
 	// Restore transport
 	transport, err := h.transportService.Restore(r.Context(), id)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			WriteNotFound(w, "Transport not found")
-			return
-		}
-		WriteInternalError(w, "Failed to restore transport")
+		WriteError(w, err, "Failed to restore transport")
 		return
 	}
 
@@ -251,7 +219,7 @@ func (h *transportHandler) GetAvailable(w http.ResponseWriter, r *http.Request)
 	// Get available transport
 	response, err := h.transportService.GetAvailable(r.Context(), req)
 	if err != nil {
-		WriteInternalError(w, "Failed to get available transport")
+		WriteError(w, err, "Failed to get available transport")
 		return
 	}
 
@@ -276,20 +244,14 @@ func (h *transportHandler) AssignDriver(w http.ResponseWriter, r *http.Request)
 
 	// Validate request
 	if err := h.validate.Struct(req); err != nil {
-		WriteValidationError(w, "Validation failed")
+		WriteInvalidRequest(w, err)
 		return
 	}
 
 	// Assign driver
 	err = h.transportService.AssignDriver(r.Context(), idStr, req)
 	if err != nil {
-		// Check for specific error types
-		if strings.Contains(err.Error(), "already assigned to another transport") ||
-			strings.Contains(err.Error(), "not found") {
-			WriteConflict(w, err.Error())
-			return
-		}
-		WriteInternalError(w, "Failed to assign driver")
+		WriteError(w, err, "Failed to assign driver")
 		return
 	}
 
@@ -314,21 +276,14 @@ func (h *transportHandler) AssignEquipment(w http.ResponseWriter, r *http.Reques
 
 	// Validate request
 	if err := h.validate.Struct(req); err != nil {
-		WriteValidationError(w, "Validation failed")
+		WriteInvalidRequest(w, err)
 		return
 	}
 
 	// Assign equipment
 	err = h.transportService.AssignEquipment(r.Context(), idStr, req)
 	if err != nil {
-		// Check for specific error types
-		if strings.Contains(err.Error(), "not available for assignment") ||
-			strings.Contains(err.Error(), "already assigned to another transport") ||
-			strings.Contains(err.Error(), "not found") {
-			WriteConflict(w, err.Error())
-			return
-		}
-		WriteInternalError(w, "Failed to assign equipment")
+		WriteError(w, err, "Failed to assign equipment")
 		return
 	}
 
@@ -348,13 +303,7 @@ func (h *transportHandler) UnassignDriver(w http.ResponseWriter, r *http.Request
 	// Unassign driver
 	err = h.transportService.UnassignDriver(r.Context(), idStr)
 	if err != nil {
-		// Check for specific error types
-		if strings.Contains(err.Error(), "not found") ||
-			strings.Contains(err.Error(), "no driver assigned") {
-			WriteConflict(w, err.Error())
-			return
-		}
-		WriteInternalError(w, "Failed to unassign driver")
+		WriteError(w, err, "Failed to unassign driver")
 		return
 	}
 
@@ -0,0 +1,150 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"eco-van-api/internal/domainerr"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// newValidator creates the request body validator; failing fields are named after their JSON fields
+func newValidator() *validator.Validate {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			return ""
+		case "":
+			return field.Name
+		}
+		return name
+	})
+	// deprecated fields are still decoded so that clients setting them get an error instead of being ignored
+	validate.RegisterAlias("deprecated", "isdefault")
+	return validate
+}
+
+// WriteInvalidRequest writes the problem for a request body rejected by the validator, with one entry in errors
+// per failing field
+func WriteInvalidRequest(w http.ResponseWriter, err error) {
+	WriteError(w, validationError(err), "Validation failed")
+}
+
+// validationError turns the errors of the validator into a validation error carrying a field error per field
+func validationError(err error) *domainerr.Error {
+	result := domainerr.Validation(domainerr.CodeValidationFailed, "validation failed")
+
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return result
+	}
+	for _, fieldErr := range fieldErrs {
+		result.WithFieldError(fieldPointer(fieldErr.Namespace()), fieldErr.Tag(), fieldMessage(fieldErr)).
+			WithMessageID(fieldMessageID(fieldErr))
+	}
+	return result
+}
+
+// fieldMessageID names the translation of a rule that has a parameter, together with the parameter; rules
+// without one are translated by their tag
+func fieldMessageID(fieldErr validator.FieldError) (messageID, param string) {
+	param = fieldErr.Param()
+	if param == "" {
+		return "", ""
+	}
+
+	switch fieldErr.Tag() {
+	case "min", "max":
+		return fieldErr.Tag() + "." + sizeUnit(fieldErr.Kind()), param
+	case "gt", "gte", "lt", "lte":
+		return fieldErr.Tag() + ".number", param
+	case "oneof":
+		return "oneof.values", strings.Join(strings.Fields(param), ", ")
+	default:
+		return "", ""
+	}
+}
+
+// fieldPointer converts a validator namespace such as CreateOrderRequest.items[0].quantity into a JSON pointer
+// such as /items/0/quantity
+func fieldPointer(namespace string) string {
+	_, path, found := strings.Cut(namespace, ".")
+	if !found {
+		return ""
+	}
+
+	var pointer strings.Builder
+	for _, segment := range strings.Split(path, ".") {
+		name, index, hasIndex := strings.Cut(segment, "[")
+		pointer.WriteString("/" + name)
+		for hasIndex {
+			var key string
+			key, index, _ = strings.Cut(index, "]")
+			pointer.WriteString("/" + key)
+			_, index, hasIndex = strings.Cut(index, "[")
+		}
+	}
+	return pointer.String()
+}
+
+// fieldMessage describes the rule a field broke in English
+func fieldMessage(fieldErr validator.FieldError) string {
+	field, param := fieldErr.Field(), fieldErr.Param()
+
+	switch fieldErr.Tag() {
+	case "required":
+		return field + " is required"
+	case "min":
+		return sizeMessage(field, "at least", param, fieldErr.Kind())
+	case "max":
+		return sizeMessage(field, "at most", param, fieldErr.Kind())
+	case "gt":
+		return fmt.Sprintf("%s must be greater than %s", field, param)
+	case "gte":
+		return fmt.Sprintf("%s must be at least %s", field, param)
+	case "lt":
+		return fmt.Sprintf("%s must be less than %s", field, param)
+	case "lte":
+		return fmt.Sprintf("%s must be at most %s", field, param)
+	case "oneof":
+		return fmt.Sprintf("%s must be one of: %s", field, strings.Join(strings.Fields(param), ", "))
+	case "email":
+		return field + " must be a valid email address"
+	case "datetime":
+		return fmt.Sprintf("%s must have the format %s", field, param)
+	case "deprecated":
+		return field + " is deprecated and can no longer be set"
+	default:
+		return fmt.Sprintf("%s failed the %s rule", field, fieldErr.Tag())
+	}
+}
+
+// sizeMessage describes a min or max bound, which counts characters of strings and items of collections
+func sizeMessage(field, bound, param string, kind reflect.Kind) string {
+	switch sizeUnit(kind) {
+	case "string":
+		return fmt.Sprintf("%s must be %s %s characters long", field, bound, param)
+	case "items":
+		return fmt.Sprintf("%s must contain %s %s items", field, bound, param)
+	default:
+		return fmt.Sprintf("%s must be %s %s", field, bound, param)
+	}
+}
+
+// sizeUnit tells what a min or max bound counts for a field of the given kind
+func sizeUnit(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return "items"
+	default:
+		return "number"
+	}
+}
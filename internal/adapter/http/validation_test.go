@@ -0,0 +1,87 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"eco-van-api/internal/domainerr"
+)
+
+type testValidationItem struct {
+	Quantity int `json:"quantity" validate:"min=1"`
+}
+
+type testValidationRequest struct {
+	Name   string               `json:"name" validate:"required,max=5"`
+	Status string               `json:"status,omitempty" validate:"omitempty,oneof=OPEN CLOSED"`
+	Items  []testValidationItem `json:"items" validate:"max=2,dive"`
+	Legacy *string              `json:"legacy,omitempty" validate:"deprecated"`
+}
+
+func TestFieldPointer(t *testing.T) {
+	tests := map[string]string{
+		"CreateOrderRequest.name":                 "/name",
+		"CreateOrderRequest.items[0].quantity":    "/items/0/quantity",
+		"RoutePlanRequest.vehicles[12].transport": "/vehicles/12/transport",
+		"Request.matrix[1][2]":                    "/matrix/1/2",
+		"Request":                                 "",
+	}
+
+	for namespace, want := range tests {
+		if got := fieldPointer(namespace); got != want {
+			t.Errorf("fieldPointer(%q) = %q, want %q", namespace, got, want)
+		}
+	}
+}
+
+func TestValidator_Deprecated(t *testing.T) {
+	if err := newValidator().Struct(testValidationRequest{Name: "ok"}); err != nil {
+		t.Errorf("Expected an unset deprecated field to pass, got %v", err)
+	}
+}
+
+func TestWriteInvalidRequest(t *testing.T) {
+	legacy := ""
+	req := testValidationRequest{
+		Name:   "too long",
+		Status: "PENDING",
+		Items:  []testValidationItem{{Quantity: 1}, {Quantity: 0}},
+		Legacy: &legacy,
+	}
+	err := newValidator().Struct(req)
+	if err == nil {
+		t.Fatal("Expected validation to fail")
+	}
+
+	w := httptest.NewRecorder()
+	WriteInvalidRequest(w, err)
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status %d, got %d", http.StatusUnprocessableEntity, w.Code)
+	}
+
+	var problem Problem
+	if err := json.NewDecoder(w.Body).Decode(&problem); err != nil {
+		t.Fatalf("Failed to decode problem: %v", err)
+	}
+	if problem.Detail != "Validation failed" {
+		t.Errorf("Expected detail %q, got %q", "Validation failed", problem.Detail)
+	}
+
+	want := []domainerr.FieldError{
+		{Pointer: "/name", Rule: "max", Message: "name must be at most 5 characters long"},
+		{Pointer: "/status", Rule: "oneof", Message: "status must be one of: OPEN, CLOSED"},
+		{Pointer: "/items/1/quantity", Rule: "min", Message: "quantity must be at least 1"},
+		{Pointer: "/legacy", Rule: "deprecated", Message: "legacy is deprecated and can no longer be set"},
+	}
+	if len(problem.Errors) != len(want) {
+		t.Fatalf("Expected %d field errors, got %+v", len(want), problem.Errors)
+	}
+	for i := range want {
+		if problem.Errors[i] != want[i] {
+			t.Errorf("Expected field error %+v, got %+v", want[i], problem.Errors[i])
+		}
+	}
+}
@@ -3,7 +3,6 @@ package http
 import (
 	"net/http"
 	"strconv"
-	"strings"
 
 	"eco-van-api/internal/models"
 	"eco-van-api/internal/port"
@@ -23,7 +22,7 @@ type warehouseHandler struct {
 func NewWarehouseHandler(warehouseService port.WarehouseService) *warehouseHandler {
 	return &warehouseHandler{
 		warehouseService: warehouseService,
-		validate:         validator.New(),
+		validate:         newValidator(),
 	}
 }
 
@@ -62,7 +61,7 @@ func (h *warehouseHandler) ListWarehouses(w http.ResponseWriter, r *http.Request
 	// Get warehouses from service
 	response, err := h.warehouseService.List(r.Context(), req)
 	if err != nil {
-		WriteInternalError(w, "Failed to list warehouses")
+		WriteError(w, err, "Failed to list warehouses")
 		return
 	}
 
@@ -84,18 +83,14 @@ func (h *warehouseHandler) CreateWarehouse(w http.ResponseWriter, r *http.Reques
 
 	// Validate request
 	if err := h.validate.Struct(req); err != nil {
-		WriteValidationError(w, "Validation failed")
+		WriteInvalidRequest(w, err)
 		return
 	}
 
 	// Create warehouse via service
 	response, err := h.warehouseService.Create(r.Context(), req)
 	if err != nil {
-		if strings.Contains(err.Error(), "already exists") {
-			WriteConflict(w, "Warehouse creation failed")
-			return
-		}
-		WriteInternalError(w, "Failed to create warehouse")
+		WriteError(w, err, "Failed to create warehouse")
 		return
 	}
 
@@ -116,11 +111,7 @@ func (h *warehouseHandler) GetWarehouse(w http.ResponseWriter, r *http.Request)
 	// Get warehouse from service
 	warehouse, err := h.warehouseService.GetByID(r.Context(), id)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			WriteNotFound(w, "Warehouse not found")
-			return
-		}
-		WriteInternalError(w, "Failed to get warehouse")
+		WriteError(w, err, "Failed to get warehouse")
 		return
 	}
 
@@ -150,22 +141,14 @@ func (h *warehouseHandler) UpdateWarehouse(w http.ResponseWriter, r *http.Reques
 
 	// Validate request
 	if err := h.validate.Struct(req); err != nil {
-		WriteValidationError(w, "Validation failed")
+		WriteInvalidRequest(w, err)
 		return
 	}
 
 	// Update warehouse via service
 	response, err := h.warehouseService.Update(r.Context(), id, req)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			WriteNotFound(w, "Warehouse not found")
-			return
-		}
-		if strings.Contains(err.Error(), "already exists") {
-			WriteConflict(w, "Warehouse update failed")
-			return
-		}
-		WriteInternalError(w, "Failed to update warehouse")
+		WriteError(w, err, "Failed to update warehouse")
 		return
 	}
 
@@ -186,20 +169,7 @@ func (h *warehouseHandler) DeleteWarehouse(w http.ResponseWriter, r *http.Reques
 	// Delete warehouse via service
 	err = h.warehouseService.Delete(r.Context(), id)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			WriteNotFound(w, "Warehouse not found")
-			return
-		}
-		if strings.Contains(err.Error(), "equipment is still present") {
-			WriteCustomProblem(w,
-				"/errors/conflict",
-				"Conflict",
-				http.StatusConflict,
-				"Cannot delete warehouse: equipment is still present",
-				"/warehouses/"+idStr)
-			return
-		}
-		WriteInternalError(w, "Failed to delete warehouse")
+		WriteError(w, err, "Failed to delete warehouse")
 		return
 	}
 
@@ -222,19 +192,7 @@ func (h *warehouseHandler) RestoreWarehouse(w http.ResponseWriter, r *http.Reque
 	// Restore warehouse via service
 	response, err := h.warehouseService.Restore(r.Context(), id)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			WriteNotFound(w, "Warehouse not found")
-			return
-		}
-		if strings.Contains(err.Error(), "not deleted") {
-			WriteBadRequest(w, "Warehouse is not deleted")
-			return
-		}
-		if strings.Contains(err.Error(), "conflicts with existing warehouse") {
-			WriteConflict(w, "Cannot restore warehouse: name conflicts with existing warehouse")
-			return
-		}
-		WriteInternalError(w, "Failed to restore warehouse")
+		WriteError(w, err, "Failed to restore warehouse")
 		return
 	}
 
@@ -0,0 +1,285 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"eco-van-api/internal/config"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testClientID     = "eco-van"
+	testClientSecret = "client-secret"
+	testRedirectURL  = "https://app.example.com/sso/callback"
+)
+
+// mockProvider is a minimal OpenID Connect provider: discovery, JWKS, and a token endpoint that checks PKCE
+// and returns ID tokens signed with its current RSA key
+type mockProvider struct {
+	t      *testing.T
+	server *httptest.Server
+	issuer string // overrides the issuer announced by discovery
+
+	mu         sync.Mutex
+	key        *rsa.PrivateKey
+	kid        string
+	challenges map[string]string // code -> PKCE challenge
+	claims     jwt.MapClaims     // extra claims of issued ID tokens
+	jwksHits   int
+}
+
+func newMockProvider(t *testing.T) *mockProvider {
+	p := &mockProvider{t: t, challenges: map[string]string{}, claims: jwt.MapClaims{}}
+	p.rotateKey("key-1")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		issuer := p.server.URL
+		if p.issuer != "" {
+			issuer = p.issuer
+		}
+		writeTestJSON(w, map[string]string{
+			"issuer":                 issuer,
+			"authorization_endpoint": p.server.URL + "/authorize",
+			"token_endpoint":         p.server.URL + "/token",
+			"jwks_uri":               p.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", p.serveJWKS)
+	mux.HandleFunc("/token", p.serveToken)
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+func (p *mockProvider) rotateKey(kid string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(p.t, err)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.key, p.kid = key, kid
+}
+
+// authorize simulates the user signing in: the provider remembers the challenge for the code it returns
+func (p *mockProvider) authorize(authURL, code string) url.Values {
+	u, err := url.Parse(authURL)
+	require.NoError(p.t, err)
+	params := u.Query()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.challenges[code] = params.Get("code_challenge")
+	p.claims["nonce"] = params.Get("nonce")
+	return params
+}
+
+func (p *mockProvider) serveJWKS(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.jwksHits++
+	writeTestJSON(w, map[string]interface{}{"keys": []map[string]string{{
+		"kty": "RSA", "use": "sig", "alg": "RS256", "kid": p.kid,
+		"n": base64.RawURLEncoding.EncodeToString(p.key.N.Bytes()),
+		"e": base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.key.E)).Bytes()),
+	}}})
+}
+
+func (p *mockProvider) serveToken(w http.ResponseWriter, r *http.Request) {
+	require.NoError(p.t, r.ParseForm())
+	clientID, secret, ok := r.BasicAuth()
+	if !ok || clientID != testClientID || secret != testClientSecret {
+		w.WriteHeader(http.StatusUnauthorized)
+		writeTestJSON(w, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	challenge, known := p.challenges[r.Form.Get("code")]
+	verifierHash := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+	if !known || challenge != base64.RawURLEncoding.EncodeToString(verifierHash[:]) ||
+		r.Form.Get("redirect_uri") != testRedirectURL || r.Form.Get("grant_type") != "authorization_code" {
+		w.WriteHeader(http.StatusBadRequest)
+		writeTestJSON(w, map[string]string{"error": "invalid_grant"})
+		return
+	}
+	delete(p.challenges, r.Form.Get("code"))
+
+	claims := jwt.MapClaims{
+		"iss":            p.server.URL,
+		"sub":            "user-42",
+		"aud":            testClientID,
+		"exp":            time.Now().Add(5 * time.Minute).Unix(),
+		"iat":            time.Now().Unix(),
+		"email":          "jane@example.com",
+		"email_verified": true,
+		"groups":         []string{"office", "eco-admins"},
+	}
+	for name, value := range p.claims {
+		claims[name] = value
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = p.kid
+	idToken, err := token.SignedString(p.key)
+	require.NoError(p.t, err)
+
+	writeTestJSON(w, map[string]string{"access_token": "opaque", "token_type": "Bearer", "id_token": idToken})
+}
+
+func writeTestJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func newTestProvider(p *mockProvider) *Provider {
+	return NewProvider(config.OIDCConfig{
+		IssuerURL:    p.server.URL,
+		ClientID:     testClientID,
+		ClientSecret: testClientSecret,
+		RedirectURL:  testRedirectURL,
+		Scopes:       []string{"email", "profile"},
+		GroupsClaim:  "groups",
+	})
+}
+
+// startLogin builds the authorization URL with a fresh PKCE pair and lets the mock provider issue the code
+func startLogin(t *testing.T, p *mockProvider, provider *Provider, code string) (verifier, nonce string) {
+	t.Helper()
+	verifier = "verifier-" + code + "-0123456789012345678901234567890123"
+	challenge := sha256.Sum256([]byte(verifier))
+	authURL, err := provider.AuthCodeURL(context.Background(), "state", "nonce-"+code,
+		base64.RawURLEncoding.EncodeToString(challenge[:]))
+	require.NoError(t, err)
+	params := p.authorize(authURL, code)
+	return verifier, params.Get("nonce")
+}
+
+func TestProvider_AuthCodeURL(t *testing.T) {
+	p := newMockProvider(t)
+	provider := newTestProvider(p)
+
+	authURL, err := provider.AuthCodeURL(context.Background(), "the-state", "the-nonce", "the-challenge")
+	require.NoError(t, err)
+
+	u, err := url.Parse(authURL)
+	require.NoError(t, err)
+	assert.Equal(t, p.server.URL+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+	params := u.Query()
+	assert.Equal(t, "code", params.Get("response_type"))
+	assert.Equal(t, testClientID, params.Get("client_id"))
+	assert.Equal(t, testRedirectURL, params.Get("redirect_uri"))
+	assert.Equal(t, "openid email profile", params.Get("scope"))
+	assert.Equal(t, "the-state", params.Get("state"))
+	assert.Equal(t, "the-nonce", params.Get("nonce"))
+	assert.Equal(t, "the-challenge", params.Get("code_challenge"))
+	assert.Equal(t, "S256", params.Get("code_challenge_method"))
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("verified ID token claims are returned", func(t *testing.T) {
+		p := newMockProvider(t)
+		provider := newTestProvider(p)
+		verifier, nonce := startLogin(t, p, provider, "code-1")
+
+		claims, err := provider.Exchange(ctx, "code-1", verifier, nonce)
+		require.NoError(t, err)
+		assert.Equal(t, p.server.URL, claims.Issuer)
+		assert.Equal(t, "user-42", claims.Subject)
+		assert.Equal(t, "jane@example.com", claims.Email)
+		assert.True(t, claims.EmailVerified)
+		assert.Equal(t, []string{"office", "eco-admins"}, claims.Groups)
+	})
+
+	t.Run("wrong PKCE verifier is rejected by the provider", func(t *testing.T) {
+		p := newMockProvider(t)
+		provider := newTestProvider(p)
+		_, nonce := startLogin(t, p, provider, "code-1")
+
+		_, err := provider.Exchange(ctx, "code-1", "another-verifier", nonce)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid_grant")
+	})
+
+	t.Run("nonce of another login is rejected", func(t *testing.T) {
+		p := newMockProvider(t)
+		provider := newTestProvider(p)
+		verifier, _ := startLogin(t, p, provider, "code-1")
+
+		_, err := provider.Exchange(ctx, "code-1", verifier, "other-nonce")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "nonce mismatch")
+	})
+
+	t.Run("token for another audience is rejected", func(t *testing.T) {
+		p := newMockProvider(t)
+		p.claims["aud"] = "another-client"
+		provider := newTestProvider(p)
+		verifier, nonce := startLogin(t, p, provider, "code-1")
+
+		_, err := provider.Exchange(ctx, "code-1", verifier, nonce)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid ID token")
+	})
+
+	t.Run("expired token is rejected", func(t *testing.T) {
+		p := newMockProvider(t)
+		p.claims["exp"] = time.Now().Add(-time.Hour).Unix()
+		provider := newTestProvider(p)
+		verifier, nonce := startLogin(t, p, provider, "code-1")
+
+		_, err := provider.Exchange(ctx, "code-1", verifier, nonce)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid ID token")
+	})
+
+	t.Run("provider key rotation refetches the JWKS", func(t *testing.T) {
+		p := newMockProvider(t)
+		provider := newTestProvider(p)
+		now := time.Now()
+		provider.now = func() time.Time { return now }
+
+		verifier, nonce := startLogin(t, p, provider, "code-1")
+		_, err := provider.Exchange(ctx, "code-1", verifier, nonce)
+		require.NoError(t, err)
+
+		// An unknown kid right after a fetch is not fetched again
+		p.rotateKey("key-2")
+		verifier, nonce = startLogin(t, p, provider, "code-2")
+		_, err = provider.Exchange(ctx, "code-2", verifier, nonce)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown signing key")
+
+		now = now.Add(2 * jwksRefreshInterval)
+		verifier, nonce = startLogin(t, p, provider, "code-3")
+		_, err = provider.Exchange(ctx, "code-3", verifier, nonce)
+		require.NoError(t, err)
+		assert.Equal(t, 2, p.jwksHits)
+	})
+}
+
+func TestProvider_DiscoveryIssuerMismatch(t *testing.T) {
+	p := newMockProvider(t)
+	p.issuer = "https://idp.attacker.example"
+	provider := newTestProvider(p)
+
+	_, err := provider.AuthCodeURL(context.Background(), "state", "nonce", "challenge")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not match")
+}
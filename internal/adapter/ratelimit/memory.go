@@ -0,0 +1,57 @@
+// Package ratelimit provides in-process storage for the HTTP rate limiter
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/port"
+)
+
+// sweepInterval is how often full buckets are dropped to bound memory
+const sweepInterval = time.Minute
+
+// MemoryStore keeps token buckets in process memory; limits apply per replica
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]time.Time // key -> time the bucket is full again
+	lastSweep time.Time
+}
+
+var _ port.RateLimitStore = (*MemoryStore)(nil)
+
+// NewMemoryStore creates an empty in-memory rate limit store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: map[string]time.Time{}}
+}
+
+// Take charges one request to the bucket of key
+func (s *MemoryStore) Take(
+	_ context.Context, key string, policy models.RateLimitPolicy, now time.Time,
+) (models.RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastSweep) >= sweepInterval {
+		s.sweep(now)
+	}
+
+	fullAt, result := policy.Take(s.buckets[key], now)
+	if result.Allowed {
+		s.buckets[key] = fullAt
+	}
+
+	return result, nil
+}
+
+// sweep drops buckets that are full again; a missing bucket is treated as full
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, fullAt := range s.buckets {
+		if !fullAt.After(now) {
+			delete(s.buckets, key)
+		}
+	}
+	s.lastSweep = now
+}
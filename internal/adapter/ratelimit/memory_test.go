@@ -0,0 +1,49 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eco-van-api/internal/models"
+)
+
+func TestMemoryStore_Take(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	policy := models.RateLimitPolicy{Requests: 2, Period: 2 * time.Second}
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 2; i++ {
+		if result, _ := store.Take(ctx, "ip:10.0.0.1", policy, now); !result.Allowed {
+			t.Fatalf("Request %d: expected allowed", i+1)
+		}
+	}
+	if result, _ := store.Take(ctx, "ip:10.0.0.1", policy, now); result.Allowed || result.RetryAfter != time.Second {
+		t.Errorf("Expected rejection with 1s retry, got %+v", result)
+	}
+
+	// Buckets are independent per key
+	if result, _ := store.Take(ctx, "ip:10.0.0.2", policy, now); !result.Allowed {
+		t.Error("Expected another key to have its own bucket")
+	}
+
+	// A token is back after one interval
+	if result, _ := store.Take(ctx, "ip:10.0.0.1", policy, now.Add(time.Second)); !result.Allowed {
+		t.Error("Expected a refilled token to be allowed")
+	}
+}
+
+func TestMemoryStore_SweepsFullBuckets(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	policy := models.RateLimitPolicy{Requests: 10, Period: time.Second}
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	_, _ = store.Take(ctx, "ip:10.0.0.1", policy, now)
+	_, _ = store.Take(ctx, "ip:10.0.0.2", policy, now.Add(sweepInterval))
+
+	if len(store.buckets) != 1 {
+		t.Errorf("Expected the full bucket to be swept, got %d buckets", len(store.buckets))
+	}
+}
@@ -0,0 +1,131 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"eco-van-api/internal/domainerr"
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/port"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// cancellationReasonRepository implements port.CancellationReasonRepository
+type cancellationReasonRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewCancellationReasonRepository creates a new cancellation reason repository
+func NewCancellationReasonRepository(pool *pgxpool.Pool) port.CancellationReasonRepository {
+	return &cancellationReasonRepository{
+		pool: pool,
+	}
+}
+
+// Create creates a new cancellation reason
+func (r *cancellationReasonRepository) Create(ctx context.Context, reason *models.CancellationReason) error {
+	query := `
+		INSERT INTO cancellation_reasons (code, label, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	now := time.Now()
+	reason.CreatedAt = now
+	reason.UpdatedAt = now
+
+	_, err := r.pool.Exec(ctx, query, reason.Code, reason.Label, reason.IsActive, reason.CreatedAt, reason.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create cancellation reason: %w", err)
+	}
+	return nil
+}
+
+// GetByCode retrieves a cancellation reason by code
+func (r *cancellationReasonRepository) GetByCode(ctx context.Context, code string) (*models.CancellationReason, error) {
+	query := `
+		SELECT code, label, is_active, created_at, updated_at
+		FROM cancellation_reasons
+		WHERE code = $1
+	`
+
+	var reason models.CancellationReason
+	err := r.pool.QueryRow(ctx, query, code).Scan(
+		&reason.Code,
+		&reason.Label,
+		&reason.IsActive,
+		&reason.CreatedAt,
+		&reason.UpdatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get cancellation reason: %w", err)
+	}
+
+	return &reason, nil
+}
+
+// List retrieves cancellation reasons, optionally including inactive ones
+func (r *cancellationReasonRepository) List(ctx context.Context, includeInactive bool) ([]models.CancellationReason, error) {
+	query := `
+		SELECT code, label, is_active, created_at, updated_at
+		FROM cancellation_reasons
+	`
+	if !includeInactive {
+		query += " WHERE is_active"
+	}
+	query += " ORDER BY code"
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cancellation reasons: %w", err)
+	}
+	defer rows.Close()
+
+	reasons := make([]models.CancellationReason, 0)
+	for rows.Next() {
+		var reason models.CancellationReason
+		err := rows.Scan(
+			&reason.Code,
+			&reason.Label,
+			&reason.IsActive,
+			&reason.CreatedAt,
+			&reason.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan cancellation reason: %w", err)
+		}
+		reasons = append(reasons, reason)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over cancellation reasons: %w", err)
+	}
+
+	return reasons, nil
+}
+
+// Update updates an existing cancellation reason
+func (r *cancellationReasonRepository) Update(ctx context.Context, reason *models.CancellationReason) error {
+	query := `
+		UPDATE cancellation_reasons
+		SET label = $1, is_active = $2, updated_at = $3
+		WHERE code = $4
+	`
+
+	reason.UpdatedAt = time.Now()
+	result, err := r.pool.Exec(ctx, query, reason.Label, reason.IsActive, reason.UpdatedAt, reason.Code)
+	if err != nil {
+		return fmt.Errorf("failed to update cancellation reason: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domainerr.NotFound(domainerr.CodeCancellationReasonNotFound, "cancellation reason not found")
+	}
+
+	return nil
+}
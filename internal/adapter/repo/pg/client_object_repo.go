@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"eco-van-api/internal/domainerr"
 	"eco-van-api/internal/models"
 	"eco-van-api/internal/port"
 
@@ -73,7 +74,7 @@ func (r *clientObjectRepository) GetByID(ctx context.Context, id uuid.UUID, incl
 
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("client object not found")
+			return nil, domainerr.NotFound(domainerr.CodeClientObjectNotFound, "client object not found")
 		}
 		return nil, fmt.Errorf("failed to get client object: %w", err)
 	}
@@ -101,7 +102,7 @@ func (r *clientObjectRepository) Update(ctx context.Context, clientObject *model
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("client object not found or already deleted")
+		return domainerr.NotFound(domainerr.CodeClientObjectNotFound, "client object not found or already deleted")
 	}
 
 	return nil
@@ -116,7 +117,7 @@ func (r *clientObjectRepository) SoftDelete(ctx context.Context, id uuid.UUID) e
 	}
 
 	if conflicts.HasActiveOrders || conflicts.HasActiveEquipment {
-		return fmt.Errorf("cannot delete client object: %s", conflicts.Message)
+		return domainerr.Conflict(domainerr.CodeClientObjectInUse, "cannot delete client object: %s", conflicts.Message)
 	}
 
 	query := `
@@ -129,7 +130,7 @@ func (r *clientObjectRepository) SoftDelete(ctx context.Context, id uuid.UUID) e
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("client object not found or already deleted")
+		return domainerr.NotFound(domainerr.CodeClientObjectNotFound, "client object not found or already deleted")
 	}
 
 	return nil
@@ -0,0 +1,145 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/port"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// dispatchRepository implements port.DispatchRepository for PostgreSQL
+type dispatchRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewDispatchRepository creates a new dispatch board repository
+func NewDispatchRepository(db *pgxpool.Pool) port.DispatchRepository {
+	return &dispatchRepository{db: db}
+}
+
+// ListTransports returns the non-deleted transport with its current driver and attached equipment
+func (r *dispatchRepository) ListTransports(ctx context.Context) ([]models.DispatchTransport, error) {
+	query := `
+		SELECT t.id, t.plate_no, t.brand, t.model, t.capacity_l, t.status,
+		       d.id, d.full_name, d.phone,
+		       e.id, e.number, e.type, e.volume_l
+		FROM transport t
+		LEFT JOIN drivers d ON d.id = t.current_driver_id AND d.deleted_at IS NULL
+		LEFT JOIN equipment e ON e.id = t.current_equipment_id AND e.deleted_at IS NULL
+		WHERE t.deleted_at IS NULL
+		ORDER BY t.plate_no
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dispatch transport: %w", err)
+	}
+	defer rows.Close()
+
+	transports := make([]models.DispatchTransport, 0)
+	for rows.Next() {
+		var (
+			transport       models.DispatchTransport
+			driverID        *uuid.UUID
+			driverName      *string
+			driverPhone     *string
+			equipmentID     *uuid.UUID
+			equipmentNumber *string
+			equipmentType   *string
+			equipmentVolume *int
+		)
+		err := rows.Scan(
+			&transport.ID,
+			&transport.PlateNo,
+			&transport.Brand,
+			&transport.Model,
+			&transport.CapacityL,
+			&transport.Status,
+			&driverID,
+			&driverName,
+			&driverPhone,
+			&equipmentID,
+			&equipmentNumber,
+			&equipmentType,
+			&equipmentVolume,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan dispatch transport: %w", err)
+		}
+
+		if driverID != nil {
+			transport.Driver = &models.DispatchDriver{ID: *driverID, FullName: *driverName, Phone: driverPhone}
+		}
+		if equipmentID != nil {
+			transport.Equipment = &models.DispatchEquipment{
+				ID: *equipmentID, Number: equipmentNumber, Type: *equipmentType, VolumeL: *equipmentVolume,
+			}
+		}
+		transports = append(transports, transport)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over dispatch transport: %w", err)
+	}
+
+	return transports, nil
+}
+
+// ListDayOrders returns the non-canceled orders of a date with client and object details, in display order
+func (r *dispatchRepository) ListDayOrders(ctx context.Context, date time.Time) ([]models.DispatchOrder, error) {
+	query := `
+		SELECT o.id, c.id, c.name, co.id, co.name, co.address,
+		       to_char(o.scheduled_window_from, 'HH24:MI'), to_char(o.scheduled_window_to, 'HH24:MI'),
+		       o.status, o.priority, o.transport_id, ` + orderVolumeExpr + `, o.notes, o.updated_at
+		FROM orders o
+		JOIN clients c ON c.id = o.client_id
+		JOIN client_objects co ON co.id = o.object_id
+		WHERE o.scheduled_date = $1::date
+		  AND o.status <> 'CANCELED'
+		  AND o.deleted_at IS NULL
+		ORDER BY o.transport_id NULLS LAST, o.scheduled_window_from NULLS LAST,
+		         CASE o.priority WHEN 'HIGH' THEN 0 WHEN 'MEDIUM' THEN 1 ELSE 2 END, o.created_at
+	`
+
+	rows, err := r.db.Query(ctx, query, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dispatch orders: %w", err)
+	}
+	defer rows.Close()
+
+	orders := make([]models.DispatchOrder, 0)
+	for rows.Next() {
+		var order models.DispatchOrder
+		err := rows.Scan(
+			&order.ID,
+			&order.ClientID,
+			&order.ClientName,
+			&order.ObjectID,
+			&order.ObjectName,
+			&order.Address,
+			&order.ScheduledWindowFrom,
+			&order.ScheduledWindowTo,
+			&order.Status,
+			&order.Priority,
+			&order.TransportID,
+			&order.VolumeL,
+			&order.Notes,
+			&order.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan dispatch order: %w", err)
+		}
+		orders = append(orders, order)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over dispatch orders: %w", err)
+	}
+
+	return orders, nil
+}
@@ -29,7 +29,7 @@ func NewDriverRepository(pool *pgxpool.Pool) port.DriverRepository {
 // Create creates a new driver
 func (r *driverRepository) Create(ctx context.Context, driver *models.Driver) error {
 	query := `
-		INSERT INTO drivers (id, full_name, phone, license_no, license_classes, photo, created_at, updated_at)
+		INSERT INTO drivers (id, full_name, phone, license_no, license_classes, user_id, created_at, updated_at)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 	`
 
@@ -50,7 +50,7 @@ func (r *driverRepository) Create(ctx context.Context, driver *models.Driver) er
 
 	_, err := r.pool.Exec(ctx, query,
 		driver.ID, driver.FullName, driver.Phone, driver.LicenseNo,
-		licenseClassesJSON, driver.Photo, driver.CreatedAt, driver.UpdatedAt,
+		licenseClassesJSON, driver.UserID, driver.CreatedAt, driver.UpdatedAt,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create driver: %w", err)
@@ -63,7 +63,8 @@ func (r *driverRepository) Create(ctx context.Context, driver *models.Driver) er
 //nolint:dupl // Similar pattern across repositories but with different models and fields
 func (r *driverRepository) GetByID(ctx context.Context, id uuid.UUID, includeDeleted bool) (*models.Driver, error) {
 	query := `
-		SELECT id, full_name, phone, license_no, license_classes, photo, created_at, updated_at, deleted_at
+		SELECT id, full_name, phone, license_no, license_classes, ` + latestPhotoIDColumn(models.PhotoEntityDrivers, "drivers") + `,
+		       user_id, created_at, updated_at, deleted_at
 		FROM drivers WHERE id = $1
 	`
 	if !includeDeleted {
@@ -74,7 +75,7 @@ func (r *driverRepository) GetByID(ctx context.Context, id uuid.UUID, includeDel
 	var licenseClassesJSON []byte
 	err := r.pool.QueryRow(ctx, query, id).Scan(
 		&driver.ID, &driver.FullName, &driver.Phone, &driver.LicenseNo,
-		&licenseClassesJSON, &driver.Photo, &driver.CreatedAt, &driver.UpdatedAt, &driver.DeletedAt,
+		&licenseClassesJSON, &driver.PhotoID, &driver.UserID, &driver.CreatedAt, &driver.UpdatedAt, &driver.DeletedAt,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -132,12 +133,13 @@ func (r *driverRepository) List(ctx context.Context, req models.DriverListReques
 
 	// Build pagination query
 	query := fmt.Sprintf(`
-		SELECT id, full_name, phone, license_no, license_classes, photo, created_at, updated_at, deleted_at
+		SELECT id, full_name, phone, license_no, license_classes, %s,
+		       user_id, created_at, updated_at, deleted_at
 		%s
 		%s
 		ORDER BY created_at DESC
 		LIMIT $%d OFFSET $%d
-	`, baseQuery, whereClause, argIndex, argIndex+1)
+	`, latestPhotoIDColumn(models.PhotoEntityDrivers, "drivers"), baseQuery, whereClause, argIndex, argIndex+1)
 
 	// Calculate pagination
 	limit := req.PageSize
@@ -175,8 +177,8 @@ func (r *driverRepository) List(ctx context.Context, req models.DriverListReques
 func (r *driverRepository) Update(ctx context.Context, driver *models.Driver) error {
 	query := `
 		UPDATE drivers
-		SET full_name = $1, phone = $2, license_no = $3, license_classes = $4, photo = $5, updated_at = $6
-		WHERE id = $7
+		SET full_name = $1, phone = $2, license_no = $3, license_classes = $4, updated_at = $5
+		WHERE id = $6
 	`
 
 	driver.UpdatedAt = time.Now()
@@ -193,7 +195,7 @@ func (r *driverRepository) Update(ctx context.Context, driver *models.Driver) er
 
 	_, err := r.pool.Exec(ctx, query,
 		driver.FullName, driver.Phone, driver.LicenseNo,
-		licenseClassesJSON, driver.Photo, driver.UpdatedAt, driver.ID,
+		licenseClassesJSON, driver.UpdatedAt, driver.ID,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update driver: %w", err)
@@ -281,11 +283,12 @@ func (r *driverRepository) ListAvailable(ctx context.Context, req models.DriverL
 
 	// Build pagination query
 	query := fmt.Sprintf(`
-		SELECT d.id, d.full_name, d.phone, d.license_no, d.license_classes, d.photo, d.created_at, d.updated_at, d.deleted_at
+		SELECT d.id, d.full_name, d.phone, d.license_no, d.license_classes, %s, d.user_id,
+		       d.created_at, d.updated_at, d.deleted_at
 		%s
 		ORDER BY d.full_name
 		LIMIT $%d OFFSET $%d
-	`, fullQuery, argIndex, argIndex+1)
+	`, latestPhotoIDColumn(models.PhotoEntityDrivers, "d"), fullQuery, argIndex, argIndex+1)
 
 	// Calculate pagination
 	limit := req.PageSize
@@ -319,6 +322,41 @@ func (r *driverRepository) ListAvailable(ctx context.Context, req models.DriverL
 	}, nil
 }
 
+// GetByUserID retrieves the non-deleted driver linked to a user account
+func (r *driverRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*models.Driver, error) {
+	query := `
+		SELECT id, full_name, phone, license_no, license_classes, ` + latestPhotoIDColumn(models.PhotoEntityDrivers, "drivers") + `,
+		       user_id, created_at, updated_at, deleted_at
+		FROM drivers WHERE user_id = $1 AND deleted_at IS NULL
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get driver by user: %w", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("failed to get driver by user: %w", err)
+		}
+		return nil, nil
+	}
+
+	return r.scanDriverRow(rows)
+}
+
+// SetUser links a user account to a driver, or unlinks it when userID is nil
+func (r *driverRepository) SetUser(ctx context.Context, driverID uuid.UUID, userID *uuid.UUID) error {
+	query := `UPDATE drivers SET user_id = $1, updated_at = NOW() WHERE id = $2 AND deleted_at IS NULL`
+
+	_, err := r.pool.Exec(ctx, query, userID, driverID)
+	if err != nil {
+		return fmt.Errorf("failed to set driver user: %w", err)
+	}
+	return nil
+}
+
 // IsAssignedToTransport checks if driver is currently assigned to a transport
 func (r *driverRepository) IsAssignedToTransport(ctx context.Context, driverID uuid.UUID) (bool, error) {
 	query := `
@@ -341,7 +379,7 @@ func (r *driverRepository) scanDriverRow(rows pgx.Rows) (*models.Driver, error)
 	var licenseClassesJSON []byte
 	err := rows.Scan(
 		&driver.ID, &driver.FullName, &driver.Phone, &driver.LicenseNo,
-		&licenseClassesJSON, &driver.Photo, &driver.CreatedAt, &driver.UpdatedAt, &driver.DeletedAt,
+		&licenseClassesJSON, &driver.PhotoID, &driver.UserID, &driver.CreatedAt, &driver.UpdatedAt, &driver.DeletedAt,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan driver: %w", err)
@@ -30,8 +30,8 @@ func NewEquipmentRepository(pool *pgxpool.Pool) port.EquipmentRepository {
 // Create creates a new equipment
 func (r *equipmentRepository) Create(ctx context.Context, equipment *models.Equipment) error {
 	query := `
-		INSERT INTO equipment (id, number, type, volume_l, condition, photo, client_object_id, warehouse_id, transport_id, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		INSERT INTO equipment (id, number, type, volume_l, condition, client_object_id, warehouse_id, transport_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 	`
 
 	now := time.Now()
@@ -45,7 +45,6 @@ func (r *equipmentRepository) Create(ctx context.Context, equipment *models.Equi
 		equipment.Type,
 		equipment.VolumeL,
 		equipment.Condition,
-		equipment.Photo,
 		equipment.ClientObjectID,
 		equipment.WarehouseID,
 		equipment.TransportID,
@@ -59,7 +58,8 @@ func (r *equipmentRepository) Create(ctx context.Context, equipment *models.Equi
 // GetByID retrieves equipment by ID, optionally including soft-deleted
 func (r *equipmentRepository) GetByID(ctx context.Context, id uuid.UUID, includeDeleted bool) (*models.Equipment, error) {
 	query := `
-		SELECT id, number, type, volume_l, condition, photo, client_object_id, warehouse_id, transport_id, created_at, updated_at, deleted_at
+		SELECT id, number, type, volume_l, condition, ` + latestPhotoIDColumn(models.PhotoEntityEquipment, "equipment") + `,
+		       client_object_id, warehouse_id, transport_id, created_at, updated_at, deleted_at
 		FROM equipment
 		WHERE id = $1
 	`
@@ -75,7 +75,7 @@ func (r *equipmentRepository) GetByID(ctx context.Context, id uuid.UUID, include
 		&equipment.Type,
 		&equipment.VolumeL,
 		&equipment.Condition,
-		&equipment.Photo,
+		&equipment.PhotoID,
 		&equipment.ClientObjectID,
 		&equipment.WarehouseID,
 		&equipment.TransportID,
@@ -146,12 +146,13 @@ func (r *equipmentRepository) List(ctx context.Context, req models.EquipmentList
 
 	// Build pagination query
 	query := fmt.Sprintf(`
-		SELECT id, number, type, volume_l, condition, photo, client_object_id, warehouse_id, transport_id, created_at, updated_at, deleted_at
+		SELECT id, number, type, volume_l, condition, %s,
+		       client_object_id, warehouse_id, transport_id, created_at, updated_at, deleted_at
 		%s
 		%s
 		ORDER BY created_at DESC
 		LIMIT $%d OFFSET $%d
-	`, baseQuery, whereClause, argIndex, argIndex+1)
+	`, latestPhotoIDColumn(models.PhotoEntityEquipment, "equipment"), baseQuery, whereClause, argIndex, argIndex+1)
 
 	// Calculate pagination
 	limit := req.PageSize
@@ -173,7 +174,7 @@ func (r *equipmentRepository) List(ctx context.Context, req models.EquipmentList
 			&equipment.Type,
 			&equipment.VolumeL,
 			&equipment.Condition,
-			&equipment.Photo,
+			&equipment.PhotoID,
 			&equipment.ClientObjectID,
 			&equipment.WarehouseID,
 			&equipment.TransportID,
@@ -203,9 +204,9 @@ func (r *equipmentRepository) List(ctx context.Context, req models.EquipmentList
 func (r *equipmentRepository) Update(ctx context.Context, equipment *models.Equipment) error {
 	query := `
 		UPDATE equipment
-		SET number = $1, type = $2, volume_l = $3, condition = $4, photo = $5, 
-		    client_object_id = $6, warehouse_id = $7, transport_id = $8, updated_at = $9
-		WHERE id = $10
+		SET number = $1, type = $2, volume_l = $3, condition = $4,
+		    client_object_id = $5, warehouse_id = $6, transport_id = $7, updated_at = $8
+		WHERE id = $9
 	`
 
 	equipment.UpdatedAt = time.Now()
@@ -214,7 +215,6 @@ func (r *equipmentRepository) Update(ctx context.Context, equipment *models.Equi
 		equipment.Type,
 		equipment.VolumeL,
 		equipment.Condition,
-		equipment.Photo,
 		equipment.ClientObjectID,
 		equipment.WarehouseID,
 		equipment.TransportID,
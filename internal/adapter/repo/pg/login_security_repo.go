@@ -0,0 +1,201 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/port"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type loginSecurityRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewLoginSecurityRepository creates a new login security repository
+func NewLoginSecurityRepository(pool *pgxpool.Pool) port.LoginSecurityRepository {
+	return &loginSecurityRepository{pool: pool}
+}
+
+// GetThrottle retrieves the failure counter of a key
+func (r *loginSecurityRepository) GetThrottle(ctx context.Context, key string) (*models.LoginThrottle, error) {
+	query := `
+		SELECT key, failures, last_failure_at, locked_until
+		FROM login_throttles
+		WHERE key = $1
+	`
+
+	var throttle models.LoginThrottle
+	err := r.pool.QueryRow(ctx, query, key).Scan(
+		&throttle.Key,
+		&throttle.Failures,
+		&throttle.LastFailureAt,
+		&throttle.LockedUntil,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get login throttle: %w", err)
+	}
+
+	return &throttle, nil
+}
+
+// RegisterFailure counts a failure in a single upsert so concurrent attempts on any replica are all counted
+func (r *loginSecurityRepository) RegisterFailure(
+	ctx context.Context, key string, maxFailures int, window, lockout time.Duration,
+) (*models.LoginThrottle, error) {
+	query := `
+		INSERT INTO login_throttles (key, failures, last_failure_at, locked_until)
+		VALUES ($1, 1, NOW(), CASE WHEN 1 >= $2::int THEN NOW() + make_interval(secs => $4) END)
+		ON CONFLICT (key) DO UPDATE SET
+			failures = CASE
+				WHEN login_throttles.last_failure_at < NOW() - make_interval(secs => $3) THEN 1
+				ELSE login_throttles.failures + 1
+			END,
+			last_failure_at = NOW(),
+			locked_until = CASE
+				WHEN (CASE
+					WHEN login_throttles.last_failure_at < NOW() - make_interval(secs => $3) THEN 1
+					ELSE login_throttles.failures + 1
+				END) >= $2::int THEN NOW() + make_interval(secs => $4)
+				ELSE login_throttles.locked_until
+			END
+		RETURNING key, failures, last_failure_at, locked_until
+	`
+
+	var throttle models.LoginThrottle
+	err := r.pool.QueryRow(ctx, query, key, maxFailures, window.Seconds(), lockout.Seconds()).Scan(
+		&throttle.Key,
+		&throttle.Failures,
+		&throttle.LastFailureAt,
+		&throttle.LockedUntil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register login failure: %w", err)
+	}
+
+	return &throttle, nil
+}
+
+// ResetThrottle removes the failure counter of a key
+func (r *loginSecurityRepository) ResetThrottle(ctx context.Context, key string) error {
+	query := `DELETE FROM login_throttles WHERE key = $1`
+
+	if _, err := r.pool.Exec(ctx, query, key); err != nil {
+		return fmt.Errorf("failed to reset login throttle: %w", err)
+	}
+
+	return nil
+}
+
+// RecordAttempt appends a login attempt to the log
+func (r *loginSecurityRepository) RecordAttempt(ctx context.Context, attempt *models.LoginAttempt) error {
+	query := `
+		INSERT INTO login_attempts (email, user_id, ip, user_agent, success, failure_reason)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+
+	err := r.pool.QueryRow(ctx, query,
+		attempt.Email,
+		attempt.UserID,
+		attempt.IP,
+		attempt.UserAgent,
+		attempt.Success,
+		attempt.FailureReason,
+	).Scan(&attempt.ID, &attempt.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record login attempt: %w", err)
+	}
+
+	return nil
+}
+
+// ListAttempts retrieves the login attempt log, newest first
+func (r *loginSecurityRepository) ListAttempts(
+	ctx context.Context, req models.LoginAttemptListRequest,
+) ([]models.LoginAttempt, int64, error) {
+	whereClauses := []string{}
+	args := []interface{}{}
+
+	if req.Email != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("email = $%d", len(args)+1))
+		args = append(args, *req.Email)
+	}
+
+	if req.IP != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("ip = $%d", len(args)+1))
+		args = append(args, *req.IP)
+	}
+
+	if req.UserID != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("user_id = $%d", len(args)+1))
+		args = append(args, *req.UserID)
+	}
+
+	if req.Success != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("success = $%d", len(args)+1))
+		args = append(args, *req.Success)
+	}
+
+	whereClause := ""
+	if len(whereClauses) > 0 {
+		whereClause = "WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	var total int64
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM login_attempts %s", whereClause)
+	if err := r.pool.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count login attempts: %w", err)
+	}
+
+	limitParamNum := len(args) + 1
+	const offsetIncrement = 2
+	offsetParamNum := len(args) + offsetIncrement
+	query := fmt.Sprintf(`
+		SELECT id, email, user_id, ip, user_agent, success, failure_reason, created_at
+		FROM login_attempts
+		%s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d
+	`, whereClause, limitParamNum, offsetParamNum)
+	args = append(args, req.PageSize, (req.Page-1)*req.PageSize)
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list login attempts: %w", err)
+	}
+	defer rows.Close()
+
+	attempts := []models.LoginAttempt{}
+	for rows.Next() {
+		var attempt models.LoginAttempt
+		err := rows.Scan(
+			&attempt.ID,
+			&attempt.Email,
+			&attempt.UserID,
+			&attempt.IP,
+			&attempt.UserAgent,
+			&attempt.Success,
+			&attempt.FailureReason,
+			&attempt.CreatedAt,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan login attempt: %w", err)
+		}
+		attempts = append(attempts, attempt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating login attempts: %w", err)
+	}
+
+	return attempts, total, nil
+}
@@ -0,0 +1,105 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/port"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type oidcRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewOIDCRepository creates a new single sign-on repository
+func NewOIDCRepository(pool *pgxpool.Pool) port.OIDCRepository {
+	return &oidcRepository{pool: pool}
+}
+
+// CreateLoginState stores a pending authorization request and drops expired ones
+func (r *oidcRepository) CreateLoginState(ctx context.Context, state *models.OIDCLoginState) error {
+	if _, err := r.pool.Exec(ctx, "DELETE FROM oidc_login_states WHERE expires_at <= NOW()"); err != nil {
+		return fmt.Errorf("failed to delete expired OIDC login states: %w", err)
+	}
+
+	query := `
+		INSERT INTO oidc_login_states (state_hash, nonce, code_verifier, expires_at)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	if _, err := r.pool.Exec(ctx, query, state.StateHash, state.Nonce, state.CodeVerifier, state.ExpiresAt); err != nil {
+		return fmt.Errorf("failed to create OIDC login state: %w", err)
+	}
+
+	return nil
+}
+
+// ConsumeLoginState deletes a login state and returns it; a concurrent second callback finds nothing
+func (r *oidcRepository) ConsumeLoginState(ctx context.Context, stateHash string, now time.Time) (*models.OIDCLoginState, error) {
+	query := `
+		DELETE FROM oidc_login_states
+		WHERE state_hash = $1
+		RETURNING state_hash, nonce, code_verifier, expires_at
+	`
+
+	var state models.OIDCLoginState
+	err := r.pool.QueryRow(ctx, query, stateHash).Scan(&state.StateHash, &state.Nonce, &state.CodeVerifier, &state.ExpiresAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to consume OIDC login state: %w", err)
+	}
+
+	if !now.Before(state.ExpiresAt) {
+		return nil, nil
+	}
+
+	return &state, nil
+}
+
+// GetIdentity retrieves a linked identity
+func (r *oidcRepository) GetIdentity(ctx context.Context, issuer, subject string) (*models.OIDCIdentity, error) {
+	query := `
+		SELECT issuer, subject, user_id, created_at
+		FROM user_identities
+		WHERE issuer = $1 AND subject = $2
+	`
+
+	var identity models.OIDCIdentity
+	err := r.pool.QueryRow(ctx, query, issuer, subject).Scan(
+		&identity.Issuer,
+		&identity.Subject,
+		&identity.UserID,
+		&identity.CreatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get user identity: %w", err)
+	}
+
+	return &identity, nil
+}
+
+// CreateIdentity links an identity provider account to a user
+func (r *oidcRepository) CreateIdentity(ctx context.Context, identity *models.OIDCIdentity) error {
+	query := `
+		INSERT INTO user_identities (issuer, subject, user_id)
+		VALUES ($1, $2, $3)
+		RETURNING created_at
+	`
+
+	err := r.pool.QueryRow(ctx, query, identity.Issuer, identity.Subject, identity.UserID).Scan(&identity.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create user identity: %w", err)
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"eco-van-api/internal/domainerr"
 	"eco-van-api/internal/models"
 	"eco-van-api/internal/port"
 
@@ -19,6 +20,11 @@ type orderRepository struct {
 	db *pgxpool.Pool
 }
 
+// rowQuerier runs single-row queries on the pool or inside a transaction
+type rowQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
 // NewOrderRepository creates a new order repository
 func NewOrderRepository(db *pgxpool.Pool) port.OrderRepository {
 	return &orderRepository{db: db}
@@ -32,7 +38,7 @@ func (r *orderRepository) Create(ctx context.Context, order *models.Order) error
 			scheduled_window_to, status, priority, transport_id, notes, created_by, 
 			created_at, updated_at
 		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
-		RETURNING id, created_at, updated_at
+		RETURNING id, created_at, updated_at, version
 	`
 
 	now := time.Now()
@@ -52,7 +58,7 @@ func (r *orderRepository) Create(ctx context.Context, order *models.Order) error
 		order.CreatedBy,
 		order.CreatedAt,
 		order.UpdatedAt,
-	).Scan(&order.ID, &order.CreatedAt, &order.UpdatedAt)
+	).Scan(&order.ID, &order.CreatedAt, &order.UpdatedAt, &order.Version)
 
 	if err != nil {
 		return fmt.Errorf("failed to create order: %w", err)
@@ -65,8 +71,9 @@ func (r *orderRepository) Create(ctx context.Context, order *models.Order) error
 func (r *orderRepository) GetByID(ctx context.Context, id uuid.UUID, includeDeleted bool) (*models.Order, error) {
 	query := `
 		SELECT id, client_id, object_id, scheduled_date, scheduled_window_from,
-		       scheduled_window_to, status, priority, transport_id, notes, created_by,
-		       created_at, updated_at, deleted_at
+		       scheduled_window_to, status, priority, transport_id, notes, created_by, schedule_id,
+		       cancellation_reason_code, cancellation_note, canceled_at,
+		       created_at, updated_at, deleted_at, version
 		FROM orders
 		WHERE id = $1
 	`
@@ -88,9 +95,14 @@ func (r *orderRepository) GetByID(ctx context.Context, id uuid.UUID, includeDele
 		&order.TransportID,
 		&order.Notes,
 		&order.CreatedBy,
+		&order.ScheduleID,
+		&order.CancellationReasonCode,
+		&order.CancellationNote,
+		&order.CanceledAt,
 		&order.CreatedAt,
 		&order.UpdatedAt,
 		&order.DeletedAt,
+		&order.Version,
 	)
 
 	if err != nil {
@@ -103,18 +115,20 @@ func (r *orderRepository) GetByID(ctx context.Context, id uuid.UUID, includeDele
 	return &order, nil
 }
 
-// Update updates an existing order
+// Update updates an existing order. The update only applies if the order is still at order.Version, so an
+// update based on a stale copy cannot overwrite a concurrent one; on success order.Version is the new version.
 func (r *orderRepository) Update(ctx context.Context, order *models.Order) error {
 	query := `
 		UPDATE orders
 		SET client_id = $1, object_id = $2, scheduled_date = $3, 
 		    scheduled_window_from = $4, scheduled_window_to = $5, 
 		    status = $6, priority = $7, transport_id = $8, notes = $9, updated_at = $10
-		WHERE id = $11
+		WHERE id = $11 AND version = $12 AND deleted_at IS NULL
+		RETURNING version
 	`
 
 	order.UpdatedAt = time.Now()
-	result, err := r.db.Exec(ctx, query,
+	err := r.db.QueryRow(ctx, query,
 		order.ClientID,
 		order.ObjectID,
 		order.ScheduledDate,
@@ -126,31 +140,398 @@ func (r *orderRepository) Update(ctx context.Context, order *models.Order) error
 		order.Notes,
 		order.UpdatedAt,
 		order.ID,
+		order.Version,
+	).Scan(&order.Version)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return orderWriteMissed(ctx, r.db, order.ID,
+				domainerr.Conflict(domainerr.CodeOrderChangedConcurrently, "order was changed concurrently"))
+		}
+		return fmt.Errorf("failed to update order: %w", err)
+	}
+
+	return nil
+}
+
+// orderWriteMissed explains a guarded order write that matched no row: a missing or deleted order is not found,
+// otherwise the order changed since it was read and conflict is returned
+func orderWriteMissed(ctx context.Context, q rowQuerier, id uuid.UUID, conflict error) error {
+	var deleted bool
+	err := q.QueryRow(ctx, "SELECT deleted_at IS NOT NULL FROM orders WHERE id = $1", id).Scan(&deleted)
+	if err == pgx.ErrNoRows || (err == nil && deleted) {
+		return domainerr.NotFound(domainerr.CodeOrderNotFound, "order not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check order: %w", err)
+	}
+	return conflict
+}
+
+// UpdateStatus changes the order status, records the transition in order_status_history and applies
+// equipment moves. The update only applies if the order is still in entry.FromStatus at order.Version, so
+// concurrent transitions cannot both succeed.
+func (r *orderRepository) UpdateStatus(
+	ctx context.Context, order *models.Order, entry *models.OrderStatusHistory, moves []models.EquipmentMove,
+) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil {
+			// Rollback after a successful commit is a no-op error; nothing to report
+			_ = err
+		}
+	}()
+
+	order.UpdatedAt = time.Now()
+	if entry.ToStatus == models.OrderStatusCanceled {
+		order.CanceledAt = &order.UpdatedAt
+	}
+	updateQuery := `
+		UPDATE orders
+		SET status = $1, cancellation_reason_code = $2, cancellation_note = $3, canceled_at = $4, updated_at = $5
+		WHERE id = $6 AND status = $7 AND version = $8 AND deleted_at IS NULL
+		RETURNING version
+	`
+	err = tx.QueryRow(ctx, updateQuery,
+		string(entry.ToStatus),
+		order.CancellationReasonCode,
+		order.CancellationNote,
+		order.CanceledAt,
+		order.UpdatedAt,
+		order.ID,
+		string(entry.FromStatus),
+		order.Version,
+	).Scan(&order.Version)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return orderWriteMissed(ctx, tx, order.ID,
+				domainerr.Conflict(domainerr.CodeOrderChangedConcurrently, "order status was changed concurrently"))
+		}
+		return fmt.Errorf("failed to update order status: %w", err)
+	}
+
+	entry.ChangedAt = order.UpdatedAt
+	historyQuery := `
+		INSERT INTO order_status_history (order_id, from_status, to_status, changed_by, reason, changed_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+	err = tx.QueryRow(ctx, historyQuery,
+		entry.OrderID,
+		string(entry.FromStatus),
+		string(entry.ToStatus),
+		entry.ChangedBy,
+		entry.Reason,
+		entry.ChangedAt,
+	).Scan(&entry.ID)
+	if err != nil {
+		return fmt.Errorf("failed to record order status history: %w", err)
+	}
+
+	if order.Completion != nil {
+		if err := insertOrderCompletion(ctx, tx, order.Completion, order.UpdatedAt); err != nil {
+			return err
+		}
+	}
+
+	for i := range moves {
+		if err := applyEquipmentMove(ctx, tx, &moves[i]); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	order.Status = string(entry.ToStatus)
+	return nil
+}
+
+// insertOrderCompletion stores the completion record of an order
+func insertOrderCompletion(ctx context.Context, tx pgx.Tx, completion *models.OrderCompletion, completedAt time.Time) error {
+	completion.CompletedAt = completedAt
+	query := `
+		INSERT INTO order_completions (order_id, volume_l, weight_kg, waste_category, completed_by, completed_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := tx.Exec(ctx, query,
+		completion.OrderID,
+		completion.VolumeL,
+		completion.WeightKg,
+		string(completion.WasteCategory),
+		completion.CompletedBy,
+		completion.CompletedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record order completion: %w", err)
+	}
+	return nil
+}
+
+// GetCompletion returns the completion record of an order, or nil if the order has none
+func (r *orderRepository) GetCompletion(ctx context.Context, orderID uuid.UUID) (*models.OrderCompletion, error) {
+	query := `
+		SELECT order_id, volume_l, weight_kg, waste_category, completed_by, completed_at
+		FROM order_completions
+		WHERE order_id = $1
+	`
+
+	var completion models.OrderCompletion
+	err := r.db.QueryRow(ctx, query, orderID).Scan(
+		&completion.OrderID,
+		&completion.VolumeL,
+		&completion.WeightKg,
+		&completion.WasteCategory,
+		&completion.CompletedBy,
+		&completion.CompletedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get order completion: %w", err)
+	}
+
+	return &completion, nil
+}
+
+// applyEquipmentMove checks where the equipment is and moves it to the target placement,
+// keeping transport.current_equipment_id in sync with equipment.transport_id
+func applyEquipmentMove(ctx context.Context, tx pgx.Tx, move *models.EquipmentMove) error {
+	var current models.EquipmentPlacement
+	selectQuery := `
+		SELECT client_object_id, warehouse_id, transport_id
+		FROM equipment
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE
+	`
+	err := tx.QueryRow(ctx, selectQuery, move.EquipmentID).Scan(
+		&current.ClientObjectID,
+		&current.WarehouseID,
+		&current.TransportID,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domainerr.Conflict(
+				domainerr.CodeEquipmentPlacementConflict, "equipment placement conflict: equipment %s no longer exists", move.EquipmentID,
+			).With("equipmentId", move.EquipmentID)
+		}
+		return fmt.Errorf("failed to get equipment placement: %w", err)
+	}
+
+	if err := move.CheckSource(current); err != nil {
+		return err
+	}
+	if move.Target == nil {
+		return nil
+	}
+
+	if current.TransportID != nil {
+		releaseQuery := "UPDATE transport SET current_equipment_id = NULL, updated_at = NOW() WHERE id = $1 AND current_equipment_id = $2"
+		if _, err := tx.Exec(ctx, releaseQuery, *current.TransportID, move.EquipmentID); err != nil {
+			return fmt.Errorf("failed to release equipment from transport: %w", err)
+		}
+	}
+
+	updateQuery := `
+		UPDATE equipment
+		SET client_object_id = $1, warehouse_id = $2, transport_id = $3, updated_at = NOW()
+		WHERE id = $4
+	`
+	_, err = tx.Exec(ctx, updateQuery,
+		move.Target.ClientObjectID,
+		move.Target.WarehouseID,
+		move.Target.TransportID,
+		move.EquipmentID,
 	)
+	if err != nil {
+		return fmt.Errorf("failed to move equipment: %w", err)
+	}
+
+	if move.Target.TransportID != nil {
+		loadQuery := `
+			UPDATE transport SET current_equipment_id = $1, updated_at = NOW()
+			WHERE id = $2 AND current_equipment_id IS NULL AND deleted_at IS NULL
+		`
+		result, err := tx.Exec(ctx, loadQuery, move.EquipmentID, *move.Target.TransportID)
+		if err != nil {
+			return fmt.Errorf("failed to load equipment onto transport: %w", err)
+		}
+		// A transport carries one piece of equipment; loading a second one would leave two claiming it
+		if result.RowsAffected() == 0 {
+			return domainerr.Conflict(
+				domainerr.CodeEquipmentPlacementConflict,
+				"equipment placement conflict: transport %s already carries equipment or no longer exists", *move.Target.TransportID,
+			).With("equipmentId", move.EquipmentID).With("transportId", *move.Target.TransportID)
+		}
+	}
+
+	return nil
+}
+
+// ListItems returns the equipment items of an order
+func (r *orderRepository) ListItems(ctx context.Context, orderID uuid.UUID) ([]models.OrderItem, error) {
+	query := `
+		SELECT id, order_id, equipment_id, operation, replacement_equipment_id, warehouse_id, created_at
+		FROM order_items
+		WHERE order_id = $1
+		ORDER BY created_at ASC, id ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list order items: %w", err)
+	}
+	defer rows.Close()
 
+	items := make([]models.OrderItem, 0)
+	for rows.Next() {
+		var item models.OrderItem
+		err := rows.Scan(
+			&item.ID,
+			&item.OrderID,
+			&item.EquipmentID,
+			&item.Operation,
+			&item.ReplacementEquipmentID,
+			&item.WarehouseID,
+			&item.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan order item: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over order items: %w", err)
+	}
+
+	return items, nil
+}
+
+// ReplaceItems replaces all equipment items of an order. The items are part of the order, so the replacement
+// only applies if the order is still at order.Version; on success order.Version is the new version.
+func (r *orderRepository) ReplaceItems(ctx context.Context, order *models.Order, items []models.OrderItem) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil {
+			// Rollback after a successful commit is a no-op error; nothing to report
+			_ = err
+		}
+	}()
+
+	// Bumping the version first also locks the order against concurrent item replacements
+	now := time.Now()
+	var version int
+	err = tx.QueryRow(ctx,
+		"UPDATE orders SET updated_at = $1 WHERE id = $2 AND version = $3 AND deleted_at IS NULL RETURNING version",
+		now, order.ID, order.Version,
+	).Scan(&version)
 	if err != nil {
+		if err == pgx.ErrNoRows {
+			return orderWriteMissed(ctx, tx, order.ID,
+				domainerr.Conflict(domainerr.CodeOrderChangedConcurrently, "order was changed concurrently"))
+		}
 		return fmt.Errorf("failed to update order: %w", err)
 	}
 
-	if result.RowsAffected() == 0 {
-		return fmt.Errorf("order not found")
+	if _, err := tx.Exec(ctx, "DELETE FROM order_items WHERE order_id = $1", order.ID); err != nil {
+		return fmt.Errorf("failed to delete order items: %w", err)
 	}
 
+	insertQuery := `
+		INSERT INTO order_items (order_id, equipment_id, operation, replacement_equipment_id, warehouse_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+	for i := range items {
+		item := &items[i]
+		item.OrderID = order.ID
+		item.CreatedAt = now
+		err := tx.QueryRow(ctx, insertQuery,
+			item.OrderID,
+			item.EquipmentID,
+			string(item.Operation),
+			item.ReplacementEquipmentID,
+			item.WarehouseID,
+			item.CreatedAt,
+		).Scan(&item.ID)
+		if err != nil {
+			return fmt.Errorf("failed to create order item: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	order.Version = version
+	order.UpdatedAt = now
+
 	return nil
 }
 
-// SoftDelete marks an order as deleted by setting deleted_at
-func (r *orderRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
-	query := "UPDATE orders SET deleted_at = $1 WHERE id = $2"
+// ListStatusHistory returns the status transitions of an order, oldest first
+func (r *orderRepository) ListStatusHistory(ctx context.Context, orderID uuid.UUID) ([]models.OrderStatusHistory, error) {
+	query := `
+		SELECT id, order_id, from_status, to_status, changed_by, reason, changed_at
+		FROM order_status_history
+		WHERE order_id = $1
+		ORDER BY changed_at ASC, id ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list order status history: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]models.OrderStatusHistory, 0)
+	for rows.Next() {
+		var entry models.OrderStatusHistory
+		err := rows.Scan(
+			&entry.ID,
+			&entry.OrderID,
+			&entry.FromStatus,
+			&entry.ToStatus,
+			&entry.ChangedBy,
+			&entry.Reason,
+			&entry.ChangedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan order status history: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over order status history: %w", err)
+	}
+
+	return entries, nil
+}
+
+// SoftDelete marks an order as deleted by setting deleted_at. It only applies if the order is still at
+// version, so a delete cannot discard a change made after the caller checked the order.
+func (r *orderRepository) SoftDelete(ctx context.Context, id uuid.UUID, version int) error {
+	query := "UPDATE orders SET deleted_at = $1 WHERE id = $2 AND version = $3 AND deleted_at IS NULL"
 
 	now := time.Now()
-	result, err := r.db.Exec(ctx, query, now, id)
+	result, err := r.db.Exec(ctx, query, now, id, version)
 	if err != nil {
 		return fmt.Errorf("failed to soft delete order: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("order not found")
+		return orderWriteMissed(ctx, r.db, id,
+			domainerr.Conflict(domainerr.CodeOrderChangedConcurrently, "order was changed concurrently"))
 	}
 
 	return nil
@@ -166,7 +547,7 @@ func (r *orderRepository) Restore(ctx context.Context, id uuid.UUID) error {
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("order not found")
+		return domainerr.NotFound(domainerr.CodeOrderNotFound, "order not found")
 	}
 
 	return nil
@@ -207,12 +588,24 @@ func (r *orderRepository) List(ctx context.Context, req models.OrderListRequest)
 		args = append(args, *req.ObjectID)
 	}
 
+	// Add transport filter
+	if req.TransportID != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("transport_id = $%d", len(args)+1))
+		args = append(args, *req.TransportID)
+	}
+
 	// Add priority filter
 	if req.Priority != nil {
 		whereClauses = append(whereClauses, fmt.Sprintf("priority = $%d", len(args)+1))
 		args = append(args, *req.Priority)
 	}
 
+	// Add cancellation reason filter
+	if req.CancellationReason != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("cancellation_reason_code = $%d", len(args)+1))
+		args = append(args, *req.CancellationReason)
+	}
+
 	// Build WHERE clause
 	whereClause := ""
 	if len(whereClauses) > 0 {
@@ -237,8 +630,9 @@ func (r *orderRepository) List(ctx context.Context, req models.OrderListRequest)
 	offsetParamNum := len(args) + offsetIncrement
 	mainQuery := fmt.Sprintf(`
 		SELECT id, client_id, object_id, scheduled_date, scheduled_window_from,
-		       scheduled_window_to, status, priority, transport_id, notes, created_by,
-		       created_at, updated_at, deleted_at
+		       scheduled_window_to, status, priority, transport_id, notes, created_by, schedule_id,
+		       cancellation_reason_code, cancellation_note, canceled_at,
+		       created_at, updated_at, deleted_at, version
 		FROM orders
 		%s
 		ORDER BY scheduled_date DESC, created_at DESC
@@ -269,9 +663,14 @@ func (r *orderRepository) List(ctx context.Context, req models.OrderListRequest)
 			&order.TransportID,
 			&order.Notes,
 			&order.CreatedBy,
+			&order.ScheduleID,
+			&order.CancellationReasonCode,
+			&order.CancellationNote,
+			&order.CanceledAt,
 			&order.CreatedAt,
 			&order.UpdatedAt,
 			&order.DeletedAt,
+			&order.Version,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan order: %w", err)
@@ -297,6 +696,41 @@ func (r *orderRepository) List(ctx context.Context, req models.OrderListRequest)
 	}, nil
 }
 
+// CancellationStats counts canceled orders per calendar month and reason within [from, to).
+// Soft-deleted orders are included so that statistics do not change when old orders are cleaned up.
+func (r *orderRepository) CancellationStats(ctx context.Context, req models.CancellationStatsRequest) ([]models.CancellationStat, error) {
+	query := `
+		SELECT to_char(date_trunc('month', canceled_at), 'YYYY-MM') AS month,
+		       cancellation_reason_code,
+		       COUNT(*)
+		FROM orders
+		WHERE status = 'CANCELED' AND canceled_at >= $1 AND canceled_at < $2
+		GROUP BY 1, 2
+		ORDER BY 1, 2
+	`
+
+	rows, err := r.db.Query(ctx, query, req.From, req.To)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cancellation stats: %w", err)
+	}
+	defer rows.Close()
+
+	stats := make([]models.CancellationStat, 0)
+	for rows.Next() {
+		var stat models.CancellationStat
+		if err := rows.Scan(&stat.Month, &stat.ReasonCode, &stat.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan cancellation stat: %w", err)
+		}
+		stats = append(stats, stat)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over cancellation stats: %w", err)
+	}
+
+	return stats, nil
+}
+
 // ExistsByClientAndObject checks if an order exists for the given client and object
 func (r *orderRepository) ExistsByClientAndObject(ctx context.Context, clientID, objectID uuid.UUID, excludeID *uuid.UUID) (bool, error) {
 	query := "SELECT EXISTS(SELECT 1 FROM orders WHERE client_id = $1 AND object_id = $2 AND deleted_at IS NULL"
@@ -341,8 +775,9 @@ func (r *orderRepository) HasActiveOrders(ctx context.Context, objectID uuid.UUI
 func (r *orderRepository) GetActiveOrdersByObject(ctx context.Context, objectID uuid.UUID) ([]models.Order, error) {
 	query := `
 		SELECT id, client_id, object_id, scheduled_date, scheduled_window_from,
-		       scheduled_window_to, status, transport_id, notes, created_by,
-		       created_at, updated_at, deleted_at
+		       scheduled_window_to, status, priority, transport_id, notes, created_by, schedule_id,
+		       cancellation_reason_code, cancellation_note, canceled_at,
+		       created_at, updated_at, deleted_at, version
 		FROM orders
 		WHERE object_id = $1 
 		AND deleted_at IS NULL
@@ -367,12 +802,18 @@ func (r *orderRepository) GetActiveOrdersByObject(ctx context.Context, objectID
 			&order.ScheduledWindowFrom,
 			&order.ScheduledWindowTo,
 			&order.Status,
+			&order.Priority,
 			&order.TransportID,
 			&order.Notes,
 			&order.CreatedBy,
+			&order.ScheduleID,
+			&order.CancellationReasonCode,
+			&order.CancellationNote,
+			&order.CanceledAt,
 			&order.CreatedAt,
 			&order.UpdatedAt,
 			&order.DeletedAt,
+			&order.Version,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan order: %w", err)
@@ -386,3 +827,63 @@ func (r *orderRepository) GetActiveOrdersByObject(ctx context.Context, objectID
 
 	return orders, nil
 }
+
+// orderVolumeExpr sums the volume of the equipment an order (aliased o) empties or picks up
+const orderVolumeExpr = `COALESCE((
+	SELECT SUM(e.volume_l)
+	FROM order_items oi
+	JOIN equipment e ON e.id = oi.equipment_id
+	WHERE oi.order_id = o.id AND oi.operation IN ('PICKUP', 'SWAP', 'EMPTY')
+), 0)`
+
+// ListTransportDayOrders returns the non-canceled orders booked on a transport for a date with their volumes
+func (r *orderRepository) ListTransportDayOrders(
+	ctx context.Context, transportID uuid.UUID, date time.Time,
+) ([]models.TransportDayOrder, error) {
+	query := `
+		SELECT o.id, to_char(o.scheduled_window_from, 'HH24:MI'), to_char(o.scheduled_window_to, 'HH24:MI'),
+		       ` + orderVolumeExpr + `
+		FROM orders o
+		WHERE o.transport_id = $1
+		  AND o.scheduled_date = $2::date
+		  AND o.status <> 'CANCELED'
+		  AND o.deleted_at IS NULL
+		ORDER BY o.scheduled_window_from NULLS LAST, o.id
+	`
+
+	rows, err := r.db.Query(ctx, query, transportID, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transport day orders: %w", err)
+	}
+	defer rows.Close()
+
+	orders := make([]models.TransportDayOrder, 0)
+	for rows.Next() {
+		var order models.TransportDayOrder
+		if err := rows.Scan(&order.OrderID, &order.WindowFrom, &order.WindowTo, &order.VolumeL); err != nil {
+			return nil, fmt.Errorf("failed to scan transport day order: %w", err)
+		}
+		orders = append(orders, order)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over transport day orders: %w", err)
+	}
+
+	return orders, nil
+}
+
+// GetVolume returns the volume of the equipment an order empties or picks up
+func (r *orderRepository) GetVolume(ctx context.Context, orderID uuid.UUID) (int, error) {
+	query := `SELECT ` + orderVolumeExpr + ` FROM orders o WHERE o.id = $1`
+
+	var volume int
+	if err := r.db.QueryRow(ctx, query, orderID).Scan(&volume); err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get order volume: %w", err)
+	}
+
+	return volume, nil
+}
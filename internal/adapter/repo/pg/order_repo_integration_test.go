@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"eco-van-api/internal/domainerr"
 	"eco-van-api/internal/models"
 
 	"github.com/google/uuid"
@@ -161,10 +162,21 @@ func TestOrderRepository_Integration(t *testing.T) {
 		err := orderRepo.Create(ctx, &order)
 		require.NoError(t, err)
 
+		// A delete based on an older version is a conflict
+		err = orderRepo.SoftDelete(ctx, order.ID, order.Version-1)
+		require.Error(t, err)
+		assert.True(t, domainerr.HasCode(err, domainerr.CodeOrderChangedConcurrently))
+
 		// Test soft delete
-		err = orderRepo.SoftDelete(ctx, order.ID)
+		err = orderRepo.SoftDelete(ctx, order.ID, order.Version)
 		require.NoError(t, err)
 
+		// Writes to the deleted order report it as not found
+		err = orderRepo.SoftDelete(ctx, order.ID, order.Version+1)
+		assert.True(t, domainerr.HasCode(err, domainerr.CodeOrderNotFound))
+		err = orderRepo.Update(ctx, &order)
+		assert.True(t, domainerr.HasCode(err, domainerr.CodeOrderNotFound))
+
 		// Should not be found with includeDeleted=false
 		retrievedOrder, err := orderRepo.GetByID(ctx, order.ID, false)
 		require.NoError(t, err)
@@ -222,6 +234,124 @@ func TestOrderRepository_Integration(t *testing.T) {
 		assert.Equal(t, string(models.OrderStatusScheduled), retrievedOrder.Status)
 		assert.Equal(t, "Updated order", *retrievedOrder.Notes)
 	})
+
+	t.Run("Update with stale version", func(t *testing.T) {
+		ctx := context.Background()
+
+		clientID := MakeClient(t, ctx, TestPool, "OrderTest-StaleOrder-"+uuid.New().String()[:8])
+		objectID := MakeClientObject(t, ctx, TestPool, clientID, "OrderTest-Office-StaleOrder-"+uuid.New().String()[:8])
+
+		order := models.Order{
+			ClientID:      clientID,
+			ObjectID:      objectID,
+			ScheduledDate: time.Date(2024, 1, 19, 0, 0, 0, 0, time.UTC),
+			Status:        string(models.OrderStatusDraft),
+			Priority:      "MEDIUM",
+		}
+
+		err := orderRepo.Create(ctx, &order)
+		require.NoError(t, err)
+		assert.Equal(t, 1, order.Version)
+
+		// Two dispatchers load the same version of the order
+		first, err := orderRepo.GetByID(ctx, order.ID, false)
+		require.NoError(t, err)
+		second, err := orderRepo.GetByID(ctx, order.ID, false)
+		require.NoError(t, err)
+
+		first.Notes = stringPtrOrder("First edit")
+		err = orderRepo.Update(ctx, first)
+		require.NoError(t, err)
+		assert.Equal(t, 2, first.Version)
+
+		// The second write is based on the overwritten version and is rejected
+		second.Notes = stringPtrOrder("Second edit")
+		err = orderRepo.Update(ctx, second)
+		require.Error(t, err)
+		assert.True(t, domainerr.HasCode(err, domainerr.CodeOrderChangedConcurrently))
+
+		retrievedOrder, err := orderRepo.GetByID(ctx, order.ID, false)
+		require.NoError(t, err)
+		assert.Equal(t, "First edit", *retrievedOrder.Notes)
+		assert.Equal(t, 2, retrievedOrder.Version)
+
+		// Replacing the items is a change of the order too
+		err = orderRepo.ReplaceItems(ctx, second, nil)
+		require.Error(t, err)
+		assert.True(t, domainerr.HasCode(err, domainerr.CodeOrderChangedConcurrently))
+		err = orderRepo.ReplaceItems(ctx, retrievedOrder, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 3, retrievedOrder.Version)
+
+		// A missing order is not found rather than changed
+		missing := *retrievedOrder
+		missing.ID = uuid.New()
+		err = orderRepo.Update(ctx, &missing)
+		assert.True(t, domainerr.HasCode(err, domainerr.CodeOrderNotFound))
+	})
+
+	t.Run("Pickup onto loaded transport", func(t *testing.T) {
+		ctx := context.Background()
+
+		clientID := MakeClient(t, ctx, TestPool, "OrderTest-LoadedTransport-"+uuid.New().String()[:8])
+		objectID := MakeClientObject(t, ctx, TestPool, clientID, "OrderTest-Office-LoadedTransport-"+uuid.New().String()[:8])
+
+		// The transport already carries a bin and the order picks up another one onto it
+		transportID, loadedID, pickupID := uuid.New(), uuid.New(), uuid.New()
+		_, err := TestPool.Exec(ctx, `
+			INSERT INTO transport (id, plate_no, brand, model, capacity_l)
+			VALUES ($1, $2, 'Volvo', 'FL', 10000)
+		`, transportID, "T-"+uuid.New().String()[:8])
+		require.NoError(t, err)
+		_, err = TestPool.Exec(ctx, `
+			INSERT INTO equipment (id, type, volume_l, condition, transport_id, client_object_id)
+			VALUES ($1, 'BIN', 1100, 'GOOD', $2, NULL), ($3, 'BIN', 1100, 'GOOD', NULL, $4)
+		`, loadedID, transportID, pickupID, objectID)
+		require.NoError(t, err)
+		_, err = TestPool.Exec(ctx, `UPDATE transport SET current_equipment_id = $1 WHERE id = $2`, loadedID, transportID)
+		require.NoError(t, err)
+
+		order := models.Order{
+			ClientID:      clientID,
+			ObjectID:      objectID,
+			ScheduledDate: time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC),
+			Status:        string(models.OrderStatusInProgress),
+			Priority:      "MEDIUM",
+			TransportID:   &transportID,
+		}
+		err = orderRepo.Create(ctx, &order)
+		require.NoError(t, err)
+
+		moves, err := models.PlanEquipmentMoves(&order, []models.OrderItem{
+			{EquipmentID: pickupID, Operation: models.OrderItemPickup},
+		})
+		require.NoError(t, err)
+
+		order.Status = string(models.OrderStatusCompleted)
+		entry := &models.OrderStatusHistory{
+			OrderID:    order.ID,
+			FromStatus: models.OrderStatusInProgress,
+			ToStatus:   models.OrderStatusCompleted,
+		}
+		err = orderRepo.UpdateStatus(ctx, &order, entry, moves)
+		require.Error(t, err)
+		assert.True(t, domainerr.HasCode(err, domainerr.CodeEquipmentPlacementConflict))
+
+		// Nothing moved and the order kept its status
+		var currentEquipmentID uuid.UUID
+		err = TestPool.QueryRow(ctx, `SELECT current_equipment_id FROM transport WHERE id = $1`, transportID).Scan(&currentEquipmentID)
+		require.NoError(t, err)
+		assert.Equal(t, loadedID, currentEquipmentID)
+
+		var pickupObjectID uuid.UUID
+		err = TestPool.QueryRow(ctx, `SELECT client_object_id FROM equipment WHERE id = $1`, pickupID).Scan(&pickupObjectID)
+		require.NoError(t, err)
+		assert.Equal(t, objectID, pickupObjectID)
+
+		retrievedOrder, err := orderRepo.GetByID(ctx, order.ID, false)
+		require.NoError(t, err)
+		assert.Equal(t, string(models.OrderStatusInProgress), retrievedOrder.Status)
+	})
 }
 
 func TestOrderRepository_ExistsByClientAndObject(t *testing.T) {
@@ -0,0 +1,374 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"eco-van-api/internal/domainerr"
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/port"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// orderScheduleColumns is the column list matching scanOrderSchedule
+const orderScheduleColumns = `id, object_id, rrule, start_date, end_date, window_from, window_to,
+		       priority, transport_id, order_status, notes, is_active, generated_until, created_by,
+		       created_at, updated_at, deleted_at`
+
+// orderScheduleRepository implements port.OrderScheduleRepository for PostgreSQL
+type orderScheduleRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewOrderScheduleRepository creates a new order schedule repository
+func NewOrderScheduleRepository(db *pgxpool.Pool) port.OrderScheduleRepository {
+	return &orderScheduleRepository{db: db}
+}
+
+// scanOrderSchedule scans a row selected with orderScheduleColumns
+func scanOrderSchedule(row pgx.Row, schedule *models.OrderSchedule) error {
+	return row.Scan(
+		&schedule.ID,
+		&schedule.ObjectID,
+		&schedule.RRule,
+		&schedule.StartDate,
+		&schedule.EndDate,
+		&schedule.WindowFrom,
+		&schedule.WindowTo,
+		&schedule.Priority,
+		&schedule.TransportID,
+		&schedule.OrderStatus,
+		&schedule.Notes,
+		&schedule.IsActive,
+		&schedule.GeneratedUntil,
+		&schedule.CreatedBy,
+		&schedule.CreatedAt,
+		&schedule.UpdatedAt,
+		&schedule.DeletedAt,
+	)
+}
+
+// Create creates a new order schedule
+func (r *orderScheduleRepository) Create(ctx context.Context, schedule *models.OrderSchedule) error {
+	query := `
+		INSERT INTO order_schedules (
+			object_id, rrule, start_date, end_date, window_from, window_to, priority,
+			transport_id, order_status, notes, is_active, created_by, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING id
+	`
+
+	now := time.Now()
+	schedule.CreatedAt = now
+	schedule.UpdatedAt = now
+
+	err := r.db.QueryRow(ctx, query,
+		schedule.ObjectID,
+		schedule.RRule,
+		schedule.StartDate,
+		schedule.EndDate,
+		schedule.WindowFrom,
+		schedule.WindowTo,
+		schedule.Priority,
+		schedule.TransportID,
+		schedule.OrderStatus,
+		schedule.Notes,
+		schedule.IsActive,
+		schedule.CreatedBy,
+		schedule.CreatedAt,
+		schedule.UpdatedAt,
+	).Scan(&schedule.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create order schedule: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an order schedule by ID
+func (r *orderScheduleRepository) GetByID(ctx context.Context, id uuid.UUID, includeDeleted bool) (*models.OrderSchedule, error) {
+	query := "SELECT " + orderScheduleColumns + " FROM order_schedules WHERE id = $1"
+	if !includeDeleted {
+		query += DeletedAtFilter
+	}
+
+	var schedule models.OrderSchedule
+	err := scanOrderSchedule(r.db.QueryRow(ctx, query, id), &schedule)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get order schedule: %w", err)
+	}
+
+	return &schedule, nil
+}
+
+// Update updates an existing order schedule
+func (r *orderScheduleRepository) Update(ctx context.Context, schedule *models.OrderSchedule) error {
+	query := `
+		UPDATE order_schedules
+		SET rrule = $1, start_date = $2, end_date = $3, window_from = $4, window_to = $5,
+		    priority = $6, transport_id = $7, order_status = $8, notes = $9, is_active = $10,
+		    updated_at = $11
+		WHERE id = $12 AND deleted_at IS NULL
+	`
+
+	schedule.UpdatedAt = time.Now()
+	result, err := r.db.Exec(ctx, query,
+		schedule.RRule,
+		schedule.StartDate,
+		schedule.EndDate,
+		schedule.WindowFrom,
+		schedule.WindowTo,
+		schedule.Priority,
+		schedule.TransportID,
+		schedule.OrderStatus,
+		schedule.Notes,
+		schedule.IsActive,
+		schedule.UpdatedAt,
+		schedule.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update order schedule: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domainerr.NotFound(domainerr.CodeOrderScheduleNotFound, "order schedule not found")
+	}
+
+	return nil
+}
+
+// SoftDelete marks an order schedule as deleted by setting deleted_at
+func (r *orderScheduleRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
+	query := "UPDATE order_schedules SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL"
+
+	result, err := r.db.Exec(ctx, query, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to soft delete order schedule: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domainerr.NotFound(domainerr.CodeOrderScheduleNotFound, "order schedule not found")
+	}
+
+	return nil
+}
+
+// List retrieves order schedules with pagination and filtering
+func (r *orderScheduleRepository) List(
+	ctx context.Context, req models.OrderScheduleListRequest,
+) (*models.OrderScheduleListResponse, error) {
+	whereClauses := []string{}
+	args := []interface{}{}
+
+	if !req.IncludeDeleted {
+		whereClauses = append(whereClauses, "deleted_at IS NULL")
+	}
+
+	if req.ObjectID != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("object_id = $%d", len(args)+1))
+		args = append(args, *req.ObjectID)
+	}
+
+	whereClause := ""
+	if len(whereClauses) > 0 {
+		whereClause = "WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	var total int64
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM order_schedules %s", whereClause)
+	if err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count order schedules: %w", err)
+	}
+
+	const offsetIncrement = 2
+	mainQuery := fmt.Sprintf(`
+		SELECT %s
+		FROM order_schedules
+		%s
+		ORDER BY created_at DESC
+		LIMIT $%d
+		OFFSET $%d
+	`, orderScheduleColumns, whereClause, len(args)+1, len(args)+offsetIncrement)
+	args = append(args, req.PageSize, (req.Page-1)*req.PageSize)
+
+	rows, err := r.db.Query(ctx, mainQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list order schedules: %w", err)
+	}
+	defer rows.Close()
+
+	schedules := make([]models.OrderSchedule, 0)
+	for rows.Next() {
+		var schedule models.OrderSchedule
+		if err := scanOrderSchedule(rows, &schedule); err != nil {
+			return nil, fmt.Errorf("failed to scan order schedule: %w", err)
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over order schedules: %w", err)
+	}
+
+	return &models.OrderScheduleListResponse{
+		Items:    schedules,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Total:    total,
+	}, nil
+}
+
+// ListDue returns active schedules that have not been generated up to the horizon yet. Schedules of deleted
+// client objects are left out until the object is restored.
+func (r *orderScheduleRepository) ListDue(ctx context.Context, horizon time.Time) ([]models.OrderSchedule, error) {
+	query := "SELECT " + orderScheduleColumns + ` FROM order_schedules
+		WHERE deleted_at IS NULL AND is_active
+		  AND start_date <= $1
+		  AND (generated_until IS NULL OR (generated_until < $1 AND (end_date IS NULL OR generated_until < end_date)))
+		  AND EXISTS (SELECT 1 FROM client_objects co WHERE co.id = order_schedules.object_id AND co.deleted_at IS NULL)
+		ORDER BY id`
+
+	rows, err := r.db.Query(ctx, query, horizon)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due order schedules: %w", err)
+	}
+	defer rows.Close()
+
+	schedules := make([]models.OrderSchedule, 0)
+	for rows.Next() {
+		var schedule models.OrderSchedule
+		if err := scanOrderSchedule(rows, &schedule); err != nil {
+			return nil, fmt.Errorf("failed to scan order schedule: %w", err)
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over order schedules: %w", err)
+	}
+
+	return schedules, nil
+}
+
+// GenerateOrders inserts orders for the given dates and advances generated_until atomically.
+// The schedule row is locked and compared with the caller's generated_until, so when several
+// replicas run the generator only the first one to see a given state materializes its orders.
+// Nothing is generated or advanced while the client object is deleted.
+func (r *orderScheduleRepository) GenerateOrders(
+	ctx context.Context, schedule *models.OrderSchedule, dates []time.Time, generatedUntil time.Time,
+) (int, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil {
+			// Rollback after a successful commit is a no-op error; nothing to report
+			_ = err
+		}
+	}()
+
+	lockQuery := `
+		SELECT s.id FROM order_schedules s
+		JOIN client_objects co ON co.id = s.object_id AND co.deleted_at IS NULL
+		WHERE s.id = $1 AND s.deleted_at IS NULL AND s.generated_until IS NOT DISTINCT FROM $2
+		FOR UPDATE OF s
+	`
+	var lockedID uuid.UUID
+	err = tx.QueryRow(ctx, lockQuery, schedule.ID, schedule.GeneratedUntil).Scan(&lockedID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			// Another generator run or an update got there first, or the client object was deleted
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to lock order schedule: %w", err)
+	}
+
+	created := 0
+	if len(dates) > 0 {
+		insertQuery := `
+			INSERT INTO orders (
+				client_id, object_id, scheduled_date, scheduled_window_from, scheduled_window_to,
+				status, priority, transport_id, notes, created_by, schedule_id, created_at, updated_at
+			)
+			SELECT co.client_id, co.id, d, $3, $4, $5, $6, $7, $8, $9, $10, now(), now()
+			FROM client_objects co, unnest($2::date[]) AS d
+			WHERE co.id = $1 AND co.deleted_at IS NULL
+			ON CONFLICT (schedule_id, scheduled_date) WHERE schedule_id IS NOT NULL AND deleted_at IS NULL DO NOTHING
+		`
+		result, err := tx.Exec(ctx, insertQuery,
+			schedule.ObjectID,
+			dates,
+			schedule.WindowFrom,
+			schedule.WindowTo,
+			schedule.OrderStatus,
+			schedule.Priority,
+			schedule.TransportID,
+			schedule.Notes,
+			schedule.CreatedBy,
+			schedule.ID,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("failed to generate orders: %w", err)
+		}
+		created = int(result.RowsAffected())
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE order_schedules SET generated_until = $1 WHERE id = $2", generatedUntil, schedule.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to advance order schedule: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	schedule.GeneratedUntil = &generatedUntil
+	return created, nil
+}
+
+// DiscardFutureOrders soft-deletes generated DRAFT and SCHEDULED orders dated on or after from
+// and rewinds generated_until so that they are generated again from the current schedule
+func (r *orderScheduleRepository) DiscardFutureOrders(ctx context.Context, scheduleID uuid.UUID, from time.Time) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil {
+			// Rollback after a successful commit is a no-op error; nothing to report
+			_ = err
+		}
+	}()
+
+	discardQuery := `
+		UPDATE orders SET deleted_at = now()
+		WHERE schedule_id = $1 AND scheduled_date >= $2 AND status IN ('DRAFT','SCHEDULED') AND deleted_at IS NULL
+	`
+	result, err := tx.Exec(ctx, discardQuery, scheduleID, from)
+	if err != nil {
+		return 0, fmt.Errorf("failed to discard generated orders: %w", err)
+	}
+
+	rewindQuery := `
+		UPDATE order_schedules SET generated_until = $2::date - 1
+		WHERE id = $1 AND generated_until >= $2
+	`
+	if _, err := tx.Exec(ctx, rewindQuery, scheduleID, from); err != nil {
+		return 0, fmt.Errorf("failed to rewind order schedule: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,94 @@
+//go:build integration
+
+package pg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eco-van-api/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderScheduleRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	scheduleRepo := NewOrderScheduleRepository(TestPool)
+
+	newSchedule := func(t *testing.T, ctx context.Context, name string) *models.OrderSchedule {
+		t.Helper()
+
+		clientID := MakeClient(t, ctx, TestPool, "ScheduleTest-"+name+"-"+uuid.New().String()[:8])
+		objectID := MakeClientObject(t, ctx, TestPool, clientID, "ScheduleTest-Office-"+name+"-"+uuid.New().String()[:8])
+
+		schedule := &models.OrderSchedule{
+			ObjectID:    objectID,
+			RRule:       "FREQ=WEEKLY;BYDAY=MO",
+			StartDate:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			Priority:    string(models.OrderPriorityMedium),
+			OrderStatus: string(models.OrderStatusDraft),
+			IsActive:    true,
+		}
+		require.NoError(t, scheduleRepo.Create(ctx, schedule))
+		return schedule
+	}
+
+	countOrders := func(t *testing.T, ctx context.Context, scheduleID uuid.UUID) int {
+		t.Helper()
+
+		var count int
+		err := TestPool.QueryRow(ctx, `SELECT count(*) FROM orders WHERE schedule_id = $1`, scheduleID).Scan(&count)
+		require.NoError(t, err)
+		return count
+	}
+
+	dates := []time.Time{
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+	}
+	generatedUntil := time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)
+	horizon := time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Generate orders", func(t *testing.T) {
+		ctx := context.Background()
+		schedule := newSchedule(t, ctx, "Generate")
+
+		created, err := scheduleRepo.GenerateOrders(ctx, schedule, dates, generatedUntil)
+		require.NoError(t, err)
+		assert.Equal(t, 2, created)
+		assert.Equal(t, 2, countOrders(t, ctx, schedule.ID))
+		require.NotNil(t, schedule.GeneratedUntil)
+		assert.Equal(t, generatedUntil, *schedule.GeneratedUntil)
+	})
+
+	t.Run("Deleted client object generates nothing", func(t *testing.T) {
+		ctx := context.Background()
+		schedule := newSchedule(t, ctx, "DeletedObject")
+
+		_, err := TestPool.Exec(ctx, `UPDATE client_objects SET deleted_at = now() WHERE id = $1`, schedule.ObjectID)
+		require.NoError(t, err)
+
+		due, err := scheduleRepo.ListDue(ctx, horizon)
+		require.NoError(t, err)
+		for _, dueSchedule := range due {
+			assert.NotEqual(t, schedule.ID, dueSchedule.ID, "schedule of a deleted client object is due")
+		}
+
+		created, err := scheduleRepo.GenerateOrders(ctx, schedule, dates, generatedUntil)
+		require.NoError(t, err)
+		assert.Equal(t, 0, created)
+		assert.Equal(t, 0, countOrders(t, ctx, schedule.ID))
+
+		// The schedule is not advanced, so restoring the object resumes generation where it stopped
+		stored, err := scheduleRepo.GetByID(ctx, schedule.ID, false)
+		require.NoError(t, err)
+		require.NotNil(t, stored)
+		assert.Nil(t, stored.GeneratedUntil)
+	})
+}
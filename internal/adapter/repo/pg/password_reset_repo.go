@@ -0,0 +1,90 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/port"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type passwordResetRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPasswordResetRepository creates a new password reset token repository
+func NewPasswordResetRepository(pool *pgxpool.Pool) port.PasswordResetRepository {
+	return &passwordResetRepository{pool: pool}
+}
+
+// Create stores a newly issued reset token
+func (r *passwordResetRepository) Create(ctx context.Context, token *models.PasswordResetToken) error {
+	query := `
+		INSERT INTO password_reset_tokens (user_id, token_hash, created_by, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+
+	err := r.pool.QueryRow(ctx, query, token.UserID, token.TokenHash, token.CreatedBy, token.ExpiresAt).
+		Scan(&token.ID, &token.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create password reset token: %w", err)
+	}
+
+	return nil
+}
+
+// GetByHash retrieves a reset token by the hash of its value
+func (r *passwordResetRepository) GetByHash(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error) {
+	query := `
+		SELECT id, user_id, token_hash, created_by, expires_at, created_at, used_at
+		FROM password_reset_tokens
+		WHERE token_hash = $1
+	`
+
+	var token models.PasswordResetToken
+	err := r.pool.QueryRow(ctx, query, tokenHash).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.TokenHash,
+		&token.CreatedBy,
+		&token.ExpiresAt,
+		&token.CreatedAt,
+		&token.UsedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get password reset token: %w", err)
+	}
+
+	return &token, nil
+}
+
+// MarkUsed consumes an unused token; the conditional update makes concurrent redemptions lose
+func (r *passwordResetRepository) MarkUsed(ctx context.Context, id uuid.UUID) (bool, error) {
+	query := `UPDATE password_reset_tokens SET used_at = NOW() WHERE id = $1 AND used_at IS NULL`
+
+	result, err := r.pool.Exec(ctx, query, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark password reset token used: %w", err)
+	}
+
+	return result.RowsAffected() == 1, nil
+}
+
+// InvalidateForUser consumes every unused token of a user
+func (r *passwordResetRepository) InvalidateForUser(ctx context.Context, userID uuid.UUID) error {
+	query := `UPDATE password_reset_tokens SET used_at = NOW() WHERE user_id = $1 AND used_at IS NULL`
+
+	if _, err := r.pool.Exec(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to invalidate password reset tokens: %w", err)
+	}
+
+	return nil
+}
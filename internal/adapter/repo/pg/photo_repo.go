@@ -0,0 +1,157 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/port"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// latestPhotoIDColumn selects the ID of the most recent photo of the entity rows of table, which is the name or
+// alias of the entity table in the enclosing query
+func latestPhotoIDColumn(entityType models.PhotoEntityType, table string) string {
+	return fmt.Sprintf(
+		"(SELECT p.id FROM photos p WHERE p.entity_type = '%s' AND p.entity_id = %s.id ORDER BY p.created_at DESC LIMIT 1)",
+		entityType, table,
+	)
+}
+
+// photoRepository implements port.PhotoRepository
+type photoRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPhotoRepository creates a new photo repository
+func NewPhotoRepository(pool *pgxpool.Pool) port.PhotoRepository {
+	return &photoRepository{
+		pool: pool,
+	}
+}
+
+// Create stores photo metadata
+func (r *photoRepository) Create(ctx context.Context, photo *models.Photo) error {
+	query := `
+		INSERT INTO photos (id, entity_type, entity_id, filename, original_name, mime_type, size, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		photo.ID,
+		photo.EntityType,
+		photo.EntityID,
+		photo.Filename,
+		photo.OriginalName,
+		photo.MimeType,
+		photo.Size,
+		photo.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create photo: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves photo metadata by ID
+func (r *photoRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Photo, error) {
+	query := `
+		SELECT id, entity_type, entity_id, filename, original_name, mime_type, size, created_at
+		FROM photos
+		WHERE id = $1
+	`
+
+	var photo models.Photo
+	err := r.pool.QueryRow(ctx, query, id).Scan(
+		&photo.ID,
+		&photo.EntityType,
+		&photo.EntityID,
+		&photo.Filename,
+		&photo.OriginalName,
+		&photo.MimeType,
+		&photo.Size,
+		&photo.CreatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get photo: %w", err)
+	}
+
+	return &photo, nil
+}
+
+// ListByEntity retrieves all photos attached to an entity, newest first
+func (r *photoRepository) ListByEntity(
+	ctx context.Context, entityType models.PhotoEntityType, entityID uuid.UUID,
+) ([]models.Photo, error) {
+	query := `
+		SELECT id, entity_type, entity_id, filename, original_name, mime_type, size, created_at
+		FROM photos
+		WHERE entity_type = $1 AND entity_id = $2
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.pool.Query(ctx, query, entityType, entityID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list photos: %w", err)
+	}
+	defer rows.Close()
+
+	photos := make([]models.Photo, 0)
+	for rows.Next() {
+		var photo models.Photo
+		err := rows.Scan(
+			&photo.ID,
+			&photo.EntityType,
+			&photo.EntityID,
+			&photo.Filename,
+			&photo.OriginalName,
+			&photo.MimeType,
+			&photo.Size,
+			&photo.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan photo: %w", err)
+		}
+		photos = append(photos, photo)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over photos: %w", err)
+	}
+
+	return photos, nil
+}
+
+// Delete removes photo metadata by ID
+func (r *photoRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM photos WHERE id = $1`
+
+	_, err := r.pool.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete photo: %w", err)
+	}
+	return nil
+}
+
+// EntityExists checks if the referenced entity exists (excluding soft-deleted)
+func (r *photoRepository) EntityExists(ctx context.Context, entityType models.PhotoEntityType, entityID uuid.UUID) (bool, error) {
+	// Entity types map one-to-one onto table names, so only whitelisted values may reach the query
+	if !entityType.IsValid() {
+		return false, fmt.Errorf("invalid photo entity type: %s", entityType)
+	}
+
+	//nolint:gosec // Table name comes from the validated PhotoEntityType whitelist
+	query := fmt.Sprintf(`SELECT EXISTS(SELECT 1 FROM %s WHERE id = $1`+DeletedAtFilter+`)`, string(entityType))
+
+	var exists bool
+	if err := r.pool.QueryRow(ctx, query, entityID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check %s existence: %w", entityType, err)
+	}
+	return exists, nil
+}
@@ -0,0 +1,84 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/port"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// rateLimitCleanupInterval is how often buckets that are full again are deleted
+const rateLimitCleanupInterval = 5 * time.Minute
+
+type rateLimitStore struct {
+	pool *pgxpool.Pool
+
+	mu          sync.Mutex
+	lastCleanup time.Time
+}
+
+// NewRateLimitStore creates a rate limit store that shares token buckets between replicas through Postgres
+func NewRateLimitStore(pool *pgxpool.Pool) port.RateLimitStore {
+	return &rateLimitStore{pool: pool}
+}
+
+// Take charges one request to the bucket of key. The conditional upsert takes a token only while the bucket
+// has one, so concurrent requests on any replica cannot overdraw it.
+func (s *rateLimitStore) Take(
+	ctx context.Context, key string, policy models.RateLimitPolicy, now time.Time,
+) (models.RateLimitResult, error) {
+	if err := s.cleanup(ctx, now); err != nil {
+		return models.RateLimitResult{}, err
+	}
+
+	query := `
+		INSERT INTO rate_limit_buckets AS b (key, full_at)
+		VALUES ($1, $2::timestamptz + $3::bigint * INTERVAL '1 microsecond')
+		ON CONFLICT (key) DO UPDATE
+		SET full_at = GREATEST(b.full_at, $2) + $3::bigint * INTERVAL '1 microsecond'
+		WHERE GREATEST(b.full_at, $2) + $3::bigint * INTERVAL '1 microsecond' <= $2 + $4::bigint * INTERVAL '1 microsecond'
+		RETURNING full_at
+	`
+
+	var fullAt time.Time
+	err := s.pool.QueryRow(ctx, query, key, now, policy.Interval().Microseconds(), policy.Period.Microseconds()).Scan(&fullAt)
+	if err == nil {
+		return policy.Allowed(fullAt, now), nil
+	}
+	if err != pgx.ErrNoRows {
+		return models.RateLimitResult{}, fmt.Errorf("failed to take rate limit token: %w", err)
+	}
+
+	// The bucket is empty; read it to tell the client how long to wait
+	if err := s.pool.QueryRow(ctx, `SELECT full_at FROM rate_limit_buckets WHERE key = $1`, key).Scan(&fullAt); err != nil {
+		return models.RateLimitResult{}, fmt.Errorf("failed to get rate limit bucket: %w", err)
+	}
+	_, result := policy.Take(fullAt, now)
+	// A token refilled since the upsert is left for the next request
+	result.Allowed = false
+	return result, nil
+}
+
+// cleanup deletes buckets that are full again, at most once per rateLimitCleanupInterval per replica
+func (s *rateLimitStore) cleanup(ctx context.Context, now time.Time) error {
+	s.mu.Lock()
+	due := now.Sub(s.lastCleanup) >= rateLimitCleanupInterval
+	if due {
+		s.lastCleanup = now
+	}
+	s.mu.Unlock()
+	if !due {
+		return nil
+	}
+
+	if _, err := s.pool.Exec(ctx, `DELETE FROM rate_limit_buckets WHERE full_at < $1`, now); err != nil {
+		return fmt.Errorf("failed to delete full rate limit buckets: %w", err)
+	}
+	return nil
+}
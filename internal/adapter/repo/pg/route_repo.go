@@ -0,0 +1,111 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/port"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// routeRepository implements port.RouteRepository for PostgreSQL
+type routeRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewRouteRepository creates a new route planning repository
+func NewRouteRepository(db *pgxpool.Pool) port.RouteRepository {
+	return &routeRepository{db: db}
+}
+
+// ListPlannableOrders returns the SCHEDULED orders of a date with their object coordinates and volumes
+func (r *routeRepository) ListPlannableOrders(ctx context.Context, date time.Time) ([]models.RoutingOrder, error) {
+	query := `
+		SELECT o.id, o.object_id, co.geo_lat, co.geo_lng,
+		       to_char(o.scheduled_window_from, 'HH24:MI'), to_char(o.scheduled_window_to, 'HH24:MI'),
+		       o.priority, ` + orderVolumeExpr + `, o.transport_id
+		FROM orders o
+		JOIN client_objects co ON co.id = o.object_id
+		WHERE o.scheduled_date = $1::date
+		  AND o.status = 'SCHEDULED'
+		  AND o.deleted_at IS NULL
+		ORDER BY o.id
+	`
+
+	rows, err := r.db.Query(ctx, query, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list plannable orders: %w", err)
+	}
+	defer rows.Close()
+
+	orders := make([]models.RoutingOrder, 0)
+	for rows.Next() {
+		var order models.RoutingOrder
+		err := rows.Scan(
+			&order.OrderID,
+			&order.ObjectID,
+			&order.GeoLat,
+			&order.GeoLng,
+			&order.WindowFrom,
+			&order.WindowTo,
+			&order.Priority,
+			&order.VolumeL,
+			&order.TransportID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan plannable order: %w", err)
+		}
+		orders = append(orders, order)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over plannable orders: %w", err)
+	}
+
+	return orders, nil
+}
+
+// ListDepots returns the non-deleted warehouses that have coordinates
+func (r *routeRepository) ListDepots(ctx context.Context) ([]models.Warehouse, error) {
+	query := `
+		SELECT id, name, address, geo_lat, geo_lng, notes, created_at, updated_at, deleted_at
+		FROM warehouses
+		WHERE deleted_at IS NULL AND geo_lat IS NOT NULL AND geo_lng IS NOT NULL
+		ORDER BY name
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list depots: %w", err)
+	}
+	defer rows.Close()
+
+	depots := make([]models.Warehouse, 0)
+	for rows.Next() {
+		var depot models.Warehouse
+		err := rows.Scan(
+			&depot.ID,
+			&depot.Name,
+			&depot.Address,
+			&depot.GeoLat,
+			&depot.GeoLng,
+			&depot.Notes,
+			&depot.CreatedAt,
+			&depot.UpdatedAt,
+			&depot.DeletedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan depot: %w", err)
+		}
+		depots = append(depots, depot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over depots: %w", err)
+	}
+
+	return depots, nil
+}
@@ -9,20 +9,23 @@ import (
 	"eco-van-api/internal/models"
 )
 
-// SeedAdminUser creates an admin user if it doesn't exist
+// defaultAdminPassword is used for the seeded admin when ADMIN_PASSWORD is not set
+const defaultAdminPassword = "admin123456"
+
+// SeedAdminUser creates an admin user if it doesn't exist.
+// An admin with the default password is flagged to change it on next login.
 func (r *UserRepository) SeedAdminUser(ctx context.Context) error {
 	// Check if admin user already exists
 	existingUser, err := r.FindByEmail(ctx, "admin@example.com")
 	if err == nil && existingUser != nil {
-		// Admin user already exists
-		return nil
+		return r.flagDefaultAdminPassword(ctx, existingUser)
 	}
 
 	// Get admin password from environment
 	adminPassword := os.Getenv("ADMIN_PASSWORD")
 	if adminPassword == "" {
 		// Use default password if not set
-		adminPassword = "admin123456"
+		adminPassword = defaultAdminPassword
 	}
 
 	// Validate password
@@ -37,11 +40,25 @@ func (r *UserRepository) SeedAdminUser(ctx context.Context) error {
 	}
 
 	// Create admin user
-	_, err = r.Create(ctx, "admin@example.com", passwordHash, models.UserRoleAdmin.String())
+	admin, err := r.Create(ctx, "admin@example.com", passwordHash, models.UserRoleAdmin.String())
 	if err != nil {
 		return fmt.Errorf("failed to create admin user: %w", err)
 	}
 
 	// Admin user created successfully
-	return nil
+	return r.flagDefaultAdminPassword(ctx, admin)
+}
+
+// flagDefaultAdminPassword forces a password change if the admin still uses the default password
+func (r *UserRepository) flagDefaultAdminPassword(ctx context.Context, admin *models.User) error {
+	if admin.MustChangePassword {
+		return nil
+	}
+
+	isDefault, err := auth.VerifyPassword(defaultAdminPassword, admin.PasswordHash)
+	if err != nil || !isDefault {
+		return err
+	}
+
+	return r.UpdatePassword(ctx, admin.ID, admin.PasswordHash, true)
 }
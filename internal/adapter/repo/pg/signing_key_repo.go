@@ -0,0 +1,112 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/port"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// signingKeyRotationLock is the advisory lock key serializing key rotation across replicas
+const signingKeyRotationLock = "jwt_signing_keys"
+
+type signingKeyRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewSigningKeyRepository creates a new JWT signing key repository
+func NewSigningKeyRepository(pool *pgxpool.Pool) port.SigningKeyRepository {
+	return &signingKeyRepository{pool: pool}
+}
+
+// List retrieves the keys not yet expired at now, ordered by activation time
+func (r *signingKeyRepository) List(ctx context.Context, now time.Time) ([]models.JWTSigningKey, error) {
+	query := `
+		SELECT kid, algorithm, private_key, activates_at, expires_at, created_at
+		FROM jwt_signing_keys
+		WHERE expires_at IS NULL OR expires_at > $1
+		ORDER BY activates_at
+	`
+
+	rows, err := r.pool.Query(ctx, query, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signing keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []models.JWTSigningKey{}
+	for rows.Next() {
+		var key models.JWTSigningKey
+		err := rows.Scan(&key.KID, &key.Algorithm, &key.PrivateKeyPEM, &key.ActivatesAt, &key.ExpiresAt, &key.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan signing key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating signing keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// Rotate stores a new key and retires the keys it replaces. An advisory lock serializes replicas and the
+// newest key is compared with the caller's previousKID, so only the first replica to see a given state rotates.
+func (r *signingKeyRepository) Rotate(
+	ctx context.Context, key *models.JWTSigningKey, previousKID string, retireAt time.Time,
+) (bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil {
+			// Rollback after a successful commit is a no-op error; nothing to report
+			_ = err
+		}
+	}()
+
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", signingKeyRotationLock); err != nil {
+		return false, fmt.Errorf("failed to lock signing keys: %w", err)
+	}
+
+	var newestKID string
+	err = tx.QueryRow(ctx, "SELECT kid FROM jwt_signing_keys ORDER BY activates_at DESC LIMIT 1").Scan(&newestKID)
+	if err != nil && err != pgx.ErrNoRows {
+		return false, fmt.Errorf("failed to get newest signing key: %w", err)
+	}
+	if newestKID != previousKID {
+		// Another replica rotated first
+		return false, nil
+	}
+
+	if _, err := tx.Exec(ctx, "UPDATE jwt_signing_keys SET expires_at = $1 WHERE expires_at IS NULL", retireAt); err != nil {
+		return false, fmt.Errorf("failed to retire signing keys: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, "DELETE FROM jwt_signing_keys WHERE expires_at <= NOW()"); err != nil {
+		return false, fmt.Errorf("failed to delete expired signing keys: %w", err)
+	}
+
+	insertQuery := `
+		INSERT INTO jwt_signing_keys (kid, algorithm, private_key, activates_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at
+	`
+	err = tx.QueryRow(ctx, insertQuery, key.KID, key.Algorithm, key.PrivateKeyPEM, key.ActivatesAt).Scan(&key.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to create signing key: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return true, nil
+}
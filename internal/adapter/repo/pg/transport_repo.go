@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"eco-van-api/internal/domainerr"
 	"eco-van-api/internal/models"
 	"eco-van-api/internal/port"
 
@@ -131,7 +132,7 @@ func (r *transportRepository) Update(ctx context.Context, transport *models.Tran
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("transport not found or already deleted")
+		return domainerr.NotFound(domainerr.CodeTransportNotFound, "transport not found or already deleted")
 	}
 
 	return nil
@@ -312,7 +313,7 @@ func (r *transportRepository) AssignDriver(ctx context.Context, transportID, dri
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("transport not found or already deleted")
+		return domainerr.NotFound(domainerr.CodeTransportNotFound, "transport not found or already deleted")
 	}
 
 	return nil
@@ -328,7 +329,7 @@ func (r *transportRepository) UnassignDriver(ctx context.Context, transportID uu
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("transport not found or already deleted")
+		return domainerr.NotFound(domainerr.CodeTransportNotFound, "transport not found or already deleted")
 	}
 
 	return nil
@@ -356,7 +357,7 @@ func (r *transportRepository) AssignEquipment(ctx context.Context, transportID,
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("transport not found or already deleted")
+		return domainerr.NotFound(domainerr.CodeTransportNotFound, "transport not found or already deleted")
 	}
 
 	// Update equipment table to set transport_id
@@ -368,7 +369,7 @@ func (r *transportRepository) AssignEquipment(ctx context.Context, transportID,
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("equipment not found or already deleted")
+		return domainerr.NotFound(domainerr.CodeEquipmentNotFound, "equipment not found or already deleted")
 	}
 
 	// Commit transaction
@@ -402,7 +403,7 @@ func (r *transportRepository) UnassignEquipment(ctx context.Context, transportID
 	}
 
 	if equipmentID == nil {
-		return fmt.Errorf("no equipment assigned to transport")
+		return domainerr.Conflict(domainerr.CodeEquipmentNotAvailable, "no equipment assigned to transport")
 	}
 
 	// Update transport table
@@ -413,7 +414,7 @@ func (r *transportRepository) UnassignEquipment(ctx context.Context, transportID
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("transport not found or already deleted")
+		return domainerr.NotFound(domainerr.CodeTransportNotFound, "transport not found or already deleted")
 	}
 
 	// Update equipment table to clear transport_id
@@ -424,7 +425,7 @@ func (r *transportRepository) UnassignEquipment(ctx context.Context, transportID
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("equipment not found or already deleted")
+		return domainerr.NotFound(domainerr.CodeEquipmentNotFound, "equipment not found or already deleted")
 	}
 
 	// Commit transaction
@@ -505,6 +506,39 @@ func (r *transportRepository) GetAvailable(ctx context.Context, req models.Trans
 	}, nil
 }
 
+// GetByDriverID returns the non-deleted transport the driver is currently assigned to
+func (r *transportRepository) GetByDriverID(ctx context.Context, driverID uuid.UUID) (*models.Transport, error) {
+	query := `
+		SELECT id, plate_no, brand, model, capacity_l, current_driver_id, current_equipment_id, status, created_at, updated_at, deleted_at
+		FROM transport
+		WHERE current_driver_id = $1 AND deleted_at IS NULL
+	`
+
+	var transport models.Transport
+	err := r.pool.QueryRow(ctx, query, driverID).Scan(
+		&transport.ID,
+		&transport.PlateNo,
+		&transport.Brand,
+		&transport.Model,
+		&transport.CapacityL,
+		&transport.CurrentDriverID,
+		&transport.CurrentEquipmentID,
+		&transport.Status,
+		&transport.CreatedAt,
+		&transport.UpdatedAt,
+		&transport.DeletedAt,
+	)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get transport by driver: %w", err)
+	}
+
+	return &transport, nil
+}
+
 // IsDriverAssignedToOtherTransport checks if driver is assigned to another non-deleted transport
 func (r *transportRepository) IsDriverAssignedToOtherTransport(ctx context.Context, driverID, excludeTransportID uuid.UUID) (bool, error) {
 	query := `
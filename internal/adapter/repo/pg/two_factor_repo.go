@@ -0,0 +1,210 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/port"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type twoFactorRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewTwoFactorRepository creates a new two-factor authentication repository
+func NewTwoFactorRepository(pool *pgxpool.Pool) port.TwoFactorRepository {
+	return &twoFactorRepository{pool: pool}
+}
+
+// Get retrieves the TOTP secret of a user
+func (r *twoFactorRepository) Get(ctx context.Context, userID uuid.UUID) (*models.UserTOTP, error) {
+	query := `
+		SELECT user_id, secret, confirmed_at, last_used_step, created_at
+		FROM user_totp
+		WHERE user_id = $1
+	`
+
+	var totp models.UserTOTP
+	err := r.pool.QueryRow(ctx, query, userID).Scan(
+		&totp.UserID,
+		&totp.Secret,
+		&totp.ConfirmedAt,
+		&totp.LastUsedStep,
+		&totp.CreatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get TOTP secret: %w", err)
+	}
+
+	return &totp, nil
+}
+
+// SavePending stores the secret of a new enrollment; a confirmed secret is never overwritten
+func (r *twoFactorRepository) SavePending(ctx context.Context, userID uuid.UUID, secret string) error {
+	query := `
+		INSERT INTO user_totp (user_id, secret)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret, last_used_step = NULL, created_at = NOW()
+		WHERE user_totp.confirmed_at IS NULL
+	`
+
+	if _, err := r.pool.Exec(ctx, query, userID, secret); err != nil {
+		return fmt.Errorf("failed to save TOTP secret: %w", err)
+	}
+
+	return nil
+}
+
+// Confirm enables the pending enrollment and stores its recovery codes in one transaction
+func (r *twoFactorRepository) Confirm(
+	ctx context.Context, userID uuid.UUID, step int64, recoveryCodeHashes []string,
+) (bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil {
+			// Rollback after a successful commit is a no-op error; nothing to report
+			_ = err
+		}
+	}()
+
+	query := `
+		UPDATE user_totp SET confirmed_at = NOW(), last_used_step = $2
+		WHERE user_id = $1 AND confirmed_at IS NULL
+	`
+	result, err := tx.Exec(ctx, query, userID, step)
+	if err != nil {
+		return false, fmt.Errorf("failed to confirm TOTP enrollment: %w", err)
+	}
+	if result.RowsAffected() != 1 {
+		return false, nil
+	}
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, recoveryCodeHashes); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return true, nil
+}
+
+// UseStep records an accepted step; the conditional update makes a replayed or concurrent code lose
+func (r *twoFactorRepository) UseStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	query := `
+		UPDATE user_totp SET last_used_step = $2
+		WHERE user_id = $1 AND confirmed_at IS NOT NULL AND (last_used_step IS NULL OR last_used_step < $2)
+	`
+
+	result, err := r.pool.Exec(ctx, query, userID, step)
+	if err != nil {
+		return false, fmt.Errorf("failed to record TOTP step: %w", err)
+	}
+
+	return result.RowsAffected() == 1, nil
+}
+
+// ReplaceRecoveryCodes discards the recovery codes of a user and stores new ones
+func (r *twoFactorRepository) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil {
+			// Rollback after a successful commit is a no-op error; nothing to report
+			_ = err
+		}
+	}()
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, codeHashes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// replaceRecoveryCodes deletes the recovery codes of a user and inserts the given ones within a transaction
+func replaceRecoveryCodes(ctx context.Context, tx pgx.Tx, userID uuid.UUID, codeHashes []string) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM user_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+
+	query := `INSERT INTO user_recovery_codes (user_id, code_hash) SELECT $1, unnest($2::text[])`
+	if _, err := tx.Exec(ctx, query, userID, codeHashes); err != nil {
+		return fmt.Errorf("failed to store recovery codes: %w", err)
+	}
+
+	return nil
+}
+
+// UseRecoveryCode consumes an unused recovery code; the conditional update makes concurrent use lose
+func (r *twoFactorRepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error) {
+	query := `
+		UPDATE user_recovery_codes SET used_at = NOW()
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+	`
+
+	result, err := r.pool.Exec(ctx, query, userID, codeHash)
+	if err != nil {
+		return false, fmt.Errorf("failed to use recovery code: %w", err)
+	}
+
+	return result.RowsAffected() == 1, nil
+}
+
+// CountRecoveryCodes counts the unused recovery codes of a user
+func (r *twoFactorRepository) CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM user_recovery_codes WHERE user_id = $1 AND used_at IS NULL`
+
+	var count int
+	if err := r.pool.QueryRow(ctx, query, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count recovery codes: %w", err)
+	}
+
+	return count, nil
+}
+
+// Delete removes the TOTP secret and recovery codes of a user
+func (r *twoFactorRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil {
+			// Rollback after a successful commit is a no-op error; nothing to report
+			_ = err
+		}
+	}()
+
+	if _, err := tx.Exec(ctx, `DELETE FROM user_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM user_totp WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete TOTP secret: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
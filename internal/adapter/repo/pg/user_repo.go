@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"eco-van-api/internal/domainerr"
 	"eco-van-api/internal/models"
 	"eco-van-api/internal/port"
 
@@ -26,7 +27,7 @@ func (r *UserRepository) Create(ctx context.Context, email, passwordHash, role s
 	query := `
 		INSERT INTO users (email, password_hash, role)
 		VALUES ($1, $2, $3)
-		RETURNING id, email, password_hash, role, created_at, updated_at
+		RETURNING id, email, password_hash, role, must_change_password, disabled_at, created_at, updated_at
 	`
 
 	var user models.User
@@ -35,6 +36,8 @@ func (r *UserRepository) Create(ctx context.Context, email, passwordHash, role s
 		&user.Email,
 		&user.PasswordHash,
 		&user.Role,
+		&user.MustChangePassword,
+		&user.DisabledAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -49,7 +52,7 @@ func (r *UserRepository) Create(ctx context.Context, email, passwordHash, role s
 // FindByEmail finds a user by email address
 func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
 	query := `
-		SELECT id, email, password_hash, role, created_at, updated_at
+		SELECT id, email, password_hash, role, must_change_password, disabled_at, created_at, updated_at
 		FROM users
 		WHERE email = $1
 	`
@@ -60,13 +63,15 @@ func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*models
 		&user.Email,
 		&user.PasswordHash,
 		&user.Role,
+		&user.MustChangePassword,
+		&user.DisabledAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
 
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("user not found: %w", err)
+			return nil, domainerr.NotFound(domainerr.CodeUserNotFound, "user not found")
 		}
 		return nil, fmt.Errorf("failed to find user by email: %w", err)
 	}
@@ -77,7 +82,7 @@ func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*models
 // Get retrieves a user by ID
 func (r *UserRepository) Get(ctx context.Context, id uuid.UUID) (*models.User, error) {
 	query := `
-		SELECT id, email, password_hash, role, created_at, updated_at
+		SELECT id, email, password_hash, role, must_change_password, disabled_at, created_at, updated_at
 		FROM users
 		WHERE id = $1
 	`
@@ -88,13 +93,15 @@ func (r *UserRepository) Get(ctx context.Context, id uuid.UUID) (*models.User, e
 		&user.Email,
 		&user.PasswordHash,
 		&user.Role,
+		&user.MustChangePassword,
+		&user.DisabledAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
 
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("user not found: %w", err)
+			return nil, domainerr.NotFound(domainerr.CodeUserNotFound, "user not found")
 		}
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
@@ -117,7 +124,7 @@ func (r *UserRepository) List(ctx context.Context, page, pageSize int) ([]*model
 
 	// Get users with pagination
 	query := `
-		SELECT id, email, password_hash, role, created_at, updated_at
+		SELECT id, email, password_hash, role, must_change_password, disabled_at, created_at, updated_at
 		FROM users
 		ORDER BY created_at DESC
 		LIMIT $1 OFFSET $2
@@ -137,6 +144,8 @@ func (r *UserRepository) List(ctx context.Context, page, pageSize int) ([]*model
 			&user.Email,
 			&user.PasswordHash,
 			&user.Role,
+			&user.MustChangePassword,
+			&user.DisabledAt,
 			&user.CreatedAt,
 			&user.UpdatedAt,
 		)
@@ -163,7 +172,47 @@ func (r *UserRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("user not found")
+		return domainerr.NotFound(domainerr.CodeUserNotFound, "user not found")
+	}
+
+	return nil
+}
+
+// Update updates the email, role and disabled state of a user
+func (r *UserRepository) Update(ctx context.Context, user *models.User) error {
+	query := `
+		UPDATE users
+		SET email = $2, role = $3, disabled_at = $4, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at
+	`
+
+	err := r.db.pool.QueryRow(ctx, query, user.ID, user.Email, user.Role, user.DisabledAt).Scan(&user.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domainerr.NotFound(domainerr.CodeUserNotFound, "user not found")
+		}
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	return nil
+}
+
+// UpdatePassword replaces the password hash and sets whether the user must change it on next login
+func (r *UserRepository) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string, mustChange bool) error {
+	query := `
+		UPDATE users
+		SET password_hash = $2, must_change_password = $3, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	result, err := r.db.pool.Exec(ctx, query, id, passwordHash, mustChange)
+	if err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domainerr.NotFound(domainerr.CodeUserNotFound, "user not found")
 	}
 
 	return nil
@@ -30,8 +30,8 @@ func NewWarehouseRepository(pool *pgxpool.Pool) port.WarehouseRepository {
 // Create creates a new warehouse
 func (r *warehouseRepository) Create(ctx context.Context, warehouse *models.Warehouse) error {
 	query := `
-		INSERT INTO warehouses (id, name, address, notes, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO warehouses (id, name, address, geo_lat, geo_lng, notes, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 	`
 
 	now := time.Now()
@@ -43,6 +43,8 @@ func (r *warehouseRepository) Create(ctx context.Context, warehouse *models.Ware
 		warehouse.ID,
 		warehouse.Name,
 		warehouse.Address,
+		warehouse.GeoLat,
+		warehouse.GeoLng,
 		warehouse.Notes,
 		warehouse.CreatedAt,
 		warehouse.UpdatedAt,
@@ -54,7 +56,7 @@ func (r *warehouseRepository) Create(ctx context.Context, warehouse *models.Ware
 // GetByID retrieves a warehouse by ID, optionally including soft-deleted
 func (r *warehouseRepository) GetByID(ctx context.Context, id uuid.UUID, includeDeleted bool) (*models.Warehouse, error) {
 	query := `
-		SELECT id, name, address, notes, created_at, updated_at, deleted_at
+		SELECT id, name, address, geo_lat, geo_lng, notes, created_at, updated_at, deleted_at
 		FROM warehouses
 		WHERE id = $1
 	`
@@ -68,6 +70,8 @@ func (r *warehouseRepository) GetByID(ctx context.Context, id uuid.UUID, include
 		&warehouse.ID,
 		&warehouse.Name,
 		&warehouse.Address,
+		&warehouse.GeoLat,
+		&warehouse.GeoLng,
 		&warehouse.Notes,
 		&warehouse.CreatedAt,
 		&warehouse.UpdatedAt,
@@ -111,7 +115,7 @@ func (r *warehouseRepository) List(ctx context.Context, req models.WarehouseList
 	const offsetIncrement = 2
 	offsetParamNum := len(args) + offsetIncrement
 	mainQuery := fmt.Sprintf(`
-		SELECT id, name, address, notes, created_at, updated_at, deleted_at
+		SELECT id, name, address, geo_lat, geo_lng, notes, created_at, updated_at, deleted_at
 		%s
 		ORDER BY name
 		LIMIT $%d
@@ -136,6 +140,8 @@ func (r *warehouseRepository) List(ctx context.Context, req models.WarehouseList
 			&warehouse.ID,
 			&warehouse.Name,
 			&warehouse.Address,
+			&warehouse.GeoLat,
+			&warehouse.GeoLng,
 			&warehouse.Notes,
 			&warehouse.CreatedAt,
 			&warehouse.UpdatedAt,
@@ -163,14 +169,16 @@ func (r *warehouseRepository) List(ctx context.Context, req models.WarehouseList
 func (r *warehouseRepository) Update(ctx context.Context, warehouse *models.Warehouse) error {
 	query := `
 		UPDATE warehouses
-		SET name = $1, address = $2, notes = $3, updated_at = $4
-		WHERE id = $5
+		SET name = $1, address = $2, geo_lat = $3, geo_lng = $4, notes = $5, updated_at = $6
+		WHERE id = $7
 	`
 
 	warehouse.UpdatedAt = time.Now()
 	_, err := r.pool.Exec(ctx, query,
 		warehouse.Name,
 		warehouse.Address,
+		warehouse.GeoLat,
+		warehouse.GeoLng,
 		warehouse.Notes,
 		warehouse.UpdatedAt,
 		warehouse.ID,
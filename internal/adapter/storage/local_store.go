@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"eco-van-api/internal/port"
+)
+
+const (
+	localDirPerm  = 0o750
+	localFilePerm = 0o640
+)
+
+// LocalStore implements port.BlobStore on the local filesystem
+type LocalStore struct {
+	dir string
+}
+
+// NewLocalStore creates a blob store rooted at dir
+func NewLocalStore(dir string) *LocalStore {
+	return &LocalStore{dir: dir}
+}
+
+var _ port.BlobStore = (*LocalStore)(nil)
+
+// Put writes content to a file under the store directory
+func (s *LocalStore) Put(_ context.Context, key string, content io.Reader, _ string) (int64, error) {
+	fullPath, err := s.path(key)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(fullPath), localDirPerm); err != nil {
+		return 0, fmt.Errorf("failed to create blob directory: %w", err)
+	}
+
+	file, err := os.OpenFile(fullPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, localFilePerm)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create blob file: %w", err)
+	}
+
+	size, err := io.Copy(file, content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(fullPath)
+		return 0, fmt.Errorf("failed to write blob file: %w", err)
+	}
+
+	return size, nil
+}
+
+// Get opens the file stored under key
+func (s *LocalStore) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	fullPath, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(fullPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, port.ErrBlobNotFound
+		}
+		return nil, fmt.Errorf("failed to open blob file: %w", err)
+	}
+
+	return file, nil
+}
+
+// Delete removes the file stored under key
+func (s *LocalStore) Delete(_ context.Context, key string) error {
+	fullPath, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(fullPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove blob file: %w", err)
+	}
+	return nil
+}
+
+// path converts a slash-separated key into a path inside the store directory
+func (s *LocalStore) path(key string) (string, error) {
+	rel := filepath.FromSlash(key)
+	if !filepath.IsLocal(rel) {
+		return "", fmt.Errorf("invalid blob key: %q", key)
+	}
+	return filepath.Join(s.dir, rel), nil
+}
@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"eco-van-api/internal/port"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store := NewLocalStore(dir)
+	key := "equipment/abc/photo.jpg"
+
+	size, err := store.Put(ctx, key, strings.NewReader("jpeg-bytes"), "image/jpeg")
+	require.NoError(t, err)
+	assert.Equal(t, int64(len("jpeg-bytes")), size)
+	assert.FileExists(t, filepath.Join(dir, "equipment", "abc", "photo.jpg"))
+
+	reader, err := store.Get(ctx, key)
+	require.NoError(t, err)
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+	assert.Equal(t, "jpeg-bytes", string(data))
+
+	require.NoError(t, store.Delete(ctx, key))
+	_, err = os.Stat(filepath.Join(dir, "equipment", "abc", "photo.jpg"))
+	assert.True(t, os.IsNotExist(err))
+
+	_, err = store.Get(ctx, key)
+	assert.True(t, errors.Is(err, port.ErrBlobNotFound))
+	assert.NoError(t, store.Delete(ctx, key))
+}
+
+func TestLocalStore_RejectsEscapingKeys(t *testing.T) {
+	store := NewLocalStore(t.TempDir())
+
+	_, err := store.Put(context.Background(), "../outside.jpg", strings.NewReader("x"), "image/jpeg")
+	assert.Error(t, err)
+
+	_, err = store.Get(context.Background(), "/etc/passwd")
+	assert.Error(t, err)
+}
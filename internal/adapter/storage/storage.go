@@ -0,0 +1,25 @@
+// Package storage provides port.BlobStore implementations for uploaded files.
+package storage
+
+import (
+	"fmt"
+
+	"eco-van-api/internal/config"
+	"eco-van-api/internal/port"
+)
+
+// NewBlobStore creates the blob store selected by the photo storage configuration
+func NewBlobStore(cfg config.PhotosConfig) (port.BlobStore, error) {
+	switch cfg.Storage {
+	case config.PhotoStorageLocal, "":
+		return NewLocalStore(cfg.Dir), nil
+	case config.PhotoStorageS3:
+		store, err := NewS3Store(cfg.S3)
+		if err != nil {
+			return nil, err
+		}
+		return store, nil
+	default:
+		return nil, fmt.Errorf("unsupported photo storage backend: %q", cfg.Storage)
+	}
+}
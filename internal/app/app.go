@@ -8,9 +8,12 @@ import (
 	"syscall"
 	"time"
 
+	"eco-van-api/internal/adapter/auth"
 	"eco-van-api/internal/adapter/repo/pg"
 	"eco-van-api/internal/adapter/telemetry"
 	"eco-van-api/internal/config"
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/service"
 )
 
 const (
@@ -23,6 +26,8 @@ type App struct {
 	config    *config.Config
 	telemetry *telemetry.Manager
 	db        *pg.DB
+	generator *OrderGenerator
+	rotator   *KeyRotator
 }
 
 // New creates a new App instance
@@ -52,16 +57,57 @@ func New() (*App, error) {
 	// Run migrations using migrate command
 	// For now, we'll skip migrations in the Go code and rely on the migrate command
 
-	server := NewServer(cfg, telemetry, db)
+	jwtManager, rotator, err := newJWTManager(cfg, db, telemetry)
+	if err != nil {
+		return nil, err
+	}
+
+	server := NewServer(cfg, telemetry, db, jwtManager)
+
+	// Background generation of orders from recurring schedules
+	var generator *OrderGenerator
+	if cfg.Schedules.GeneratorEnabled {
+		scheduleService := service.NewOrderScheduleService(
+			pg.NewOrderScheduleRepository(db.GetPool()),
+			pg.NewClientObjectRepository(db.GetPool()),
+			pg.NewTransportRepository(db.GetPool()),
+			cfg.Schedules.AheadDays,
+		)
+		generator = NewOrderGenerator(scheduleService, cfg.Schedules.Interval, telemetry.Logger)
+	}
 
 	return &App{
 		server:    server,
 		config:    cfg,
 		telemetry: telemetry,
 		db:        db,
+		generator: generator,
+		rotator:   rotator,
 	}, nil
 }
 
+// newJWTManager creates the token manager for the configured algorithm. RS256/EdDSA keys are loaded,
+// and created on first start, before serving so tokens can be signed right away.
+func newJWTManager(cfg *config.Config, db *pg.DB, telemetry *telemetry.Manager) (*auth.JWTManager, *KeyRotator, error) {
+	if !models.IsAsymmetricJWTAlgorithm(cfg.Auth.SigningAlgorithm) {
+		return auth.NewJWTManager(cfg.Auth.JWTSecret, cfg.Auth.AccessTTL, cfg.Auth.RefreshTTL), nil, nil
+	}
+
+	keys := auth.NewKeySet()
+	signingKeyService := service.NewSigningKeyService(pg.NewSigningKeyRepository(db.GetPool()), keys, service.SigningKeySettings{
+		Algorithm:        cfg.Auth.SigningAlgorithm,
+		RotationInterval: cfg.Auth.KeyRotationInterval,
+		PublishAhead:     cfg.Auth.KeyPublishAhead,
+		VerifyFor:        max(cfg.Auth.AccessTTL, cfg.Auth.RefreshTTL),
+	})
+	if err := signingKeyService.Refresh(context.Background(), time.Now()); err != nil {
+		return nil, nil, fmt.Errorf("failed to load JWT signing keys: %w", err)
+	}
+
+	rotator := NewKeyRotator(signingKeyService, keyRefreshInterval, telemetry.Logger)
+	return auth.NewKeySetJWTManager(keys, cfg.Auth.AccessTTL, cfg.Auth.RefreshTTL), rotator, nil
+}
+
 // Run starts the application and handles graceful shutdown
 func Run(ctx context.Context) error {
 	app, err := New()
@@ -80,6 +126,16 @@ func Run(ctx context.Context) error {
 		}
 	}()
 
+	// Start order schedule generator; it stops when ctx is canceled
+	if app.generator != nil {
+		go app.generator.Run(ctx)
+	}
+
+	// Start JWT signing key rotation; it stops when ctx is canceled
+	if app.rotator != nil {
+		go app.rotator.Run(ctx)
+	}
+
 	// Wait for interrupt signal
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
@@ -95,6 +151,9 @@ func Run(ctx context.Context) error {
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), shutdownTimeout)
 	defer shutdownCancel()
 
+	// Stop background workers before closing their dependencies
+	cancel()
+
 	// Shutdown telemetry first
 	_ = app.telemetry.Shutdown(shutdownCtx)
 
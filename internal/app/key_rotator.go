@@ -0,0 +1,47 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"eco-van-api/internal/adapter/telemetry"
+	"eco-van-api/internal/service"
+)
+
+// keyRefreshInterval is how often each instance reloads the signing keys and rotates them when due.
+// It must stay well below JWT_KEY_PUBLISH_AHEAD so every instance knows a new key before it signs.
+const keyRefreshInterval = time.Minute
+
+// KeyRotator periodically rotates and reloads the JWT signing keys
+type KeyRotator struct {
+	signingKeyService *service.SigningKeyService
+	interval          time.Duration
+	logger            *telemetry.Logger
+}
+
+// NewKeyRotator creates a rotator that runs every interval
+func NewKeyRotator(signingKeyService *service.SigningKeyService, interval time.Duration, logger *telemetry.Logger) *KeyRotator {
+	return &KeyRotator{
+		signingKeyService: signingKeyService,
+		interval:          interval,
+		logger:            logger,
+	}
+}
+
+// Run refreshes the keys on every tick until ctx is canceled; the initial load happens at startup
+func (k *KeyRotator) Run(ctx context.Context) {
+	ticker := time.NewTicker(k.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := k.signingKeyService.Refresh(ctx, time.Now()); err != nil {
+			k.logger.Error("JWT signing key refresh failed", err)
+		}
+	}
+}
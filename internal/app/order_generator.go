@@ -0,0 +1,53 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"eco-van-api/internal/adapter/telemetry"
+	"eco-van-api/internal/port"
+)
+
+// OrderGenerator periodically materializes orders from recurring order schedules
+type OrderGenerator struct {
+	scheduleService port.OrderScheduleService
+	interval        time.Duration
+	logger          *telemetry.Logger
+}
+
+// NewOrderGenerator creates a generator that runs every interval
+func NewOrderGenerator(scheduleService port.OrderScheduleService, interval time.Duration, logger *telemetry.Logger) *OrderGenerator {
+	return &OrderGenerator{
+		scheduleService: scheduleService,
+		interval:        interval,
+		logger:          logger,
+	}
+}
+
+// Run generates orders immediately and then on every tick until ctx is canceled
+func (g *OrderGenerator) Run(ctx context.Context) {
+	ticker := time.NewTicker(g.interval)
+	defer ticker.Stop()
+
+	for {
+		g.runOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce performs a single generation pass and logs its outcome
+func (g *OrderGenerator) runOnce(ctx context.Context) {
+	created, err := g.scheduleService.GenerateOrders(ctx, time.Now())
+	if err != nil {
+		g.logger.Error("Order schedule generation failed", err)
+	}
+	if created > 0 {
+		g.logger.Info(fmt.Sprintf("Generated %d orders from schedules", created))
+	}
+}
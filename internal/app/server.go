@@ -8,12 +8,18 @@ import (
 
 	"eco-van-api/internal/adapter/auth"
 	httpmiddleware "eco-van-api/internal/adapter/http"
+	"eco-van-api/internal/adapter/oidc"
+	"eco-van-api/internal/adapter/ratelimit"
 	"eco-van-api/internal/adapter/repo/pg"
+	"eco-van-api/internal/adapter/storage"
 	"eco-van-api/internal/adapter/telemetry"
 	appconfig "eco-van-api/internal/config"
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/port"
 	"eco-van-api/internal/service"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
 )
 
 // Server represents the HTTP server
@@ -26,23 +32,29 @@ type Server struct {
 }
 
 // NewServer creates a new Server instance
-func NewServer(cfg *appconfig.Config, telemetry *telemetry.Manager, db *pg.DB) *Server {
+func NewServer(cfg *appconfig.Config, telemetry *telemetry.Manager, db *pg.DB, jwtManager *auth.JWTManager) *Server {
 	router := chi.NewRouter()
 
 	// Create middleware instance
 	mw := httpmiddleware.NewMiddleware(telemetry.Logger, telemetry.Tracer, telemetry.Metrics)
 
+	// Behind a trusted proxy, take the client IP from X-Forwarded-For/X-Real-IP for login throttling
+	if cfg.HTTP.TrustProxyHeaders {
+		router.Use(middleware.RealIP)
+	}
+
 	// Add custom middleware
 	router.Use(mw.RequestID())
+	router.Use(mw.Language())
 	router.Use(mw.Recover())
 	router.Use(mw.AccessLog())
 	router.Use(mw.Trace())
 	router.Use(mw.CORS(cfg.HTTP.CORSOrigins))
-	router.Use(mw.RateLimit())
+	router.Use(mw.RateLimit(newRateLimiter(cfg, db, jwtManager)))
 	router.Use(mw.MetricsInFlight())
 
 	// Setup routes
-	setupRoutes(router, telemetry, db, cfg)
+	setupRoutes(router, telemetry, db, cfg, jwtManager)
 
 	server := &http.Server{
 		Addr:         cfg.HTTP.Addr,
@@ -61,8 +73,51 @@ func NewServer(cfg *appconfig.Config, telemetry *telemetry.Manager, db *pg.DB) *
 	}
 }
 
+// newAuthService wires the authentication service with its repositories and settings
+func newAuthService(cfg *appconfig.Config, db *pg.DB, userRepo *pg.UserRepository, jwtManager *auth.JWTManager) *service.AuthService {
+	return service.NewAuthService(service.AuthRepositories{
+		Users:          userRepo,
+		RefreshTokens:  pg.NewRefreshTokenRepository(db.GetPool()),
+		PasswordResets: pg.NewPasswordResetRepository(db.GetPool()),
+		LoginSecurity:  pg.NewLoginSecurityRepository(db.GetPool()),
+		TwoFactor:      pg.NewTwoFactorRepository(db.GetPool()),
+	}, jwtManager, service.AuthSettings{
+		PasswordResetTTL: cfg.Auth.PasswordResetTTL,
+		LoginThrottle:    cfg.Auth.LoginThrottle,
+		TwoFactor:        cfg.Auth.TwoFactor,
+	})
+}
+
+// newAuthMiddleware wires the authentication middleware with the user state reader and API key authentication
+func newAuthMiddleware(jwtManager *auth.JWTManager, db *pg.DB) *httpmiddleware.AuthMiddleware {
+	apiKeyService := service.NewAPIKeyService(pg.NewAPIKeyRepository(db.GetPool()))
+	return httpmiddleware.NewAuthMiddleware(jwtManager, pg.NewUserRepository(db), apiKeyService)
+}
+
+// newRateLimiter wires the request rate limiter with its bucket store and budgets; nil disables rate limiting
+func newRateLimiter(cfg *appconfig.Config, db *pg.DB, jwtManager *auth.JWTManager) *httpmiddleware.RateLimiter {
+	if !cfg.RateLimit.Enabled {
+		return nil
+	}
+
+	var store port.RateLimitStore = ratelimit.NewMemoryStore()
+	if cfg.RateLimit.Store == appconfig.RateLimitStorePostgres {
+		store = pg.NewRateLimitStore(db.GetPool())
+	}
+
+	apiKeyService := service.NewAPIKeyService(pg.NewAPIKeyRepository(db.GetPool()))
+	limiter := httpmiddleware.NewRateLimiter(store, cfg.RateLimit.Default, jwtManager, apiKeyService).
+		Limit(httpmiddleware.RateLimitScopeLogin, cfg.RateLimit.Login, "/api/v1/auth/login", "/api/v1/auth/login/2fa").
+		Limit(httpmiddleware.RateLimitScopeRefresh, cfg.RateLimit.Refresh, "/api/v1/auth/refresh").
+		Exempt("/healthz", "/readyz", "/metrics")
+	if !cfg.RateLimit.FailOpen {
+		limiter.FailClosed()
+	}
+	return limiter
+}
+
 // setupRoutes configures the application routes
-func setupRoutes(router chi.Router, telemetry *telemetry.Manager, db *pg.DB, cfg *appconfig.Config) {
+func setupRoutes(router chi.Router, telemetry *telemetry.Manager, db *pg.DB, cfg *appconfig.Config, jwtManager *auth.JWTManager) {
 	// Health check endpoint
 	router.Get("/healthz", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -96,6 +151,9 @@ func setupRoutes(router chi.Router, telemetry *telemetry.Manager, db *pg.DB, cfg
 		fmt.Fprintf(w, `{"message":"Eco Van API","version":"1.0.0"}`)
 	})
 
+	// Public token signing keys, so other services can verify our access tokens
+	router.Get("/.well-known/jwks.json", httpmiddleware.NewJWKSHandler(jwtManager).GetJWKS)
+
 	// Metrics endpoint
 	if telemetry.IsMetricsEnabled() {
 		router.Get("/metrics", telemetry.Metrics.GetHandler().ServeHTTP)
@@ -108,7 +166,8 @@ func setupRoutes(router chi.Router, telemetry *telemetry.Manager, db *pg.DB, cfg
 			w.Header().Set("Content-Type", "application/json")
 			w.WriteHeader(http.StatusOK)
 			endpoints := `["/healthz","/metrics","/auth/login","/auth/refresh","/auth/me","/users",` +
-				`"/clients","/warehouses","/equipment","/drivers","/transport","/orders","/docs","/docs/ui"]`
+				`"/clients","/warehouses","/equipment","/drivers","/transport","/orders","/order-schedules","/cancellation-reasons",` +
+				`"/routes","/dispatch","/me/driver","/photos","/api-keys","/docs","/docs/ui"]`
 			fmt.Fprintf(w, `{"message":"API v1","endpoints":%s}`, endpoints)
 		})
 
@@ -188,53 +247,106 @@ func setupRoutes(router chi.Router, telemetry *telemetry.Manager, db *pg.DB, cfg
 		r.Route("/auth", func(r chi.Router) {
 			// Create auth handler
 			userRepo := pg.NewUserRepository(db)
-			jwtManager := auth.NewDefaultJWTManager(cfg.Auth.JWTSecret)
-			authService := service.NewAuthService(userRepo, jwtManager)
+			authService := newAuthService(cfg, db, userRepo, jwtManager)
 			authHandler := httpmiddleware.NewAuthHandler(authService)
 
 			// Public auth endpoints
 			r.Post("/login", authHandler.Login)
+			r.Post("/login/2fa", authHandler.LoginTwoFactor)
 			r.Post("/refresh", authHandler.Refresh)
+			r.Post("/logout", authHandler.Logout)
+			r.Post("/password/reset", authHandler.ResetPassword)
+
+			// Single sign-on with the configured OpenID Connect provider
+			if cfg.OIDC.Enabled() {
+				oidcService := service.NewOIDCService(
+					oidc.NewProvider(cfg.OIDC),
+					pg.NewOIDCRepository(db.GetPool()),
+					userRepo,
+					authService,
+					service.OIDCSettings{Roles: cfg.OIDC.Roles, DefaultRole: cfg.OIDC.DefaultRole, StateTTL: cfg.OIDC.StateTTL},
+				)
+				oidcHandler := httpmiddleware.NewOIDCHandler(oidcService)
+				r.Post("/oidc/authorize", oidcHandler.Authorize)
+				r.Post("/oidc/callback", oidcHandler.Callback)
+			}
 		})
 
 		// Protected auth endpoints
 		r.Route("/auth/me", func(r chi.Router) {
 			// Create auth handler and middleware
 			userRepo := pg.NewUserRepository(db)
-			jwtManager := auth.NewDefaultJWTManager(cfg.Auth.JWTSecret)
-			authService := service.NewAuthService(userRepo, jwtManager)
+			authService := newAuthService(cfg, db, userRepo, jwtManager)
 			authHandler := httpmiddleware.NewAuthHandler(authService)
-			authMiddleware := httpmiddleware.NewAuthMiddleware(jwtManager)
+			authMiddleware := newAuthMiddleware(jwtManager, db)
 
-			// Require authentication
-			r.Use(authMiddleware.RequireAuth)
-			r.Get("/", authHandler.GetCurrentUser)
+			// Require authentication; users who must change their password can still reach these endpoints
+			r.Group(func(r chi.Router) {
+				r.Use(authMiddleware.RequireAuthAllowingPasswordChange)
+				r.Get("/", authHandler.GetCurrentUser)
+				r.Post("/password", authHandler.ChangePassword)
+			})
+
+			// Two-factor settings; users who must enroll before getting a session can still reach these endpoints
+			r.Route("/2fa", func(r chi.Router) {
+				r.Use(authMiddleware.RequireAuthAllowingTwoFactorSetup)
+				r.Get("/", authHandler.GetTwoFactorStatus)
+				r.Post("/enroll", authHandler.EnrollTwoFactor)
+				r.Post("/confirm", authHandler.ConfirmTwoFactor)
+				r.Post("/recovery-codes", authHandler.RegenerateRecoveryCodes)
+				r.Post("/disable", authHandler.DisableTwoFactor)
+			})
 		})
 
 		// Protected user management endpoints
 		r.Route("/users", func(r chi.Router) {
 			// Create auth handler and middleware
 			userRepo := pg.NewUserRepository(db)
-			jwtManager := auth.NewDefaultJWTManager(cfg.Auth.JWTSecret)
-			authService := service.NewAuthService(userRepo, jwtManager)
+			authService := newAuthService(cfg, db, userRepo, jwtManager)
 			authHandler := httpmiddleware.NewAuthHandler(authService)
-			authMiddleware := httpmiddleware.NewAuthMiddleware(jwtManager)
-			rbacMiddleware := httpmiddleware.NewRBACMiddleware()
+			authMiddleware := newAuthMiddleware(jwtManager, db)
+			rbacMiddleware := httpmiddleware.NewRBACMiddlewareWithPermissions(cfg.RBAC.Permissions)
 
-			// Require authentication for all user endpoints
-			r.Use(authMiddleware.RequireAuth)
+			// Require user authentication for all user endpoints; API keys cannot manage accounts
+			r.Use(authMiddleware.RequireUserAuth)
 
-			// Read endpoints - accessible by all authenticated users (ADMIN, DISPATCHER, DRIVER, VIEWER)
-			r.With(rbacMiddleware.RequireReadAccess).Group(func(r chi.Router) {
+			// Read endpoints - accessible by all roles in the default permission matrix
+			r.With(rbacMiddleware.RequirePermission(models.ResourceUsers, models.PermissionActionRead)).Group(func(r chi.Router) {
 				r.Get("/", authHandler.ListUsers)
 				r.Get("/{id}", authHandler.GetUser)
 			})
 
-			// Write endpoints - ADMIN only
-			r.With(rbacMiddleware.RequireWriteAccess).Group(func(r chi.Router) {
+			// Write endpoints - ADMIN only, the permission matrix never grants user management to other roles
+			r.With(rbacMiddleware.RequirePermission(models.ResourceUsers, models.PermissionActionWrite)).Group(func(r chi.Router) {
 				r.Post("/", authHandler.CreateUser)
-				r.Delete("/{id}", authHandler.DeleteUser)
+				r.Put("/{id}", authHandler.ReplaceUser)
+				r.Patch("/{id}", authHandler.PatchUser)
+				r.Post("/{id}/password-reset", authHandler.IssuePasswordReset)
+				r.Post("/{id}/unlock", authHandler.UnlockUser)
+				r.Delete("/{id}/2fa", authHandler.ResetTwoFactor)
+				// The login attempt log is a security record, so reading it needs the ADMIN-only write permission
+				r.Get("/login-attempts", authHandler.ListLoginAttempts)
 			})
+			r.With(rbacMiddleware.RequirePermission(models.ResourceUsers, models.PermissionActionDelete)).Delete("/{id}", authHandler.DeleteUser)
+		})
+
+		// Protected API key management endpoints
+		r.Route("/api-keys", func(r chi.Router) {
+			// Create API key handler and middleware
+			apiKeyService := service.NewAPIKeyService(pg.NewAPIKeyRepository(db.GetPool()))
+			apiKeyHandler := httpmiddleware.NewAPIKeyHandler(apiKeyService)
+
+			authMiddleware := newAuthMiddleware(jwtManager, db)
+			rbacMiddleware := httpmiddleware.NewRBACMiddlewareWithPermissions(cfg.RBAC.Permissions)
+
+			// API keys are managed by ADMIN users only, never by another API key
+			r.Use(authMiddleware.RequireUserAuth)
+			r.Use(rbacMiddleware.RequireAdminRole)
+
+			r.Get("/", apiKeyHandler.ListAPIKeys)
+			r.Post("/", apiKeyHandler.CreateAPIKey)
+			r.Get("/{id}", apiKeyHandler.GetAPIKey)
+			r.Delete("/{id}", apiKeyHandler.RevokeAPIKey)
 		})
 
 		// Protected client management endpoints
@@ -243,23 +355,26 @@ func setupRoutes(router chi.Router, telemetry *telemetry.Manager, db *pg.DB, cfg
 			clientRepo := pg.NewClientRepository(db.GetPool())
 			clientService := service.NewClientService(clientRepo)
 			clientHandler := httpmiddleware.NewClientHandler(clientService)
-			clientJWTManager := auth.NewDefaultJWTManager(cfg.Auth.JWTSecret)
-			authMiddleware := httpmiddleware.NewAuthMiddleware(clientJWTManager)
-			rbacMiddleware := httpmiddleware.NewRBACMiddleware()
+			authMiddleware := newAuthMiddleware(jwtManager, db)
+			rbacMiddleware := httpmiddleware.NewRBACMiddlewareWithPermissions(cfg.RBAC.Permissions)
 
 			// Require authentication for all client endpoints
 			r.Use(authMiddleware.RequireAuth)
 
-			// Read endpoints - accessible by all authenticated users
-			r.With(rbacMiddleware.RequireReadAccess).Group(func(r chi.Router) {
+			// Read endpoints - roles granted read on the resource
+			r.With(rbacMiddleware.RequirePermission(models.ResourceClients, models.PermissionActionRead)).Group(func(r chi.Router) {
 				r.Get("/", clientHandler.ListClients)
 				r.Get("/{id}", clientHandler.GetClient)
 			})
 
-			// Write endpoints - ADMIN and DISPATCHER only
-			r.With(rbacMiddleware.RequireWriteAccess).Group(func(r chi.Router) {
+			// Write endpoints - roles granted write on the resource
+			r.With(rbacMiddleware.RequirePermission(models.ResourceClients, models.PermissionActionWrite)).Group(func(r chi.Router) {
 				r.Post("/", clientHandler.CreateClient)
 				r.Put("/{id}", clientHandler.UpdateClient)
+			})
+
+			// Delete and restore endpoints - roles granted delete on the resource
+			r.With(rbacMiddleware.RequirePermission(models.ResourceClients, models.PermissionActionDelete)).Group(func(r chi.Router) {
 				r.Delete("/{id}", clientHandler.DeleteClient)
 				r.Post("/{id}/restore", clientHandler.RestoreClient)
 			})
@@ -271,16 +386,20 @@ func setupRoutes(router chi.Router, telemetry *telemetry.Manager, db *pg.DB, cfg
 				clientObjectService := service.NewClientObjectService(clientObjectRepo, clientRepo)
 				clientObjectHandler := httpmiddleware.NewClientObjectHandler(clientObjectService)
 
-				// Read endpoints - accessible by all authenticated users
-				r.With(rbacMiddleware.RequireReadAccess).Group(func(r chi.Router) {
+				// Read endpoints - roles granted read on the resource
+				r.With(rbacMiddleware.RequirePermission(models.ResourceClientObjects, models.PermissionActionRead)).Group(func(r chi.Router) {
 					r.Get("/", clientObjectHandler.ListClientObjects)
 					r.Get("/{id}", clientObjectHandler.GetClientObject)
 				})
 
-				// Write endpoints - ADMIN and DISPATCHER only
-				r.With(rbacMiddleware.RequireWriteAccess).Group(func(r chi.Router) {
+				// Write endpoints - roles granted write on the resource
+				r.With(rbacMiddleware.RequirePermission(models.ResourceClientObjects, models.PermissionActionWrite)).Group(func(r chi.Router) {
 					r.Post("/", clientObjectHandler.CreateClientObject)
 					r.Put("/{id}", clientObjectHandler.UpdateClientObject)
+				})
+
+				// Delete and restore endpoints - roles granted delete on the resource
+				r.With(rbacMiddleware.RequirePermission(models.ResourceClientObjects, models.PermissionActionDelete)).Group(func(r chi.Router) {
 					r.Delete("/{id}", clientObjectHandler.DeleteClientObject)
 					r.Post("/{id}/restore", clientObjectHandler.RestoreClientObject)
 				})
@@ -294,23 +413,26 @@ func setupRoutes(router chi.Router, telemetry *telemetry.Manager, db *pg.DB, cfg
 			warehouseRepo := pg.NewWarehouseRepository(db.GetPool())
 			warehouseService := service.NewWarehouseService(warehouseRepo)
 			warehouseHandler := httpmiddleware.NewWarehouseHandler(warehouseService)
-			warehouseJWTManager := auth.NewDefaultJWTManager(cfg.Auth.JWTSecret)
-			authMiddleware := httpmiddleware.NewAuthMiddleware(warehouseJWTManager)
-			rbacMiddleware := httpmiddleware.NewRBACMiddleware()
+			authMiddleware := newAuthMiddleware(jwtManager, db)
+			rbacMiddleware := httpmiddleware.NewRBACMiddlewareWithPermissions(cfg.RBAC.Permissions)
 
 			// Require authentication for all warehouse endpoints
 			r.Use(authMiddleware.RequireAuth)
 
-			// Read endpoints - accessible by all authenticated users
-			r.With(rbacMiddleware.RequireReadAccess).Group(func(r chi.Router) {
+			// Read endpoints - roles granted read on the resource
+			r.With(rbacMiddleware.RequirePermission(models.ResourceWarehouses, models.PermissionActionRead)).Group(func(r chi.Router) {
 				r.Get("/", warehouseHandler.ListWarehouses)
 				r.Get("/{id}", warehouseHandler.GetWarehouse)
 			})
 
-			// Write endpoints - ADMIN and DISPATCHER only
-			r.With(rbacMiddleware.RequireWriteAccess).Group(func(r chi.Router) {
+			// Write endpoints - roles granted write on the resource
+			r.With(rbacMiddleware.RequirePermission(models.ResourceWarehouses, models.PermissionActionWrite)).Group(func(r chi.Router) {
 				r.Post("/", warehouseHandler.CreateWarehouse)
 				r.Put("/{id}", warehouseHandler.UpdateWarehouse)
+			})
+
+			// Delete and restore endpoints - roles granted delete on the resource
+			r.With(rbacMiddleware.RequirePermission(models.ResourceWarehouses, models.PermissionActionDelete)).Group(func(r chi.Router) {
 				r.Delete("/{id}", warehouseHandler.DeleteWarehouse)
 				r.Post("/{id}/restore", warehouseHandler.RestoreWarehouse)
 			})
@@ -323,23 +445,26 @@ func setupRoutes(router chi.Router, telemetry *telemetry.Manager, db *pg.DB, cfg
 			equipmentRepo := pg.NewEquipmentRepository(db.GetPool())
 			equipmentService := service.NewEquipmentService(equipmentRepo)
 			equipmentHandler := httpmiddleware.NewEquipmentHandler(equipmentService)
-			equipmentJWTManager := auth.NewDefaultJWTManager(cfg.Auth.JWTSecret)
-			authMiddleware := httpmiddleware.NewAuthMiddleware(equipmentJWTManager)
-			rbacMiddleware := httpmiddleware.NewRBACMiddleware()
+			authMiddleware := newAuthMiddleware(jwtManager, db)
+			rbacMiddleware := httpmiddleware.NewRBACMiddlewareWithPermissions(cfg.RBAC.Permissions)
 
 			// Require authentication for all equipment endpoints
 			r.Use(authMiddleware.RequireAuth)
 
-			// Read endpoints - accessible by all authenticated users
-			r.With(rbacMiddleware.RequireReadAccess).Group(func(r chi.Router) {
+			// Read endpoints - roles granted read on the resource
+			r.With(rbacMiddleware.RequirePermission(models.ResourceEquipment, models.PermissionActionRead)).Group(func(r chi.Router) {
 				r.Get("/", equipmentHandler.ListEquipment)
 				r.Get("/{id}", equipmentHandler.GetEquipment)
 			})
 
-			// Write endpoints - ADMIN and DISPATCHER only
-			r.With(rbacMiddleware.RequireWriteAccess).Group(func(r chi.Router) {
+			// Write endpoints - roles granted write on the resource
+			r.With(rbacMiddleware.RequirePermission(models.ResourceEquipment, models.PermissionActionWrite)).Group(func(r chi.Router) {
 				r.Post("/", equipmentHandler.CreateEquipment)
 				r.Put("/{id}", equipmentHandler.UpdateEquipment)
+			})
+
+			// Delete and restore endpoints - roles granted delete on the resource
+			r.With(rbacMiddleware.RequirePermission(models.ResourceEquipment, models.PermissionActionDelete)).Group(func(r chi.Router) {
 				r.Delete("/{id}", equipmentHandler.DeleteEquipment)
 				r.Post("/{id}/restore", equipmentHandler.RestoreEquipment)
 			})
@@ -350,28 +475,34 @@ func setupRoutes(router chi.Router, telemetry *telemetry.Manager, db *pg.DB, cfg
 		r.Route("/drivers", func(r chi.Router) {
 			// Create driver handler and middleware
 			driverRepo := pg.NewDriverRepository(db.GetPool())
-			driverService := service.NewDriverService(driverRepo)
+			userRepo := pg.NewUserRepository(db)
+			driverService := service.NewDriverService(driverRepo, userRepo)
 			driverHandler := httpmiddleware.NewDriverHandler(driverService)
-			driverJWTManager := auth.NewDefaultJWTManager(cfg.Auth.JWTSecret)
-			authMiddleware := httpmiddleware.NewAuthMiddleware(driverJWTManager)
-			rbacMiddleware := httpmiddleware.NewRBACMiddleware()
+			authMiddleware := newAuthMiddleware(jwtManager, db)
+			rbacMiddleware := httpmiddleware.NewRBACMiddlewareWithPermissions(cfg.RBAC.Permissions)
 
 			// Require authentication for all driver endpoints
 			r.Use(authMiddleware.RequireAuth)
 
-			// Read endpoints - accessible by all authenticated users
-			r.With(rbacMiddleware.RequireReadAccess).Group(func(r chi.Router) {
+			// Read endpoints - roles granted read on the resource
+			r.With(rbacMiddleware.RequirePermission(models.ResourceDrivers, models.PermissionActionRead)).Group(func(r chi.Router) {
 				r.Get("/", driverHandler.ListDrivers)
 				r.Get("/{id}", driverHandler.GetDriver)
 				r.Get("/available", driverHandler.ListAvailableDrivers)
 			})
 
-			// Write endpoints - ADMIN and DISPATCHER only
-			r.With(rbacMiddleware.RequireWriteAccess).Group(func(r chi.Router) {
+			// Write endpoints - roles granted write on the resource
+			r.With(rbacMiddleware.RequirePermission(models.ResourceDrivers, models.PermissionActionWrite)).Group(func(r chi.Router) {
 				r.Post("/", driverHandler.CreateDriver)
 				r.Put("/{id}", driverHandler.UpdateDriver)
+				r.Put("/{id}/link-user", driverHandler.LinkUser)
+			})
+
+			// Delete, restore and user unlink endpoints - roles granted delete on the resource
+			r.With(rbacMiddleware.RequirePermission(models.ResourceDrivers, models.PermissionActionDelete)).Group(func(r chi.Router) {
 				r.Delete("/{id}", driverHandler.DeleteDriver)
 				r.Post("/{id}/restore", driverHandler.RestoreDriver)
+				r.Delete("/{id}/user", driverHandler.UnlinkUser)
 			})
 		})
 
@@ -385,28 +516,39 @@ func setupRoutes(router chi.Router, telemetry *telemetry.Manager, db *pg.DB, cfg
 			transportService := service.NewTransportService(transportRepo, driverRepo, equipmentRepo)
 			transportHandler := httpmiddleware.NewTransportHandler(transportService)
 
-			transportJWTManager := auth.NewDefaultJWTManager(cfg.Auth.JWTSecret)
-			authMiddleware := httpmiddleware.NewAuthMiddleware(transportJWTManager)
-			rbacMiddleware := httpmiddleware.NewRBACMiddleware()
+			authMiddleware := newAuthMiddleware(jwtManager, db)
+			rbacMiddleware := httpmiddleware.NewRBACMiddlewareWithPermissions(cfg.RBAC.Permissions)
 
 			// Require authentication for all transport endpoints
 			r.Use(authMiddleware.RequireAuth)
 
-			// Read endpoints - accessible by all authenticated users
-			r.With(rbacMiddleware.RequireReadAccess).Group(func(r chi.Router) {
+			// Read endpoints - roles granted read on the resource
+			r.With(rbacMiddleware.RequirePermission(models.ResourceTransport, models.PermissionActionRead)).Group(func(r chi.Router) {
 				r.Get("/", transportHandler.ListItems)
 				r.Get("/{id}", transportHandler.GetItem)
 				r.Get("/available", transportHandler.GetAvailable)
 			})
 
-			// Write endpoints - ADMIN and DISPATCHER only
-			r.With(rbacMiddleware.RequireWriteAccess).Group(func(r chi.Router) {
+			// Write endpoints - roles granted write on the resource
+			r.With(rbacMiddleware.RequirePermission(models.ResourceTransport, models.PermissionActionWrite)).Group(func(r chi.Router) {
 				r.Post("/", transportHandler.CreateItem)
 				r.Put("/{id}", transportHandler.UpdateItem)
+			})
+
+			// Delete and restore endpoints - roles granted delete on the resource
+			r.With(rbacMiddleware.RequirePermission(models.ResourceTransport, models.PermissionActionDelete)).Group(func(r chi.Router) {
 				r.Delete("/{id}", transportHandler.DeleteItem)
 				r.Post("/{id}/restore", transportHandler.RestoreItem)
+			})
+
+			// Driver and equipment assignment - roles granted write on transport assignments
+			r.With(rbacMiddleware.RequirePermission(models.ResourceTransportAssignments, models.PermissionActionWrite)).Group(func(r chi.Router) {
 				r.Put("/{id}/assign-driver", transportHandler.AssignDriver)
 				r.Put("/{id}/assign-equipment", transportHandler.AssignEquipment)
+			})
+
+			// Unassignment - roles granted delete on transport assignments
+			r.With(rbacMiddleware.RequirePermission(models.ResourceTransportAssignments, models.PermissionActionDelete)).Group(func(r chi.Router) {
 				r.Delete("/{id}/drivers", transportHandler.UnassignDriver)
 			})
 		})
@@ -419,31 +561,195 @@ func setupRoutes(router chi.Router, telemetry *telemetry.Manager, db *pg.DB, cfg
 			clientRepo := pg.NewClientRepository(db.GetPool())
 			clientObjRepo := pg.NewClientObjectRepository(db.GetPool())
 			transportRepo := pg.NewTransportRepository(db.GetPool())
-			orderService := service.NewOrderService(orderRepo, clientRepo, clientObjRepo, transportRepo)
+			reasonRepo := pg.NewCancellationReasonRepository(db.GetPool())
+			orderService := service.NewOrderService(orderRepo, clientRepo, clientObjRepo, transportRepo, reasonRepo)
 			orderHandler := httpmiddleware.NewOrderHandler(orderService)
+			equipmentRepo := pg.NewEquipmentRepository(db.GetPool())
+			warehouseRepo := pg.NewWarehouseRepository(db.GetPool())
+			itemService := service.NewOrderItemService(orderRepo, equipmentRepo, warehouseRepo, transportRepo)
+			itemHandler := httpmiddleware.NewOrderItemHandler(itemService)
 
-			orderJWTManager := auth.NewDefaultJWTManager(cfg.Auth.JWTSecret)
-			authMiddleware := httpmiddleware.NewAuthMiddleware(orderJWTManager)
-			rbacMiddleware := httpmiddleware.NewRBACMiddleware()
+			authMiddleware := newAuthMiddleware(jwtManager, db)
+			rbacMiddleware := httpmiddleware.NewRBACMiddlewareWithPermissions(cfg.RBAC.Permissions)
 
 			// Require authentication for all order endpoints
 			r.Use(authMiddleware.RequireAuth)
 
-			// Read endpoints - accessible by all authenticated users
-			r.With(rbacMiddleware.RequireReadAccess).Group(func(r chi.Router) {
+			// Read endpoints - roles granted read on the resource
+			r.With(rbacMiddleware.RequirePermission(models.ResourceOrders, models.PermissionActionRead)).Group(func(r chi.Router) {
 				r.Get("/", orderHandler.ListOrders)
 				r.Get("/{id}", orderHandler.GetOrder)
+				r.Get("/{id}/history", orderHandler.GetOrderHistory)
+				r.Get("/{id}/items", itemHandler.ListOrderItems)
+				r.Get("/stats/cancellations", orderHandler.GetCancellationStats)
 			})
 
-			// Write endpoints - ADMIN and DISPATCHER only
-			r.With(rbacMiddleware.RequireWriteAccess).Group(func(r chi.Router) {
+			// Write endpoints - roles granted write on the resource
+			r.With(rbacMiddleware.RequirePermission(models.ResourceOrders, models.PermissionActionWrite)).Group(func(r chi.Router) {
 				r.Post("/", orderHandler.CreateOrder)
 				r.Put("/{id}", orderHandler.UpdateOrder)
-				r.Delete("/{id}", orderHandler.DeleteOrder)
-				r.Post("/{id}/restore", orderHandler.RestoreOrder)
 				r.Put("/{id}/status", orderHandler.UpdateOrderStatus)
 				r.Put("/{id}/assign-transport", orderHandler.AssignTransport)
+				r.Put("/{id}/items", itemHandler.ReplaceOrderItems)
+			})
+
+			// Delete and restore endpoints - roles granted delete on the resource
+			r.With(rbacMiddleware.RequirePermission(models.ResourceOrders, models.PermissionActionDelete)).Group(func(r chi.Router) {
+				r.Delete("/{id}", orderHandler.DeleteOrder)
+				r.Post("/{id}/restore", orderHandler.RestoreOrder)
+			})
+		})
+
+		// Protected order schedule endpoints
+		//nolint:dupl // Similar route pattern across resources but with different handlers and services
+		r.Route("/order-schedules", func(r chi.Router) {
+			// Create order schedule handler and middleware
+			scheduleRepo := pg.NewOrderScheduleRepository(db.GetPool())
+			clientObjRepo := pg.NewClientObjectRepository(db.GetPool())
+			transportRepo := pg.NewTransportRepository(db.GetPool())
+			scheduleService := service.NewOrderScheduleService(scheduleRepo, clientObjRepo, transportRepo, cfg.Schedules.AheadDays)
+			scheduleHandler := httpmiddleware.NewOrderScheduleHandler(scheduleService)
+
+			authMiddleware := newAuthMiddleware(jwtManager, db)
+			rbacMiddleware := httpmiddleware.NewRBACMiddlewareWithPermissions(cfg.RBAC.Permissions)
+
+			// Require authentication for all order schedule endpoints
+			r.Use(authMiddleware.RequireAuth)
+
+			// Read endpoints - roles granted read on the resource
+			r.With(rbacMiddleware.RequirePermission(models.ResourceOrderSchedules, models.PermissionActionRead)).Group(func(r chi.Router) {
+				r.Get("/", scheduleHandler.ListOrderSchedules)
+				r.Get("/{id}", scheduleHandler.GetOrderSchedule)
+			})
+
+			// Write endpoints - roles granted write on the resource
+			r.With(rbacMiddleware.RequirePermission(models.ResourceOrderSchedules, models.PermissionActionWrite)).Group(func(r chi.Router) {
+				r.Post("/", scheduleHandler.CreateOrderSchedule)
+				r.Put("/{id}", scheduleHandler.UpdateOrderSchedule)
+			})
+
+			// Delete endpoints - roles granted delete on the resource
+			r.With(rbacMiddleware.RequirePermission(models.ResourceOrderSchedules, models.PermissionActionDelete)).Group(func(r chi.Router) {
+				r.Delete("/{id}", scheduleHandler.DeleteOrderSchedule)
+			})
+		})
+
+		// Protected cancellation reason endpoints
+		r.Route("/cancellation-reasons", func(r chi.Router) {
+			// Create cancellation reason handler and middleware
+			reasonRepo := pg.NewCancellationReasonRepository(db.GetPool())
+			reasonService := service.NewCancellationReasonService(reasonRepo)
+			reasonHandler := httpmiddleware.NewCancellationReasonHandler(reasonService)
+
+			authMiddleware := newAuthMiddleware(jwtManager, db)
+			rbacMiddleware := httpmiddleware.NewRBACMiddlewareWithPermissions(cfg.RBAC.Permissions)
+
+			// Require authentication for all cancellation reason endpoints
+			r.Use(authMiddleware.RequireAuth)
+
+			// Read endpoints - roles granted read on the resource
+			r.With(rbacMiddleware.RequirePermission(models.ResourceCancellationReasons, models.PermissionActionRead)).
+				Get("/", reasonHandler.ListCancellationReasons)
+
+			// Write endpoints - the taxonomy is managed by ADMIN in the default permission matrix
+			r.With(rbacMiddleware.RequirePermission(models.ResourceCancellationReasons, models.PermissionActionWrite)).Group(func(r chi.Router) {
+				r.Post("/", reasonHandler.CreateCancellationReason)
+				r.Put("/{code}", reasonHandler.UpdateCancellationReason)
+			})
+		})
+
+		// Protected route planning endpoints
+		r.Route("/routes", func(r chi.Router) {
+			// Create route handler and middleware
+			routeRepo := pg.NewRouteRepository(db.GetPool())
+			transportRepo := pg.NewTransportRepository(db.GetPool())
+			routeService := service.NewRouteService(routeRepo, transportRepo)
+			routeHandler := httpmiddleware.NewRouteHandler(routeService)
+
+			authMiddleware := newAuthMiddleware(jwtManager, db)
+			rbacMiddleware := httpmiddleware.NewRBACMiddlewareWithPermissions(cfg.RBAC.Permissions)
+
+			// Require authentication for all route planning endpoints
+			r.Use(authMiddleware.RequireAuth)
+
+			// Planning is a dispatcher tool - ADMIN and DISPATCHER in the default permission matrix
+			r.With(rbacMiddleware.RequirePermission(models.ResourceRoutes, models.PermissionActionWrite)).
+				Post("/optimize", routeHandler.OptimizeRoutes)
+		})
+
+		// Driver self-service endpoints
+		r.Route("/me/driver", func(r chi.Router) {
+			// Create driver self-service handler and middleware
+			driverRepo := pg.NewDriverRepository(db.GetPool())
+			orderRepo := pg.NewOrderRepository(db.GetPool())
+			clientRepo := pg.NewClientRepository(db.GetPool())
+			clientObjRepo := pg.NewClientObjectRepository(db.GetPool())
+			transportRepo := pg.NewTransportRepository(db.GetPool())
+			reasonRepo := pg.NewCancellationReasonRepository(db.GetPool())
+			orderService := service.NewOrderService(orderRepo, clientRepo, clientObjRepo, transportRepo, reasonRepo)
+			driverSelfService := service.NewDriverSelfService(driverRepo, transportRepo, orderService)
+			driverSelfHandler := httpmiddleware.NewDriverSelfHandler(driverSelfService)
+
+			authMiddleware := newAuthMiddleware(jwtManager, db)
+
+			// Only DRIVER users linked to a driver can use these endpoints
+			r.Use(authMiddleware.RequireUserAuth)
+			r.Use(authMiddleware.RequireRole(models.UserRoleDriver))
+
+			r.Get("/", driverSelfHandler.GetProfile)
+			r.Get("/orders", driverSelfHandler.ListOrders)
+			r.Post("/orders/{id}/start", driverSelfHandler.StartOrder)
+			r.Post("/orders/{id}/complete", driverSelfHandler.CompleteOrder)
+		})
+
+		// Protected dispatch board endpoints
+		r.Route("/dispatch", func(r chi.Router) {
+			// Create dispatch handler and middleware
+			dispatchRepo := pg.NewDispatchRepository(db.GetPool())
+			dispatchService := service.NewDispatchService(dispatchRepo)
+			dispatchHandler := httpmiddleware.NewDispatchHandler(dispatchService)
+
+			authMiddleware := newAuthMiddleware(jwtManager, db)
+			rbacMiddleware := httpmiddleware.NewRBACMiddlewareWithPermissions(cfg.RBAC.Permissions)
+
+			// Require authentication for all dispatch endpoints
+			r.Use(authMiddleware.RequireAuth)
+
+			// Read endpoints - roles granted read on the resource
+			r.With(rbacMiddleware.RequirePermission(models.ResourceDispatch, models.PermissionActionRead)).Get("/", dispatchHandler.GetBoard)
+		})
+
+		// Protected photo endpoints
+		r.Route("/photos", func(r chi.Router) {
+			// Create photo handler and middleware
+			photoStore, err := storage.NewBlobStore(cfg.Photos)
+			if err != nil {
+				telemetry.Logger.Error("Photo storage is not configured, photo endpoints disabled", err)
+				return
+			}
+			photoRepo := pg.NewPhotoRepository(db.GetPool())
+			photoService := service.NewPhotoService(photoRepo, photoStore)
+			photoHandler := httpmiddleware.NewPhotoHandler(photoService, cfg.HTTP.MaxBodyBytes)
+
+			authMiddleware := newAuthMiddleware(jwtManager, db)
+			rbacMiddleware := httpmiddleware.NewRBACMiddlewareWithPermissions(cfg.RBAC.Permissions)
+
+			// Require authentication for all photo endpoints
+			r.Use(authMiddleware.RequireAuth)
+
+			// Read endpoints - roles granted read on the resource
+			r.With(rbacMiddleware.RequirePermission(models.ResourcePhotos, models.PermissionActionRead)).Group(func(r chi.Router) {
+				r.Get("/{entityType}/{entityId}", photoHandler.ListPhotos)
+				r.Get("/{entityType}/{entityId}/{photoId}", photoHandler.DownloadPhoto)
 			})
+
+			// Upload - drivers attach proof-of-service photos, so everyone except VIEWER may upload by default
+			r.With(rbacMiddleware.RequirePermission(models.ResourcePhotos, models.PermissionActionWrite)).
+				Post("/{entityType}/{entityId}", photoHandler.UploadPhoto)
+
+			// Delete - ADMIN only by default
+			r.With(rbacMiddleware.RequirePermission(models.ResourcePhotos, models.PermissionActionDelete)).
+				Delete("/{entityType}/{entityId}/{photoId}", photoHandler.DeletePhoto)
 		})
 
 		// 404 handler for unmatched routes
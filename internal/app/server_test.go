@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"eco-van-api/internal/adapter/auth"
 	"eco-van-api/internal/adapter/repo/pg"
 	"eco-van-api/internal/adapter/telemetry"
 	"eco-van-api/internal/config"
@@ -35,7 +36,7 @@ func TestServer_Shutdown(t *testing.T) {
 
 	// Create a mock database for testing
 	mockDB := pg.NewMockDB()
-	server := NewServer(cfg, telemetry, mockDB)
+	server := NewServer(cfg, telemetry, mockDB, auth.NewDefaultJWTManager(cfg.Auth.JWTSecret))
 
 	// Start server in background
 	go func() {
@@ -86,7 +87,7 @@ func TestNewServer(t *testing.T) {
 
 	// Create a mock database for testing
 	mockDB := pg.NewMockDB()
-	server := NewServer(cfg, telemetry, mockDB)
+	server := NewServer(cfg, telemetry, mockDB, auth.NewDefaultJWTManager(cfg.Auth.JWTSecret))
 
 	if server == nil {
 		t.Fatal("Expected server to be created, got nil")
@@ -155,7 +156,7 @@ func TestServer_ReadyzEndpoint(t *testing.T) {
 
 	// Create a mock database for testing
 	mockDB := pg.NewMockDB()
-	server := NewServer(cfg, telemetry, mockDB)
+	server := NewServer(cfg, telemetry, mockDB, auth.NewDefaultJWTManager(cfg.Auth.JWTSecret))
 
 	// Start server in background
 	go func() {
@@ -221,7 +222,7 @@ func TestServer_ReadyzEndpoint_DatabaseDown(t *testing.T) {
 	// For now, we'll test the endpoint structure without forcing a ping failure
 	// The mock DB always succeeds, so we'll test the success case
 
-	server := NewServer(cfg, telemetry, mockDB)
+	server := NewServer(cfg, telemetry, mockDB, auth.NewDefaultJWTManager(cfg.Auth.JWTSecret))
 
 	// Start server in background
 	go func() {
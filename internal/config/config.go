@@ -1,6 +1,10 @@
 package config
 
-import "time"
+import (
+	"time"
+
+	"eco-van-api/internal/models"
+)
 
 // HTTPConfig holds HTTP server configuration
 type HTTPConfig struct {
@@ -10,6 +14,8 @@ type HTTPConfig struct {
 	IdleTimeout  time.Duration
 	CORSOrigins  []string
 	MaxBodyBytes int64
+	// TrustProxyHeaders takes the client IP from X-Forwarded-For/X-Real-IP; enable only behind a trusted proxy
+	TrustProxyHeaders bool
 }
 
 // DBConfig holds database configuration
@@ -26,6 +32,18 @@ type AuthConfig struct {
 	JWTSecret  string
 	AccessTTL  time.Duration
 	RefreshTTL time.Duration
+	// SigningAlgorithm is HS256 (shared JWT_SECRET) or RS256/EdDSA (rotating key pairs published as JWKS)
+	SigningAlgorithm string
+	// KeyRotationInterval is how long an RS256/EdDSA key signs tokens before it is replaced
+	KeyRotationInterval time.Duration
+	// KeyPublishAhead is how long a new key is published in the JWKS before it starts signing
+	KeyPublishAhead time.Duration
+	// PasswordResetTTL is how long an admin-issued password reset token stays valid
+	PasswordResetTTL time.Duration
+	// LoginThrottle configures brute-force protection of the login endpoint
+	LoginThrottle models.LoginThrottlePolicy
+	// TwoFactor configures TOTP two-factor authentication
+	TwoFactor models.TwoFactorPolicy
 }
 
 // TelemetryConfig holds telemetry configuration
@@ -38,7 +56,75 @@ type TelemetryConfig struct {
 
 // PhotosConfig holds photo storage configuration
 type PhotosConfig struct {
-	Dir string
+	Dir     string
+	Storage string // "local" or "s3"
+	S3      S3Config
+}
+
+// S3Config holds S3-compatible object storage configuration
+type S3Config struct {
+	Endpoint     string
+	Region       string
+	Bucket       string
+	AccessKey    string
+	SecretKey    string
+	UsePathStyle bool
+}
+
+// SchedulesConfig holds recurring order generation configuration
+type SchedulesConfig struct {
+	GeneratorEnabled bool
+	AheadDays        int
+	Interval         time.Duration
+}
+
+// RBACConfig holds the role permission matrix configuration
+type RBACConfig struct {
+	// Policy overrides the grants of the listed roles, e.g. "DISPATCHER=*:read,orders:*;VIEWER=*:read"
+	Policy string
+	// Permissions is the matrix compiled from the default policy and Policy during Load
+	Permissions *models.PermissionMatrix
+}
+
+// OIDCConfig holds OpenID Connect single sign-on configuration; SSO is enabled when IssuerURL is set
+type OIDCConfig struct {
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is the client page the provider returns to; it posts the code to /auth/oidc/callback
+	RedirectURL string
+	Scopes      []string
+	// GroupsClaim is the ID token claim whose values are mapped to roles
+	GroupsClaim string
+	// RoleMapping maps claim values to roles, e.g. "eco-admins=ADMIN,office=DISPATCHER"
+	RoleMapping string
+	// DefaultRole is given to users without a mapped value; empty rejects them
+	DefaultRole models.UserRole
+	// Roles is RoleMapping compiled during Load
+	Roles models.OIDCRoleMapping
+	// StateTTL is how long a started login can be completed
+	StateTTL time.Duration
+}
+
+// Enabled reports whether single sign-on is configured
+func (c *OIDCConfig) Enabled() bool {
+	return c.IssuerURL != ""
+}
+
+// RateLimitConfig holds request rate limiting configuration
+type RateLimitConfig struct {
+	Enabled bool
+	// Store keeps the token buckets: "memory" limits each replica on its own, "postgres" shares them between replicas
+	Store string
+	// Default is the budget of every caller on endpoints without a budget of their own
+	Default models.RateLimitPolicy
+	// Login is the stricter budget of the login endpoints, per client IP
+	Login models.RateLimitPolicy
+	// Refresh is the budget of the token refresh endpoint
+	Refresh models.RateLimitPolicy
+	// FailOpen lets requests on the default budget through when the store fails; login and refresh are
+	// always rejected then
+	FailOpen bool
 }
 
 // Config holds all application configuration
@@ -46,6 +132,10 @@ type Config struct {
 	HTTP      HTTPConfig
 	DB        DBConfig
 	Auth      AuthConfig
+	OIDC      OIDCConfig
+	RateLimit RateLimitConfig
 	Telemetry TelemetryConfig
 	Photos    PhotosConfig
+	Schedules SchedulesConfig
+	RBAC      RBACConfig
 }
@@ -2,11 +2,14 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
 	"time"
 
+	"eco-van-api/internal/models"
+
 	"github.com/joho/godotenv"
 )
 
@@ -26,6 +29,49 @@ const (
 	// Authentication TTLs
 	DefaultAccessTTL  = 15 * time.Minute
 	DefaultRefreshTTL = 720 * time.Hour // 30 days
+
+	// JWT signing key rotation
+	DefaultJWTKeyRotationInterval = 720 * time.Hour // 30 days
+	DefaultJWTKeyPublishAhead     = time.Hour
+
+	// Password reset tokens
+	DefaultPasswordResetTTL = 24 * time.Hour
+
+	// Login brute-force protection
+	DefaultLoginMaxFailures   = 5
+	DefaultLoginIPMaxFailures = 20
+	DefaultLoginFailureWindow = 15 * time.Minute
+	DefaultLoginLockout       = 15 * time.Minute
+	DefaultLoginDelayBase     = time.Second
+	DefaultLoginDelayMax      = 30 * time.Second
+
+	// TOTP two-factor authentication
+	DefaultTOTPIssuer = "Eco-Van"
+
+	// OpenID Connect single sign-on
+	DefaultOIDCGroupsClaim = "groups"
+	DefaultOIDCStateTTL    = 10 * time.Minute
+
+	// Request rate limiting
+	DefaultRateLimitRequests        = 300
+	DefaultRateLimitPeriod          = time.Minute
+	DefaultRateLimitLoginRequests   = 10
+	DefaultRateLimitLoginPeriod     = time.Minute
+	DefaultRateLimitRefreshRequests = 30
+	DefaultRateLimitRefreshPeriod   = time.Minute
+
+	// Recurring order generation
+	DefaultScheduleAheadDays = 14
+	DefaultScheduleInterval  = time.Hour
+	MaxScheduleAheadDays     = 366
+
+	// Photo storage backends
+	PhotoStorageLocal = "local"
+	PhotoStorageS3    = "s3"
+
+	// Rate limit bucket stores
+	RateLimitStoreMemory   = "memory"
+	RateLimitStorePostgres = "postgres"
 )
 
 // Load loads configuration from environment variables with defaults
@@ -40,8 +86,12 @@ func Load() (*Config, error) {
 		HTTP:      loadHTTPConfig(),
 		DB:        loadDBConfig(),
 		Auth:      loadAuthConfig(),
+		OIDC:      loadOIDCConfig(),
+		RateLimit: loadRateLimitConfig(),
 		Telemetry: loadTelemetryConfig(),
 		Photos:    loadPhotosConfig(),
+		Schedules: loadSchedulesConfig(),
+		RBAC:      loadRBACConfig(),
 	}
 
 	// Validate required fields
@@ -55,12 +105,13 @@ func Load() (*Config, error) {
 // loadHTTPConfig loads HTTP configuration with defaults
 func loadHTTPConfig() HTTPConfig {
 	return HTTPConfig{
-		Addr:         getEnv("HTTP_ADDR", ":8080"),
-		ReadTimeout:  getEnvAsDuration("HTTP_READ_TIMEOUT", DefaultHTTPReadTimeout),
-		WriteTimeout: getEnvAsDuration("HTTP_WRITE_TIMEOUT", DefaultHTTPWriteTimeout),
-		IdleTimeout:  getEnvAsDuration("HTTP_IDLE_TIMEOUT", DefaultHTTPIdleTimeout),
-		CORSOrigins:  getEnvAsStringSlice("CORS_ORIGINS", []string{"*"}),
-		MaxBodyBytes: getEnvAsInt64("HTTP_MAX_BODY", DefaultHTTPMaxBody),
+		Addr:              getEnv("HTTP_ADDR", ":8080"),
+		ReadTimeout:       getEnvAsDuration("HTTP_READ_TIMEOUT", DefaultHTTPReadTimeout),
+		WriteTimeout:      getEnvAsDuration("HTTP_WRITE_TIMEOUT", DefaultHTTPWriteTimeout),
+		IdleTimeout:       getEnvAsDuration("HTTP_IDLE_TIMEOUT", DefaultHTTPIdleTimeout),
+		CORSOrigins:       getEnvAsStringSlice("CORS_ORIGINS", []string{"*"}),
+		MaxBodyBytes:      getEnvAsInt64("HTTP_MAX_BODY", DefaultHTTPMaxBody),
+		TrustProxyHeaders: getEnvAsBool("TRUST_PROXY_HEADERS", false),
 	}
 }
 
@@ -78,9 +129,61 @@ func loadDBConfig() DBConfig {
 // loadAuthConfig loads authentication configuration with defaults
 func loadAuthConfig() AuthConfig {
 	return AuthConfig{
-		JWTSecret:  getEnv("JWT_SECRET", ""),
-		AccessTTL:  getEnvAsDuration("ACCESS_TTL", DefaultAccessTTL),
-		RefreshTTL: getEnvAsDuration("REFRESH_TTL", DefaultRefreshTTL),
+		JWTSecret:           getEnv("JWT_SECRET", ""),
+		AccessTTL:           getEnvAsDuration("ACCESS_TTL", DefaultAccessTTL),
+		RefreshTTL:          getEnvAsDuration("REFRESH_TTL", DefaultRefreshTTL),
+		SigningAlgorithm:    getEnv("JWT_SIGNING_ALG", models.JWTAlgorithmHS256),
+		KeyRotationInterval: getEnvAsDuration("JWT_KEY_ROTATION_INTERVAL", DefaultJWTKeyRotationInterval),
+		KeyPublishAhead:     getEnvAsDuration("JWT_KEY_PUBLISH_AHEAD", DefaultJWTKeyPublishAhead),
+		PasswordResetTTL:    getEnvAsDuration("PASSWORD_RESET_TTL", DefaultPasswordResetTTL),
+		LoginThrottle: models.LoginThrottlePolicy{
+			MaxAccountFailures: getEnvAsInt("LOGIN_MAX_FAILURES", DefaultLoginMaxFailures),
+			MaxIPFailures:      getEnvAsInt("LOGIN_IP_MAX_FAILURES", DefaultLoginIPMaxFailures),
+			FailureWindow:      getEnvAsDuration("LOGIN_FAILURE_WINDOW", DefaultLoginFailureWindow),
+			Lockout:            getEnvAsDuration("LOGIN_LOCKOUT", DefaultLoginLockout),
+			BaseDelay:          getEnvAsDuration("LOGIN_DELAY_BASE", DefaultLoginDelayBase),
+			MaxDelay:           getEnvAsDuration("LOGIN_DELAY_MAX", DefaultLoginDelayMax),
+		},
+		TwoFactor: models.TwoFactorPolicy{
+			Issuer:           getEnv("TOTP_ISSUER", DefaultTOTPIssuer),
+			RequiredForAdmin: getEnvAsBool("TOTP_REQUIRED_FOR_ADMIN", false),
+		},
+	}
+}
+
+// loadOIDCConfig loads single sign-on configuration with defaults
+func loadOIDCConfig() OIDCConfig {
+	return OIDCConfig{
+		IssuerURL:    strings.TrimSuffix(getEnv("OIDC_ISSUER_URL", ""), "/"),
+		ClientID:     getEnv("OIDC_CLIENT_ID", ""),
+		ClientSecret: getEnv("OIDC_CLIENT_SECRET", ""),
+		RedirectURL:  getEnv("OIDC_REDIRECT_URL", ""),
+		Scopes:       getEnvAsStringSlice("OIDC_SCOPES", []string{"openid", "email", "profile"}),
+		GroupsClaim:  getEnv("OIDC_GROUPS_CLAIM", DefaultOIDCGroupsClaim),
+		RoleMapping:  getEnv("OIDC_ROLE_MAPPING", ""),
+		DefaultRole:  models.UserRole(strings.ToUpper(getEnv("OIDC_DEFAULT_ROLE", ""))),
+		StateTTL:     getEnvAsDuration("OIDC_STATE_TTL", DefaultOIDCStateTTL),
+	}
+}
+
+// loadRateLimitConfig loads request rate limiting configuration with defaults
+func loadRateLimitConfig() RateLimitConfig {
+	return RateLimitConfig{
+		Enabled: getEnvAsBool("RATE_LIMIT_ENABLED", true),
+		Store:   getEnv("RATE_LIMIT_STORE", RateLimitStoreMemory),
+		Default: models.RateLimitPolicy{
+			Requests: getEnvAsInt("RATE_LIMIT_REQUESTS", DefaultRateLimitRequests),
+			Period:   getEnvAsDuration("RATE_LIMIT_PERIOD", DefaultRateLimitPeriod),
+		},
+		Login: models.RateLimitPolicy{
+			Requests: getEnvAsInt("RATE_LIMIT_LOGIN_REQUESTS", DefaultRateLimitLoginRequests),
+			Period:   getEnvAsDuration("RATE_LIMIT_LOGIN_PERIOD", DefaultRateLimitLoginPeriod),
+		},
+		Refresh: models.RateLimitPolicy{
+			Requests: getEnvAsInt("RATE_LIMIT_REFRESH_REQUESTS", DefaultRateLimitRefreshRequests),
+			Period:   getEnvAsDuration("RATE_LIMIT_REFRESH_PERIOD", DefaultRateLimitRefreshPeriod),
+		},
+		FailOpen: getEnvAsBool("RATE_LIMIT_FAIL_OPEN", true),
 	}
 }
 
@@ -97,7 +200,32 @@ func loadTelemetryConfig() TelemetryConfig {
 // loadPhotosConfig loads photos configuration with defaults
 func loadPhotosConfig() PhotosConfig {
 	return PhotosConfig{
-		Dir: getEnv("PHOTOS_DIR", "/photos"),
+		Dir:     getEnv("PHOTOS_DIR", "/photos"),
+		Storage: getEnv("PHOTOS_STORAGE", PhotoStorageLocal),
+		S3: S3Config{
+			Endpoint:     getEnv("PHOTOS_S3_ENDPOINT", ""),
+			Region:       getEnv("PHOTOS_S3_REGION", "us-east-1"),
+			Bucket:       getEnv("PHOTOS_S3_BUCKET", ""),
+			AccessKey:    getEnv("PHOTOS_S3_ACCESS_KEY", ""),
+			SecretKey:    getEnv("PHOTOS_S3_SECRET_KEY", ""),
+			UsePathStyle: getEnvAsBool("PHOTOS_S3_PATH_STYLE", true),
+		},
+	}
+}
+
+// loadSchedulesConfig loads recurring order generation configuration with defaults
+func loadSchedulesConfig() SchedulesConfig {
+	return SchedulesConfig{
+		GeneratorEnabled: getEnvAsBool("SCHEDULE_GENERATOR_ENABLED", true),
+		AheadDays:        getEnvAsInt("SCHEDULE_AHEAD_DAYS", DefaultScheduleAheadDays),
+		Interval:         getEnvAsDuration("SCHEDULE_INTERVAL", DefaultScheduleInterval),
+	}
+}
+
+// loadRBACConfig loads the permission policy overrides
+func loadRBACConfig() RBACConfig {
+	return RBACConfig{
+		Policy: getEnv("RBAC_POLICY", ""),
 	}
 }
 
@@ -106,12 +234,165 @@ func validateConfig(cfg *Config) error {
 	if cfg.DB.DSN == "" {
 		return fmt.Errorf("DB_DSN is required")
 	}
-	if cfg.Auth.JWTSecret == "" {
-		return fmt.Errorf("JWT_SECRET is required")
+	if err := validateJWTSigning(&cfg.Auth); err != nil {
+		return err
+	}
+	if cfg.Auth.PasswordResetTTL <= 0 {
+		return fmt.Errorf("PASSWORD_RESET_TTL must be positive")
+	}
+	if err := validateLoginThrottle(&cfg.Auth.LoginThrottle); err != nil {
+		return err
+	}
+	if strings.TrimSpace(cfg.Auth.TwoFactor.Issuer) == "" {
+		return fmt.Errorf("TOTP_ISSUER must not be empty")
+	}
+	if err := validateOIDCConfig(&cfg.OIDC); err != nil {
+		return err
+	}
+	if err := validateRateLimitConfig(&cfg.RateLimit); err != nil {
+		return err
+	}
+	if err := validatePhotosConfig(&cfg.Photos); err != nil {
+		return err
+	}
+	if err := validateSchedulesConfig(&cfg.Schedules); err != nil {
+		return err
+	}
+	return validateRBACConfig(&cfg.RBAC)
+}
+
+// validateJWTSigning validates the token signing algorithm and its key material settings
+func validateJWTSigning(cfg *AuthConfig) error {
+	switch {
+	case cfg.SigningAlgorithm == models.JWTAlgorithmHS256:
+		if cfg.JWTSecret == "" {
+			return fmt.Errorf("JWT_SECRET is required")
+		}
+		return nil
+	case models.IsAsymmetricJWTAlgorithm(cfg.SigningAlgorithm):
+		if cfg.KeyRotationInterval <= 0 {
+			return fmt.Errorf("JWT_KEY_ROTATION_INTERVAL must be positive, got %s", cfg.KeyRotationInterval)
+		}
+		if cfg.KeyPublishAhead < 0 || cfg.KeyPublishAhead >= cfg.KeyRotationInterval {
+			return fmt.Errorf("JWT_KEY_PUBLISH_AHEAD must not be negative or reach JWT_KEY_ROTATION_INTERVAL")
+		}
+		return nil
+	default:
+		return fmt.Errorf("JWT_SIGNING_ALG must be %q, %q or %q, got %q",
+			models.JWTAlgorithmHS256, models.JWTAlgorithmRS256, models.JWTAlgorithmEdDSA, cfg.SigningAlgorithm)
+	}
+}
+
+// validateLoginThrottle validates the login brute-force protection settings
+func validateLoginThrottle(policy *models.LoginThrottlePolicy) error {
+	if policy.MaxAccountFailures < 1 || policy.MaxIPFailures < 1 {
+		return fmt.Errorf("LOGIN_MAX_FAILURES and LOGIN_IP_MAX_FAILURES must be at least 1")
+	}
+	if policy.FailureWindow <= 0 || policy.Lockout <= 0 {
+		return fmt.Errorf("LOGIN_FAILURE_WINDOW and LOGIN_LOCKOUT must be positive")
+	}
+	if policy.BaseDelay < 0 || policy.MaxDelay < policy.BaseDelay {
+		return fmt.Errorf("LOGIN_DELAY_BASE must not be negative or exceed LOGIN_DELAY_MAX")
 	}
 	return nil
 }
 
+// validateOIDCConfig checks the single sign-on settings and compiles the role mapping
+func validateOIDCConfig(cfg *OIDCConfig) error {
+	if !cfg.Enabled() {
+		return nil
+	}
+	if u, err := url.Parse(cfg.IssuerURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return fmt.Errorf("OIDC_ISSUER_URL must be an http(s) URL, got %q", cfg.IssuerURL)
+	}
+	if cfg.ClientID == "" || cfg.RedirectURL == "" {
+		return fmt.Errorf("OIDC_CLIENT_ID and OIDC_REDIRECT_URL are required when OIDC_ISSUER_URL is set")
+	}
+	if cfg.DefaultRole != "" && !cfg.DefaultRole.IsValid() {
+		return fmt.Errorf("OIDC_DEFAULT_ROLE must be a valid role, got %q", cfg.DefaultRole)
+	}
+	if cfg.StateTTL <= 0 {
+		return fmt.Errorf("OIDC_STATE_TTL must be positive, got %s", cfg.StateTTL)
+	}
+
+	roles, err := models.ParseOIDCRoleMapping(cfg.RoleMapping)
+	if err != nil {
+		return fmt.Errorf("OIDC_ROLE_MAPPING is invalid: %w", err)
+	}
+	if len(roles) == 0 && cfg.DefaultRole == "" {
+		return fmt.Errorf("OIDC_ROLE_MAPPING or OIDC_DEFAULT_ROLE is required when OIDC_ISSUER_URL is set")
+	}
+	cfg.Roles = roles
+	return nil
+}
+
+// validateRateLimitConfig validates the bucket store and the budgets of the rate limiter
+func validateRateLimitConfig(cfg *RateLimitConfig) error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.Store != RateLimitStoreMemory && cfg.Store != RateLimitStorePostgres {
+		return fmt.Errorf("RATE_LIMIT_STORE must be %q or %q, got %q", RateLimitStoreMemory, RateLimitStorePostgres, cfg.Store)
+	}
+
+	budgets := []struct {
+		prefix string
+		policy models.RateLimitPolicy
+	}{
+		{"RATE_LIMIT", cfg.Default},
+		{"RATE_LIMIT_LOGIN", cfg.Login},
+		{"RATE_LIMIT_REFRESH", cfg.Refresh},
+	}
+	for _, budget := range budgets {
+		if err := budget.policy.Validate(); err != nil {
+			return fmt.Errorf("%s_REQUESTS and %s_PERIOD are invalid: %w", budget.prefix, budget.prefix, err)
+		}
+	}
+	return nil
+}
+
+// validateRBACConfig compiles the permission policy so an invalid RBAC_POLICY fails at startup
+func validateRBACConfig(cfg *RBACConfig) error {
+	permissions, err := models.ParsePermissionMatrix(cfg.Policy)
+	if err != nil {
+		return fmt.Errorf("RBAC_POLICY is invalid: %w", err)
+	}
+	cfg.Permissions = permissions
+	return nil
+}
+
+// validateSchedulesConfig validates the recurring order generation settings
+func validateSchedulesConfig(cfg *SchedulesConfig) error {
+	if cfg.AheadDays < 1 || cfg.AheadDays > MaxScheduleAheadDays {
+		return fmt.Errorf("SCHEDULE_AHEAD_DAYS must be between 1 and %d, got %d", MaxScheduleAheadDays, cfg.AheadDays)
+	}
+	if cfg.Interval <= 0 {
+		return fmt.Errorf("SCHEDULE_INTERVAL must be positive, got %s", cfg.Interval)
+	}
+	return nil
+}
+
+// validatePhotosConfig validates the selected photo storage backend
+func validatePhotosConfig(cfg *PhotosConfig) error {
+	switch cfg.Storage {
+	case PhotoStorageLocal:
+		return nil
+	case PhotoStorageS3:
+		if cfg.S3.Endpoint == "" || cfg.S3.Bucket == "" {
+			return fmt.Errorf("PHOTOS_S3_ENDPOINT and PHOTOS_S3_BUCKET are required when PHOTOS_STORAGE=s3")
+		}
+		if u, err := url.Parse(cfg.S3.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("PHOTOS_S3_ENDPOINT must be an http(s) URL, got %q", cfg.S3.Endpoint)
+		}
+		if cfg.S3.AccessKey == "" || cfg.S3.SecretKey == "" {
+			return fmt.Errorf("PHOTOS_S3_ACCESS_KEY and PHOTOS_S3_SECRET_KEY are required when PHOTOS_STORAGE=s3")
+		}
+		return nil
+	default:
+		return fmt.Errorf("PHOTOS_STORAGE must be %q or %q, got %q", PhotoStorageLocal, PhotoStorageS3, cfg.Storage)
+	}
+}
+
 // Helper functions for environment variable loading
 
 func getEnv(key, defaultValue string) string {
@@ -0,0 +1,100 @@
+package domainerr
+
+// Error codes. They are part of the API contract: clients may branch on them, so existing codes are never
+// renamed or reused for another condition.
+const (
+	// Generic
+	CodeValidationFailed = "VALIDATION_FAILED"
+	CodeInvalidID        = "INVALID_ID"
+	CodeInvalidDateRange = "INVALID_DATE_RANGE"
+
+	// Users and authentication
+	CodeUserNotFound                = "USER_NOT_FOUND"
+	CodeUserEmailExists             = "USER_EMAIL_EXISTS"
+	CodeSelfDisable                 = "USER_SELF_DISABLE"
+	CodeSelfRoleChange              = "USER_SELF_ROLE_CHANGE"
+	CodeInvalidCredentials          = "INVALID_CREDENTIALS"
+	CodeAccountDisabled             = "ACCOUNT_DISABLED"
+	CodeInvalidRefreshToken         = "INVALID_REFRESH_TOKEN"
+	CodeInvalidResetToken           = "INVALID_RESET_TOKEN"
+	CodeCurrentPasswordIncorrect    = "CURRENT_PASSWORD_INCORRECT"
+	CodePasswordUnchanged           = "PASSWORD_UNCHANGED"
+	CodeInvalidTwoFactorToken       = "INVALID_2FA_TOKEN"
+	CodeInvalidTwoFactorCode        = "INVALID_2FA_CODE"
+	CodeTwoFactorAlreadyEnabled     = "TWO_FACTOR_ALREADY_ENABLED"
+	CodeTwoFactorNotEnabled         = "TWO_FACTOR_NOT_ENABLED"
+	CodeTwoFactorEnrollmentRequired = "TWO_FACTOR_ENROLLMENT_NOT_STARTED"
+	CodeTwoFactorRequired           = "TWO_FACTOR_REQUIRED"
+	CodeInvalidOIDCLogin            = "INVALID_OIDC_LOGIN"
+	CodeOIDCRoleNotMapped           = "OIDC_ROLE_NOT_MAPPED"
+	CodeAPIKeyNotFound              = "API_KEY_NOT_FOUND"
+	CodeInvalidAPIKey               = "INVALID_API_KEY"
+
+	// Clients and client objects
+	CodeClientNotFound             = "CLIENT_NOT_FOUND"
+	CodeClientNameExists           = "CLIENT_NAME_EXISTS"
+	CodeClientNotDeleted           = "CLIENT_NOT_DELETED"
+	CodeClientObjectNotFound       = "CLIENT_OBJECT_NOT_FOUND"
+	CodeClientObjectNameExists     = "CLIENT_OBJECT_NAME_EXISTS"
+	CodeClientObjectInUse          = "CLIENT_OBJECT_IN_USE"
+	CodeClientObjectClientMismatch = "CLIENT_OBJECT_CLIENT_MISMATCH"
+
+	// Warehouses
+	CodeWarehouseNotFound     = "WAREHOUSE_NOT_FOUND"
+	CodeWarehouseNameExists   = "WAREHOUSE_NAME_EXISTS"
+	CodeWarehouseNotDeleted   = "WAREHOUSE_NOT_DELETED"
+	CodeWarehouseHasEquipment = "WAREHOUSE_HAS_EQUIPMENT"
+
+	// Equipment
+	CodeEquipmentNotFound            = "EQUIPMENT_NOT_FOUND"
+	CodeEquipmentNumberExists        = "EQUIPMENT_NUMBER_EXISTS"
+	CodeEquipmentNotDeleted          = "EQUIPMENT_NOT_DELETED"
+	CodeEquipmentInvalidPlacement    = "EQUIPMENT_INVALID_PLACEMENT"
+	CodeEquipmentAttachedToTransport = "EQUIPMENT_ATTACHED_TO_TRANSPORT"
+	CodeEquipmentNotAvailable        = "EQUIPMENT_NOT_AVAILABLE"
+	CodeEquipmentAlreadyAssigned     = "EQUIPMENT_ALREADY_ASSIGNED"
+	CodeEquipmentPlacementConflict   = "EQUIPMENT_PLACEMENT_CONFLICT"
+
+	// Drivers
+	CodeDriverNotFound            = "DRIVER_NOT_FOUND"
+	CodeDriverLicenseExists       = "DRIVER_LICENSE_EXISTS"
+	CodeDriverNotDeleted          = "DRIVER_NOT_DELETED"
+	CodeDriverAssigned            = "DRIVER_ASSIGNED_TO_TRANSPORT"
+	CodeDriverAlreadyAssigned     = "DRIVER_ALREADY_ASSIGNED"
+	CodeDriverUserRoleRequired    = "DRIVER_USER_ROLE_REQUIRED"
+	CodeDriverUserAlreadyLinked   = "DRIVER_USER_ALREADY_LINKED"
+	CodeDriverNotLinked           = "DRIVER_NOT_LINKED"
+	CodeDriverWithoutTransport    = "DRIVER_WITHOUT_TRANSPORT"
+	CodeOrderNotOnDriverTransport = "ORDER_NOT_ON_DRIVER_TRANSPORT"
+
+	// Transports
+	CodeTransportNotFound        = "TRANSPORT_NOT_FOUND"
+	CodeTransportPlateExists     = "TRANSPORT_PLATE_EXISTS"
+	CodeTransportNotAvailable    = "TRANSPORT_NOT_AVAILABLE"
+	CodeTransportHasDriver       = "TRANSPORT_HAS_DRIVER"
+	CodeTransportHasEquipment    = "TRANSPORT_HAS_EQUIPMENT"
+	CodeTransportHasActiveOrders = "TRANSPORT_HAS_ACTIVE_ORDERS"
+	CodeTransportHasNoDriver     = "TRANSPORT_HAS_NO_DRIVER"
+
+	// Orders
+	CodeOrderNotFound              = "ORDER_NOT_FOUND"
+	CodeOrderNotDeleted            = "ORDER_NOT_DELETED"
+	CodeOrderStatusTransition      = "ORDER_STATUS_TRANSITION"
+	CodeOrderNotDeletable          = "ORDER_NOT_DELETABLE"
+	CodeOrderItemsLocked           = "ORDER_ITEMS_LOCKED"
+	CodeOrderChangedConcurrently   = "ORDER_CHANGED_CONCURRENTLY"
+	CodeOrderVersionMismatch       = "ORDER_VERSION_MISMATCH"
+	CodeOrderScheduleNotFound      = "ORDER_SCHEDULE_NOT_FOUND"
+	CodeCancellationReasonNotFound = "CANCELLATION_REASON_NOT_FOUND"
+	CodeCancellationReasonExists   = "CANCELLATION_REASON_EXISTS"
+
+	// Routes
+	CodeRouteNoDepot = "ROUTE_NO_DEPOT"
+
+	// Photos
+	CodePhotoNotFound        = "PHOTO_NOT_FOUND"
+	CodePhotoEntityNotFound  = "PHOTO_ENTITY_NOT_FOUND"
+	CodePhotoUnsupportedType = "PHOTO_UNSUPPORTED_TYPE"
+	CodePhotoEmpty           = "PHOTO_EMPTY"
+	CodePhotoInvalidEntity   = "PHOTO_INVALID_ENTITY_TYPE"
+)
@@ -0,0 +1,189 @@
+// Package domainerr defines the expected failures of use cases. Services return them instead of formatted
+// strings; the HTTP adapter maps their kind to a status code and their code to a stable problem, so rewording
+// a message never changes the API.
+package domainerr
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kind classifies a domain error; it decides the HTTP status code
+type Kind string
+
+// Domain error kinds
+const (
+	// KindNotFound means the addressed resource does not exist
+	KindNotFound Kind = "NOT_FOUND"
+	// KindConflict means the request clashes with the current state of other resources, e.g. a duplicate name
+	KindConflict Kind = "CONFLICT"
+	// KindValidation means the request is well-formed but breaks a business rule
+	KindValidation Kind = "VALIDATION"
+	// KindInvalidTransition means the resource is in a state that does not allow the operation
+	KindInvalidTransition Kind = "INVALID_TRANSITION"
+	// KindForbidden means the caller may not perform the operation on this resource
+	KindForbidden Kind = "FORBIDDEN"
+	// KindUnauthorized means the presented credentials or tokens are not valid
+	KindUnauthorized Kind = "UNAUTHORIZED"
+	// KindInvalidInput means a request parameter cannot be parsed, e.g. a malformed ID
+	KindInvalidInput Kind = "INVALID_INPUT"
+	// KindPreconditionFailed means the client based the request on a version of the resource that is outdated
+	KindPreconditionFailed Kind = "PRECONDITION_FAILED"
+)
+
+// Error is a domain error. Code identifies the condition for clients and stays stable when Message is reworded;
+// Fields carry structured details such as the conflicting value, FieldErrors the request fields that broke a rule.
+// Message is shown to clients, so it must not include the text of internal errors; those go into the cause.
+type Error struct {
+	Kind        Kind
+	Code        string
+	Message     string
+	Fields      map[string]interface{}
+	FieldErrors []FieldError
+	cause       error
+}
+
+// FieldError describes a request field that broke a validation rule
+type FieldError struct {
+	// Pointer is the JSON pointer of the field in the request body, e.g. /items/0/equipmentId
+	Pointer string `json:"pointer"`
+	// Rule names the broken rule, e.g. required, max or a business rule such as exactly_one
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+	// MessageID selects the translation of Message when it differs from Rule, e.g. min.string for a length
+	MessageID string `json:"-"`
+	// Param is the parameter of the rule that translations refer to, e.g. the bound of max
+	Param string `json:"-"`
+}
+
+// New creates a domain error of the given kind
+func New(kind Kind, code, format string, args ...interface{}) *Error {
+	return &Error{Kind: kind, Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// NotFound creates an error for a missing resource
+func NotFound(code, format string, args ...interface{}) *Error {
+	return New(KindNotFound, code, format, args...)
+}
+
+// Conflict creates an error for a clash with the state of other resources
+func Conflict(code, format string, args ...interface{}) *Error {
+	return New(KindConflict, code, format, args...)
+}
+
+// Validation creates an error for a broken business rule
+func Validation(code, format string, args ...interface{}) *Error {
+	return New(KindValidation, code, format, args...)
+}
+
+// InvalidTransition creates an error for an operation the current state of a resource does not allow
+func InvalidTransition(code, format string, args ...interface{}) *Error {
+	return New(KindInvalidTransition, code, format, args...)
+}
+
+// Forbidden creates an error for an operation the caller may not perform
+func Forbidden(code, format string, args ...interface{}) *Error {
+	return New(KindForbidden, code, format, args...)
+}
+
+// Unauthorized creates an error for invalid credentials or tokens
+func Unauthorized(code, format string, args ...interface{}) *Error {
+	return New(KindUnauthorized, code, format, args...)
+}
+
+// InvalidInput creates an error for a request parameter that cannot be parsed
+func InvalidInput(code, format string, args ...interface{}) *Error {
+	return New(KindInvalidInput, code, format, args...)
+}
+
+// PreconditionFailed creates an error for a request based on an outdated version of a resource
+func PreconditionFailed(code, format string, args ...interface{}) *Error {
+	return New(KindPreconditionFailed, code, format, args...)
+}
+
+// InvalidField creates a validation error for a single request field
+func InvalidField(pointer, rule, format string, args ...interface{}) *Error {
+	return Validation(CodeValidationFailed, format, args...).At(pointer, rule)
+}
+
+// Error returns the message, followed by the cause if there is one
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return e.Message + ": " + e.cause.Error()
+	}
+	return e.Message
+}
+
+// Unwrap returns the cause of the error
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Wrap records the error that caused e; its text is appended to Error for logs, but not to the message, and the
+// field errors of a domain error cause are taken over
+func (e *Error) Wrap(cause error) *Error {
+	return e.WrapAt("", cause)
+}
+
+// WrapAt is Wrap for a cause that validated a nested part of the request: the pointers of its field errors are
+// prefixed with pointer, e.g. /items/2
+func (e *Error) WrapAt(pointer string, cause error) *Error {
+	e.cause = cause
+	if causeErr, ok := As(cause); ok {
+		for _, fieldErr := range causeErr.FieldErrors {
+			fieldErr.Pointer = pointer + fieldErr.Pointer
+			e.FieldErrors = append(e.FieldErrors, fieldErr)
+		}
+	}
+	return e
+}
+
+// At adds a field error for the request field at pointer, using the message of e
+func (e *Error) At(pointer, rule string) *Error {
+	return e.WithFieldError(pointer, rule, e.Message)
+}
+
+// WithFieldError adds a request field that broke a rule
+func (e *Error) WithFieldError(pointer, rule, message string) *Error {
+	e.FieldErrors = append(e.FieldErrors, FieldError{Pointer: pointer, Rule: rule, Message: message})
+	return e
+}
+
+// WithMessageID sets the translation and the rule parameter of the field error added last
+func (e *Error) WithMessageID(messageID, param string) *Error {
+	if len(e.FieldErrors) > 0 {
+		last := &e.FieldErrors[len(e.FieldErrors)-1]
+		last.MessageID, last.Param = messageID, param
+	}
+	return e
+}
+
+// With adds a structured detail to the error
+func (e *Error) With(key string, value interface{}) *Error {
+	if e.Fields == nil {
+		e.Fields = map[string]interface{}{}
+	}
+	e.Fields[key] = value
+	return e
+}
+
+// As finds the first domain error in the chain of err
+func As(err error) (*Error, bool) {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr, true
+	}
+	return nil, false
+}
+
+// IsKind reports whether err is or wraps a domain error of the given kind
+func IsKind(err error, kind Kind) bool {
+	domainErr, ok := As(err)
+	return ok && domainErr.Kind == kind
+}
+
+// HasCode reports whether err is or wraps a domain error with the given code
+func HasCode(err error, code string) bool {
+	domainErr, ok := As(err)
+	return ok && domainErr.Code == code
+}
@@ -0,0 +1,83 @@
+package domainerr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestError_Message(t *testing.T) {
+	err := Conflict(CodeWarehouseNameExists, "warehouse with name '%s' already exists", "Main")
+	if err.Error() != "warehouse with name 'Main' already exists" {
+		t.Errorf("unexpected message: %s", err.Error())
+	}
+
+	cause := errors.New("start is after end")
+	wrapped := Validation(CodeValidationFailed, "validation failed").Wrap(cause)
+	if wrapped.Error() != "validation failed: start is after end" {
+		t.Errorf("unexpected message: %s", wrapped.Error())
+	}
+	if !errors.Is(wrapped, cause) {
+		t.Error("expected the cause to be unwrapped")
+	}
+}
+
+func TestError_With(t *testing.T) {
+	err := Conflict(CodeTransportPlateExists, "transport already exists").
+		With("plateNo", "A123BC").
+		With("status", "IN_WORK")
+
+	if len(err.Fields) != 2 || err.Fields["plateNo"] != "A123BC" || err.Fields["status"] != "IN_WORK" {
+		t.Errorf("unexpected fields: %v", err.Fields)
+	}
+}
+
+func TestError_FieldErrors(t *testing.T) {
+	cause := InvalidField("/replacementEquipmentId", "required_if", "replacementEquipmentId is required for SWAP")
+	err := Validation(CodeValidationFailed, "validation failed: items[%d]", 2).WrapAt("/items/2", cause)
+
+	if err.Error() != "validation failed: items[2]: replacementEquipmentId is required for SWAP" {
+		t.Errorf("unexpected message: %s", err.Error())
+	}
+	want := FieldError{
+		Pointer: "/items/2/replacementEquipmentId",
+		Rule:    "required_if",
+		Message: "replacementEquipmentId is required for SWAP",
+	}
+	if len(err.FieldErrors) != 1 || err.FieldErrors[0] != want {
+		t.Errorf("unexpected field errors: %+v", err.FieldErrors)
+	}
+
+	sized := InvalidField("/name", "max", "name must be at most 100 characters long").WithMessageID("max.string", "100")
+	nested := Validation(CodeValidationFailed, "validation failed").WrapAt("/vehicles/0", sized)
+	if got := nested.FieldErrors[0]; got.Pointer != "/vehicles/0/name" || got.MessageID != "max.string" || got.Param != "100" {
+		t.Errorf("expected the translation of the cause to be kept, got %+v", got)
+	}
+
+	plain := Validation(CodeValidationFailed, "validation failed").Wrap(errors.New("bad date"))
+	if len(plain.FieldErrors) != 0 {
+		t.Errorf("expected no field errors from a plain cause, got %+v", plain.FieldErrors)
+	}
+}
+
+func TestAs(t *testing.T) {
+	err := fmt.Errorf("failed to get user: %w", NotFound(CodeUserNotFound, "user not found"))
+
+	domainErr, ok := As(err)
+	if !ok {
+		t.Fatal("expected a domain error in the chain")
+	}
+	if domainErr.Kind != KindNotFound || domainErr.Code != CodeUserNotFound {
+		t.Errorf("unexpected domain error: %+v", domainErr)
+	}
+	if !IsKind(err, KindNotFound) || IsKind(err, KindConflict) {
+		t.Error("IsKind does not match the wrapped kind")
+	}
+	if !HasCode(err, CodeUserNotFound) || HasCode(err, CodeDriverNotFound) {
+		t.Error("HasCode does not match the wrapped code")
+	}
+
+	if _, ok := As(errors.New("connection refused")); ok {
+		t.Error("expected no domain error in a plain error")
+	}
+}
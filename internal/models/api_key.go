@@ -0,0 +1,133 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"eco-van-api/internal/domainerr"
+
+	"github.com/google/uuid"
+)
+
+// APIKeyPrefix starts every API key so leaked keys are easy to recognize
+const APIKeyPrefix = "evk_"
+
+// apiKeyDisplayLength is how many leading characters of a key are kept to identify it in listings
+const apiKeyDisplayLength = 12
+
+// maxAPIKeyNameLength bounds the descriptive name of an API key
+const maxAPIKeyNameLength = 100
+
+// APIKey is an admin-managed credential for machine-to-machine integrations; only the hash of the key is stored.
+// Requests made with a key act with its role, further limited to its scopes.
+type APIKey struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	KeyPrefix  string     `json:"keyPrefix"`
+	KeyHash    string     `json:"-"`
+	Role       UserRole   `json:"role"`
+	Scopes     []string   `json:"scopes"`
+	CreatedBy  *uuid.UUID `json:"createdBy,omitempty"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+// CreateAPIKeyRequest represents a request to create an API key
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name"`
+	Role      UserRole   `json:"role"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// CreateAPIKeyResponse returns the key itself; it is shown only once
+type CreateAPIKeyResponse struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+// APIKeyListResponse represents the list of API keys
+type APIKeyListResponse struct {
+	Items []APIKey `json:"items"`
+}
+
+// APIKeyDisplayPrefix returns the leading part of a key that identifies it without revealing it
+func APIKeyDisplayPrefix(key string) string {
+	if len(key) <= apiKeyDisplayLength {
+		return key
+	}
+	return key[:apiKeyDisplayLength]
+}
+
+// IsActive reports whether the key is neither revoked nor expired
+func (k *APIKey) IsActive(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+// AllowsScope reports whether one of the key's scopes covers the action on the resource
+func (k *APIKey) AllowsScope(resource PermissionResource, action PermissionAction) bool {
+	for _, scope := range k.Scopes {
+		resourceName, actionName, ok := strings.Cut(scope, ":")
+		if !ok {
+			continue
+		}
+		if (resourceName == permissionWildcard || resourceName == string(resource)) &&
+			(actionName == permissionWildcard || actionName == string(action)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate validates the create API key request
+func (req *CreateAPIKeyRequest) Validate(now time.Time) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return domainerr.InvalidField("/name", "required", "name is required")
+	}
+	if len(name) > maxAPIKeyNameLength {
+		return domainerr.InvalidField("/name", "max", "name must be at most %d characters long", maxAPIKeyNameLength).
+			WithMessageID("max.string", strconv.Itoa(maxAPIKeyNameLength))
+	}
+
+	if !req.Role.IsValid() {
+		return domainerr.InvalidField("/role", "oneof", "invalid role")
+	}
+
+	if len(req.Scopes) == 0 {
+		return domainerr.InvalidField("/scopes", "required", "at least one scope is required")
+	}
+	for i, scope := range req.Scopes {
+		if err := ValidateAPIKeyScope(scope); err != nil {
+			return domainerr.InvalidField(fmt.Sprintf("/scopes/%d", i), "scope", "%v", err)
+		}
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return domainerr.InvalidField("/expiresAt", "future", "expiresAt must be in the future")
+	}
+
+	return nil
+}
+
+// ValidateAPIKeyScope checks that a scope has the resource:action form of the permission matrix, wildcards allowed
+func ValidateAPIKeyScope(scope string) error {
+	resourceName, actionName, ok := strings.Cut(scope, ":")
+	if !ok {
+		return fmt.Errorf("scope %q must have the form resource:action", scope)
+	}
+	if _, err := matchPermissionResources(resourceName); err != nil {
+		return fmt.Errorf("scope %q: %w", scope, err)
+	}
+	if _, err := matchPermissionActions(actionName); err != nil {
+		return fmt.Errorf("scope %q: %w", scope, err)
+	}
+	return nil
+}
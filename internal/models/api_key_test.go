@@ -0,0 +1,86 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAPIKey_AllowsScope(t *testing.T) {
+	key := &APIKey{Scopes: []string{"orders:read", "transport:*", "*:delete"}}
+
+	tests := []struct {
+		resource PermissionResource
+		action   PermissionAction
+		allowed  bool
+	}{
+		{ResourceOrders, PermissionActionRead, true},
+		{ResourceOrders, PermissionActionWrite, false},
+		{ResourceTransport, PermissionActionWrite, true},
+		{ResourceClients, PermissionActionDelete, true},
+		{ResourceClients, PermissionActionRead, false},
+	}
+
+	for _, tt := range tests {
+		if got := key.AllowsScope(tt.resource, tt.action); got != tt.allowed {
+			t.Errorf("AllowsScope(%s, %s) = %v, expected %v", tt.resource, tt.action, got, tt.allowed)
+		}
+	}
+}
+
+func TestAPIKey_IsActive(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name   string
+		key    APIKey
+		active bool
+	}{
+		{"no expiry", APIKey{}, true},
+		{"not yet expired", APIKey{ExpiresAt: &future}, true},
+		{"expired", APIKey{ExpiresAt: &past}, false},
+		{"revoked", APIKey{RevokedAt: &past}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.key.IsActive(now); got != tt.active {
+				t.Errorf("IsActive = %v, expected %v", got, tt.active)
+			}
+		})
+	}
+}
+
+func TestCreateAPIKeyRequest_Validate(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	valid := func() CreateAPIKeyRequest {
+		return CreateAPIKeyRequest{Name: "Accounting", Role: UserRoleViewer, Scopes: []string{"orders:read", "*:read"}}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(req *CreateAPIKeyRequest)
+		wantErr bool
+	}{
+		{"valid", func(req *CreateAPIKeyRequest) {}, false},
+		{"missing name", func(req *CreateAPIKeyRequest) { req.Name = "  " }, true},
+		{"invalid role", func(req *CreateAPIKeyRequest) { req.Role = "ROBOT" }, true},
+		{"no scopes", func(req *CreateAPIKeyRequest) { req.Scopes = nil }, true},
+		{"malformed scope", func(req *CreateAPIKeyRequest) { req.Scopes = []string{"orders"} }, true},
+		{"unknown resource", func(req *CreateAPIKeyRequest) { req.Scopes = []string{"invoices:read"} }, true},
+		{"unknown action", func(req *CreateAPIKeyRequest) { req.Scopes = []string{"orders:approve"} }, true},
+		{"expiry in the past", func(req *CreateAPIKeyRequest) { req.ExpiresAt = &past }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.mutate(&req)
+			if err := req.Validate(now); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
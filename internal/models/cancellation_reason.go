@@ -0,0 +1,73 @@
+package models
+
+import (
+	"time"
+)
+
+// Default cancellation reason codes seeded by migrations; admins may add more
+const (
+	CancellationReasonClientRequest    = "CLIENT_REQUEST"
+	CancellationReasonNoAccess         = "NO_ACCESS"
+	CancellationReasonVehicleBreakdown = "VEHICLE_BREAKDOWN"
+	CancellationReasonWeather          = "WEATHER"
+	CancellationReasonDuplicate        = "DUPLICATE"
+)
+
+// MonthLayout is the YYYY-MM format used by cancellation statistics
+const MonthLayout = "2006-01"
+
+// CancellationReason is an entry of the admin-managed order cancellation taxonomy
+type CancellationReason struct {
+	Code      string    `json:"code" db:"code"`
+	Label     string    `json:"label" db:"label"`
+	IsActive  bool      `json:"isActive" db:"is_active"`
+	CreatedAt time.Time `json:"createdAt" db:"created_at"`
+	UpdatedAt time.Time `json:"updatedAt" db:"updated_at"`
+}
+
+// CreateCancellationReasonRequest represents the request to create a cancellation reason
+type CreateCancellationReasonRequest struct {
+	Code  string `json:"code" validate:"required,min=2,max=50"`
+	Label string `json:"label" validate:"required,min=1,max=255"`
+}
+
+// UpdateCancellationReasonRequest represents the request to update a cancellation reason
+type UpdateCancellationReasonRequest struct {
+	Label    *string `json:"label,omitempty" validate:"omitempty,min=1,max=255"`
+	IsActive *bool   `json:"isActive,omitempty"`
+}
+
+// CancellationReasonListResponse represents the list of cancellation reasons
+type CancellationReasonListResponse struct {
+	Items []CancellationReason `json:"items"`
+}
+
+// CancellationStat is the number of orders canceled for a reason within a calendar month
+type CancellationStat struct {
+	Month      string  `json:"month"` // YYYY-MM
+	ReasonCode *string `json:"reasonCode"`
+	Count      int64   `json:"count"`
+}
+
+// CancellationStatsRequest represents the period for cancellation statistics (From inclusive, To exclusive)
+type CancellationStatsRequest struct {
+	From time.Time
+	To   time.Time
+}
+
+// CancellationStatsResponse represents monthly cancellation counts grouped by reason
+type CancellationStatsResponse struct {
+	From  string             `json:"from"` // YYYY-MM
+	To    string             `json:"to"`   // YYYY-MM, inclusive
+	Items []CancellationStat `json:"items"`
+}
+
+// UpdateFromRequest updates a CancellationReason from UpdateCancellationReasonRequest
+func (c *CancellationReason) UpdateFromRequest(req UpdateCancellationReasonRequest) {
+	if req.Label != nil {
+		c.Label = *req.Label
+	}
+	if req.IsActive != nil {
+		c.IsActive = *req.IsActive
+	}
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DispatchBoard is a day's orders grouped by transport
+type DispatchBoard struct {
+	Date       string              `json:"date"`
+	Transports []DispatchTransport `json:"transports"`
+	Unassigned []DispatchOrder     `json:"unassigned"`
+}
+
+// DispatchTransport is a transport with its current crew, equipment and orders for the day
+type DispatchTransport struct {
+	ID        uuid.UUID          `json:"id"`
+	PlateNo   string             `json:"plateNo"`
+	Brand     string             `json:"brand"`
+	Model     string             `json:"model"`
+	CapacityL int                `json:"capacityL"`
+	Status    string             `json:"status"`
+	Driver    *DispatchDriver    `json:"driver"`
+	Equipment *DispatchEquipment `json:"equipment"`
+	Orders    []DispatchOrder    `json:"orders"`
+}
+
+// DispatchDriver is the driver currently assigned to a transport
+type DispatchDriver struct {
+	ID       uuid.UUID `json:"id"`
+	FullName string    `json:"fullName"`
+	Phone    *string   `json:"phone,omitempty"`
+}
+
+// DispatchEquipment is the equipment currently attached to a transport
+type DispatchEquipment struct {
+	ID      uuid.UUID `json:"id"`
+	Number  *string   `json:"number,omitempty"`
+	Type    string    `json:"type"`
+	VolumeL int       `json:"volumeL"`
+}
+
+// DispatchOrder is an order as shown on the dispatch board
+type DispatchOrder struct {
+	ID                  uuid.UUID  `json:"id"`
+	ClientID            uuid.UUID  `json:"clientId"`
+	ClientName          string     `json:"clientName"`
+	ObjectID            uuid.UUID  `json:"objectId"`
+	ObjectName          string     `json:"objectName"`
+	Address             string     `json:"address"`
+	ScheduledWindowFrom *string    `json:"scheduledWindowFrom,omitempty"`
+	ScheduledWindowTo   *string    `json:"scheduledWindowTo,omitempty"`
+	Status              string     `json:"status"`
+	Priority            string     `json:"priority"`
+	TransportID         *uuid.UUID `json:"transportId,omitempty"`
+	// VolumeL is the volume of the equipment the order empties or picks up
+	VolumeL   int       `json:"volumeL"`
+	Notes     *string   `json:"notes,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// NewDispatchBoard groups the day's orders under their transport; orders are expected in display order
+func NewDispatchBoard(date string, transports []DispatchTransport, orders []DispatchOrder) *DispatchBoard {
+	board := &DispatchBoard{
+		Date:       date,
+		Transports: transports,
+		Unassigned: make([]DispatchOrder, 0),
+	}
+
+	byTransport := make(map[uuid.UUID]*DispatchTransport, len(transports))
+	for i := range board.Transports {
+		board.Transports[i].Orders = make([]DispatchOrder, 0)
+		byTransport[board.Transports[i].ID] = &board.Transports[i]
+	}
+
+	for _, order := range orders {
+		if order.TransportID != nil {
+			if transport, ok := byTransport[*order.TransportID]; ok {
+				transport.Orders = append(transport.Orders, order)
+				continue
+			}
+		}
+		// Orders whose transport was deleted need a new assignment as well
+		board.Unassigned = append(board.Unassigned, order)
+	}
+
+	return board
+}
@@ -32,7 +32,8 @@ type CreateDriverRequest struct {
 	Phone          *string              `json:"phone,omitempty" validate:"omitempty,max=20"`
 	LicenseNo      *string              `json:"licenseNo,omitempty" validate:"omitempty,min=5,max=20"`
 	LicenseClasses []DriverLicenseClass `json:"licenseClasses,omitempty" validate:"omitempty,dive,oneof=A A1 B B1 C C1 D D1 BE B1E CE C1E DE D1E"` //nolint:lll // long license validation enum
-	Photo          *string              `json:"photo,omitempty" validate:"omitempty,max=500"`
+	// Deprecated: photos are uploaded via /photos; setting photo fails validation
+	Photo *string `json:"photo,omitempty" validate:"deprecated"`
 }
 
 // UpdateDriverRequest represents the request to update an existing driver
@@ -41,7 +42,13 @@ type UpdateDriverRequest struct {
 	Phone          *string              `json:"phone,omitempty" validate:"omitempty,max=20"`
 	LicenseNo      *string              `json:"licenseNo,omitempty" validate:"omitempty,min=5,max=20"`
 	LicenseClasses []DriverLicenseClass `json:"licenseClasses,omitempty" validate:"omitempty,dive,oneof=A A1 B B1 C C1 D D1 BE B1E CE C1E DE D1E"` //nolint:lll // long license validation enum
-	Photo          *string              `json:"photo,omitempty" validate:"omitempty,max=500"`
+	// Deprecated: photos are uploaded via /photos; setting photo fails validation
+	Photo *string `json:"photo,omitempty" validate:"deprecated"`
+}
+
+// LinkDriverUserRequest represents the request to link a DRIVER user account to a driver
+type LinkDriverUserRequest struct {
+	UserID uuid.UUID `json:"userId" validate:"required"`
 }
 
 // DriverListRequest represents the request to list drivers with filtering and pagination
@@ -68,7 +75,9 @@ type DriverResponse struct {
 	Phone          *string    `json:"phone,omitempty"`
 	LicenseNo      *string    `json:"licenseNo,omitempty"`
 	LicenseClasses []string   `json:"licenseClasses,omitempty"`
-	Photo          *string    `json:"photo,omitempty"`
+	PhotoID        *uuid.UUID `json:"photoId,omitempty"`
+	Photo          *string    `json:"photo,omitempty"` // Deprecated: download path of PhotoID
+	UserID         *uuid.UUID `json:"userId,omitempty"`
 	CreatedAt      time.Time  `json:"createdAt"`
 	UpdatedAt      time.Time  `json:"updatedAt"`
 	DeletedAt      *time.Time `json:"deletedAt,omitempty"`
@@ -82,7 +91,9 @@ func (d *Driver) ToResponse() DriverResponse {
 		Phone:          d.Phone,
 		LicenseNo:      d.LicenseNo,
 		LicenseClasses: d.LicenseClasses,
-		Photo:          d.Photo,
+		PhotoID:        d.PhotoID,
+		Photo:          photoURL(PhotoEntityDrivers, d.ID, d.PhotoID),
+		UserID:         d.UserID,
 		CreatedAt:      d.CreatedAt,
 		UpdatedAt:      d.UpdatedAt,
 		DeletedAt:      d.DeletedAt,
@@ -95,7 +106,6 @@ func FromDriverCreateRequest(req CreateDriverRequest) *Driver {
 	driver := &Driver{
 		FullName:  req.FullName,
 		Phone:     req.Phone,
-		Photo:     req.Photo,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
@@ -131,8 +141,5 @@ func (d *Driver) UpdateFromRequest(req UpdateDriverRequest) {
 			d.LicenseClasses[i] = string(class)
 		}
 	}
-	if req.Photo != nil {
-		d.Photo = req.Photo
-	}
 	d.UpdatedAt = time.Now()
 }
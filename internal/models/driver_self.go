@@ -0,0 +1,19 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// DriverSelfResponse is the driver profile of the authenticated DRIVER user
+type DriverSelfResponse struct {
+	Driver DriverResponse `json:"driver"`
+	// Transport is the transport the driver is currently assigned to, if any
+	Transport *TransportResponse `json:"transport"`
+}
+
+// DriverOrdersResponse lists the orders of the driver's transport for a day
+type DriverOrdersResponse struct {
+	Date        string          `json:"date"`
+	TransportID *uuid.UUID      `json:"transportId"`
+	Items       []OrderResponse `json:"items"`
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"time"
 
+	"eco-van-api/internal/domainerr"
+
 	"github.com/google/uuid"
 )
 
@@ -33,10 +35,11 @@ type CreateEquipmentRequest struct {
 	Type           EquipmentType      `json:"type" validate:"required,oneof=BIN CONTAINER"`
 	VolumeL        int                `json:"volumeL" validate:"required,gt=0"`
 	Condition      EquipmentCondition `json:"condition" validate:"required,oneof=GOOD DAMAGED OUT_OF_SERVICE"`
-	Photo          *string            `json:"photo,omitempty" validate:"omitempty,max=500"`
 	ClientObjectID *uuid.UUID         `json:"clientObjectId"`
 	WarehouseID    *uuid.UUID         `json:"warehouseId"`
 	TransportID    *uuid.UUID         `json:"transportId"`
+	// Deprecated: photos are uploaded via /photos; setting photo fails validation
+	Photo *string `json:"photo,omitempty" validate:"deprecated"`
 }
 
 // UpdateEquipmentRequest represents the request to update existing equipment
@@ -45,10 +48,11 @@ type UpdateEquipmentRequest struct {
 	Type           EquipmentType      `json:"type" validate:"required,oneof=BIN CONTAINER"`
 	VolumeL        int                `json:"volumeL" validate:"required,gt=0"`
 	Condition      EquipmentCondition `json:"condition" validate:"required,oneof=GOOD DAMAGED OUT_OF_SERVICE"`
-	Photo          *string            `json:"photo,omitempty" validate:"omitempty,max=500"`
 	ClientObjectID *uuid.UUID         `json:"clientObjectId"`
 	WarehouseID    *uuid.UUID         `json:"warehouseId"`
 	TransportID    *uuid.UUID         `json:"transportId"`
+	// Deprecated: photos are uploaded via /photos; setting photo fails validation
+	Photo *string `json:"photo,omitempty" validate:"deprecated"`
 }
 
 // EquipmentListRequest represents the request to list equipment with filtering and pagination
@@ -77,7 +81,8 @@ type EquipmentResponse struct {
 	Type           EquipmentType      `json:"type"`
 	VolumeL        int                `json:"volumeL"`
 	Condition      EquipmentCondition `json:"condition"`
-	Photo          *string            `json:"photo,omitempty"`
+	PhotoID        *uuid.UUID         `json:"photoId,omitempty"`
+	Photo          *string            `json:"photo,omitempty"` // Deprecated: download path of PhotoID
 	ClientObjectID *uuid.UUID         `json:"clientObjectId"`
 	WarehouseID    *uuid.UUID         `json:"warehouseId"`
 	TransportID    *uuid.UUID         `json:"transportId"`
@@ -94,7 +99,8 @@ func (e *Equipment) ToResponse() EquipmentResponse {
 		Type:           EquipmentType(e.Type),
 		VolumeL:        e.VolumeL,
 		Condition:      EquipmentCondition(e.Condition),
-		Photo:          e.Photo,
+		PhotoID:        e.PhotoID,
+		Photo:          photoURL(PhotoEntityEquipment, e.ID, e.PhotoID),
 		ClientObjectID: e.ClientObjectID,
 		WarehouseID:    e.WarehouseID,
 		TransportID:    e.TransportID,
@@ -113,7 +119,6 @@ func FromEquipmentCreateRequest(req CreateEquipmentRequest) Equipment {
 		VolumeL:        req.VolumeL,
 		Condition:      string(req.Condition),
 		Number:         req.Number,
-		Photo:          req.Photo,
 		ClientObjectID: req.ClientObjectID,
 		WarehouseID:    req.WarehouseID,
 		TransportID:    req.TransportID,
@@ -128,7 +133,6 @@ func (e *Equipment) UpdateFromRequest(req UpdateEquipmentRequest) {
 	e.VolumeL = req.VolumeL
 	e.Condition = string(req.Condition)
 	e.Number = req.Number
-	e.Photo = req.Photo
 	e.ClientObjectID = req.ClientObjectID
 	e.WarehouseID = req.WarehouseID
 	e.TransportID = req.TransportID
@@ -148,7 +152,7 @@ func (req *CreateEquipmentRequest) ValidatePlacement() error {
 	}
 
 	if count != 1 {
-		return fmt.Errorf("equipment must be assigned to exactly one of: transport, client object, or warehouse")
+		return errInvalidPlacement()
 	}
 	return nil
 }
@@ -169,7 +173,16 @@ func (req *UpdateEquipmentRequest) ValidatePlacement() error {
 	// For updates, if no placement is specified, it's valid (will keep existing placement)
 	// If placement is specified, exactly one must be set
 	if count > 0 && count != 1 {
-		return fmt.Errorf("equipment must be assigned to exactly one of: transport, client object, or warehouse")
+		return errInvalidPlacement()
 	}
 	return nil
 }
+
+// errInvalidPlacement reports a placement that is not exactly one of the three; each placement field is flagged
+func errInvalidPlacement() error {
+	const message = "equipment must be assigned to exactly one of: transport, client object, or warehouse"
+	return domainerr.Validation(domainerr.CodeEquipmentInvalidPlacement, message).
+		WithFieldError("/transportId", "exactly_one", message).
+		WithFieldError("/clientObjectId", "exactly_one", message).
+		WithFieldError("/warehouseId", "exactly_one", message)
+}
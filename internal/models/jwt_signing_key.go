@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// JWT signing algorithms; HS256 uses the shared JWT_SECRET, the others use rotating key pairs published as JWKS
+const (
+	JWTAlgorithmHS256 = "HS256"
+	JWTAlgorithmRS256 = "RS256"
+	JWTAlgorithmEdDSA = "EdDSA"
+)
+
+// JWTSigningKey is a persisted token signing key pair identified by the kid header of the tokens it signs.
+// A key signs tokens from ActivatesAt until a newer key activates and verifies them until ExpiresAt.
+type JWTSigningKey struct {
+	KID           string
+	Algorithm     string
+	PrivateKeyPEM string
+	ActivatesAt   time.Time
+	// ExpiresAt is set once a successor is created; nil for the newest key
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+}
+
+// IsAsymmetricJWTAlgorithm reports whether tokens are signed with rotating key pairs instead of the shared secret
+func IsAsymmetricJWTAlgorithm(algorithm string) bool {
+	return algorithm == JWTAlgorithmRS256 || algorithm == JWTAlgorithmEdDSA
+}
@@ -0,0 +1,132 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Login attempt failure reasons recorded for security review
+const (
+	LoginFailureInvalidCredentials = "INVALID_CREDENTIALS"
+	LoginFailureAccountDisabled    = "ACCOUNT_DISABLED"
+	LoginFailureThrottled          = "THROTTLED"
+)
+
+// LoginThrottlePolicy configures brute-force protection of the login endpoint
+type LoginThrottlePolicy struct {
+	// MaxAccountFailures locks an account after this many failures within FailureWindow
+	MaxAccountFailures int
+	// MaxIPFailures locks a client IP after this many failures within FailureWindow
+	MaxIPFailures int
+	// FailureWindow is how long a failure counts; counters restart after a quiet window
+	FailureWindow time.Duration
+	// Lockout is how long a locked account or IP is rejected
+	Lockout time.Duration
+	// BaseDelay is the wait after the first failure; it doubles with every further failure up to MaxDelay
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+// Delay returns the wait required after the given number of consecutive failures
+func (p LoginThrottlePolicy) Delay(failures int) time.Duration {
+	if failures <= 0 || p.BaseDelay <= 0 {
+		return 0
+	}
+	delay := p.BaseDelay
+	for i := 1; i < failures && delay < p.MaxDelay; i++ {
+		delay += delay
+	}
+	if delay > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return delay
+}
+
+// LoginThrottle is the stored failure counter of an account or client IP
+type LoginThrottle struct {
+	Key           string
+	Failures      int
+	LastFailureAt time.Time
+	LockedUntil   *time.Time
+}
+
+// RetryAfter returns how long the next login attempt must wait, zero if it is allowed now
+func (t *LoginThrottle) RetryAfter(now time.Time, policy LoginThrottlePolicy) time.Duration {
+	if t == nil {
+		return 0
+	}
+	if t.LockedUntil != nil && now.Before(*t.LockedUntil) {
+		return t.LockedUntil.Sub(now)
+	}
+	if now.Sub(t.LastFailureAt) >= policy.FailureWindow {
+		return 0
+	}
+	if wait := t.LastFailureAt.Add(policy.Delay(t.Failures)).Sub(now); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+// AccountThrottleKey is the throttle key of the account with the given email
+func AccountThrottleKey(email string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+// IPThrottleKey is the throttle key of a client IP
+func IPThrottleKey(ip string) string {
+	return "ip:" + ip
+}
+
+// LoginThrottledError is returned when a login attempt arrives before the throttle allows it
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return fmt.Sprintf("login throttled: too many failed attempts, retry in %d seconds", e.RetryAfterSeconds())
+}
+
+// RetryAfterSeconds rounds the wait up to whole seconds for the Retry-After header
+func (e *LoginThrottledError) RetryAfterSeconds() int {
+	return int(math.Ceil(e.RetryAfter.Seconds()))
+}
+
+// ClientInfo identifies the client of a request
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
+
+// LoginAttempt is a recorded login attempt
+type LoginAttempt struct {
+	ID            uuid.UUID  `json:"id"`
+	Email         string     `json:"email"`
+	UserID        *uuid.UUID `json:"userId,omitempty"`
+	IP            string     `json:"ip"`
+	UserAgent     string     `json:"userAgent"`
+	Success       bool       `json:"success"`
+	FailureReason *string    `json:"failureReason,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+}
+
+// LoginAttemptListRequest filters the login attempt log
+type LoginAttemptListRequest struct {
+	Page     int        `json:"page" validate:"min=1"`
+	PageSize int        `json:"pageSize" validate:"min=1,max=100"`
+	Email    *string    `json:"email,omitempty"`
+	IP       *string    `json:"ip,omitempty"`
+	UserID   *uuid.UUID `json:"userId,omitempty"`
+	Success  *bool      `json:"success,omitempty"`
+}
+
+// LoginAttemptListResponse represents the paginated login attempt log
+type LoginAttemptListResponse struct {
+	Items    []LoginAttempt `json:"items"`
+	Page     int            `json:"page"`
+	PageSize int            `json:"pageSize"`
+	Total    int64          `json:"total"`
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func testLoginThrottlePolicy() LoginThrottlePolicy {
+	return LoginThrottlePolicy{
+		MaxAccountFailures: 5,
+		MaxIPFailures:      20,
+		FailureWindow:      15 * time.Minute,
+		Lockout:            15 * time.Minute,
+		BaseDelay:          time.Second,
+		MaxDelay:           30 * time.Second,
+	}
+}
+
+func TestLoginThrottlePolicy_Delay(t *testing.T) {
+	policy := testLoginThrottlePolicy()
+
+	tests := []struct {
+		failures int
+		expected time.Duration
+	}{
+		{0, 0},
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{5, 16 * time.Second},
+		{6, 30 * time.Second},
+		{60, 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := policy.Delay(tt.failures); got != tt.expected {
+			t.Errorf("Delay(%d) = %v, expected %v", tt.failures, got, tt.expected)
+		}
+	}
+
+	policy.BaseDelay = 0
+	if got := policy.Delay(3); got != 0 {
+		t.Errorf("Delay with zero base = %v, expected 0", got)
+	}
+}
+
+func TestLoginThrottle_RetryAfter(t *testing.T) {
+	policy := testLoginThrottlePolicy()
+	now := time.Now()
+	lockedUntil := now.Add(10 * time.Minute)
+	expiredLock := now.Add(-time.Minute)
+
+	tests := []struct {
+		name     string
+		throttle *LoginThrottle
+		expected time.Duration
+	}{
+		{"no counter", nil, 0},
+		{"locked", &LoginThrottle{Failures: 5, LastFailureAt: now, LockedUntil: &lockedUntil}, 10 * time.Minute},
+		{"delay after failures", &LoginThrottle{Failures: 3, LastFailureAt: now.Add(-time.Second)}, 3 * time.Second},
+		{"delay elapsed", &LoginThrottle{Failures: 2, LastFailureAt: now.Add(-5 * time.Second)}, 0},
+		{"window elapsed", &LoginThrottle{Failures: 4, LastFailureAt: now.Add(-20 * time.Minute)}, 0},
+		{"lock expired", &LoginThrottle{Failures: 5, LastFailureAt: now.Add(-20 * time.Minute), LockedUntil: &expiredLock}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.throttle.RetryAfter(now, policy); got != tt.expected {
+				t.Errorf("RetryAfter = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLoginThrottledError_RetryAfterSeconds(t *testing.T) {
+	err := &LoginThrottledError{RetryAfter: 1500 * time.Millisecond}
+	if got := err.RetryAfterSeconds(); got != 2 {
+		t.Errorf("RetryAfterSeconds = %d, expected 2", got)
+	}
+}
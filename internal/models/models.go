@@ -2,8 +2,11 @@ package models
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
+	"eco-van-api/internal/domainerr"
+
 	"github.com/google/uuid"
 )
 
@@ -41,7 +44,7 @@ type Equipment struct {
 	Type           string     `json:"type" db:"type"`
 	VolumeL        int        `json:"volumeL" db:"volume_l"`
 	Condition      string     `json:"condition" db:"condition"`
-	Photo          *string    `json:"photo,omitempty" db:"photo"`
+	PhotoID        *uuid.UUID `json:"photoId,omitempty" db:"photo_id"` // latest row of photos, read-only
 	ClientObjectID *uuid.UUID `json:"clientObjectId" db:"client_object_id"`
 	WarehouseID    *uuid.UUID `json:"warehouseId" db:"warehouse_id"`
 	TransportID    *uuid.UUID `json:"transportId" db:"transport_id"`
@@ -72,7 +75,8 @@ type Driver struct {
 	Phone          *string    `json:"phone,omitempty" db:"phone"`
 	LicenseNo      *string    `json:"licenseNo,omitempty" db:"license_no"`
 	LicenseClasses []string   `json:"licenseClasses,omitempty" db:"license_classes"`
-	Photo          *string    `json:"photo,omitempty" db:"photo"`
+	PhotoID        *uuid.UUID `json:"photoId,omitempty" db:"photo_id"` // latest row of photos, read-only
+	UserID         *uuid.UUID `json:"userId,omitempty" db:"user_id"`
 	CreatedAt      time.Time  `json:"createdAt" db:"created_at"`
 	UpdatedAt      time.Time  `json:"updatedAt" db:"updated_at"`
 	DeletedAt      *time.Time `json:"deletedAt,omitempty" db:"deleted_at"`
@@ -106,39 +110,71 @@ func IsValidOrderPriority(priority string) bool {
 
 // Order represents waste collection requests
 type Order struct {
-	ID                  uuid.UUID  `json:"id" db:"id"`
-	ClientID            uuid.UUID  `json:"clientId" db:"client_id"`
-	ObjectID            uuid.UUID  `json:"objectId" db:"object_id"`
-	ScheduledDate       time.Time  `json:"scheduledDate" db:"scheduled_date"`
-	ScheduledWindowFrom *string    `json:"scheduledWindowFrom,omitempty" db:"scheduled_window_from"`
-	ScheduledWindowTo   *string    `json:"scheduledWindowTo,omitempty" db:"scheduled_window_to"`
-	Status              string     `json:"status" db:"status"`
-	Priority            string     `json:"priority" db:"priority"`
-	TransportID         *uuid.UUID `json:"transportId" db:"transport_id"`
-	Notes               *string    `json:"notes,omitempty" db:"notes"`
-	CreatedBy           *uuid.UUID `json:"createdBy,omitempty" db:"created_by"`
-	CreatedAt           time.Time  `json:"createdAt" db:"created_at"`
-	UpdatedAt           time.Time  `json:"updatedAt" db:"updated_at"`
-	DeletedAt           *time.Time `json:"deletedAt,omitempty" db:"deleted_at"`
+	ID                     uuid.UUID  `json:"id" db:"id"`
+	ClientID               uuid.UUID  `json:"clientId" db:"client_id"`
+	ObjectID               uuid.UUID  `json:"objectId" db:"object_id"`
+	ScheduledDate          time.Time  `json:"scheduledDate" db:"scheduled_date"`
+	ScheduledWindowFrom    *string    `json:"scheduledWindowFrom,omitempty" db:"scheduled_window_from"`
+	ScheduledWindowTo      *string    `json:"scheduledWindowTo,omitempty" db:"scheduled_window_to"`
+	Status                 string     `json:"status" db:"status"`
+	Priority               string     `json:"priority" db:"priority"`
+	TransportID            *uuid.UUID `json:"transportId" db:"transport_id"`
+	Notes                  *string    `json:"notes,omitempty" db:"notes"`
+	CreatedBy              *uuid.UUID `json:"createdBy,omitempty" db:"created_by"`
+	ScheduleID             *uuid.UUID `json:"scheduleId,omitempty" db:"schedule_id"`
+	CancellationReasonCode *string    `json:"cancellationReasonCode,omitempty" db:"cancellation_reason_code"`
+	CancellationNote       *string    `json:"cancellationNote,omitempty" db:"cancellation_note"`
+	CanceledAt             *time.Time `json:"canceledAt,omitempty" db:"canceled_at"`
+	CreatedAt              time.Time  `json:"createdAt" db:"created_at"`
+	UpdatedAt              time.Time  `json:"updatedAt" db:"updated_at"`
+	DeletedAt              *time.Time `json:"deletedAt,omitempty" db:"deleted_at"`
+	// Version is bumped by every update; clients echo it in If-Match to detect concurrent edits
+	Version int `json:"version" db:"version"`
+	// Completion is stored in order_completions when the order transitions to COMPLETED
+	Completion *OrderCompletion `json:"completion,omitempty" db:"-"`
+}
+
+// VersionMatch is the If-Match precondition of a write: it holds when the current version is one of Versions
+type VersionMatch struct {
+	Versions []int
+}
+
+// Matches reports whether a write conditional on m may apply at version; a nil m makes the write unconditional
+func (m *VersionMatch) Matches(version int) bool {
+	if m == nil {
+		return true
+	}
+	for _, v := range m.Versions {
+		if v == version {
+			return true
+		}
+	}
+	return false
 }
 
 // ToResponse converts an Order model to OrderResponse
 func (o *Order) ToResponse() OrderResponse {
 	return OrderResponse{
-		ID:                  o.ID,
-		ClientID:            o.ClientID,
-		ObjectID:            o.ObjectID,
-		ScheduledDate:       o.ScheduledDate,
-		ScheduledWindowFrom: o.ScheduledWindowFrom,
-		ScheduledWindowTo:   o.ScheduledWindowTo,
-		Status:              o.Status,
-		Priority:            o.Priority,
-		TransportID:         o.TransportID,
-		Notes:               o.Notes,
-		CreatedBy:           o.CreatedBy,
-		CreatedAt:           o.CreatedAt,
-		UpdatedAt:           o.UpdatedAt,
-		DeletedAt:           o.DeletedAt,
+		ID:                     o.ID,
+		ClientID:               o.ClientID,
+		ObjectID:               o.ObjectID,
+		ScheduledDate:          o.ScheduledDate,
+		ScheduledWindowFrom:    o.ScheduledWindowFrom,
+		ScheduledWindowTo:      o.ScheduledWindowTo,
+		Status:                 o.Status,
+		Priority:               o.Priority,
+		TransportID:            o.TransportID,
+		Notes:                  o.Notes,
+		CreatedBy:              o.CreatedBy,
+		ScheduleID:             o.ScheduleID,
+		CancellationReasonCode: o.CancellationReasonCode,
+		CancellationNote:       o.CancellationNote,
+		CanceledAt:             o.CanceledAt,
+		CreatedAt:              o.CreatedAt,
+		UpdatedAt:              o.UpdatedAt,
+		DeletedAt:              o.DeletedAt,
+		Version:                o.Version,
+		Completion:             o.Completion,
 	}
 }
 
@@ -280,6 +316,8 @@ type CreateOrderRequest struct {
 	Priority            *string    `json:"priority,omitempty" validate:"omitempty,oneof=LOW MEDIUM HIGH"`
 	TransportID         *uuid.UUID `json:"transportId,omitempty" validate:"omitempty"`
 	Notes               *string    `json:"notes,omitempty" validate:"omitempty,max=1000"`
+	// OverrideConflicts lets a dispatcher book the transport despite overlapping windows or exceeded capacity
+	OverrideConflicts bool `json:"overrideConflicts,omitempty"`
 }
 
 // UpdateOrderRequest represents the request to update an existing order
@@ -292,28 +330,56 @@ type UpdateOrderRequest struct {
 	Priority            *string    `json:"priority,omitempty" validate:"omitempty,oneof=LOW MEDIUM HIGH"`
 	TransportID         *uuid.UUID `json:"transportId,omitempty" validate:"omitempty"`
 	Notes               *string    `json:"notes,omitempty" validate:"omitempty,max=1000"`
+	// OverrideConflicts lets a dispatcher book the transport despite overlapping windows or exceeded capacity
+	OverrideConflicts bool `json:"overrideConflicts,omitempty"`
 }
 
 // UpdateOrderStatusRequest represents the request to update order status
 type UpdateOrderStatusRequest struct {
 	Status OrderStatus `json:"status" validate:"required,oneof=DRAFT SCHEDULED IN_PROGRESS COMPLETED CANCELED"`
+	Reason *string     `json:"reason,omitempty" validate:"omitempty,max=1000"`
+	// ReasonCode is a cancellation reason code; required together with Reason when Status is CANCELED
+	ReasonCode *string `json:"reasonCode,omitempty" validate:"omitempty,max=50"`
+	// Completion records the collected volume and waste category; required when Status is COMPLETED
+	Completion *OrderCompletionRequest `json:"completion,omitempty"`
+}
+
+// ValidateCancellation checks that a transition to CANCELED carries a reason code and free-text reason
+func (r *UpdateOrderStatusRequest) ValidateCancellation() error {
+	if r.Status != OrderStatusCanceled {
+		if r.ReasonCode != nil {
+			return domainerr.InvalidField("/reasonCode", "excluded_unless", "reasonCode is only allowed when canceling an order")
+		}
+		return nil
+	}
+	if r.ReasonCode == nil || strings.TrimSpace(*r.ReasonCode) == "" {
+		return domainerr.InvalidField("/reasonCode", "required_if", "reasonCode is required when canceling an order")
+	}
+	if r.Reason == nil || strings.TrimSpace(*r.Reason) == "" {
+		return domainerr.InvalidField("/reason", "required_if", "reason is required when canceling an order")
+	}
+	return nil
 }
 
 // AssignTransportRequest represents the request to assign transport to an order
 type AssignTransportRequest struct {
 	TransportID uuid.UUID `json:"transportId" validate:"required"`
+	// OverrideConflicts lets a dispatcher book the transport despite overlapping windows or exceeded capacity
+	OverrideConflicts bool `json:"overrideConflicts,omitempty"`
 }
 
 // OrderListRequest represents the request to list orders with filtering and pagination
 type OrderListRequest struct {
-	Page           int          `json:"page" validate:"min=1"`
-	PageSize       int          `json:"pageSize" validate:"min=1,max=100"`
-	Status         *OrderStatus `json:"status,omitempty"`
-	Priority       *string      `json:"priority,omitempty" validate:"omitempty,oneof=LOW MEDIUM HIGH"`
-	Date           *time.Time   `json:"date,omitempty"`
-	ClientID       *uuid.UUID   `json:"clientId,omitempty"`
-	ObjectID       *uuid.UUID   `json:"objectId,omitempty"`
-	IncludeDeleted bool         `json:"includeDeleted"`
+	Page               int          `json:"page" validate:"min=1"`
+	PageSize           int          `json:"pageSize" validate:"min=1,max=100"`
+	Status             *OrderStatus `json:"status,omitempty"`
+	Priority           *string      `json:"priority,omitempty" validate:"omitempty,oneof=LOW MEDIUM HIGH"`
+	Date               *time.Time   `json:"date,omitempty"`
+	ClientID           *uuid.UUID   `json:"clientId,omitempty"`
+	TransportID        *uuid.UUID   `json:"transportId,omitempty"`
+	ObjectID           *uuid.UUID   `json:"objectId,omitempty"`
+	CancellationReason *string      `json:"cancellationReason,omitempty"`
+	IncludeDeleted     bool         `json:"includeDeleted"`
 }
 
 // OrderListResponse represents the paginated response for listing orders
@@ -326,18 +392,24 @@ type OrderListResponse struct {
 
 // OrderResponse represents a single order response
 type OrderResponse struct {
-	ID                  uuid.UUID  `json:"id"`
-	ClientID            uuid.UUID  `json:"clientId"`
-	ObjectID            uuid.UUID  `json:"objectId"`
-	ScheduledDate       time.Time  `json:"scheduledDate"`
-	ScheduledWindowFrom *string    `json:"scheduledWindowFrom,omitempty"`
-	ScheduledWindowTo   *string    `json:"scheduledWindowTo,omitempty"`
-	Status              string     `json:"status"`
-	Priority            string     `json:"priority"`
-	TransportID         *uuid.UUID `json:"transportId"`
-	Notes               *string    `json:"notes,omitempty"`
-	CreatedBy           *uuid.UUID `json:"createdBy,omitempty"`
-	CreatedAt           time.Time  `json:"createdAt"`
-	UpdatedAt           time.Time  `json:"updatedAt"`
-	DeletedAt           *time.Time `json:"deletedAt,omitempty"`
+	ID                     uuid.UUID        `json:"id"`
+	ClientID               uuid.UUID        `json:"clientId"`
+	ObjectID               uuid.UUID        `json:"objectId"`
+	ScheduledDate          time.Time        `json:"scheduledDate"`
+	ScheduledWindowFrom    *string          `json:"scheduledWindowFrom,omitempty"`
+	ScheduledWindowTo      *string          `json:"scheduledWindowTo,omitempty"`
+	Status                 string           `json:"status"`
+	Priority               string           `json:"priority"`
+	TransportID            *uuid.UUID       `json:"transportId"`
+	Notes                  *string          `json:"notes,omitempty"`
+	CreatedBy              *uuid.UUID       `json:"createdBy,omitempty"`
+	ScheduleID             *uuid.UUID       `json:"scheduleId,omitempty"`
+	CancellationReasonCode *string          `json:"cancellationReasonCode,omitempty"`
+	CancellationNote       *string          `json:"cancellationNote,omitempty"`
+	CanceledAt             *time.Time       `json:"canceledAt,omitempty"`
+	CreatedAt              time.Time        `json:"createdAt"`
+	UpdatedAt              time.Time        `json:"updatedAt"`
+	DeletedAt              *time.Time       `json:"deletedAt,omitempty"`
+	Version                int              `json:"version"`
+	Completion             *OrderCompletion `json:"completion,omitempty"`
 }
@@ -462,3 +462,18 @@ func TestDriver_RequiredFields(t *testing.T) {
 		t.Errorf("Driver should have FullName field filled")
 	}
 }
+
+func TestEquipment_ToResponsePhoto(t *testing.T) {
+	equipment := Equipment{ID: uuid.New()}
+	if response := equipment.ToResponse(); response.PhotoID != nil || response.Photo != nil {
+		t.Errorf("Expected no photo without a photo row, got %v %v", response.PhotoID, response.Photo)
+	}
+
+	photoID := uuid.New()
+	equipment.PhotoID = &photoID
+	response := equipment.ToResponse()
+	want := "/api/v1/photos/equipment/" + equipment.ID.String() + "/" + photoID.String()
+	if response.Photo == nil || *response.Photo != want {
+		t.Errorf("Expected the photo download path %s, got %v", want, response.Photo)
+	}
+}
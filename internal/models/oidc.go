@@ -0,0 +1,105 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"eco-van-api/internal/domainerr"
+
+	"github.com/google/uuid"
+)
+
+// oidcRolePrecedence orders roles from most to least privileged; a user in several mapped groups gets the first
+var oidcRolePrecedence = []UserRole{UserRoleAdmin, UserRoleDispatcher, UserRoleDriver, UserRoleViewer}
+
+// OIDCClaims are the verified ID token claims of a single sign-on login
+type OIDCClaims struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	// Groups holds the values of the configured groups/roles claim
+	Groups []string
+}
+
+// OIDCIdentity links an identity provider account to a user
+type OIDCIdentity struct {
+	Issuer    string
+	Subject   string
+	UserID    uuid.UUID
+	CreatedAt time.Time
+}
+
+// OIDCLoginState is a pending authorization request; it is stored under the hash of its state and used once
+type OIDCLoginState struct {
+	StateHash    string
+	Nonce        string
+	CodeVerifier string
+	ExpiresAt    time.Time
+}
+
+// OIDCAuthorizeResponse tells the client where to send the user to sign in; the client keeps the state
+// and passes it back with the code, which binds the callback to the browser that started the login
+type OIDCAuthorizeResponse struct {
+	AuthorizationURL string `json:"authorizationUrl"`
+	State            string `json:"state"`
+}
+
+// OIDCCallbackRequest completes a single sign-on login with the code returned by the identity provider
+type OIDCCallbackRequest struct {
+	Code  string `json:"code"`
+	State string `json:"state"`
+}
+
+// Validate validates the OIDC callback request
+func (req *OIDCCallbackRequest) Validate() error {
+	if strings.TrimSpace(req.Code) == "" {
+		return domainerr.InvalidField("/code", "required", "code is required")
+	}
+	if strings.TrimSpace(req.State) == "" {
+		return domainerr.InvalidField("/state", "required", "state is required")
+	}
+	return nil
+}
+
+// OIDCRoleMapping maps identity provider group or role claim values to user roles
+type OIDCRoleMapping map[string]UserRole
+
+// ParseOIDCRoleMapping parses a comma-separated list of value=ROLE pairs, e.g. "eco-admins=ADMIN,office=DISPATCHER"
+func ParseOIDCRoleMapping(spec string) (OIDCRoleMapping, error) {
+	mapping := OIDCRoleMapping{}
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		value, roleName, ok := strings.Cut(pair, "=")
+		value = strings.TrimSpace(value)
+		role := UserRole(strings.ToUpper(strings.TrimSpace(roleName)))
+		if !ok || value == "" {
+			return nil, fmt.Errorf("mapping %q must have the form value=ROLE", pair)
+		}
+		if !role.IsValid() {
+			return nil, fmt.Errorf("mapping %q: invalid role %q", pair, roleName)
+		}
+		mapping[value] = role
+	}
+	return mapping, nil
+}
+
+// Resolve returns the most privileged role mapped from the given claim values
+func (m OIDCRoleMapping) Resolve(groups []string) (UserRole, bool) {
+	mapped := make(map[UserRole]bool, len(groups))
+	for _, group := range groups {
+		if role, ok := m[group]; ok {
+			mapped[role] = true
+		}
+	}
+	for _, role := range oidcRolePrecedence {
+		if mapped[role] {
+			return role, true
+		}
+	}
+	return "", false
+}
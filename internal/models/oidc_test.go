@@ -0,0 +1,50 @@
+package models
+
+import "testing"
+
+func TestParseOIDCRoleMapping(t *testing.T) {
+	mapping, err := ParseOIDCRoleMapping(" eco-admins=ADMIN, office=dispatcher ,,drivers=DRIVER")
+	if err != nil {
+		t.Fatalf("Failed to parse mapping: %v", err)
+	}
+	expected := OIDCRoleMapping{"eco-admins": UserRoleAdmin, "office": UserRoleDispatcher, "drivers": UserRoleDriver}
+	if len(mapping) != len(expected) {
+		t.Fatalf("Expected %d mappings, got %d", len(expected), len(mapping))
+	}
+	for value, role := range expected {
+		if mapping[value] != role {
+			t.Errorf("Expected %s to map to %s, got %s", value, role, mapping[value])
+		}
+	}
+
+	for _, spec := range []string{"eco-admins", "=ADMIN", "office=OWNER"} {
+		if _, err := ParseOIDCRoleMapping(spec); err == nil {
+			t.Errorf("Expected error for mapping %q", spec)
+		}
+	}
+}
+
+func TestOIDCRoleMapping_Resolve(t *testing.T) {
+	mapping := OIDCRoleMapping{"eco-admins": UserRoleAdmin, "office": UserRoleDispatcher, "everyone": UserRoleViewer}
+
+	tests := []struct {
+		name     string
+		groups   []string
+		expected UserRole
+		ok       bool
+	}{
+		{"single group", []string{"office"}, UserRoleDispatcher, true},
+		{"most privileged wins", []string{"everyone", "eco-admins", "office"}, UserRoleAdmin, true},
+		{"unmapped groups", []string{"finance"}, "", false},
+		{"no groups", nil, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role, ok := mapping.Resolve(tt.groups)
+			if role != tt.expected || ok != tt.ok {
+				t.Errorf("Resolve(%v) = %s, %v, expected %s, %v", tt.groups, role, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"time"
+
+	"eco-van-api/internal/domainerr"
+
+	"github.com/google/uuid"
+)
+
+// WasteCategory classifies collected waste for billing and regulatory reporting
+type WasteCategory string
+
+const (
+	WasteCategoryMixed        WasteCategory = "MIXED"
+	WasteCategoryPaper        WasteCategory = "PAPER"
+	WasteCategoryPlastic      WasteCategory = "PLASTIC"
+	WasteCategoryGlass        WasteCategory = "GLASS"
+	WasteCategoryMetal        WasteCategory = "METAL"
+	WasteCategoryOrganic      WasteCategory = "ORGANIC"
+	WasteCategoryConstruction WasteCategory = "CONSTRUCTION"
+	WasteCategoryBulky        WasteCategory = "BULKY"
+	WasteCategoryHazardous    WasteCategory = "HAZARDOUS"
+)
+
+// OrderCompletion records what was actually collected when an order was completed
+type OrderCompletion struct {
+	OrderID       uuid.UUID     `json:"orderId" db:"order_id"`
+	VolumeL       int           `json:"volumeL" db:"volume_l"`
+	WeightKg      *float64      `json:"weightKg,omitempty" db:"weight_kg"`
+	WasteCategory WasteCategory `json:"wasteCategory" db:"waste_category"`
+	CompletedBy   *uuid.UUID    `json:"completedBy,omitempty" db:"completed_by"`
+	CompletedAt   time.Time     `json:"completedAt" db:"completed_at"`
+}
+
+// OrderCompletionRequest carries the collected amounts in UpdateOrderStatusRequest
+type OrderCompletionRequest struct {
+	VolumeL       int           `json:"volumeL" validate:"required,min=1,max=1000000"`
+	WeightKg      *float64      `json:"weightKg,omitempty" validate:"omitempty,gt=0,max=1000000"`
+	WasteCategory WasteCategory `json:"wasteCategory" validate:"required,oneof=MIXED PAPER PLASTIC GLASS METAL ORGANIC CONSTRUCTION BULKY HAZARDOUS"` //nolint:lll // long waste category validation enum
+}
+
+// ValidateCompletion checks that a transition to COMPLETED carries a completion record and no other transition does
+func (r *UpdateOrderStatusRequest) ValidateCompletion() error {
+	if r.Status != OrderStatusCompleted {
+		if r.Completion != nil {
+			return domainerr.InvalidField("/completion", "excluded_unless", "completion is only allowed when completing an order")
+		}
+		return nil
+	}
+	if r.Completion == nil {
+		return domainerr.InvalidField("/completion", "required_if",
+			"completion with volumeL and wasteCategory is required when completing an order")
+	}
+	return nil
+}
+
+// ToCompletion creates the completion record of an order from the request
+func (r *OrderCompletionRequest) ToCompletion(orderID uuid.UUID, completedBy *uuid.UUID) *OrderCompletion {
+	return &OrderCompletion{
+		OrderID:       orderID,
+		VolumeL:       r.VolumeL,
+		WeightKg:      r.WeightKg,
+		WasteCategory: r.WasteCategory,
+		CompletedBy:   completedBy,
+	}
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrderStatusHistory records a single status transition of an order
+type OrderStatusHistory struct {
+	ID         uuid.UUID   `json:"id" db:"id"`
+	OrderID    uuid.UUID   `json:"orderId" db:"order_id"`
+	FromStatus OrderStatus `json:"fromStatus" db:"from_status"`
+	ToStatus   OrderStatus `json:"toStatus" db:"to_status"`
+	ChangedBy  *uuid.UUID  `json:"changedBy,omitempty" db:"changed_by"`
+	Reason     *string     `json:"reason,omitempty" db:"reason"`
+	ChangedAt  time.Time   `json:"changedAt" db:"changed_at"`
+}
+
+// OrderStatusHistoryResponse represents the status history of an order, oldest transition first
+type OrderStatusHistoryResponse struct {
+	OrderID uuid.UUID            `json:"orderId"`
+	Items   []OrderStatusHistory `json:"items"`
+}
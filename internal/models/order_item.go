@@ -0,0 +1,178 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"eco-van-api/internal/domainerr"
+
+	"github.com/google/uuid"
+)
+
+// OrderItemOperation is what the crew does with a piece of equipment during an order
+type OrderItemOperation string
+
+const (
+	// OrderItemDeliver places equipment at the order's client object
+	OrderItemDeliver OrderItemOperation = "DELIVER"
+	// OrderItemPickup takes equipment away from the client object
+	OrderItemPickup OrderItemOperation = "PICKUP"
+	// OrderItemSwap takes equipment away and leaves the replacement equipment in its place
+	OrderItemSwap OrderItemOperation = "SWAP"
+	// OrderItemEmpty empties equipment on site; its placement does not change
+	OrderItemEmpty OrderItemOperation = "EMPTY"
+)
+
+// OrderItem references a piece of equipment handled by an order
+type OrderItem struct {
+	ID                     uuid.UUID          `json:"id" db:"id"`
+	OrderID                uuid.UUID          `json:"orderId" db:"order_id"`
+	EquipmentID            uuid.UUID          `json:"equipmentId" db:"equipment_id"`
+	Operation              OrderItemOperation `json:"operation" db:"operation"`
+	ReplacementEquipmentID *uuid.UUID         `json:"replacementEquipmentId,omitempty" db:"replacement_equipment_id"`
+	WarehouseID            *uuid.UUID         `json:"warehouseId,omitempty" db:"warehouse_id"`
+	CreatedAt              time.Time          `json:"createdAt" db:"created_at"`
+}
+
+// OrderItemRequest describes a single item in ReplaceOrderItemsRequest
+type OrderItemRequest struct {
+	EquipmentID            uuid.UUID          `json:"equipmentId" validate:"required"`
+	Operation              OrderItemOperation `json:"operation" validate:"required,oneof=DELIVER PICKUP SWAP EMPTY"`
+	ReplacementEquipmentID *uuid.UUID         `json:"replacementEquipmentId,omitempty"`
+	WarehouseID            *uuid.UUID         `json:"warehouseId,omitempty"`
+}
+
+// ReplaceOrderItemsRequest replaces the full list of items of an order
+type ReplaceOrderItemsRequest struct {
+	Items []OrderItemRequest `json:"items" validate:"max=100,dive"`
+	// OverrideConflicts lets a dispatcher keep the items even though they exceed the capacity of the order's transport
+	OverrideConflicts bool `json:"overrideConflicts,omitempty"`
+}
+
+// OrderItemListResponse represents the items of an order
+type OrderItemListResponse struct {
+	OrderID uuid.UUID `json:"orderId"`
+	// OrderVersion is the version of the order the items belong to, also sent as ETag
+	OrderVersion int         `json:"orderVersion"`
+	Items        []OrderItem `json:"items"`
+}
+
+// Validate checks the combination of operation, replacement and warehouse
+func (r *OrderItemRequest) Validate() error {
+	switch r.Operation {
+	case OrderItemSwap:
+		if r.ReplacementEquipmentID == nil {
+			return domainerr.InvalidField("/replacementEquipmentId", "required_if", "replacementEquipmentId is required for SWAP")
+		}
+		if *r.ReplacementEquipmentID == r.EquipmentID {
+			return domainerr.InvalidField("/replacementEquipmentId", "nefield", "replacementEquipmentId must differ from equipmentId")
+		}
+	case OrderItemDeliver, OrderItemPickup, OrderItemEmpty:
+		if r.ReplacementEquipmentID != nil {
+			return domainerr.InvalidField("/replacementEquipmentId", "excluded_unless", "replacementEquipmentId is only allowed for SWAP")
+		}
+	default:
+		return domainerr.InvalidField("/operation", "oneof", "invalid operation: %s", r.Operation)
+	}
+	if r.WarehouseID != nil && r.Operation != OrderItemPickup && r.Operation != OrderItemSwap {
+		return domainerr.InvalidField("/warehouseId", "excluded_unless", "warehouseId is only allowed for PICKUP and SWAP")
+	}
+	return nil
+}
+
+// EquipmentPlacement is where a piece of equipment is; exactly one field is set
+type EquipmentPlacement struct {
+	ClientObjectID *uuid.UUID
+	WarehouseID    *uuid.UUID
+	TransportID    *uuid.UUID
+}
+
+// PlacementOf returns the current placement of equipment
+func PlacementOf(e *Equipment) EquipmentPlacement {
+	return EquipmentPlacement{
+		ClientObjectID: e.ClientObjectID,
+		WarehouseID:    e.WarehouseID,
+		TransportID:    e.TransportID,
+	}
+}
+
+// EquipmentMove is a placement change applied when an order is completed
+type EquipmentMove struct {
+	EquipmentID uuid.UUID
+	// AtObject requires the equipment to be at this client object before the move
+	AtObject *uuid.UUID
+	// OffSite requires the equipment not to be at any client object before the move
+	OffSite bool
+	// Target is the new placement; nil leaves the equipment where it is
+	Target *EquipmentPlacement
+}
+
+// CheckSource verifies that the equipment is where the move expects it to be
+func (m *EquipmentMove) CheckSource(current EquipmentPlacement) error {
+	if m.AtObject != nil && (current.ClientObjectID == nil || *current.ClientObjectID != *m.AtObject) {
+		return fmt.Errorf("equipment placement conflict: equipment %s is not at the order's client object", m.EquipmentID)
+	}
+	if m.OffSite && current.ClientObjectID != nil {
+		return fmt.Errorf("equipment placement conflict: equipment %s is still placed at client object %s",
+			m.EquipmentID, *current.ClientObjectID)
+	}
+	return nil
+}
+
+// PlanEquipmentMoves translates the items of an order into equipment placement changes.
+// Equipment taken away goes to the item's warehouse, or onto the order's transport if none is given;
+// a transport carries one piece of equipment, so at most one item can be loaded onto it.
+func PlanEquipmentMoves(order *Order, items []OrderItem) ([]EquipmentMove, error) {
+	atObject := EquipmentPlacement{ClientObjectID: &order.ObjectID}
+
+	removals := make([]EquipmentMove, 0, len(items))
+	deliveries := make([]EquipmentMove, 0, len(items))
+	loaded := false
+	for i := range items {
+		item := &items[i]
+		switch item.Operation {
+		case OrderItemDeliver:
+			deliveries = append(deliveries, EquipmentMove{EquipmentID: item.EquipmentID, OffSite: true, Target: &atObject})
+
+		case OrderItemEmpty:
+			removals = append(removals, EquipmentMove{EquipmentID: item.EquipmentID, AtObject: &order.ObjectID})
+
+		case OrderItemPickup, OrderItemSwap:
+			target, err := removalTarget(order, item)
+			if err != nil {
+				return nil, err
+			}
+			if target.TransportID != nil {
+				if loaded {
+					return nil, fmt.Errorf("transport %s carries one piece of equipment; %s of equipment %s requires a warehouseId",
+						*target.TransportID, item.Operation, item.EquipmentID)
+				}
+				loaded = true
+			}
+			removals = append(removals, EquipmentMove{EquipmentID: item.EquipmentID, AtObject: &order.ObjectID, Target: target})
+			if item.Operation == OrderItemSwap && item.ReplacementEquipmentID != nil {
+				deliveries = append(deliveries, EquipmentMove{
+					EquipmentID: *item.ReplacementEquipmentID, OffSite: true, Target: &atObject,
+				})
+			}
+
+		default:
+			return nil, fmt.Errorf("invalid operation: %s", item.Operation)
+		}
+	}
+
+	// Take equipment away before placing new equipment on site
+	return append(removals, deliveries...), nil
+}
+
+// removalTarget is where equipment taken away from the client object ends up
+func removalTarget(order *Order, item *OrderItem) (*EquipmentPlacement, error) {
+	if item.WarehouseID != nil {
+		return &EquipmentPlacement{WarehouseID: item.WarehouseID}, nil
+	}
+	if order.TransportID != nil {
+		return &EquipmentPlacement{TransportID: order.TransportID}, nil
+	}
+	return nil, fmt.Errorf("%s of equipment %s requires a warehouseId or a transport assigned to the order",
+		item.Operation, item.EquipmentID)
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestPlanEquipmentMoves(t *testing.T) {
+	transportID := uuid.New()
+	order := &Order{ObjectID: uuid.New(), TransportID: &transportID}
+	deliverID, oldID, newID, emptyID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	items := []OrderItem{
+		{EquipmentID: deliverID, Operation: OrderItemDeliver},
+		{EquipmentID: emptyID, Operation: OrderItemEmpty},
+		{EquipmentID: oldID, Operation: OrderItemSwap, ReplacementEquipmentID: &newID},
+	}
+	moves, err := PlanEquipmentMoves(order, items)
+	if err != nil {
+		t.Fatalf("PlanEquipmentMoves() error = %v", err)
+	}
+	if len(moves) != 4 {
+		t.Fatalf("PlanEquipmentMoves() returned %d moves, want 4", len(moves))
+	}
+
+	if moves[0].EquipmentID != emptyID || moves[0].Target != nil {
+		t.Errorf("moves[0] = %+v, want EMPTY without target", moves[0])
+	}
+	if moves[1].EquipmentID != oldID || moves[1].Target.TransportID == nil || *moves[1].Target.TransportID != transportID {
+		t.Errorf("moves[1] = %+v, want swapped equipment loaded onto transport", moves[1])
+	}
+	for i, wantID := range []uuid.UUID{deliverID, newID} {
+		m := moves[i+2]
+		if m.EquipmentID != wantID || !m.OffSite || *m.Target.ClientObjectID != order.ObjectID {
+			t.Errorf("moves[%d] = %+v, want delivery to client object", i+2, m)
+		}
+	}
+}
+
+func TestPlanEquipmentMoves_PickupWithoutDestination(t *testing.T) {
+	order := &Order{ObjectID: uuid.New()}
+	items := []OrderItem{{EquipmentID: uuid.New(), Operation: OrderItemPickup}}
+
+	if _, err := PlanEquipmentMoves(order, items); err == nil {
+		t.Error("PlanEquipmentMoves() expected error without warehouse or transport")
+	}
+}
+
+func TestPlanEquipmentMoves_OneLoadPerTransport(t *testing.T) {
+	transportID, warehouseID, newID := uuid.New(), uuid.New(), uuid.New()
+	order := &Order{ObjectID: uuid.New(), TransportID: &transportID}
+	items := []OrderItem{
+		{EquipmentID: uuid.New(), Operation: OrderItemPickup},
+		{EquipmentID: uuid.New(), Operation: OrderItemSwap, ReplacementEquipmentID: &newID},
+	}
+
+	if _, err := PlanEquipmentMoves(order, items); err == nil {
+		t.Error("PlanEquipmentMoves() expected error for two loads onto one transport")
+	}
+
+	items[1].WarehouseID = &warehouseID
+	if _, err := PlanEquipmentMoves(order, items); err != nil {
+		t.Errorf("PlanEquipmentMoves() error = %v, want the second item to go to the warehouse", err)
+	}
+}
+
+func TestEquipmentMove_CheckSource(t *testing.T) {
+	objectID, otherID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name    string
+		move    EquipmentMove
+		current EquipmentPlacement
+		wantErr bool
+	}{
+		{"at expected object", EquipmentMove{AtObject: &objectID}, EquipmentPlacement{ClientObjectID: &objectID}, false},
+		{"at another object", EquipmentMove{AtObject: &objectID}, EquipmentPlacement{ClientObjectID: &otherID}, true},
+		{"in warehouse instead of object", EquipmentMove{AtObject: &objectID}, EquipmentPlacement{WarehouseID: &otherID}, true},
+		{"off site delivery", EquipmentMove{OffSite: true}, EquipmentPlacement{WarehouseID: &otherID}, false},
+		{"delivery already placed", EquipmentMove{OffSite: true}, EquipmentPlacement{ClientObjectID: &otherID}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.move.CheckSource(tt.current)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckSource() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
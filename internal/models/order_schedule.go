@@ -0,0 +1,156 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrderSchedule is a recurring collection at a client object that materializes orders ahead of time
+type OrderSchedule struct {
+	ID             uuid.UUID  `json:"id" db:"id"`
+	ObjectID       uuid.UUID  `json:"objectId" db:"object_id"`
+	RRule          string     `json:"rrule" db:"rrule"`
+	StartDate      time.Time  `json:"startDate" db:"start_date"`
+	EndDate        *time.Time `json:"endDate,omitempty" db:"end_date"`
+	WindowFrom     *string    `json:"windowFrom,omitempty" db:"window_from"`
+	WindowTo       *string    `json:"windowTo,omitempty" db:"window_to"`
+	Priority       string     `json:"priority" db:"priority"`
+	TransportID    *uuid.UUID `json:"transportId,omitempty" db:"transport_id"`
+	OrderStatus    string     `json:"orderStatus" db:"order_status"`
+	Notes          *string    `json:"notes,omitempty" db:"notes"`
+	IsActive       bool       `json:"isActive" db:"is_active"`
+	GeneratedUntil *time.Time `json:"generatedUntil,omitempty" db:"generated_until"`
+	CreatedBy      *uuid.UUID `json:"createdBy,omitempty" db:"created_by"`
+	CreatedAt      time.Time  `json:"createdAt" db:"created_at"`
+	UpdatedAt      time.Time  `json:"updatedAt" db:"updated_at"`
+	DeletedAt      *time.Time `json:"deletedAt,omitempty" db:"deleted_at"`
+}
+
+// CreateOrderScheduleRequest represents the request to create a recurring order schedule
+type CreateOrderScheduleRequest struct {
+	ObjectID    uuid.UUID  `json:"objectId" validate:"required"`
+	RRule       string     `json:"rrule" validate:"required,max=255"`
+	StartDate   time.Time  `json:"startDate" validate:"required"`
+	EndDate     *time.Time `json:"endDate,omitempty"`
+	WindowFrom  *string    `json:"windowFrom,omitempty"`
+	WindowTo    *string    `json:"windowTo,omitempty"`
+	Priority    *string    `json:"priority,omitempty" validate:"omitempty,oneof=LOW MEDIUM HIGH"`
+	TransportID *uuid.UUID `json:"transportId,omitempty"`
+	OrderStatus *string    `json:"orderStatus,omitempty" validate:"omitempty,oneof=DRAFT SCHEDULED"`
+	Notes       *string    `json:"notes,omitempty" validate:"omitempty,max=1000"`
+}
+
+// UpdateOrderScheduleRequest represents the request to update a recurring order schedule
+type UpdateOrderScheduleRequest struct {
+	RRule       *string    `json:"rrule,omitempty" validate:"omitempty,max=255"`
+	StartDate   *time.Time `json:"startDate,omitempty"`
+	EndDate     *time.Time `json:"endDate,omitempty"`
+	WindowFrom  *string    `json:"windowFrom,omitempty"`
+	WindowTo    *string    `json:"windowTo,omitempty"`
+	Priority    *string    `json:"priority,omitempty" validate:"omitempty,oneof=LOW MEDIUM HIGH"`
+	TransportID *uuid.UUID `json:"transportId,omitempty"`
+	OrderStatus *string    `json:"orderStatus,omitempty" validate:"omitempty,oneof=DRAFT SCHEDULED"`
+	Notes       *string    `json:"notes,omitempty" validate:"omitempty,max=1000"`
+	IsActive    *bool      `json:"isActive,omitempty"`
+}
+
+// OrderScheduleListRequest represents the request to list order schedules
+type OrderScheduleListRequest struct {
+	Page           int        `json:"page" validate:"min=1"`
+	PageSize       int        `json:"pageSize" validate:"min=1,max=100"`
+	ObjectID       *uuid.UUID `json:"objectId,omitempty"`
+	IncludeDeleted bool       `json:"includeDeleted"`
+}
+
+// OrderScheduleListResponse represents the paginated response for listing order schedules
+type OrderScheduleListResponse struct {
+	Items    []OrderSchedule `json:"items"`
+	Page     int             `json:"page"`
+	PageSize int             `json:"pageSize"`
+	Total    int64           `json:"total"`
+}
+
+// FromOrderScheduleCreateRequest creates a new OrderSchedule from CreateOrderScheduleRequest
+func FromOrderScheduleCreateRequest(req *CreateOrderScheduleRequest) OrderSchedule {
+	priority := string(OrderPriorityMedium)
+	if req.Priority != nil {
+		priority = *req.Priority
+	}
+	orderStatus := string(OrderStatusDraft)
+	if req.OrderStatus != nil {
+		orderStatus = *req.OrderStatus
+	}
+
+	return OrderSchedule{
+		ObjectID:    req.ObjectID,
+		RRule:       req.RRule,
+		StartDate:   dateOnly(req.StartDate),
+		EndDate:     optionalDate(req.EndDate),
+		WindowFrom:  req.WindowFrom,
+		WindowTo:    req.WindowTo,
+		Priority:    priority,
+		TransportID: req.TransportID,
+		OrderStatus: orderStatus,
+		Notes:       req.Notes,
+		IsActive:    true,
+	}
+}
+
+// UpdateFromRequest updates an OrderSchedule from UpdateOrderScheduleRequest
+func (s *OrderSchedule) UpdateFromRequest(req UpdateOrderScheduleRequest) {
+	if req.RRule != nil {
+		s.RRule = *req.RRule
+	}
+	if req.StartDate != nil {
+		s.StartDate = dateOnly(*req.StartDate)
+	}
+	if req.EndDate != nil {
+		s.EndDate = optionalDate(req.EndDate)
+	}
+	if req.WindowFrom != nil {
+		s.WindowFrom = req.WindowFrom
+	}
+	if req.WindowTo != nil {
+		s.WindowTo = req.WindowTo
+	}
+	if req.Priority != nil {
+		s.Priority = *req.Priority
+	}
+	if req.TransportID != nil {
+		s.TransportID = req.TransportID
+	}
+	if req.OrderStatus != nil {
+		s.OrderStatus = *req.OrderStatus
+	}
+	if req.Notes != nil {
+		s.Notes = req.Notes
+	}
+	if req.IsActive != nil {
+		s.IsActive = *req.IsActive
+	}
+}
+
+// NextDates returns the dates that still need orders, from the day after the last generated
+// date (or from today) up to horizon, limited by the schedule's start and end dates
+func (s *OrderSchedule) NextDates(rule *Recurrence, today, horizon time.Time) []time.Time {
+	from := dateOnly(today)
+	if s.GeneratedUntil != nil {
+		if next := dateOnly(*s.GeneratedUntil).AddDate(0, 0, 1); next.After(from) {
+			from = next
+		}
+	}
+	to := dateOnly(horizon)
+	if s.EndDate != nil && s.EndDate.Before(to) {
+		to = dateOnly(*s.EndDate)
+	}
+	return rule.Occurrences(s.StartDate, from, to)
+}
+
+func optionalDate(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	d := dateOnly(*t)
+	return &d
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"eco-van-api/internal/domainerr"
+
+	"github.com/google/uuid"
+)
+
+// ChangePasswordRequest represents a request of the current user to change their password
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword"`
+	NewPassword     string `json:"newPassword"`
+}
+
+// ResetPasswordRequest represents a request to set a new password with a one-time reset token
+type ResetPasswordRequest struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"newPassword"`
+}
+
+// PasswordResetResponse is returned to the admin who issued a password reset
+type PasswordResetResponse struct {
+	ResetToken string    `json:"resetToken"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+}
+
+// PasswordResetToken is a persisted one-time password reset token; only its hash is stored
+type PasswordResetToken struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	TokenHash string
+	CreatedBy *uuid.UUID
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UsedAt    *time.Time
+}
+
+// Validate validates the change password request
+func (req *ChangePasswordRequest) Validate() error {
+	if strings.TrimSpace(req.CurrentPassword) == "" {
+		return domainerr.InvalidField("/currentPassword", "required", "current password is required")
+	}
+
+	return validateNewPassword(req.NewPassword)
+}
+
+// Validate validates the reset password request
+func (req *ResetPasswordRequest) Validate() error {
+	if strings.TrimSpace(req.Token) == "" {
+		return domainerr.InvalidField("/token", "required", "reset token is required")
+	}
+
+	return validateNewPassword(req.NewPassword)
+}
+
+// validateNewPassword applies the same rules as user creation
+func validateNewPassword(password string) error {
+	if strings.TrimSpace(password) == "" {
+		return domainerr.InvalidField("/newPassword", "required", "new password is required")
+	}
+
+	if len(password) < minPasswordLength {
+		return domainerr.InvalidField("/newPassword", "min", "new password must be at least %d characters long", minPasswordLength).
+			WithMessageID("min.string", strconv.Itoa(minPasswordLength))
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PermissionResource is a group of endpoints the permission matrix grants access to
+type PermissionResource string
+
+const (
+	ResourceUsers                PermissionResource = "users"
+	ResourceClients              PermissionResource = "clients"
+	ResourceClientObjects        PermissionResource = "client_objects"
+	ResourceWarehouses           PermissionResource = "warehouses"
+	ResourceEquipment            PermissionResource = "equipment"
+	ResourceDrivers              PermissionResource = "drivers"
+	ResourceTransport            PermissionResource = "transport"
+	ResourceTransportAssignments PermissionResource = "transport_assignments"
+	ResourceOrders               PermissionResource = "orders"
+	ResourceOrderSchedules       PermissionResource = "order_schedules"
+	ResourceCancellationReasons  PermissionResource = "cancellation_reasons"
+	ResourceRoutes               PermissionResource = "routes"
+	ResourceDispatch             PermissionResource = "dispatch"
+	ResourcePhotos               PermissionResource = "photos"
+)
+
+// PermissionAction is what a role may do with a resource
+type PermissionAction string
+
+const (
+	// PermissionActionRead covers listing and fetching
+	PermissionActionRead PermissionAction = "read"
+	// PermissionActionWrite covers creating, updating and state changes
+	PermissionActionWrite PermissionAction = "write"
+	// PermissionActionDelete covers deleting, restoring and unassigning
+	PermissionActionDelete PermissionAction = "delete"
+)
+
+// permissionWildcard matches every resource or every action in a policy
+const permissionWildcard = "*"
+
+// AllPermissionResources lists every resource known to the permission matrix
+func AllPermissionResources() []PermissionResource {
+	return []PermissionResource{
+		ResourceUsers, ResourceClients, ResourceClientObjects, ResourceWarehouses, ResourceEquipment,
+		ResourceDrivers, ResourceTransport, ResourceTransportAssignments, ResourceOrders, ResourceOrderSchedules,
+		ResourceCancellationReasons, ResourceRoutes, ResourceDispatch, ResourcePhotos,
+	}
+}
+
+// AllPermissionActions lists every action known to the permission matrix
+func AllPermissionActions() []PermissionAction {
+	return []PermissionAction{PermissionActionRead, PermissionActionWrite, PermissionActionDelete}
+}
+
+// DefaultPermissionPolicy is the built-in policy in RBAC_POLICY syntax.
+// DISPATCHER manages orders, their schedules, transport assignments, client objects and routes;
+// DRIVER may additionally upload photos; VIEWER is read-only.
+const DefaultPermissionPolicy = "ADMIN=*:*;" +
+	"DISPATCHER=*:read,orders:*,order_schedules:*,transport_assignments:*,client_objects:*,routes:*,photos:write;" +
+	"DRIVER=*:read,photos:write;" +
+	"VIEWER=*:read"
+
+// PermissionMatrix holds the resource × action grants of every role
+type PermissionMatrix struct {
+	grants map[UserRole]map[PermissionResource]map[PermissionAction]bool
+}
+
+// DefaultPermissionMatrix returns the matrix of DefaultPermissionPolicy
+func DefaultPermissionMatrix() *PermissionMatrix {
+	matrix, err := ParsePermissionMatrix("")
+	if err != nil {
+		panic(fmt.Sprintf("invalid default permission policy: %v", err))
+	}
+	return matrix
+}
+
+// ParsePermissionMatrix builds a matrix from DefaultPermissionPolicy overridden by policy.
+// A policy is a ';'-separated list of ROLE=resource:action,... clauses; '*' matches every resource
+// or action. A clause replaces all grants of its role. Users can only be managed by ADMIN.
+func ParsePermissionMatrix(policy string) (*PermissionMatrix, error) {
+	matrix := &PermissionMatrix{grants: make(map[UserRole]map[PermissionResource]map[PermissionAction]bool)}
+	if err := matrix.apply(DefaultPermissionPolicy); err != nil {
+		return nil, err
+	}
+	if err := matrix.apply(policy); err != nil {
+		return nil, err
+	}
+
+	for role, resources := range matrix.grants {
+		if role != UserRoleAdmin && (resources[ResourceUsers][PermissionActionWrite] || resources[ResourceUsers][PermissionActionDelete]) {
+			return nil, fmt.Errorf("role %s cannot be granted user management, only %s manages users", role, UserRoleAdmin)
+		}
+	}
+	return matrix, nil
+}
+
+// Allows reports whether the role may perform the action on the resource
+func (m *PermissionMatrix) Allows(role UserRole, resource PermissionResource, action PermissionAction) bool {
+	return m.grants[role][resource][action]
+}
+
+// apply replaces the grants of every role named in the policy
+func (m *PermissionMatrix) apply(policy string) error {
+	for _, clause := range strings.Split(policy, ";") {
+		clause = strings.TrimSpace(clause)
+		if clause == "" {
+			continue
+		}
+
+		roleName, grants, ok := strings.Cut(clause, "=")
+		if !ok {
+			return fmt.Errorf("permission clause %q must have the form ROLE=resource:action,...", clause)
+		}
+		role := UserRole(strings.ToUpper(strings.TrimSpace(roleName)))
+		if !role.IsValid() {
+			return fmt.Errorf("unknown role %q in permission policy", roleName)
+		}
+
+		resources := make(map[PermissionResource]map[PermissionAction]bool)
+		for _, grant := range strings.Split(grants, ",") {
+			grant = strings.TrimSpace(grant)
+			if grant == "" {
+				continue
+			}
+			if err := addGrant(resources, grant); err != nil {
+				return fmt.Errorf("role %s: %w", role, err)
+			}
+		}
+		m.grants[role] = resources
+	}
+	return nil
+}
+
+// addGrant expands a resource:action grant, including wildcards, into resources
+func addGrant(resources map[PermissionResource]map[PermissionAction]bool, grant string) error {
+	resourceName, actionName, ok := strings.Cut(grant, ":")
+	if !ok {
+		return fmt.Errorf("grant %q must have the form resource:action", grant)
+	}
+
+	matchedResources, err := matchPermissionResources(strings.TrimSpace(resourceName))
+	if err != nil {
+		return err
+	}
+	matchedActions, err := matchPermissionActions(strings.TrimSpace(actionName))
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range matchedResources {
+		if resources[resource] == nil {
+			resources[resource] = make(map[PermissionAction]bool)
+		}
+		for _, action := range matchedActions {
+			resources[resource][action] = true
+		}
+	}
+	return nil
+}
+
+// matchPermissionResources resolves a resource name or wildcard
+func matchPermissionResources(name string) ([]PermissionResource, error) {
+	if name == permissionWildcard {
+		return AllPermissionResources(), nil
+	}
+	for _, resource := range AllPermissionResources() {
+		if string(resource) == name {
+			return []PermissionResource{resource}, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown resource %q", name)
+}
+
+// matchPermissionActions resolves an action name or wildcard
+func matchPermissionActions(name string) ([]PermissionAction, error) {
+	if name == permissionWildcard {
+		return AllPermissionActions(), nil
+	}
+	for _, action := range AllPermissionActions() {
+		if string(action) == name {
+			return []PermissionAction{action}, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown action %q", name)
+}
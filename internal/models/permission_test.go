@@ -0,0 +1,90 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDefaultPermissionMatrix(t *testing.T) {
+	matrix := DefaultPermissionMatrix()
+
+	tests := []struct {
+		role     UserRole
+		resource PermissionResource
+		action   PermissionAction
+		allowed  bool
+	}{
+		{UserRoleAdmin, ResourceUsers, PermissionActionWrite, true},
+		{UserRoleAdmin, ResourceWarehouses, PermissionActionDelete, true},
+		{UserRoleDispatcher, ResourceOrders, PermissionActionWrite, true},
+		{UserRoleDispatcher, ResourceOrders, PermissionActionDelete, true},
+		{UserRoleDispatcher, ResourceTransportAssignments, PermissionActionWrite, true},
+		{UserRoleDispatcher, ResourceClientObjects, PermissionActionWrite, true},
+		{UserRoleDispatcher, ResourceRoutes, PermissionActionWrite, true},
+		{UserRoleDispatcher, ResourceTransport, PermissionActionRead, true},
+		{UserRoleDispatcher, ResourceTransport, PermissionActionWrite, false},
+		{UserRoleDispatcher, ResourceClients, PermissionActionWrite, false},
+		{UserRoleDispatcher, ResourceUsers, PermissionActionWrite, false},
+		{UserRoleDispatcher, ResourcePhotos, PermissionActionDelete, false},
+		{UserRoleDriver, ResourcePhotos, PermissionActionWrite, true},
+		{UserRoleDriver, ResourceOrders, PermissionActionWrite, false},
+		{UserRoleViewer, ResourceDispatch, PermissionActionRead, true},
+		{UserRoleViewer, ResourcePhotos, PermissionActionWrite, false},
+		{UserRole("UNKNOWN"), ResourceOrders, PermissionActionRead, false},
+	}
+
+	for _, tt := range tests {
+		if got := matrix.Allows(tt.role, tt.resource, tt.action); got != tt.allowed {
+			t.Errorf("Allows(%s, %s, %s) = %v, want %v", tt.role, tt.resource, tt.action, got, tt.allowed)
+		}
+	}
+
+	// VIEWER is read-only on every resource
+	for _, resource := range AllPermissionResources() {
+		if !matrix.Allows(UserRoleViewer, resource, PermissionActionRead) {
+			t.Errorf("VIEWER should read %s", resource)
+		}
+		if matrix.Allows(UserRoleViewer, resource, PermissionActionWrite) || matrix.Allows(UserRoleViewer, resource, PermissionActionDelete) {
+			t.Errorf("VIEWER should not modify %s", resource)
+		}
+	}
+}
+
+func TestParsePermissionMatrix(t *testing.T) {
+	t.Run("clause replaces grants of its role only", func(t *testing.T) {
+		matrix, err := ParsePermissionMatrix("dispatcher=orders:read, warehouses:*")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if matrix.Allows(UserRoleDispatcher, ResourceOrders, PermissionActionWrite) {
+			t.Error("override should drop the default DISPATCHER orders:write grant")
+		}
+		if !matrix.Allows(UserRoleDispatcher, ResourceWarehouses, PermissionActionDelete) {
+			t.Error("override should grant DISPATCHER warehouses:delete")
+		}
+		if !matrix.Allows(UserRoleDriver, ResourcePhotos, PermissionActionWrite) {
+			t.Error("roles without a clause should keep their default grants")
+		}
+	})
+
+	invalid := []struct {
+		policy string
+		errMsg string
+	}{
+		{"DISPATCHER", "must have the form"},
+		{"MANAGER=*:read", "unknown role"},
+		{"VIEWER=invoices:read", "unknown resource"},
+		{"VIEWER=orders:approve", "unknown action"},
+		{"VIEWER=orders", "must have the form resource:action"},
+		{"DISPATCHER=*:*", "only ADMIN manages users"},
+		{"VIEWER=users:delete", "only ADMIN manages users"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.policy, func(t *testing.T) {
+			_, err := ParsePermissionMatrix(tt.policy)
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("ParsePermissionMatrix(%q) error = %v, want %q", tt.policy, err, tt.errMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PhotoEntityType represents the kind of entity a photo is attached to.
+// Values match the table names enforced by the photos.entity_type CHECK constraint.
+type PhotoEntityType string
+
+const (
+	PhotoEntityClients       PhotoEntityType = "clients"
+	PhotoEntityClientObjects PhotoEntityType = "client_objects"
+	PhotoEntityEquipment     PhotoEntityType = "equipment"
+	PhotoEntityTransport     PhotoEntityType = "transport"
+	PhotoEntityDrivers       PhotoEntityType = "drivers"
+	PhotoEntityOrders        PhotoEntityType = "orders"
+)
+
+// IsValid reports whether the entity type is one photos can be attached to
+func (t PhotoEntityType) IsValid() bool {
+	switch t {
+	case PhotoEntityClients, PhotoEntityClientObjects, PhotoEntityEquipment,
+		PhotoEntityTransport, PhotoEntityDrivers, PhotoEntityOrders:
+		return true
+	}
+	return false
+}
+
+// AllowedPhotoMimeTypes maps accepted image MIME types to the file extension used on disk
+var AllowedPhotoMimeTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// PhotoURL returns the download path of a photo, e.g. /api/v1/photos/equipment/{entityId}/{photoId}
+func PhotoURL(entityType PhotoEntityType, entityID, photoID uuid.UUID) string {
+	return "/api/v1/photos/" + string(entityType) + "/" + entityID.String() + "/" + photoID.String()
+}
+
+// photoURL returns the download path of the latest photo of an entity, nil when it has none
+func photoURL(entityType PhotoEntityType, entityID uuid.UUID, photoID *uuid.UUID) *string {
+	if photoID == nil {
+		return nil
+	}
+	url := PhotoURL(entityType, entityID, *photoID)
+	return &url
+}
+
+// Photo represents an uploaded image attached to a domain entity
+type Photo struct {
+	ID           uuid.UUID       `json:"id" db:"id"`
+	EntityType   PhotoEntityType `json:"entityType" db:"entity_type"`
+	EntityID     uuid.UUID       `json:"entityId" db:"entity_id"`
+	Filename     string          `json:"-" db:"filename"`
+	OriginalName string          `json:"originalName" db:"original_name"`
+	MimeType     string          `json:"mimeType" db:"mime_type"`
+	Size         int64           `json:"size" db:"size"`
+	CreatedAt    time.Time       `json:"createdAt" db:"created_at"`
+}
+
+// PhotoResponse represents a single photo response
+type PhotoResponse struct {
+	ID           uuid.UUID       `json:"id"`
+	EntityType   PhotoEntityType `json:"entityType"`
+	EntityID     uuid.UUID       `json:"entityId"`
+	OriginalName string          `json:"originalName"`
+	MimeType     string          `json:"mimeType"`
+	Size         int64           `json:"size"`
+	CreatedAt    time.Time       `json:"createdAt"`
+}
+
+// PhotoListResponse represents the list of photos attached to an entity
+type PhotoListResponse struct {
+	Items []PhotoResponse `json:"items"`
+	Total int             `json:"total"`
+}
+
+// ToResponse converts a Photo model to PhotoResponse
+func (p *Photo) ToResponse() PhotoResponse {
+	return PhotoResponse{
+		ID:           p.ID,
+		EntityType:   p.EntityType,
+		EntityID:     p.EntityID,
+		OriginalName: p.OriginalName,
+		MimeType:     p.MimeType,
+		Size:         p.Size,
+		CreatedAt:    p.CreatedAt,
+	}
+}
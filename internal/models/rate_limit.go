@@ -0,0 +1,70 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// RateLimitPolicy is a token bucket: it holds Requests tokens and refills them evenly over Period,
+// so a client can burst up to Requests and then sustain Requests per Period
+type RateLimitPolicy struct {
+	Requests int
+	Period   time.Duration
+}
+
+// RateLimitResult is the outcome of charging one request to a bucket
+type RateLimitResult struct {
+	Allowed bool
+	// Remaining is how many further requests the bucket allows right now
+	Remaining int
+	// RetryAfter is how long a rejected request must wait for a token
+	RetryAfter time.Duration
+	// Reset is how long until the bucket is full again
+	Reset time.Duration
+}
+
+// Validate checks that the policy allows at least one request per positive period
+func (p RateLimitPolicy) Validate() error {
+	if p.Requests <= 0 {
+		return fmt.Errorf("requests must be positive")
+	}
+	if p.Period <= 0 {
+		return fmt.Errorf("period must be positive")
+	}
+	return nil
+}
+
+// Interval is the time it takes to refill one token
+func (p RateLimitPolicy) Interval() time.Duration {
+	return p.Period / time.Duration(p.Requests)
+}
+
+// Take charges one request to a bucket. The bucket is stored as the time at which it will be full again
+// (zero for a full or unknown bucket), which is enough to derive its tokens and lets stores update it
+// with a single comparison. It returns the new full-at time to store if the request is allowed.
+func (p RateLimitPolicy) Take(fullAt, now time.Time) (time.Time, RateLimitResult) {
+	if fullAt.Before(now) {
+		fullAt = now
+	}
+
+	next := fullAt.Add(p.Interval())
+	if next.Sub(now) > p.Period {
+		return fullAt, RateLimitResult{
+			Allowed:    false,
+			Remaining:  0,
+			RetryAfter: next.Sub(now) - p.Period,
+			Reset:      fullAt.Sub(now),
+		}
+	}
+
+	return next, p.Allowed(next, now)
+}
+
+// Allowed returns the result of an allowed request that left the bucket full at fullAt
+func (p RateLimitPolicy) Allowed(fullAt, now time.Time) RateLimitResult {
+	return RateLimitResult{
+		Allowed:   true,
+		Remaining: int((p.Period - fullAt.Sub(now)) / p.Interval()),
+		Reset:     fullAt.Sub(now),
+	}
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimitPolicy_Take(t *testing.T) {
+	policy := RateLimitPolicy{Requests: 3, Period: 3 * time.Second}
+	now := time.Unix(1700000000, 0)
+
+	// A new bucket allows a burst of Requests
+	var fullAt time.Time
+	for i, remaining := range []int{2, 1, 0} {
+		var result RateLimitResult
+		fullAt, result = policy.Take(fullAt, now)
+		if !result.Allowed || result.Remaining != remaining {
+			t.Fatalf("Request %d: expected allowed with %d remaining, got %+v", i+1, remaining, result)
+		}
+	}
+	if !fullAt.Equal(now.Add(3 * time.Second)) {
+		t.Errorf("Expected bucket to be full again after the period, got %v", fullAt.Sub(now))
+	}
+
+	// The empty bucket rejects until a token is refilled
+	stored, result := policy.Take(fullAt, now.Add(500*time.Millisecond))
+	if result.Allowed || result.RetryAfter != 500*time.Millisecond || !stored.Equal(fullAt) {
+		t.Errorf("Expected rejection with 500ms retry and unchanged bucket, got %+v", result)
+	}
+
+	// One interval later a single request is allowed again
+	_, result = policy.Take(fullAt, now.Add(time.Second))
+	if !result.Allowed || result.Remaining != 0 {
+		t.Errorf("Expected one refilled token, got %+v", result)
+	}
+
+	// A bucket idle for longer than the period is full
+	_, result = policy.Take(fullAt, now.Add(time.Minute))
+	if !result.Allowed || result.Remaining != 2 || result.Reset != time.Second {
+		t.Errorf("Expected a full bucket, got %+v", result)
+	}
+}
+
+func TestRateLimitPolicy_Validate(t *testing.T) {
+	if err := (RateLimitPolicy{Requests: 10, Period: time.Minute}).Validate(); err != nil {
+		t.Errorf("Expected valid policy, got %v", err)
+	}
+	if err := (RateLimitPolicy{Requests: 0, Period: time.Minute}).Validate(); err == nil {
+		t.Error("Expected error for zero requests")
+	}
+	if err := (RateLimitPolicy{Requests: 10}).Validate(); err == nil {
+		t.Error("Expected error for zero period")
+	}
+}
@@ -0,0 +1,242 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RecurrenceFrequency is the FREQ part of a recurrence rule
+type RecurrenceFrequency string
+
+const (
+	RecurrenceDaily   RecurrenceFrequency = "DAILY"
+	RecurrenceWeekly  RecurrenceFrequency = "WEEKLY"
+	RecurrenceMonthly RecurrenceFrequency = "MONTHLY"
+)
+
+const (
+	maxRecurrenceInterval = 366
+	maxMonthDay           = 31
+	maxWeekdayOrdinal     = 5
+	daysPerWeek           = 7
+	monthsPerYear         = 12
+)
+
+// recurrenceWeekdays maps RRULE weekday codes to time.Weekday
+var recurrenceWeekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// RecurrenceWeekday is a BYDAY entry; Ordinal is non-zero for monthly rules such as 1MO or -1FR
+type RecurrenceWeekday struct {
+	Weekday time.Weekday
+	Ordinal int
+}
+
+// Recurrence is the supported subset of an RFC 5545 RRULE:
+// FREQ=DAILY|WEEKLY|MONTHLY, INTERVAL, BYDAY and BYMONTHDAY (monthly only).
+// Occurrences are whole dates anchored at the schedule start date; weeks start on Monday.
+type Recurrence struct {
+	Freq       RecurrenceFrequency
+	Interval   int
+	ByDay      []RecurrenceWeekday
+	ByMonthDay []int
+}
+
+// ParseRecurrence parses a rule such as "FREQ=WEEKLY;BYDAY=MO,TH" (an "RRULE:" prefix is allowed)
+func ParseRecurrence(rule string) (*Recurrence, error) {
+	rule = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(rule)), "RRULE:")
+	if rule == "" {
+		return nil, fmt.Errorf("recurrence rule is empty")
+	}
+
+	r := &Recurrence{Interval: 1}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(rule, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("malformed recurrence rule part %q", part)
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate recurrence rule part %s", key)
+		}
+		seen[key] = true
+
+		var err error
+		switch key {
+		case "FREQ":
+			r.Freq = RecurrenceFrequency(value)
+			if r.Freq != RecurrenceDaily && r.Freq != RecurrenceWeekly && r.Freq != RecurrenceMonthly {
+				err = fmt.Errorf("unsupported FREQ %s (expected DAILY, WEEKLY or MONTHLY)", value)
+			}
+		case "INTERVAL":
+			r.Interval, err = strconv.Atoi(value)
+			if err != nil || r.Interval < 1 || r.Interval > maxRecurrenceInterval {
+				err = fmt.Errorf("INTERVAL must be between 1 and %d", maxRecurrenceInterval)
+			}
+		case "BYDAY":
+			r.ByDay, err = parseByDay(value)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseByMonthDay(value)
+		default:
+			err = fmt.Errorf("unsupported recurrence rule part %s", key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.Freq == "" {
+		return nil, fmt.Errorf("recurrence rule requires FREQ")
+	}
+	if len(r.ByMonthDay) > 0 && r.Freq != RecurrenceMonthly {
+		return nil, fmt.Errorf("BYMONTHDAY is only supported with FREQ=MONTHLY")
+	}
+	for _, d := range r.ByDay {
+		if d.Ordinal != 0 && r.Freq != RecurrenceMonthly {
+			return nil, fmt.Errorf("BYDAY ordinals are only supported with FREQ=MONTHLY")
+		}
+	}
+
+	return r, nil
+}
+
+func parseByDay(value string) ([]RecurrenceWeekday, error) {
+	days := make([]RecurrenceWeekday, 0)
+	for _, item := range strings.Split(value, ",") {
+		if len(item) < 2 {
+			return nil, fmt.Errorf("invalid BYDAY value %q", item)
+		}
+		code := item[len(item)-2:]
+		weekday, ok := recurrenceWeekdays[code]
+		if !ok {
+			return nil, fmt.Errorf("invalid BYDAY value %q", item)
+		}
+		ordinal := 0
+		if prefix := item[:len(item)-2]; prefix != "" {
+			n, err := strconv.Atoi(prefix)
+			if err != nil || n == 0 || n > maxWeekdayOrdinal || n < -maxWeekdayOrdinal {
+				return nil, fmt.Errorf("invalid BYDAY value %q", item)
+			}
+			ordinal = n
+		}
+		days = append(days, RecurrenceWeekday{Weekday: weekday, Ordinal: ordinal})
+	}
+	return days, nil
+}
+
+func parseByMonthDay(value string) ([]int, error) {
+	monthDays := make([]int, 0)
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(item)
+		if err != nil || n == 0 || n > maxMonthDay || n < -maxMonthDay {
+			return nil, fmt.Errorf("invalid BYMONTHDAY value %q", item)
+		}
+		monthDays = append(monthDays, n)
+	}
+	return monthDays, nil
+}
+
+// Occurrences returns the dates within [from, to] (inclusive) produced by the rule anchored at start
+func (r *Recurrence) Occurrences(start, from, to time.Time) []time.Time {
+	start = dateOnly(start)
+	from = dateOnly(from)
+	to = dateOnly(to)
+	if from.Before(start) {
+		from = start
+	}
+
+	dates := make([]time.Time, 0)
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		if r.matches(start, d) {
+			dates = append(dates, d)
+		}
+	}
+	return dates
+}
+
+// matches reports whether date d (not before start) is an occurrence
+func (r *Recurrence) matches(start, d time.Time) bool {
+	switch r.Freq {
+	case RecurrenceDaily:
+		days := int(d.Sub(start).Hours() / 24)
+		return days%r.Interval == 0 && (len(r.ByDay) == 0 || r.matchesWeekday(d))
+
+	case RecurrenceWeekly:
+		weeks := int(weekStart(d).Sub(weekStart(start)).Hours() / 24 / daysPerWeek)
+		if weeks%r.Interval != 0 {
+			return false
+		}
+		if len(r.ByDay) == 0 {
+			return d.Weekday() == start.Weekday()
+		}
+		return r.matchesWeekday(d)
+
+	case RecurrenceMonthly:
+		months := (d.Year()-start.Year())*monthsPerYear + int(d.Month()) - int(start.Month())
+		if months%r.Interval != 0 {
+			return false
+		}
+		if len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 {
+			return d.Day() == start.Day()
+		}
+		// As in RFC 5545, BYDAY and BYMONTHDAY together select their intersection
+		return (len(r.ByDay) == 0 || r.matchesWeekday(d)) &&
+			(len(r.ByMonthDay) == 0 || r.matchesMonthDay(d))
+	}
+	return false
+}
+
+func (r *Recurrence) matchesWeekday(d time.Time) bool {
+	lastDay := daysInMonth(d)
+	for _, day := range r.ByDay {
+		if day.Weekday != d.Weekday() {
+			continue
+		}
+		switch {
+		case day.Ordinal == 0:
+			return true
+		case day.Ordinal > 0 && (d.Day()-1)/daysPerWeek+1 == day.Ordinal:
+			return true
+		case day.Ordinal < 0 && (lastDay-d.Day())/daysPerWeek+1 == -day.Ordinal:
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Recurrence) matchesMonthDay(d time.Time) bool {
+	lastDay := daysInMonth(d)
+	for _, n := range r.ByMonthDay {
+		if n > 0 && d.Day() == n {
+			return true
+		}
+		if n < 0 && d.Day() == lastDay+n+1 {
+			return true
+		}
+	}
+	return false
+}
+
+// dateOnly truncates t to its calendar date in UTC
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// weekStart returns the Monday of the week containing d
+func weekStart(d time.Time) time.Time {
+	offset := (int(d.Weekday()) + daysPerWeek - 1) % daysPerWeek
+	return d.AddDate(0, 0, -offset)
+}
+
+func daysInMonth(d time.Time) int {
+	return time.Date(d.Year(), d.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,144 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func formatDates(dates []time.Time) []string {
+	out := make([]string, len(dates))
+	for i, d := range dates {
+		out[i] = d.Format("2006-01-02")
+	}
+	return out
+}
+
+func TestParseRecurrence_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		rule string
+	}{
+		{"empty", ""},
+		{"missing freq", "INTERVAL=2"},
+		{"unsupported freq", "FREQ=YEARLY"},
+		{"zero interval", "FREQ=DAILY;INTERVAL=0"},
+		{"unknown weekday", "FREQ=WEEKLY;BYDAY=XX"},
+		{"ordinal on weekly", "FREQ=WEEKLY;BYDAY=1MO"},
+		{"monthday on weekly", "FREQ=WEEKLY;BYMONTHDAY=1"},
+		{"invalid monthday", "FREQ=MONTHLY;BYMONTHDAY=32"},
+		{"unsupported part", "FREQ=DAILY;COUNT=5"},
+		{"duplicate part", "FREQ=DAILY;FREQ=WEEKLY"},
+		{"malformed part", "FREQ=DAILY;BYDAY"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseRecurrence(tt.rule); err == nil {
+				t.Errorf("ParseRecurrence(%q) expected error", tt.rule)
+			}
+		})
+	}
+}
+
+func TestRecurrence_Occurrences(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  string
+		start time.Time
+		from  time.Time
+		to    time.Time
+		want  []string
+	}{
+		{
+			name:  "every Monday and Thursday",
+			rule:  "RRULE:FREQ=WEEKLY;BYDAY=MO,TH",
+			start: date(2025, time.March, 1),
+			from:  date(2025, time.March, 1),
+			to:    date(2025, time.March, 14),
+			want:  []string{"2025-03-03", "2025-03-06", "2025-03-10", "2025-03-13"},
+		},
+		{
+			name:  "every other week defaults to start weekday",
+			rule:  "FREQ=WEEKLY;INTERVAL=2",
+			start: date(2025, time.March, 5),
+			from:  date(2025, time.March, 1),
+			to:    date(2025, time.April, 5),
+			want:  []string{"2025-03-05", "2025-03-19", "2025-04-02"},
+		},
+		{
+			name:  "every third day from start",
+			rule:  "FREQ=DAILY;INTERVAL=3",
+			start: date(2025, time.January, 30),
+			from:  date(2025, time.February, 1),
+			to:    date(2025, time.February, 8),
+			want:  []string{"2025-02-02", "2025-02-05", "2025-02-08"},
+		},
+		{
+			name:  "first and last day of month",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=1,-1",
+			start: date(2025, time.January, 1),
+			from:  date(2025, time.February, 1),
+			to:    date(2025, time.March, 1),
+			want:  []string{"2025-02-01", "2025-02-28", "2025-03-01"},
+		},
+		{
+			name:  "first Monday and last Friday of month",
+			rule:  "FREQ=MONTHLY;BYDAY=1MO,-1FR",
+			start: date(2025, time.January, 1),
+			from:  date(2025, time.March, 1),
+			to:    date(2025, time.March, 31),
+			want:  []string{"2025-03-03", "2025-03-28"},
+		},
+		{
+			name:  "monthly on start day skips short months",
+			rule:  "FREQ=MONTHLY",
+			start: date(2025, time.January, 31),
+			from:  date(2025, time.January, 1),
+			to:    date(2025, time.April, 30),
+			want:  []string{"2025-01-31", "2025-03-31"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRecurrence(tt.rule)
+			if err != nil {
+				t.Fatalf("ParseRecurrence(%q) error = %v", tt.rule, err)
+			}
+			got := formatDates(rule.Occurrences(tt.start, tt.from, tt.to))
+			if len(got) != len(tt.want) {
+				t.Fatalf("Occurrences() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Occurrences() = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestOrderSchedule_NextDates(t *testing.T) {
+	rule, err := ParseRecurrence("FREQ=DAILY")
+	if err != nil {
+		t.Fatal(err)
+	}
+	generatedUntil := date(2025, time.May, 3)
+	endDate := date(2025, time.May, 5)
+	schedule := OrderSchedule{
+		StartDate:      date(2025, time.May, 1),
+		EndDate:        &endDate,
+		GeneratedUntil: &generatedUntil,
+	}
+
+	got := formatDates(schedule.NextDates(rule, date(2025, time.May, 2), date(2025, time.May, 10)))
+	want := []string{"2025-05-04", "2025-05-05"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("NextDates() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// Route planning defaults used when the request leaves them out
+const (
+	DefaultRouteDayStart       = "07:00"
+	DefaultRouteSpeedKmh       = 30.0
+	DefaultRouteServiceMinutes = 15
+)
+
+// RoutePlanRequest asks for optimized stop sequences for the scheduled orders of a day
+type RoutePlanRequest struct {
+	Date     string                `json:"date" validate:"required,datetime=2006-01-02"`
+	Vehicles []RouteVehicleRequest `json:"vehicles" validate:"required,min=1,max=50,dive"`
+	// DayStart is when vehicles leave their depots, "HH:MM"
+	DayStart *string `json:"dayStart,omitempty" validate:"omitempty,datetime=15:04"`
+	// AverageSpeedKmh converts straight-line distances to travel times
+	AverageSpeedKmh *float64 `json:"averageSpeedKmh,omitempty" validate:"omitempty,gt=0,max=130"`
+	// ServiceMinutes is the time spent at each stop
+	ServiceMinutes *int `json:"serviceMinutes,omitempty" validate:"omitempty,min=0,max=240"`
+}
+
+// RouteVehicleRequest is a transport available for the day
+type RouteVehicleRequest struct {
+	TransportID uuid.UUID `json:"transportId" validate:"required"`
+	// DepotWarehouseID is where the route starts and ends; defaults to the warehouse nearest to the day's orders
+	DepotWarehouseID *uuid.UUID `json:"depotWarehouseId,omitempty"`
+}
+
+// RoutePlan is the optimized plan for a day
+type RoutePlan struct {
+	Date       string          `json:"date"`
+	Routes     []VehicleRoute  `json:"routes"`
+	Unassigned []UnroutedOrder `json:"unassigned"`
+}
+
+// VehicleRoute is the ordered stop sequence of one transport
+type VehicleRoute struct {
+	TransportID      uuid.UUID   `json:"transportId"`
+	DepotWarehouseID uuid.UUID   `json:"depotWarehouseId"`
+	Stops            []RouteStop `json:"stops"`
+	DistanceKm       float64     `json:"distanceKm"`
+	VolumeL          int         `json:"volumeL"`
+	DepartAt         string      `json:"departAt"`
+	ReturnAt         string      `json:"returnAt"`
+}
+
+// RouteStop is a single order visit within a route
+type RouteStop struct {
+	Sequence   int       `json:"sequence"`
+	OrderID    uuid.UUID `json:"orderId"`
+	ObjectID   uuid.UUID `json:"objectId"`
+	Priority   string    `json:"priority"`
+	GeoLat     float64   `json:"geoLat"`
+	GeoLng     float64   `json:"geoLng"`
+	WindowFrom *string   `json:"windowFrom,omitempty"`
+	WindowTo   *string   `json:"windowTo,omitempty"`
+	// ArrivalAt is when service starts, after waiting for the window to open
+	ArrivalAt   string  `json:"arrivalAt"`
+	DepartureAt string  `json:"departureAt"`
+	LegKm       float64 `json:"legKm"`
+	VolumeL     int     `json:"volumeL"`
+}
+
+// UnroutedOrder is an order the planner could not place on any route
+type UnroutedOrder struct {
+	OrderID uuid.UUID `json:"orderId"`
+	Reason  string    `json:"reason"`
+}
+
+// RoutingOrder is a scheduled order as seen by the route planner
+type RoutingOrder struct {
+	OrderID     uuid.UUID
+	ObjectID    uuid.UUID
+	GeoLat      *float64
+	GeoLng      *float64
+	WindowFrom  *string
+	WindowTo    *string
+	Priority    string
+	VolumeL     int
+	TransportID *uuid.UUID
+}
+
+// RoutingVehicle is a transport with its depot as seen by the route planner
+type RoutingVehicle struct {
+	TransportID uuid.UUID
+	DepotID     uuid.UUID
+	Depot       GeoPoint
+	CapacityL   int
+}
+
+// RoutingOptions tunes travel time estimates of the route planner
+type RoutingOptions struct {
+	DayStartMinutes int
+	SpeedKmh        float64
+	ServiceMinutes  int
+}
@@ -0,0 +1,287 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+const (
+	earthRadiusKm   = 6371.0
+	minutesPerDay   = 24 * 60
+	maxTwoOptPasses = 50
+)
+
+// GeoPoint is a WGS84 coordinate
+type GeoPoint struct {
+	Lat float64
+	Lng float64
+}
+
+// HaversineKm returns the great-circle distance between two points in kilometres
+func HaversineKm(a, b GeoPoint) float64 {
+	lat1, lat2 := a.Lat*math.Pi/180, b.Lat*math.Pi/180
+	dLat := lat2 - lat1
+	dLng := (b.Lng - a.Lng) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// routeSolver holds the precomputed distance matrix; points 0..len(vehicles)-1 are depots, the rest are orders
+type routeSolver struct {
+	vehicles []RoutingVehicle
+	orders   []RoutingOrder
+	opts     RoutingOptions
+	dist     [][]float64
+	windows  [][2]float64
+}
+
+// SolveRoutes sequences orders onto vehicles in-process, without any external routing service.
+// Orders are inserted by priority and then by window end at the cheapest feasible position, respecting
+// time windows and vehicle capacity; each route is then shortened with 2-opt. Orders must have coordinates.
+func SolveRoutes(vehicles []RoutingVehicle, orders []RoutingOrder, opts RoutingOptions) ([]VehicleRoute, []UnroutedOrder) {
+	s := newRouteSolver(vehicles, orders, opts)
+
+	routes := make([][]int, len(vehicles))
+	loads := make([]int, len(vehicles))
+	unassigned := make([]UnroutedOrder, 0)
+
+	for _, o := range s.insertionOrder() {
+		order := &s.orders[o]
+		bestVehicle, bestPos, bestDelta := -1, 0, math.Inf(1)
+		capacityBlocked := true
+
+		for v := range s.vehicles {
+			if order.TransportID != nil && *order.TransportID != s.vehicles[v].TransportID {
+				continue
+			}
+			if capacity := s.vehicles[v].CapacityL; capacity > 0 && loads[v]+order.VolumeL > capacity {
+				continue
+			}
+			capacityBlocked = false
+
+			base, _ := s.simulate(v, routes[v])
+			for pos := 0; pos <= len(routes[v]); pos++ {
+				candidate := insertAt(routes[v], pos, s.point(o))
+				distance, ok := s.simulate(v, candidate)
+				if ok && distance-base < bestDelta {
+					bestVehicle, bestPos, bestDelta = v, pos, distance-base
+				}
+			}
+		}
+
+		if bestVehicle < 0 {
+			reason := "no vehicle can reach the order within its time window"
+			if capacityBlocked {
+				reason = "order volume exceeds the remaining capacity of the available transport"
+			}
+			unassigned = append(unassigned, UnroutedOrder{OrderID: order.OrderID, Reason: reason})
+			continue
+		}
+		routes[bestVehicle] = insertAt(routes[bestVehicle], bestPos, s.point(o))
+		loads[bestVehicle] += order.VolumeL
+	}
+
+	result := make([]VehicleRoute, len(vehicles))
+	for v := range s.vehicles {
+		result[v] = s.buildRoute(v, s.twoOpt(v, routes[v]))
+	}
+	return result, unassigned
+}
+
+// newRouteSolver precomputes distances and time windows
+func newRouteSolver(vehicles []RoutingVehicle, orders []RoutingOrder, opts RoutingOptions) *routeSolver {
+	points := make([]GeoPoint, 0, len(vehicles)+len(orders))
+	for _, v := range vehicles {
+		points = append(points, v.Depot)
+	}
+	windows := make([][2]float64, len(orders))
+	for i, o := range orders {
+		points = append(points, GeoPoint{Lat: *o.GeoLat, Lng: *o.GeoLng})
+		windows[i] = [2]float64{0, minutesPerDay}
+		if from, to, ok := parseWindow(o.WindowFrom, o.WindowTo); ok {
+			windows[i] = [2]float64{float64(from), float64(to)}
+		}
+	}
+
+	dist := make([][]float64, len(points))
+	for i := range points {
+		dist[i] = make([]float64, len(points))
+		for j := range points {
+			if i != j {
+				dist[i][j] = HaversineKm(points[i], points[j])
+			}
+		}
+	}
+
+	return &routeSolver{vehicles: vehicles, orders: orders, opts: opts, dist: dist, windows: windows}
+}
+
+// point converts an order index to a distance matrix index
+func (s *routeSolver) point(order int) int {
+	return len(s.vehicles) + order
+}
+
+// insertionOrder lists orders by priority, then by earliest window end
+func (s *routeSolver) insertionOrder() []int {
+	idx := make([]int, len(s.orders))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool {
+		ra, rb := priorityRank(s.orders[idx[a]].Priority), priorityRank(s.orders[idx[b]].Priority)
+		if ra != rb {
+			return ra < rb
+		}
+		return s.windows[idx[a]][1] < s.windows[idx[b]][1]
+	})
+	return idx
+}
+
+// priorityRank orders HIGH before MEDIUM before LOW
+func priorityRank(priority string) int {
+	switch priority {
+	case string(OrderPriorityHigh):
+		return 0
+	case string(OrderPriorityLow):
+		return 2
+	default:
+		return 1
+	}
+}
+
+// travelMinutes converts a distance to driving time
+func (s *routeSolver) travelMinutes(km float64) float64 {
+	return km / s.opts.SpeedKmh * 60
+}
+
+// simulate drives the route from and back to the vehicle's depot and reports its length and feasibility
+func (s *routeSolver) simulate(vehicle int, route []int) (float64, bool) {
+	distance := 0.0
+	clock := float64(s.opts.DayStartMinutes)
+	current := vehicle
+
+	for _, p := range route {
+		leg := s.dist[current][p]
+		distance += leg
+		clock += s.travelMinutes(leg)
+
+		window := s.windows[p-len(s.vehicles)]
+		if clock < window[0] {
+			clock = window[0]
+		}
+		if clock > window[1] {
+			return distance, false
+		}
+		clock += float64(s.opts.ServiceMinutes)
+		current = p
+	}
+
+	distance += s.dist[current][vehicle]
+	return distance, clock+s.travelMinutes(s.dist[current][vehicle]) <= minutesPerDay
+}
+
+// twoOpt reverses route segments while that shortens the route and keeps it feasible
+func (s *routeSolver) twoOpt(vehicle int, route []int) []int {
+	best, _ := s.simulate(vehicle, route)
+	for pass := 0; pass < maxTwoOptPasses; pass++ {
+		improved := false
+		for i := 0; i < len(route)-1; i++ {
+			for j := i + 1; j < len(route); j++ {
+				candidate := reverseSegment(route, i, j)
+				if distance, ok := s.simulate(vehicle, candidate); ok && distance < best-1e-9 {
+					route, best, improved = candidate, distance, true
+				}
+			}
+		}
+		if !improved {
+			break
+		}
+	}
+	return route
+}
+
+// buildRoute converts a sequence of matrix indexes to the response shape with arrival times
+func (s *routeSolver) buildRoute(vehicle int, route []int) VehicleRoute {
+	v := s.vehicles[vehicle]
+	result := VehicleRoute{
+		TransportID:      v.TransportID,
+		DepotWarehouseID: v.DepotID,
+		Stops:            make([]RouteStop, 0, len(route)),
+		DepartAt:         formatClock(float64(s.opts.DayStartMinutes)),
+	}
+
+	clock := float64(s.opts.DayStartMinutes)
+	current := vehicle
+	for i, p := range route {
+		order := &s.orders[p-len(s.vehicles)]
+		leg := s.dist[current][p]
+		clock += s.travelMinutes(leg)
+		if window := s.windows[p-len(s.vehicles)]; clock < window[0] {
+			clock = window[0]
+		}
+		stop := RouteStop{
+			Sequence:   i + 1,
+			OrderID:    order.OrderID,
+			ObjectID:   order.ObjectID,
+			Priority:   order.Priority,
+			GeoLat:     *order.GeoLat,
+			GeoLng:     *order.GeoLng,
+			WindowFrom: order.WindowFrom,
+			WindowTo:   order.WindowTo,
+			ArrivalAt:  formatClock(clock),
+			LegKm:      roundKm(leg),
+			VolumeL:    order.VolumeL,
+		}
+		clock += float64(s.opts.ServiceMinutes)
+		stop.DepartureAt = formatClock(clock)
+
+		result.Stops = append(result.Stops, stop)
+		result.DistanceKm += leg
+		result.VolumeL += order.VolumeL
+		current = p
+	}
+
+	back := s.dist[current][vehicle]
+	result.DistanceKm = roundKm(result.DistanceKm + back)
+	result.ReturnAt = formatClock(clock + s.travelMinutes(back))
+	return result
+}
+
+// insertAt returns a copy of route with point inserted at pos
+func insertAt(route []int, pos, point int) []int {
+	out := make([]int, 0, len(route)+1)
+	out = append(out, route[:pos]...)
+	out = append(out, point)
+	return append(out, route[pos:]...)
+}
+
+// reverseSegment returns a copy of route with route[i..j] reversed
+func reverseSegment(route []int, i, j int) []int {
+	out := append([]int(nil), route...)
+	for ; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return out
+}
+
+// formatClock renders minutes since midnight as "HH:MM"
+func formatClock(minutes float64) string {
+	total := int(math.Round(minutes))
+	return fmt.Sprintf("%02d:%02d", total/60, total%60)
+}
+
+// roundKm rounds a distance to 0.1 km
+func roundKm(km float64) float64 {
+	return math.Round(km*10) / 10
+}
+
+// ParseClock parses "15:04" or "15:04:05" into minutes since midnight
+func ParseClock(value string) (int, error) {
+	minutes, ok := parseClock(value)
+	if !ok {
+		return 0, fmt.Errorf("invalid time of day %q, expected HH:MM", value)
+	}
+	return minutes, nil
+}
@@ -0,0 +1,116 @@
+package models
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func routingOrder(lat, lng float64, priority string, volume int) RoutingOrder {
+	return RoutingOrder{OrderID: uuid.New(), ObjectID: uuid.New(), GeoLat: &lat, GeoLng: &lng, Priority: priority, VolumeL: volume}
+}
+
+func stopIDs(route VehicleRoute) []uuid.UUID {
+	ids := make([]uuid.UUID, len(route.Stops))
+	for i, stop := range route.Stops {
+		ids[i] = stop.OrderID
+	}
+	return ids
+}
+
+func TestHaversineKm(t *testing.T) {
+	// Moscow Kremlin to Saint Petersburg Palace Square
+	got := HaversineKm(GeoPoint{Lat: 55.7520, Lng: 37.6175}, GeoPoint{Lat: 59.9390, Lng: 30.3158})
+	if math.Abs(got-634) > 5 {
+		t.Errorf("HaversineKm() = %.1f, want about 634", got)
+	}
+}
+
+var testRoutingOptions = RoutingOptions{DayStartMinutes: 8 * 60, SpeedKmh: 60, ServiceMinutes: 10}
+
+func TestSolveRoutes_SequencesNearestFirst(t *testing.T) {
+	vehicle := RoutingVehicle{TransportID: uuid.New(), DepotID: uuid.New(), Depot: GeoPoint{Lat: 55.0, Lng: 37.0}}
+	far := routingOrder(55.0, 37.30, "MEDIUM", 0)
+	near := routingOrder(55.0, 37.10, "MEDIUM", 0)
+	middle := routingOrder(55.0, 37.20, "MEDIUM", 0)
+
+	routes, unassigned := SolveRoutes([]RoutingVehicle{vehicle}, []RoutingOrder{far, near, middle}, testRoutingOptions)
+
+	if len(unassigned) != 0 {
+		t.Fatalf("SolveRoutes() unassigned = %v", unassigned)
+	}
+	got := stopIDs(routes[0])
+	want := []uuid.UUID{near.OrderID, middle.OrderID, far.OrderID}
+	reversed := []uuid.UUID{far.OrderID, middle.OrderID, near.OrderID}
+	if !equalIDs(got, want) && !equalIDs(got, reversed) {
+		t.Errorf("SolveRoutes() stops = %v, want a sweep along the line", got)
+	}
+	if routes[0].Stops[0].Sequence != 1 || routes[0].DepartAt != "08:00" {
+		t.Errorf("SolveRoutes() route = %+v", routes[0])
+	}
+}
+
+func TestSolveRoutes_RespectsTimeWindows(t *testing.T) {
+	vehicle := RoutingVehicle{TransportID: uuid.New(), Depot: GeoPoint{Lat: 55.0, Lng: 37.0}}
+	near := routingOrder(55.0, 37.10, "MEDIUM", 0)
+	far := routingOrder(55.0, 37.30, "MEDIUM", 0)
+	near.WindowFrom, near.WindowTo = window("12:00", "13:00")
+	far.WindowFrom, far.WindowTo = window("08:00", "09:00")
+
+	routes, unassigned := SolveRoutes([]RoutingVehicle{vehicle}, []RoutingOrder{near, far}, testRoutingOptions)
+
+	if len(unassigned) != 0 {
+		t.Fatalf("SolveRoutes() unassigned = %v", unassigned)
+	}
+	got := stopIDs(routes[0])
+	if !equalIDs(got, []uuid.UUID{far.OrderID, near.OrderID}) {
+		t.Errorf("SolveRoutes() stops = %v, want the morning window first", got)
+	}
+	if routes[0].Stops[1].ArrivalAt != "12:00" {
+		t.Errorf("SolveRoutes() second arrival = %s, want to wait until 12:00", routes[0].Stops[1].ArrivalAt)
+	}
+}
+
+func TestSolveRoutes_CapacityAndPriority(t *testing.T) {
+	vehicle := RoutingVehicle{TransportID: uuid.New(), Depot: GeoPoint{Lat: 55.0, Lng: 37.0}, CapacityL: 1000}
+	low := routingOrder(55.0, 37.05, "LOW", 600)
+	high := routingOrder(55.0, 37.20, "HIGH", 600)
+
+	routes, unassigned := SolveRoutes([]RoutingVehicle{vehicle}, []RoutingOrder{low, high}, testRoutingOptions)
+
+	if got := stopIDs(routes[0]); !equalIDs(got, []uuid.UUID{high.OrderID}) {
+		t.Errorf("SolveRoutes() stops = %v, want only the HIGH priority order", got)
+	}
+	if len(unassigned) != 1 || unassigned[0].OrderID != low.OrderID {
+		t.Fatalf("SolveRoutes() unassigned = %v, want the LOW priority order", unassigned)
+	}
+	if routes[0].VolumeL != 600 {
+		t.Errorf("SolveRoutes() volume = %d, want 600", routes[0].VolumeL)
+	}
+}
+
+func TestSolveRoutes_KeepsAssignedTransport(t *testing.T) {
+	first := RoutingVehicle{TransportID: uuid.New(), Depot: GeoPoint{Lat: 55.0, Lng: 37.0}}
+	second := RoutingVehicle{TransportID: uuid.New(), Depot: GeoPoint{Lat: 56.0, Lng: 38.0}}
+	order := routingOrder(55.0, 37.01, "MEDIUM", 0)
+	order.TransportID = &second.TransportID
+
+	routes, _ := SolveRoutes([]RoutingVehicle{first, second}, []RoutingOrder{order}, testRoutingOptions)
+
+	if len(routes[0].Stops) != 0 || len(routes[1].Stops) != 1 {
+		t.Errorf("SolveRoutes() routes = %+v, want the order on its assigned transport", routes)
+	}
+}
+
+func equalIDs(a, b []uuid.UUID) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
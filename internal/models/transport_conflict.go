@@ -0,0 +1,120 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TransportDayOrder is an order occupying a transport on its scheduled date
+type TransportDayOrder struct {
+	OrderID    uuid.UUID
+	WindowFrom *string
+	WindowTo   *string
+	// VolumeL is the volume of the equipment the order empties or picks up
+	VolumeL int
+}
+
+// TransportConflictError reports double-booked time windows and capacity overruns of a transport
+type TransportConflictError struct {
+	TransportID uuid.UUID
+	Date        time.Time
+	// OverlappingOrderIDs are the orders whose time windows overlap the checked order
+	OverlappingOrderIDs []uuid.UUID
+	// CapacityOrderIDs are the orders sharing the day's capacity; set only when it is exceeded
+	CapacityOrderIDs []uuid.UUID
+	CapacityL        int
+	TotalVolumeL     int
+}
+
+// Error implements the error interface
+func (e *TransportConflictError) Error() string {
+	parts := make([]string, 0, 2)
+	if len(e.OverlappingOrderIDs) > 0 {
+		parts = append(parts, fmt.Sprintf("time window overlaps %d other order(s)", len(e.OverlappingOrderIDs)))
+	}
+	if len(e.CapacityOrderIDs) > 0 {
+		parts = append(parts, fmt.Sprintf("day volume %d L exceeds capacity %d L", e.TotalVolumeL, e.CapacityL))
+	}
+	return fmt.Sprintf("transport conflict: transport %s on %s: %s",
+		e.TransportID, e.Date.Format("2006-01-02"), strings.Join(parts, "; "))
+}
+
+// ConflictingOrderIDs returns every order involved in the conflict, without duplicates
+func (e *TransportConflictError) ConflictingOrderIDs() []uuid.UUID {
+	seen := make(map[uuid.UUID]bool)
+	ids := make([]uuid.UUID, 0, len(e.OverlappingOrderIDs)+len(e.CapacityOrderIDs))
+	for _, list := range [][]uuid.UUID{e.OverlappingOrderIDs, e.CapacityOrderIDs} {
+		for _, id := range list {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+// CheckTransportDay checks the candidate order against the other orders of the transport on the same date.
+// Only orders that both have a time window can overlap; windows are half-open, so 08:00-10:00 and 10:00-12:00 do not.
+func CheckTransportDay(
+	transport *Transport, date time.Time, candidate TransportDayOrder, others []TransportDayOrder,
+) *TransportConflictError {
+	conflict := &TransportConflictError{
+		TransportID:  transport.ID,
+		Date:         date,
+		CapacityL:    transport.CapacityL,
+		TotalVolumeL: candidate.VolumeL,
+	}
+
+	candidateFrom, candidateTo, hasWindow := parseWindow(candidate.WindowFrom, candidate.WindowTo)
+	for _, other := range others {
+		conflict.TotalVolumeL += other.VolumeL
+
+		if !hasWindow {
+			continue
+		}
+		otherFrom, otherTo, ok := parseWindow(other.WindowFrom, other.WindowTo)
+		if ok && candidateFrom < otherTo && otherFrom < candidateTo {
+			conflict.OverlappingOrderIDs = append(conflict.OverlappingOrderIDs, other.OrderID)
+		}
+	}
+
+	if transport.CapacityL > 0 && conflict.TotalVolumeL > transport.CapacityL {
+		for _, other := range others {
+			if other.VolumeL > 0 {
+				conflict.CapacityOrderIDs = append(conflict.CapacityOrderIDs, other.OrderID)
+			}
+		}
+	}
+
+	if len(conflict.OverlappingOrderIDs) == 0 && len(conflict.CapacityOrderIDs) == 0 {
+		return nil
+	}
+	return conflict
+}
+
+// parseWindow converts a time window to minutes since midnight; ok is false if the window is incomplete or invalid
+func parseWindow(from, to *string) (fromMin, toMin int, ok bool) {
+	if from == nil || to == nil {
+		return 0, 0, false
+	}
+	fromMin, okFrom := parseClock(*from)
+	toMin, okTo := parseClock(*to)
+	if !okFrom || !okTo || fromMin >= toMin {
+		return 0, 0, false
+	}
+	return fromMin, toMin, true
+}
+
+// parseClock parses "15:04" or "15:04:05" into minutes since midnight
+func parseClock(value string) (int, bool) {
+	for _, layout := range []string{"15:04", "15:04:05"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.Hour()*60 + t.Minute(), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func window(from, to string) (*string, *string) {
+	return &from, &to
+}
+
+func TestCheckTransportDay(t *testing.T) {
+	transport := &Transport{ID: uuid.New(), CapacityL: 1000}
+	date := time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC)
+
+	morningFrom, morningTo := window("08:00", "10:00")
+	adjacentFrom, adjacentTo := window("10:00:00", "12:00:00")
+	overlapFrom, overlapTo := window("09:30", "11:00")
+
+	tests := []struct {
+		name          string
+		candidate     TransportDayOrder
+		others        []TransportDayOrder
+		wantOverlap   int
+		wantCapacity  int
+		wantConflicts bool
+	}{
+		{
+			name:      "adjacent windows do not overlap",
+			candidate: TransportDayOrder{WindowFrom: morningFrom, WindowTo: morningTo},
+			others:    []TransportDayOrder{{OrderID: uuid.New(), WindowFrom: adjacentFrom, WindowTo: adjacentTo}},
+		},
+		{
+			name:          "overlapping windows",
+			candidate:     TransportDayOrder{WindowFrom: morningFrom, WindowTo: morningTo},
+			others:        []TransportDayOrder{{OrderID: uuid.New(), WindowFrom: overlapFrom, WindowTo: overlapTo}},
+			wantOverlap:   1,
+			wantConflicts: true,
+		},
+		{
+			name:      "orders without window never overlap",
+			candidate: TransportDayOrder{},
+			others:    []TransportDayOrder{{OrderID: uuid.New(), WindowFrom: overlapFrom, WindowTo: overlapTo}},
+		},
+		{
+			name:          "capacity exceeded",
+			candidate:     TransportDayOrder{VolumeL: 600},
+			others:        []TransportDayOrder{{OrderID: uuid.New(), VolumeL: 500}, {OrderID: uuid.New()}},
+			wantCapacity:  1,
+			wantConflicts: true,
+		},
+		{
+			name:      "capacity reached exactly",
+			candidate: TransportDayOrder{VolumeL: 500},
+			others:    []TransportDayOrder{{OrderID: uuid.New(), VolumeL: 500}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conflict := CheckTransportDay(transport, date, tt.candidate, tt.others)
+			if (conflict != nil) != tt.wantConflicts {
+				t.Fatalf("CheckTransportDay() = %v, wantConflicts %v", conflict, tt.wantConflicts)
+			}
+			if conflict == nil {
+				return
+			}
+			if len(conflict.OverlappingOrderIDs) != tt.wantOverlap || len(conflict.CapacityOrderIDs) != tt.wantCapacity {
+				t.Errorf("CheckTransportDay() overlapping = %v, capacity = %v", conflict.OverlappingOrderIDs, conflict.CapacityOrderIDs)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"eco-van-api/internal/domainerr"
+
+	"github.com/google/uuid"
+)
+
+// LoginFailureInvalidTwoFactorCode is recorded when the second login step gets a wrong code
+const LoginFailureInvalidTwoFactorCode = "INVALID_2FA_CODE"
+
+// TwoFactorPolicy configures TOTP two-factor authentication
+type TwoFactorPolicy struct {
+	// Issuer names the service in authenticator apps
+	Issuer string
+	// RequiredForAdmin makes two-factor authentication mandatory for ADMIN accounts
+	RequiredForAdmin bool
+}
+
+// Required reports whether users with the role must use two-factor authentication
+func (p TwoFactorPolicy) Required(role UserRole) bool {
+	return p.RequiredForAdmin && role == UserRoleAdmin
+}
+
+// UserTOTP is the stored TOTP secret of a user
+type UserTOTP struct {
+	UserID uuid.UUID
+	Secret string
+	// ConfirmedAt is set once the user proved the authenticator works; before that 2FA is not enabled
+	ConfirmedAt *time.Time
+	// LastUsedStep is the time step of the last accepted code, so a code cannot be replayed
+	LastUsedStep *int64
+	CreatedAt    time.Time
+}
+
+// IsEnabled reports whether the enrollment was confirmed
+func (t *UserTOTP) IsEnabled() bool {
+	return t != nil && t.ConfirmedAt != nil
+}
+
+// TwoFactorEnrollResponse carries the secret of a pending enrollment; clients render the URI as a QR code
+type TwoFactorEnrollResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioningUri"`
+}
+
+// TwoFactorStatusResponse describes the two-factor authentication state of the current user
+type TwoFactorStatusResponse struct {
+	Enabled                bool `json:"enabled"`
+	Required               bool `json:"required"`
+	RecoveryCodesRemaining int  `json:"recoveryCodesRemaining"`
+}
+
+// RecoveryCodesResponse returns newly generated recovery codes; they are shown once
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+// TwoFactorConfirmResponse returns the recovery codes and a new session after enabling two-factor authentication
+type TwoFactorConfirmResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+	AuthResponse
+}
+
+// TwoFactorCodeRequest carries a TOTP code or, where accepted, a recovery code
+type TwoFactorCodeRequest struct {
+	Code string `json:"code"`
+}
+
+// TwoFactorLoginRequest completes a login with the challenge token from the password step
+type TwoFactorLoginRequest struct {
+	TwoFactorToken string `json:"twoFactorToken"`
+	Code           string `json:"code"`
+}
+
+// Validate validates the two-factor code request
+func (req *TwoFactorCodeRequest) Validate() error {
+	if strings.TrimSpace(req.Code) == "" {
+		return domainerr.InvalidField("/code", "required", "code is required")
+	}
+
+	return nil
+}
+
+// Validate validates the two-factor login request
+func (req *TwoFactorLoginRequest) Validate() error {
+	if strings.TrimSpace(req.TwoFactorToken) == "" {
+		return domainerr.InvalidField("/twoFactorToken", "required", "two-factor token is required")
+	}
+
+	if strings.TrimSpace(req.Code) == "" {
+		return domainerr.InvalidField("/code", "required", "code is required")
+	}
+
+	return nil
+}
@@ -1,11 +1,13 @@
 package models
 
 import (
-	"errors"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
+	"eco-van-api/internal/domainerr"
+
 	"github.com/google/uuid"
 )
 
@@ -25,8 +27,12 @@ type User struct {
 	Email        string    `json:"email"`
 	PasswordHash string    `json:"-"` // Never expose password hash in JSON
 	Role         UserRole  `json:"role"`
-	CreatedAt    time.Time `json:"createdAt"`
-	UpdatedAt    time.Time `json:"updatedAt"`
+	// MustChangePassword restricts the user to changing their password until they do so
+	MustChangePassword bool `json:"mustChangePassword"`
+	// DisabledAt is set while the account is disabled; disabled users cannot authenticate
+	DisabledAt *time.Time `json:"disabledAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
 }
 
 // CreateUserRequest represents a request to create a new user
@@ -36,6 +42,13 @@ type CreateUserRequest struct {
 	Role     UserRole `json:"role"`
 }
 
+// UpdateUserRequest represents a request to update a user; PATCH may omit fields, PUT requires email and role
+type UpdateUserRequest struct {
+	Email    *string   `json:"email,omitempty"`
+	Role     *UserRole `json:"role,omitempty"`
+	Disabled *bool     `json:"disabled,omitempty"`
+}
+
 // LoginRequest represents a login request
 type LoginRequest struct {
 	Email    string `json:"email"`
@@ -44,9 +57,17 @@ type LoginRequest struct {
 
 // AuthResponse represents the response from authentication endpoints
 type AuthResponse struct {
-	AccessToken  string `json:"accessToken"`
-	RefreshToken string `json:"refreshToken"`
+	AccessToken  string `json:"accessToken,omitempty"`
+	RefreshToken string `json:"refreshToken,omitempty"`
 	ExpiresIn    int    `json:"expiresIn"`
+	// MustChangePassword means the access token only allows changing the password and no refresh token is issued
+	MustChangePassword bool `json:"mustChangePassword,omitempty"`
+	// TwoFactorRequired means the password was accepted and the login must be completed with a code;
+	// TwoFactorToken identifies the login in that second step and no other token is issued
+	TwoFactorRequired bool   `json:"twoFactorRequired,omitempty"`
+	TwoFactorToken    string `json:"twoFactorToken,omitempty"`
+	// MustEnrollTwoFactor means the access token only allows setting up two-factor authentication
+	MustEnrollTwoFactor bool `json:"mustEnrollTwoFactor,omitempty"`
 }
 
 // RefreshRequest represents a token refresh request
@@ -54,6 +75,11 @@ type RefreshRequest struct {
 	RefreshToken string `json:"refreshToken"`
 }
 
+// LogoutRequest represents a logout request revoking the session of a refresh token
+type LogoutRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
 // ValidateUserRole checks if the role is valid
 func (r UserRole) IsValid() bool {
 	switch r {
@@ -80,37 +106,73 @@ const minPasswordLength = 8
 // ValidateCreateUserRequest validates the create user request
 func (req *CreateUserRequest) Validate() error {
 	if strings.TrimSpace(req.Email) == "" {
-		return errors.New("email is required")
+		return domainerr.InvalidField("/email", "required", "email is required")
 	}
 
 	// Validate email format
 	if !emailRegex.MatchString(req.Email) {
-		return errors.New("invalid email format")
+		return domainerr.InvalidField("/email", "email", "invalid email format")
 	}
 
 	if strings.TrimSpace(req.Password) == "" {
-		return errors.New("password is required")
+		return domainerr.InvalidField("/password", "required", "password is required")
 	}
 
 	if len(req.Password) < minPasswordLength {
-		return errors.New("password must be at least 8 characters long")
+		return domainerr.InvalidField("/password", "min", "password must be at least %d characters long", minPasswordLength).
+			WithMessageID("min.string", strconv.Itoa(minPasswordLength))
 	}
 
 	if !req.Role.IsValid() {
-		return errors.New("invalid role")
+		return domainerr.InvalidField("/role", "oneof", "invalid role")
+	}
+
+	return nil
+}
+
+// Validate validates the fields present in the update user request
+func (req *UpdateUserRequest) Validate() error {
+	if req.Email == nil && req.Role == nil && req.Disabled == nil {
+		return domainerr.InvalidField("", "required_one_of", "at least one of email, role or disabled is required")
+	}
+
+	if req.Email != nil && !emailRegex.MatchString(*req.Email) {
+		return domainerr.InvalidField("/email", "email", "invalid email format")
+	}
+
+	if req.Role != nil && !req.Role.IsValid() {
+		return domainerr.InvalidField("/role", "oneof", "invalid role")
 	}
 
 	return nil
 }
 
+// ValidateReplace validates the update user request of a full replacement (PUT)
+func (req *UpdateUserRequest) ValidateReplace() error {
+	if req.Email == nil {
+		return domainerr.InvalidField("/email", "required", "email is required")
+	}
+
+	if req.Role == nil {
+		return domainerr.InvalidField("/role", "required", "role is required")
+	}
+
+	return req.Validate()
+}
+
+// IsDisabled reports whether the account is disabled
+func (u *User) IsDisabled() bool {
+	return u.DisabledAt != nil
+}
+
 // ValidateLoginRequest validates the login request
 func (req *LoginRequest) Validate() error {
 	if strings.TrimSpace(req.Email) == "" {
-		return errors.New("email is required")
+		return domainerr.InvalidField("/email", "required", "email is required")
 	}
 
 	if strings.TrimSpace(req.Password) == "" {
-		return errors.New("password is required")
+		return domainerr.InvalidField("/password", "required", "password is required")
 	}
 
 	return nil
@@ -119,7 +181,16 @@ func (req *LoginRequest) Validate() error {
 // ValidateRefreshRequest validates the refresh request
 func (req *RefreshRequest) Validate() error {
 	if strings.TrimSpace(req.RefreshToken) == "" {
-		return errors.New("refresh token is required")
+		return domainerr.InvalidField("/refreshToken", "required", "refresh token is required")
+	}
+
+	return nil
+}
+
+// Validate validates the logout request
+func (req *LogoutRequest) Validate() error {
+	if strings.TrimSpace(req.RefreshToken) == "" {
+		return domainerr.InvalidField("/refreshToken", "required", "refresh token is required")
 	}
 
 	return nil
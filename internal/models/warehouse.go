@@ -11,6 +11,8 @@ type Warehouse struct {
 	ID        uuid.UUID  `json:"id" db:"id"`
 	Name      string     `json:"name" db:"name"`
 	Address   *string    `json:"address,omitempty" db:"address"`
+	GeoLat    *float64   `json:"geoLat,omitempty" db:"geo_lat"`
+	GeoLng    *float64   `json:"geoLng,omitempty" db:"geo_lng"`
 	Notes     *string    `json:"notes,omitempty" db:"notes"`
 	CreatedAt time.Time  `json:"createdAt" db:"created_at"`
 	UpdatedAt time.Time  `json:"updatedAt" db:"updated_at"`
@@ -19,16 +21,20 @@ type Warehouse struct {
 
 // CreateWarehouseRequest represents the request to create a new warehouse
 type CreateWarehouseRequest struct {
-	Name    string  `json:"name" validate:"required,min=1,max=255"`
-	Address *string `json:"address,omitempty" validate:"omitempty,max=500"`
-	Notes   *string `json:"notes,omitempty" validate:"omitempty,max=1000"`
+	Name    string   `json:"name" validate:"required,min=1,max=255"`
+	Address *string  `json:"address,omitempty" validate:"omitempty,max=500"`
+	GeoLat  *float64 `json:"geoLat,omitempty" validate:"omitempty,min=-90,max=90"`
+	GeoLng  *float64 `json:"geoLng,omitempty" validate:"omitempty,min=-180,max=180"`
+	Notes   *string  `json:"notes,omitempty" validate:"omitempty,max=1000"`
 }
 
 // UpdateWarehouseRequest represents the request to update an existing warehouse
 type UpdateWarehouseRequest struct {
-	Name    string  `json:"name" validate:"required,min=1,max=255"`
-	Address *string `json:"address,omitempty" validate:"omitempty,max=500"`
-	Notes   *string `json:"notes,omitempty" validate:"omitempty,max=1000"`
+	Name    string   `json:"name" validate:"required,min=1,max=255"`
+	Address *string  `json:"address,omitempty" validate:"omitempty,max=500"`
+	GeoLat  *float64 `json:"geoLat,omitempty" validate:"omitempty,min=-90,max=90"`
+	GeoLng  *float64 `json:"geoLng,omitempty" validate:"omitempty,min=-180,max=180"`
+	Notes   *string  `json:"notes,omitempty" validate:"omitempty,max=1000"`
 }
 
 // WarehouseListRequest represents the request to list warehouses with filtering and pagination
@@ -51,6 +57,8 @@ type WarehouseResponse struct {
 	ID        uuid.UUID  `json:"id"`
 	Name      string     `json:"name"`
 	Address   *string    `json:"address,omitempty"`
+	GeoLat    *float64   `json:"geoLat,omitempty"`
+	GeoLng    *float64   `json:"geoLng,omitempty"`
 	Notes     *string    `json:"notes,omitempty"`
 	CreatedAt time.Time  `json:"createdAt"`
 	UpdatedAt time.Time  `json:"updatedAt"`
@@ -63,6 +71,8 @@ func (w *Warehouse) ToResponse() WarehouseResponse {
 		ID:        w.ID,
 		Name:      w.Name,
 		Address:   w.Address,
+		GeoLat:    w.GeoLat,
+		GeoLng:    w.GeoLng,
 		Notes:     w.Notes,
 		CreatedAt: w.CreatedAt,
 		UpdatedAt: w.UpdatedAt,
@@ -75,6 +85,8 @@ func FromWarehouseCreateRequest(req CreateWarehouseRequest) Warehouse {
 	return Warehouse{
 		Name:    req.Name,
 		Address: req.Address,
+		GeoLat:  req.GeoLat,
+		GeoLng:  req.GeoLng,
 		Notes:   req.Notes,
 	}
 }
@@ -83,5 +95,12 @@ func FromWarehouseCreateRequest(req CreateWarehouseRequest) Warehouse {
 func (w *Warehouse) UpdateFromRequest(req UpdateWarehouseRequest) {
 	w.Name = req.Name
 	w.Address = req.Address
+	w.GeoLat = req.GeoLat
+	w.GeoLng = req.GeoLng
 	w.Notes = req.Notes
 }
+
+// HasCoordinates reports whether the warehouse can serve as a route planning depot
+func (w *Warehouse) HasCoordinates() bool {
+	return w.GeoLat != nil && w.GeoLng != nil
+}
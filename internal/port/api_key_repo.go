@@ -0,0 +1,31 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"eco-van-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// APIKeyRepository defines the interface for API key data access operations
+type APIKeyRepository interface {
+	// Create stores a new API key
+	Create(ctx context.Context, key *models.APIKey) error
+
+	// Get retrieves an API key by ID, returning nil if it does not exist
+	Get(ctx context.Context, id uuid.UUID) (*models.APIKey, error)
+
+	// GetByHash retrieves an API key by the hash of its value, returning nil if it does not exist
+	GetByHash(ctx context.Context, keyHash string) (*models.APIKey, error)
+
+	// List retrieves all API keys, newest first
+	List(ctx context.Context) ([]models.APIKey, error)
+
+	// Revoke revokes an active key, reporting whether it was revoked by this call
+	Revoke(ctx context.Context, id uuid.UUID) (bool, error)
+
+	// TouchLastUsed records when a key was last used
+	TouchLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error
+}
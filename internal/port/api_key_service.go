@@ -0,0 +1,30 @@
+package port
+
+import (
+	"context"
+
+	"eco-van-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// APIKeyService defines the interface for API key management and authentication
+type APIKeyService interface {
+	// Create issues a new API key; the key is returned once and only its hash is stored
+	Create(ctx context.Context, req models.CreateAPIKeyRequest, createdBy uuid.UUID) (*models.CreateAPIKeyResponse, error)
+
+	// Get retrieves an API key by ID
+	Get(ctx context.Context, id uuid.UUID) (*models.APIKey, error)
+
+	// List retrieves all API keys
+	List(ctx context.Context) (*models.APIKeyListResponse, error)
+
+	// Revoke revokes an API key
+	Revoke(ctx context.Context, id uuid.UUID) error
+
+	// Authenticate resolves an active API key from its value and records its use
+	Authenticate(ctx context.Context, key string) (*models.APIKey, error)
+
+	// Resolve resolves an active API key from its value without recording its use
+	Resolve(ctx context.Context, key string) (*models.APIKey, error)
+}
@@ -0,0 +1,22 @@
+package port
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrBlobNotFound is returned by BlobStore implementations when a key does not exist
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore defines the interface for binary object storage (photos and other uploads)
+type BlobStore interface {
+	// Put stores content under key and returns the number of bytes written
+	Put(ctx context.Context, key string, content io.Reader, contentType string) (int64, error)
+
+	// Get opens the content stored under key; the caller must close the reader
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes the content stored under key; deleting a missing key is not an error
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,21 @@
+package port
+
+import (
+	"context"
+	"eco-van-api/internal/models"
+)
+
+// CancellationReasonRepository defines the interface for cancellation reason data operations
+type CancellationReasonRepository interface {
+	// Create creates a new cancellation reason
+	Create(ctx context.Context, reason *models.CancellationReason) error
+
+	// GetByCode retrieves a cancellation reason by code
+	GetByCode(ctx context.Context, code string) (*models.CancellationReason, error)
+
+	// List retrieves cancellation reasons, optionally including inactive ones
+	List(ctx context.Context, includeInactive bool) ([]models.CancellationReason, error)
+
+	// Update updates an existing cancellation reason
+	Update(ctx context.Context, reason *models.CancellationReason) error
+}
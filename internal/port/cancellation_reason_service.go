@@ -0,0 +1,18 @@
+package port
+
+import (
+	"context"
+	"eco-van-api/internal/models"
+)
+
+// CancellationReasonService defines the interface for cancellation reason business logic
+type CancellationReasonService interface {
+	// Create creates a new cancellation reason
+	Create(ctx context.Context, req models.CreateCancellationReasonRequest) (*models.CancellationReason, error)
+
+	// List retrieves cancellation reasons, optionally including inactive ones
+	List(ctx context.Context, includeInactive bool) (*models.CancellationReasonListResponse, error)
+
+	// Update updates the label or active flag of a cancellation reason
+	Update(ctx context.Context, code string, req models.UpdateCancellationReasonRequest) (*models.CancellationReason, error)
+}
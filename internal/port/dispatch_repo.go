@@ -0,0 +1,17 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"eco-van-api/internal/models"
+)
+
+// DispatchRepository defines the interface for dispatch board data access
+type DispatchRepository interface {
+	// ListTransports returns the non-deleted transport with its current driver and attached equipment
+	ListTransports(ctx context.Context) ([]models.DispatchTransport, error)
+
+	// ListDayOrders returns the non-canceled orders of a date with client and object details, in display order
+	ListDayOrders(ctx context.Context, date time.Time) ([]models.DispatchOrder, error)
+}
@@ -0,0 +1,14 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"eco-van-api/internal/models"
+)
+
+// DispatchService defines the interface for the dispatch board
+type DispatchService interface {
+	// GetBoard returns every transport with its crew, equipment and orders for the date, plus unassigned orders
+	GetBoard(ctx context.Context, date time.Time) (*models.DispatchBoard, error)
+}
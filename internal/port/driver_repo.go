@@ -17,6 +17,12 @@ type DriverRepository interface {
 	// ExistsByLicenseNo checks if driver exists with the given license number
 	ExistsByLicenseNo(ctx context.Context, licenseNo string, excludeID *uuid.UUID) (bool, error)
 
+	// GetByUserID retrieves the non-deleted driver linked to a user account
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*models.Driver, error)
+
+	// SetUser links a user account to a driver, or unlinks it when userID is nil
+	SetUser(ctx context.Context, driverID uuid.UUID, userID *uuid.UUID) error
+
 	// IsAssignedToTransport checks if driver is currently assigned to a transport
 	IsAssignedToTransport(ctx context.Context, driverID uuid.UUID) (bool, error)
 }
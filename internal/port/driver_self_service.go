@@ -0,0 +1,27 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"eco-van-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// DriverSelfService defines the self-service operations of a DRIVER user on their own driver profile
+type DriverSelfService interface {
+	// GetProfile returns the driver linked to the user and the transport the driver is assigned to
+	GetProfile(ctx context.Context, userID uuid.UUID) (*models.DriverSelfResponse, error)
+
+	// ListOrders returns the non-canceled orders of the driver's transport for a date
+	ListOrders(ctx context.Context, userID uuid.UUID, date time.Time) (*models.DriverOrdersResponse, error)
+
+	// StartOrder moves an order of the driver's transport to IN_PROGRESS
+	StartOrder(ctx context.Context, userID, orderID uuid.UUID) (*models.OrderResponse, error)
+
+	// CompleteOrder moves an order of the driver's transport to COMPLETED with the collected amounts
+	CompleteOrder(
+		ctx context.Context, userID, orderID uuid.UUID, completion models.OrderCompletionRequest,
+	) (*models.OrderResponse, error)
+}
@@ -29,4 +29,10 @@ type DriverService interface {
 
 	// ListAvailable retrieves available drivers (not assigned to any transport)
 	ListAvailable(ctx context.Context, req models.DriverListRequest) (*models.DriverListResponse, error)
+
+	// LinkUser links a DRIVER user account to a driver so the user can use the driver self-service API
+	LinkUser(ctx context.Context, id uuid.UUID, req models.LinkDriverUserRequest) (*models.DriverResponse, error)
+
+	// UnlinkUser removes the user account link of a driver
+	UnlinkUser(ctx context.Context, id uuid.UUID) (*models.DriverResponse, error)
 }
@@ -0,0 +1,27 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"eco-van-api/internal/models"
+)
+
+// LoginSecurityRepository defines the interface for login failure counters and the login attempt log
+type LoginSecurityRepository interface {
+	// GetThrottle retrieves the failure counter of a key, returning nil if there is none
+	GetThrottle(ctx context.Context, key string) (*models.LoginThrottle, error)
+
+	// RegisterFailure atomically counts a failure, restarting the count after a quiet window,
+	// and locks the key once maxFailures is reached
+	RegisterFailure(ctx context.Context, key string, maxFailures int, window, lockout time.Duration) (*models.LoginThrottle, error)
+
+	// ResetThrottle clears the failure counter and lock of a key
+	ResetThrottle(ctx context.Context, key string) error
+
+	// RecordAttempt appends a login attempt to the log
+	RecordAttempt(ctx context.Context, attempt *models.LoginAttempt) error
+
+	// ListAttempts retrieves the login attempt log, newest first
+	ListAttempts(ctx context.Context, req models.LoginAttemptListRequest) ([]models.LoginAttempt, int64, error)
+}
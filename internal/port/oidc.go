@@ -0,0 +1,20 @@
+package port
+
+import (
+	"context"
+
+	"eco-van-api/internal/models"
+)
+
+// OIDCProvider defines the interface to an OpenID Connect identity provider using the authorization code flow
+type OIDCProvider interface {
+	// Issuer returns the issuer identifier of the provider
+	Issuer() string
+
+	// AuthCodeURL returns the URL that starts a login, with the S256 PKCE challenge of the code verifier
+	AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+
+	// Exchange redeems an authorization code and returns the claims of the verified ID token;
+	// the token must carry the nonce of the login
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*models.OIDCClaims, error)
+}
@@ -0,0 +1,23 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"eco-van-api/internal/models"
+)
+
+// OIDCRepository defines the interface for single sign-on login states and linked identities
+type OIDCRepository interface {
+	// CreateLoginState stores a pending authorization request
+	CreateLoginState(ctx context.Context, state *models.OIDCLoginState) error
+
+	// ConsumeLoginState removes a login state and returns it, or nil if it does not exist or expired before now
+	ConsumeLoginState(ctx context.Context, stateHash string, now time.Time) (*models.OIDCLoginState, error)
+
+	// GetIdentity retrieves a linked identity, returning nil if it does not exist
+	GetIdentity(ctx context.Context, issuer, subject string) (*models.OIDCIdentity, error)
+
+	// CreateIdentity links an identity provider account to a user
+	CreateIdentity(ctx context.Context, identity *models.OIDCIdentity) error
+}
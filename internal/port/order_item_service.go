@@ -0,0 +1,20 @@
+package port
+
+import (
+	"context"
+	"eco-van-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// OrderItemService defines the interface for order item business logic
+type OrderItemService interface {
+	// List retrieves the equipment items of an order
+	List(ctx context.Context, orderID uuid.UUID) (*models.OrderItemListResponse, error)
+
+	// Replace validates and replaces the full list of items of an order; a non-nil ifMatch must name
+	// the current order version
+	Replace(
+		ctx context.Context, orderID uuid.UUID, req models.ReplaceOrderItemsRequest, ifMatch *models.VersionMatch,
+	) (*models.OrderItemListResponse, error)
+}
@@ -2,6 +2,7 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"eco-van-api/internal/models"
 
@@ -19,8 +20,29 @@ type OrderRepository interface {
 	// Update updates an existing order
 	Update(ctx context.Context, order *models.Order) error
 
-	// SoftDelete marks an order as deleted by setting deleted_at
-	SoftDelete(ctx context.Context, id uuid.UUID) error
+	// UpdateStatus changes the order status, records the transition and completion record, and applies equipment moves atomically
+	UpdateStatus(ctx context.Context, order *models.Order, entry *models.OrderStatusHistory, moves []models.EquipmentMove) error
+
+	// GetCompletion returns the completion record of an order, or nil if the order has none
+	GetCompletion(ctx context.Context, orderID uuid.UUID) (*models.OrderCompletion, error)
+
+	// ListItems returns the equipment items of an order
+	ListItems(ctx context.Context, orderID uuid.UUID) ([]models.OrderItem, error)
+
+	// ReplaceItems replaces all equipment items of an order at order.Version and bumps the version
+	ReplaceItems(ctx context.Context, order *models.Order, items []models.OrderItem) error
+
+	// ListTransportDayOrders returns the non-canceled orders booked on a transport for a date with their volumes
+	ListTransportDayOrders(ctx context.Context, transportID uuid.UUID, date time.Time) ([]models.TransportDayOrder, error)
+
+	// GetVolume returns the volume of the equipment an order empties or picks up
+	GetVolume(ctx context.Context, orderID uuid.UUID) (int, error)
+
+	// ListStatusHistory returns the status transitions of an order, oldest first
+	ListStatusHistory(ctx context.Context, orderID uuid.UUID) ([]models.OrderStatusHistory, error)
+
+	// SoftDelete marks an order at the given version as deleted by setting deleted_at
+	SoftDelete(ctx context.Context, id uuid.UUID, version int) error
 
 	// Restore restores a soft-deleted order by clearing deleted_at
 	Restore(ctx context.Context, id uuid.UUID) error
@@ -28,6 +50,9 @@ type OrderRepository interface {
 	// List retrieves orders with pagination and filtering
 	List(ctx context.Context, req models.OrderListRequest) (*models.OrderListResponse, error)
 
+	// CancellationStats counts canceled orders per month and reason within the requested period
+	CancellationStats(ctx context.Context, req models.CancellationStatsRequest) ([]models.CancellationStat, error)
+
 	// ExistsByClientAndObject checks if an order exists for the given client and object
 	ExistsByClientAndObject(ctx context.Context, clientID, objectID uuid.UUID, excludeID *uuid.UUID) (bool, error)
 
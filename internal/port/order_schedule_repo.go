@@ -0,0 +1,39 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"eco-van-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// OrderScheduleRepository defines the interface for recurring order schedule data access operations
+type OrderScheduleRepository interface {
+	// Create creates a new order schedule
+	Create(ctx context.Context, schedule *models.OrderSchedule) error
+
+	// GetByID retrieves an order schedule by ID
+	GetByID(ctx context.Context, id uuid.UUID, includeDeleted bool) (*models.OrderSchedule, error)
+
+	// Update updates an existing order schedule
+	Update(ctx context.Context, schedule *models.OrderSchedule) error
+
+	// SoftDelete marks an order schedule as deleted by setting deleted_at
+	SoftDelete(ctx context.Context, id uuid.UUID) error
+
+	// List retrieves order schedules with pagination and filtering
+	List(ctx context.Context, req models.OrderScheduleListRequest) (*models.OrderScheduleListResponse, error)
+
+	// ListDue returns active schedules that have not been generated up to the horizon yet
+	ListDue(ctx context.Context, horizon time.Time) ([]models.OrderSchedule, error)
+
+	// GenerateOrders inserts orders for the given dates and advances generated_until atomically.
+	// Dates that already have a live order for the schedule are skipped; the number of created orders is returned.
+	GenerateOrders(ctx context.Context, schedule *models.OrderSchedule, dates []time.Time, generatedUntil time.Time) (int, error)
+
+	// DiscardFutureOrders soft-deletes generated DRAFT and SCHEDULED orders dated on or after from
+	// and rewinds generated_until so that they are generated again from the current schedule
+	DiscardFutureOrders(ctx context.Context, scheduleID uuid.UUID, from time.Time) (int64, error)
+}
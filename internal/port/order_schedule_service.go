@@ -0,0 +1,31 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"eco-van-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// OrderScheduleService defines the interface for recurring order schedule business logic
+type OrderScheduleService interface {
+	// Create creates a new order schedule with validation
+	Create(ctx context.Context, req *models.CreateOrderScheduleRequest, createdBy *uuid.UUID) (*models.OrderSchedule, error)
+
+	// GetByID retrieves an order schedule by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*models.OrderSchedule, error)
+
+	// List retrieves order schedules with pagination and filtering
+	List(ctx context.Context, req models.OrderScheduleListRequest) (*models.OrderScheduleListResponse, error)
+
+	// Update updates an order schedule and replaces its future generated orders
+	Update(ctx context.Context, id uuid.UUID, req models.UpdateOrderScheduleRequest) (*models.OrderSchedule, error)
+
+	// Delete soft-deletes an order schedule together with its future generated orders
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// GenerateOrders materializes orders for all due schedules up to the configured horizon after now
+	GenerateOrders(ctx context.Context, now time.Time) (int, error)
+}
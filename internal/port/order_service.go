@@ -19,18 +19,27 @@ type OrderService interface {
 	// List retrieves orders with pagination and filtering
 	List(ctx context.Context, req models.OrderListRequest) (*models.OrderListResponse, error)
 
-	// Update updates an existing order with validation
-	Update(ctx context.Context, id uuid.UUID, req models.UpdateOrderRequest) (*models.OrderResponse, error)
+	// Update updates an existing order with validation. Writes that take an ifMatch fail with a
+	// precondition error when the order is at none of its versions; nil skips the check.
+	Update(ctx context.Context, id uuid.UUID, req models.UpdateOrderRequest, ifMatch *models.VersionMatch) (*models.OrderResponse, error)
 
-	// UpdateStatus updates the order status with transition validation
-	UpdateStatus(ctx context.Context, id uuid.UUID, req models.UpdateOrderStatusRequest) (*models.OrderResponse, error)
+	// UpdateStatus updates the order status with transition validation and records who changed it
+	UpdateStatus(
+		ctx context.Context, id uuid.UUID, req models.UpdateOrderStatusRequest, changedBy *uuid.UUID, ifMatch *models.VersionMatch,
+	) (*models.OrderResponse, error)
+
+	// GetStatusHistory returns the status transitions of an order
+	GetStatusHistory(ctx context.Context, id uuid.UUID) (*models.OrderStatusHistoryResponse, error)
+
+	// GetCancellationStats returns monthly cancellation counts grouped by reason
+	GetCancellationStats(ctx context.Context, req models.CancellationStatsRequest) (*models.CancellationStatsResponse, error)
 
 	// Delete soft-deletes an order (only if status allows)
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID, ifMatch *models.VersionMatch) error
 
 	// Restore restores a soft-deleted order
 	Restore(ctx context.Context, id uuid.UUID) (*models.OrderResponse, error)
 
 	// AssignTransport assigns transport to an order
-	AssignTransport(ctx context.Context, orderID uuid.UUID, req models.AssignTransportRequest) error
+	AssignTransport(ctx context.Context, orderID uuid.UUID, req models.AssignTransportRequest, ifMatch *models.VersionMatch) error
 }
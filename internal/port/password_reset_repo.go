@@ -0,0 +1,23 @@
+package port
+
+import (
+	"context"
+	"eco-van-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// PasswordResetRepository defines the interface for one-time password reset token operations
+type PasswordResetRepository interface {
+	// Create stores a newly issued reset token
+	Create(ctx context.Context, token *models.PasswordResetToken) error
+
+	// GetByHash retrieves a reset token by the hash of its value, returning nil if it does not exist
+	GetByHash(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error)
+
+	// MarkUsed consumes an unused token; it returns false if the token was already used
+	MarkUsed(ctx context.Context, id uuid.UUID) (bool, error)
+
+	// InvalidateForUser consumes every unused token of a user
+	InvalidateForUser(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,26 @@
+package port
+
+import (
+	"context"
+	"eco-van-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// PhotoRepository defines the interface for photo metadata operations
+type PhotoRepository interface {
+	// Create stores photo metadata
+	Create(ctx context.Context, photo *models.Photo) error
+
+	// GetByID retrieves photo metadata by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Photo, error)
+
+	// ListByEntity retrieves all photos attached to an entity, newest first
+	ListByEntity(ctx context.Context, entityType models.PhotoEntityType, entityID uuid.UUID) ([]models.Photo, error)
+
+	// Delete removes photo metadata by ID
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// EntityExists checks if the referenced entity exists (excluding soft-deleted)
+	EntityExists(ctx context.Context, entityType models.PhotoEntityType, entityID uuid.UUID) (bool, error)
+}
@@ -0,0 +1,25 @@
+package port
+
+import (
+	"context"
+	"eco-van-api/internal/models"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+// PhotoService defines the interface for photo business logic
+type PhotoService interface {
+	// Upload validates and stores a photo for the given entity
+	Upload(ctx context.Context, entityType models.PhotoEntityType, entityID uuid.UUID,
+		originalName string, content io.Reader) (*models.PhotoResponse, error)
+
+	// List retrieves all photos attached to an entity
+	List(ctx context.Context, entityType models.PhotoEntityType, entityID uuid.UUID) (*models.PhotoListResponse, error)
+
+	// Open returns photo metadata and its content; the caller must close the reader
+	Open(ctx context.Context, entityType models.PhotoEntityType, entityID, photoID uuid.UUID) (*models.Photo, io.ReadCloser, error)
+
+	// Delete removes a photo and its stored file
+	Delete(ctx context.Context, entityType models.PhotoEntityType, entityID, photoID uuid.UUID) error
+}
@@ -0,0 +1,14 @@
+package port
+
+import (
+	"context"
+	"eco-van-api/internal/models"
+	"time"
+)
+
+// RateLimitStore keeps the token buckets of the rate limiter; a store shared by all replicas
+// makes the limits hold for the whole deployment
+type RateLimitStore interface {
+	// Take charges one request to the bucket of key; rejected requests do not consume a token
+	Take(ctx context.Context, key string, policy models.RateLimitPolicy, now time.Time) (models.RateLimitResult, error)
+}
@@ -0,0 +1,26 @@
+package port
+
+import (
+	"context"
+	"eco-van-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// RefreshTokenRepository defines the interface for persisted refresh token operations
+type RefreshTokenRepository interface {
+	// Create stores a newly issued refresh token
+	Create(ctx context.Context, token *models.RefreshToken) error
+
+	// Get retrieves a refresh token by ID, returning nil if it does not exist
+	Get(ctx context.Context, id uuid.UUID) (*models.RefreshToken, error)
+
+	// MarkUsed marks an active token as rotated; it returns false if the token was already used or revoked
+	MarkUsed(ctx context.Context, id uuid.UUID) (bool, error)
+
+	// RevokeFamily revokes every token of a family
+	RevokeFamily(ctx context.Context, familyID uuid.UUID) error
+
+	// RevokeAllForUser revokes every token of a user, ending all of their sessions
+	RevokeAllForUser(ctx context.Context, userID uuid.UUID) error
+}
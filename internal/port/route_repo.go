@@ -0,0 +1,17 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"eco-van-api/internal/models"
+)
+
+// RouteRepository defines the interface for route planning data access
+type RouteRepository interface {
+	// ListPlannableOrders returns the SCHEDULED orders of a date with their object coordinates and volumes
+	ListPlannableOrders(ctx context.Context, date time.Time) ([]models.RoutingOrder, error)
+
+	// ListDepots returns the non-deleted warehouses that have coordinates
+	ListDepots(ctx context.Context) ([]models.Warehouse, error)
+}
@@ -0,0 +1,12 @@
+package port
+
+import (
+	"context"
+	"eco-van-api/internal/models"
+)
+
+// RouteService defines the interface for daily route planning
+type RouteService interface {
+	// Optimize produces an ordered stop sequence per vehicle for the scheduled orders of a date
+	Optimize(ctx context.Context, req models.RoutePlanRequest) (*models.RoutePlan, error)
+}
@@ -0,0 +1,18 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"eco-van-api/internal/models"
+)
+
+// SigningKeyRepository defines the interface for JWT signing key data access operations
+type SigningKeyRepository interface {
+	// List retrieves the keys not yet expired at now, ordered by activation time
+	List(ctx context.Context, now time.Time) ([]models.JWTSigningKey, error)
+
+	// Rotate stores a new key and sets retireAt as the expiry of the keys it replaces. It only rotates
+	// while previousKID is still the newest key ("" for none), so concurrent replicas rotate once.
+	Rotate(ctx context.Context, key *models.JWTSigningKey, previousKID string, retireAt time.Time) (bool, error)
+}
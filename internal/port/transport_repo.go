@@ -44,6 +44,9 @@ type TransportRepository interface {
 	// GetAvailable returns transport with status IN_WORK and no soft-delete
 	GetAvailable(ctx context.Context, req models.TransportListRequest) (*models.TransportListResponse, error)
 
+	// GetByDriverID returns the non-deleted transport the driver is currently assigned to
+	GetByDriverID(ctx context.Context, driverID uuid.UUID) (*models.Transport, error)
+
 	// IsDriverAssignedToOtherTransport checks if driver is assigned to another non-deleted transport
 	IsDriverAssignedToOtherTransport(ctx context.Context, driverID, excludeTransportID uuid.UUID) (bool, error)
 
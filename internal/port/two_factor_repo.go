@@ -0,0 +1,36 @@
+package port
+
+import (
+	"context"
+	"eco-van-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// TwoFactorRepository defines the interface for TOTP secrets and recovery codes
+type TwoFactorRepository interface {
+	// Get retrieves the TOTP secret of a user, returning nil if the user never started an enrollment
+	Get(ctx context.Context, userID uuid.UUID) (*models.UserTOTP, error)
+
+	// SavePending stores the secret of a new enrollment, replacing an unconfirmed one
+	SavePending(ctx context.Context, userID uuid.UUID, secret string) error
+
+	// Confirm enables the pending enrollment, records the step of the confirming code and replaces the recovery codes;
+	// it returns false if the enrollment is no longer pending
+	Confirm(ctx context.Context, userID uuid.UUID, step int64, recoveryCodeHashes []string) (bool, error)
+
+	// UseStep records the step of an accepted code; it returns false if that or a later step was already used
+	UseStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error)
+
+	// ReplaceRecoveryCodes discards the recovery codes of a user and stores new ones
+	ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error
+
+	// UseRecoveryCode consumes an unused recovery code; it returns false if there is no such code
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error)
+
+	// CountRecoveryCodes counts the unused recovery codes of a user
+	CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error)
+
+	// Delete removes the TOTP secret and recovery codes of a user, disabling two-factor authentication
+	Delete(ctx context.Context, userID uuid.UUID) error
+}
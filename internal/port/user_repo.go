@@ -21,6 +21,12 @@ type UserRepository interface {
 	// List retrieves a paginated list of users
 	List(ctx context.Context, page, pageSize int) ([]*models.User, int, error)
 
+	// Update updates the email, role and disabled state of a user
+	Update(ctx context.Context, user *models.User) error
+
+	// UpdatePassword replaces the password hash and sets the must-change-password flag
+	UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string, mustChange bool) error
+
 	// Delete removes a user by ID
 	Delete(ctx context.Context, id uuid.UUID) error
 
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"eco-van-api/internal/adapter/auth"
+	"eco-van-api/internal/domainerr"
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/port"
+
+	"github.com/google/uuid"
+)
+
+// apiKeyLastUsedResolution limits how often the last-used timestamp of a busy key is written
+const apiKeyLastUsedResolution = time.Minute
+
+// apiKeyService implements port.APIKeyService
+type apiKeyService struct {
+	apiKeyRepo port.APIKeyRepository
+}
+
+// NewAPIKeyService creates a new API key service
+func NewAPIKeyService(apiKeyRepo port.APIKeyRepository) port.APIKeyService {
+	return &apiKeyService{
+		apiKeyRepo: apiKeyRepo,
+	}
+}
+
+// Create issues a new API key; the key is returned once and only its hash is stored
+func (s *apiKeyService) Create(
+	ctx context.Context, req models.CreateAPIKeyRequest, createdBy uuid.UUID,
+) (*models.CreateAPIKeyResponse, error) {
+	if err := req.Validate(time.Now()); err != nil {
+		return nil, domainerr.Validation(domainerr.CodeValidationFailed, "validation failed").Wrap(err)
+	}
+
+	token, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate API key: %w", err)
+	}
+	key := models.APIKeyPrefix + token
+
+	apiKey := models.APIKey{
+		Name:      strings.TrimSpace(req.Name),
+		KeyPrefix: models.APIKeyDisplayPrefix(key),
+		KeyHash:   auth.HashOpaqueToken(key),
+		Role:      req.Role,
+		Scopes:    req.Scopes,
+		CreatedBy: &createdBy,
+		ExpiresAt: req.ExpiresAt,
+	}
+	if err := s.apiKeyRepo.Create(ctx, &apiKey); err != nil {
+		return nil, fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	return &models.CreateAPIKeyResponse{APIKey: apiKey, Key: key}, nil
+}
+
+// Get retrieves an API key by ID
+func (s *apiKeyService) Get(ctx context.Context, id uuid.UUID) (*models.APIKey, error) {
+	apiKey, err := s.apiKeyRepo.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+	if apiKey == nil {
+		return nil, domainerr.NotFound(domainerr.CodeAPIKeyNotFound, "API key not found")
+	}
+
+	return apiKey, nil
+}
+
+// List retrieves all API keys
+func (s *apiKeyService) List(ctx context.Context) (*models.APIKeyListResponse, error) {
+	keys, err := s.apiKeyRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+
+	return &models.APIKeyListResponse{Items: keys}, nil
+}
+
+// Revoke revokes an API key; revoking a revoked key is a no-op
+func (s *apiKeyService) Revoke(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.Get(ctx, id); err != nil {
+		return err
+	}
+
+	if _, err := s.apiKeyRepo.Revoke(ctx, id); err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	return nil
+}
+
+// Authenticate resolves an active API key from its value and records its use
+func (s *apiKeyService) Authenticate(ctx context.Context, key string) (*models.APIKey, error) {
+	apiKey, err := s.Resolve(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyLastUsedResolution {
+		if err := s.apiKeyRepo.TouchLastUsed(ctx, apiKey.ID, now); err != nil {
+			return nil, fmt.Errorf("failed to record API key use: %w", err)
+		}
+		apiKey.LastUsedAt = &now
+	}
+
+	return apiKey, nil
+}
+
+// Resolve resolves an active API key from its value without recording its use
+func (s *apiKeyService) Resolve(ctx context.Context, key string) (*models.APIKey, error) {
+	if !strings.HasPrefix(key, models.APIKeyPrefix) {
+		return nil, domainerr.Unauthorized(domainerr.CodeInvalidAPIKey, "invalid API key")
+	}
+
+	apiKey, err := s.apiKeyRepo.GetByHash(ctx, auth.HashOpaqueToken(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+	if apiKey == nil || !apiKey.IsActive(time.Now()) {
+		return nil, domainerr.Unauthorized(domainerr.CodeInvalidAPIKey, "invalid API key")
+	}
+
+	return apiKey, nil
+}
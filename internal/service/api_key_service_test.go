@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"eco-van-api/internal/adapter/auth"
+	"eco-van-api/internal/domainerr"
+	"eco-van-api/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockAPIKeyRepository is a mock implementation of APIKeyRepository
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) Get(ctx context.Context, id uuid.UUID) (*models.APIKey, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	args := m.Called(ctx, keyHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) List(ctx context.Context) ([]models.APIKey, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) Revoke(ctx context.Context, id uuid.UUID) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) TouchLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	args := m.Called(ctx, id, usedAt)
+	return args.Error(0)
+}
+
+func TestAPIKeyService_Create(t *testing.T) {
+	ctx := context.Background()
+	adminID := uuid.New()
+
+	t.Run("stores only the hash and returns the key once", func(t *testing.T) {
+		repo := new(MockAPIKeyRepository)
+		repo.On("Create", ctx, mock.AnythingOfType("*models.APIKey")).Return(nil)
+
+		svc := NewAPIKeyService(repo)
+		resp, err := svc.Create(ctx, models.CreateAPIKeyRequest{
+			Name: " Telematics ", Role: models.UserRoleDispatcher, Scopes: []string{"transport:*"},
+		}, adminID)
+		require.NoError(t, err)
+
+		stored := repo.Calls[0].Arguments.Get(1).(*models.APIKey)
+		assert.True(t, strings.HasPrefix(resp.Key, models.APIKeyPrefix))
+		assert.Equal(t, auth.HashOpaqueToken(resp.Key), stored.KeyHash)
+		assert.Equal(t, resp.Key[:len(stored.KeyPrefix)], stored.KeyPrefix)
+		assert.Equal(t, "Telematics", stored.Name)
+		assert.Equal(t, adminID, *stored.CreatedBy)
+	})
+
+	t.Run("invalid scope is a validation error", func(t *testing.T) {
+		repo := new(MockAPIKeyRepository)
+
+		svc := NewAPIKeyService(repo)
+		_, err := svc.Create(ctx, models.CreateAPIKeyRequest{
+			Name: "Accounting", Role: models.UserRoleViewer, Scopes: []string{"invoices:read"},
+		}, adminID)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "validation failed")
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	key := models.APIKeyPrefix + "secret"
+	keyHash := auth.HashOpaqueToken(key)
+	past := time.Now().Add(-time.Hour)
+	recent := time.Now().Add(-time.Second)
+
+	t.Run("active key is returned and its use recorded", func(t *testing.T) {
+		apiKey := &models.APIKey{ID: uuid.New(), Role: models.UserRoleViewer}
+		repo := new(MockAPIKeyRepository)
+		repo.On("GetByHash", ctx, keyHash).Return(apiKey, nil)
+		repo.On("TouchLastUsed", ctx, apiKey.ID, mock.AnythingOfType("time.Time")).Return(nil)
+
+		got, err := NewAPIKeyService(repo).Authenticate(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, apiKey.ID, got.ID)
+		assert.NotNil(t, got.LastUsedAt)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("recent use is not written again", func(t *testing.T) {
+		apiKey := &models.APIKey{ID: uuid.New(), Role: models.UserRoleViewer, LastUsedAt: &recent}
+		repo := new(MockAPIKeyRepository)
+		repo.On("GetByHash", ctx, keyHash).Return(apiKey, nil)
+
+		_, err := NewAPIKeyService(repo).Authenticate(ctx, key)
+		require.NoError(t, err)
+		repo.AssertNotCalled(t, "TouchLastUsed", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	tests := []struct {
+		name   string
+		apiKey *models.APIKey
+	}{
+		{"unknown key", nil},
+		{"revoked key", &models.APIKey{ID: uuid.New(), RevokedAt: &past}},
+		{"expired key", &models.APIKey{ID: uuid.New(), ExpiresAt: &past}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockAPIKeyRepository)
+			repo.On("GetByHash", ctx, keyHash).Return(tt.apiKey, nil)
+
+			_, err := NewAPIKeyService(repo).Authenticate(ctx, key)
+			require.Error(t, err)
+			assert.Equal(t, "invalid API key", err.Error())
+		})
+	}
+
+	t.Run("value without the key prefix is rejected without a lookup", func(t *testing.T) {
+		repo := new(MockAPIKeyRepository)
+
+		_, err := NewAPIKeyService(repo).Authenticate(ctx, "eyJhbGciOiJIUzI1NiIs")
+		require.Error(t, err)
+		repo.AssertNotCalled(t, "GetByHash", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAPIKeyService_Resolve(t *testing.T) {
+	ctx := context.Background()
+	key := models.APIKeyPrefix + "secret"
+	past := time.Now().Add(-time.Hour)
+
+	t.Run("active key is returned without recording its use", func(t *testing.T) {
+		apiKey := &models.APIKey{ID: uuid.New(), Role: models.UserRoleViewer, LastUsedAt: &past}
+		repo := new(MockAPIKeyRepository)
+		repo.On("GetByHash", ctx, auth.HashOpaqueToken(key)).Return(apiKey, nil)
+
+		got, err := NewAPIKeyService(repo).Resolve(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, apiKey.ID, got.ID)
+		repo.AssertNotCalled(t, "TouchLastUsed", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("revoked key is rejected", func(t *testing.T) {
+		repo := new(MockAPIKeyRepository)
+		repo.On("GetByHash", ctx, auth.HashOpaqueToken(key)).Return(&models.APIKey{ID: uuid.New(), RevokedAt: &past}, nil)
+
+		_, err := NewAPIKeyService(repo).Resolve(ctx, key)
+		assert.True(t, domainerr.HasCode(err, domainerr.CodeInvalidAPIKey))
+	})
+}
+
+func TestAPIKeyService_Revoke(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+
+	t.Run("revokes an existing key", func(t *testing.T) {
+		repo := new(MockAPIKeyRepository)
+		repo.On("Get", ctx, id).Return(&models.APIKey{ID: id}, nil)
+		repo.On("Revoke", ctx, id).Return(true, nil)
+
+		require.NoError(t, NewAPIKeyService(repo).Revoke(ctx, id))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("unknown key is not found", func(t *testing.T) {
+		repo := new(MockAPIKeyRepository)
+		repo.On("Get", ctx, id).Return(nil, nil)
+
+		err := NewAPIKeyService(repo).Revoke(ctx, id)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+		assert.True(t, domainerr.HasCode(err, domainerr.CodeAPIKeyNotFound))
+	})
+}
@@ -2,38 +2,90 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"eco-van-api/internal/adapter/auth"
+	"eco-van-api/internal/domainerr"
 	"eco-van-api/internal/models"
 	"eco-van-api/internal/port"
+
+	"github.com/google/uuid"
 )
 
+// AuthRepositories groups the stores the authentication service depends on
+type AuthRepositories struct {
+	Users          port.UserRepository
+	RefreshTokens  port.RefreshTokenRepository
+	PasswordResets port.PasswordResetRepository
+	LoginSecurity  port.LoginSecurityRepository
+	TwoFactor      port.TwoFactorRepository
+}
+
+// AuthSettings holds the configurable behaviour of the authentication service
+type AuthSettings struct {
+	// PasswordResetTTL is how long an admin-issued password reset token stays valid
+	PasswordResetTTL time.Duration
+	// LoginThrottle configures failed-login counters, progressive delays and lockout
+	LoginThrottle models.LoginThrottlePolicy
+	// TwoFactor configures TOTP two-factor authentication
+	TwoFactor models.TwoFactorPolicy
+}
+
 // AuthService handles authentication business logic
 type AuthService struct {
-	userRepo   port.UserRepository
-	jwtManager *auth.JWTManager
+	userRepo          port.UserRepository
+	refreshTokenRepo  port.RefreshTokenRepository
+	passwordResetRepo port.PasswordResetRepository
+	loginSecurityRepo port.LoginSecurityRepository
+	twoFactorRepo     port.TwoFactorRepository
+	jwtManager        *auth.JWTManager
+	passwordResetTTL  time.Duration
+	loginThrottle     models.LoginThrottlePolicy
+	twoFactor         models.TwoFactorPolicy
 }
 
 // NewAuthService creates a new authentication service
-func NewAuthService(userRepo port.UserRepository, jwtManager *auth.JWTManager) *AuthService {
+func NewAuthService(repos AuthRepositories, jwtManager *auth.JWTManager, settings AuthSettings) *AuthService {
 	return &AuthService{
-		userRepo:   userRepo,
-		jwtManager: jwtManager,
+		userRepo:          repos.Users,
+		refreshTokenRepo:  repos.RefreshTokens,
+		passwordResetRepo: repos.PasswordResets,
+		loginSecurityRepo: repos.LoginSecurity,
+		twoFactorRepo:     repos.TwoFactor,
+		jwtManager:        jwtManager,
+		passwordResetTTL:  settings.PasswordResetTTL,
+		loginThrottle:     settings.LoginThrottle,
+		twoFactor:         settings.TwoFactor,
 	}
 }
 
-// Login authenticates a user and returns access and refresh tokens
-func (s *AuthService) Login(ctx context.Context, req *models.LoginRequest) (*models.AuthResponse, error) {
+// Login authenticates a user and returns access and refresh tokens.
+// Failed attempts are counted per account and per client IP: every failure adds a growing delay
+// before the next attempt is allowed, and reaching the configured limit locks the key temporarily.
+// Users with two-factor authentication only get a challenge token for LoginTwoFactor.
+func (s *AuthService) Login(ctx context.Context, req *models.LoginRequest, client models.ClientInfo) (*models.AuthResponse, error) {
 	// Validate request
 	if err := req.Validate(); err != nil {
-		return nil, fmt.Errorf("invalid login request: %w", err)
+		return nil, domainerr.Validation(domainerr.CodeValidationFailed, "invalid login request").Wrap(err)
+	}
+
+	accountKey := models.AccountThrottleKey(req.Email)
+	ipKey := models.IPThrottleKey(client.IP)
+	if err := s.checkLoginThrottle(ctx, accountKey, ipKey); err != nil {
+		var throttled *models.LoginThrottledError
+		if errors.As(err, &throttled) {
+			return nil, s.recordLoginFailure(ctx, req.Email, nil, client, models.LoginFailureThrottled, err)
+		}
+		return nil, err
 	}
 
 	// Find user by email
 	user, err := s.userRepo.FindByEmail(ctx, req.Email)
 	if err != nil {
-		return nil, fmt.Errorf("invalid credentials")
+		return nil, s.registerLoginFailure(ctx, req.Email, nil, client, models.LoginFailureInvalidCredentials,
+			domainerr.Unauthorized(domainerr.CodeInvalidCredentials, "invalid credentials"))
 	}
 
 	// Verify password
@@ -43,56 +95,469 @@ func (s *AuthService) Login(ctx context.Context, req *models.LoginRequest) (*mod
 	}
 
 	if !valid {
-		return nil, fmt.Errorf("invalid credentials")
+		return nil, s.registerLoginFailure(ctx, req.Email, &user.ID, client, models.LoginFailureInvalidCredentials,
+			domainerr.Unauthorized(domainerr.CodeInvalidCredentials, "invalid credentials"))
 	}
 
-	// Generate tokens
-	accessToken, err := s.jwtManager.GenerateAccessToken(user)
+	if user.IsDisabled() {
+		return nil, s.recordLoginFailure(ctx, req.Email, &user.ID, client, models.LoginFailureAccountDisabled,
+			domainerr.Unauthorized(domainerr.CodeAccountDisabled, "invalid credentials: account is disabled"))
+	}
+
+	// The account counter is left alone until the second factor succeeds, so knowing the password
+	// does not reset the lockout of code guessing
+	enabled, err := s.twoFactorEnabled(ctx, user.ID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate access token: %w", err)
+		return nil, err
+	}
+	if enabled {
+		return s.twoFactorChallenge(user)
+	}
+
+	if err := s.completeLogin(ctx, user, client); err != nil {
+		return nil, err
 	}
 
-	refreshToken, err := s.jwtManager.GenerateRefreshToken(user)
+	return s.grantSession(ctx, user)
+}
+
+// completeLogin clears the account counter and records the successful login; the IP counter keeps
+// counting failures on other accounts
+func (s *AuthService) completeLogin(ctx context.Context, user *models.User, client models.ClientInfo) error {
+	if err := s.loginSecurityRepo.ResetThrottle(ctx, models.AccountThrottleKey(user.Email)); err != nil {
+		return fmt.Errorf("failed to reset login throttle: %w", err)
+	}
+	return s.recordLoginAttempt(ctx, user.Email, &user.ID, client, nil)
+}
+
+// grantSession issues the tokens of an authenticated user: restricted ones while a password change or
+// a mandatory two-factor enrollment is outstanding, a new session otherwise
+func (s *AuthService) grantSession(ctx context.Context, user *models.User) (*models.AuthResponse, error) {
+	// A user who must change their password only gets a restricted token and no session
+	if user.MustChangePassword {
+		accessToken, err := s.jwtManager.GeneratePasswordChangeToken(user)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate access token: %w", err)
+		}
+		return &models.AuthResponse{
+			AccessToken:        accessToken,
+			ExpiresIn:          s.jwtManager.GetAccessTokenTTL(),
+			MustChangePassword: true,
+		}, nil
+	}
+
+	// A user whose role requires two-factor authentication has to enroll before getting a session
+	setupRequired, err := s.twoFactorSetupRequired(ctx, user)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+		return nil, err
+	}
+	if setupRequired {
+		return s.twoFactorSetupSession(user)
 	}
 
+	// Every login starts a new refresh token family
+	return s.issueTokens(ctx, user, uuid.New())
+}
+
+// twoFactorSetupSession issues the restricted token of a user who has to enroll in two-factor authentication
+func (s *AuthService) twoFactorSetupSession(user *models.User) (*models.AuthResponse, error) {
+	accessToken, err := s.jwtManager.GenerateTwoFactorSetupToken(user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
 	return &models.AuthResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		ExpiresIn:    s.jwtManager.GetAccessTokenTTL(),
+		AccessToken:         accessToken,
+		ExpiresIn:           s.jwtManager.GetAccessTokenTTL(),
+		MustEnrollTwoFactor: true,
+	}, nil
+}
+
+// StartSession logs in a user who was authenticated without a password, e.g. by single sign-on.
+// Disabled accounts are refused. The two-factor policy applies as for Login: users with two-factor
+// authentication only get a challenge token for LoginTwoFactor, and users whose role requires it
+// get a setup token; otherwise the login is recorded in the attempt log and a session is issued.
+func (s *AuthService) StartSession(ctx context.Context, user *models.User, client models.ClientInfo) (*models.AuthResponse, error) {
+	if user.IsDisabled() {
+		return nil, s.recordLoginFailure(ctx, user.Email, &user.ID, client, models.LoginFailureAccountDisabled,
+			domainerr.Unauthorized(domainerr.CodeAccountDisabled, "invalid credentials: account is disabled"))
+	}
+
+	enabled, err := s.twoFactorEnabled(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return s.twoFactorChallenge(user)
+	}
+
+	if err := s.recordLoginAttempt(ctx, user.Email, &user.ID, client, nil); err != nil {
+		return nil, err
+	}
+	if s.twoFactor.Required(user.Role) {
+		return s.twoFactorSetupSession(user)
+	}
+
+	return s.issueTokens(ctx, user, uuid.New())
+}
+
+// checkLoginThrottle rejects an attempt while the account or the client IP is locked or still delayed
+func (s *AuthService) checkLoginThrottle(ctx context.Context, keys ...string) error {
+	now := time.Now()
+	var wait time.Duration
+	for _, key := range keys {
+		throttle, err := s.loginSecurityRepo.GetThrottle(ctx, key)
+		if err != nil {
+			return fmt.Errorf("failed to get login throttle: %w", err)
+		}
+		if retryAfter := throttle.RetryAfter(now, s.loginThrottle); retryAfter > wait {
+			wait = retryAfter
+		}
+	}
+
+	if wait > 0 {
+		return &models.LoginThrottledError{RetryAfter: wait}
+	}
+	return nil
+}
+
+// registerLoginFailure counts a wrong email, password or code against the account and the client IP,
+// logs the attempt and returns cause
+func (s *AuthService) registerLoginFailure(
+	ctx context.Context, email string, userID *uuid.UUID, client models.ClientInfo, reason string, cause error,
+) error {
+	policy := s.loginThrottle
+	if _, err := s.loginSecurityRepo.RegisterFailure(ctx, models.AccountThrottleKey(email),
+		policy.MaxAccountFailures, policy.FailureWindow, policy.Lockout); err != nil {
+		return fmt.Errorf("failed to register login failure: %w", err)
+	}
+	if _, err := s.loginSecurityRepo.RegisterFailure(ctx, models.IPThrottleKey(client.IP),
+		policy.MaxIPFailures, policy.FailureWindow, policy.Lockout); err != nil {
+		return fmt.Errorf("failed to register login failure: %w", err)
+	}
+
+	return s.recordLoginFailure(ctx, email, userID, client, reason, cause)
+}
+
+// recordLoginFailure logs a failed attempt and returns cause, or the logging error if the attempt could not be stored
+func (s *AuthService) recordLoginFailure(
+	ctx context.Context, email string, userID *uuid.UUID, client models.ClientInfo, reason string, cause error,
+) error {
+	if err := s.recordLoginAttempt(ctx, email, userID, client, &reason); err != nil {
+		return err
+	}
+	return cause
+}
+
+// recordLoginAttempt appends an attempt to the login log; a nil failure reason marks a successful login
+func (s *AuthService) recordLoginAttempt(
+	ctx context.Context, email string, userID *uuid.UUID, client models.ClientInfo, failureReason *string,
+) error {
+	attempt := &models.LoginAttempt{
+		Email:         email,
+		UserID:        userID,
+		IP:            client.IP,
+		UserAgent:     client.UserAgent,
+		Success:       failureReason == nil,
+		FailureReason: failureReason,
+	}
+	if err := s.loginSecurityRepo.RecordAttempt(ctx, attempt); err != nil {
+		return fmt.Errorf("failed to record login attempt: %w", err)
+	}
+	return nil
+}
+
+// UnlockUser clears the failed-login counter and lockout of a user's account
+func (s *AuthService) UnlockUser(ctx context.Context, userID string) error {
+	id, err := models.ParseUUID(userID)
+	if err != nil {
+		return domainerr.InvalidInput(domainerr.CodeInvalidID, "invalid user ID").Wrap(err)
+	}
+
+	user, err := s.userRepo.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := s.loginSecurityRepo.ResetThrottle(ctx, models.AccountThrottleKey(user.Email)); err != nil {
+		return fmt.Errorf("failed to unlock user: %w", err)
+	}
+
+	return nil
+}
+
+// ListLoginAttempts retrieves the login attempt log for security review
+func (s *AuthService) ListLoginAttempts(
+	ctx context.Context, req models.LoginAttemptListRequest,
+) (*models.LoginAttemptListResponse, error) {
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.PageSize < 1 || req.PageSize > 100 {
+		req.PageSize = 20
+	}
+
+	attempts, total, err := s.loginSecurityRepo.ListAttempts(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list login attempts: %w", err)
+	}
+
+	return &models.LoginAttemptListResponse{
+		Items:    attempts,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Total:    total,
 	}, nil
 }
 
-// Refresh generates new access and refresh tokens using a valid refresh token
+// Refresh rotates a refresh token: the presented token is marked used and a new one of the same family
+// is issued. Presenting a token that was already used revokes its whole family.
 func (s *AuthService) Refresh(ctx context.Context, req *models.RefreshRequest) (*models.AuthResponse, error) {
 	// Validate request
 	if err := req.Validate(); err != nil {
-		return nil, fmt.Errorf("invalid refresh request: %w", err)
+		return nil, domainerr.Validation(domainerr.CodeValidationFailed, "invalid refresh request").Wrap(err)
 	}
 
-	// Validate refresh token
-	claims, err := s.jwtManager.ValidateRefreshToken(req.RefreshToken)
+	stored, err := s.findRefreshToken(ctx, req.RefreshToken)
 	if err != nil {
-		return nil, fmt.Errorf("invalid refresh token: %w", err)
+		return nil, err
+	}
+
+	if stored.RevokedAt != nil {
+		return nil, domainerr.Unauthorized(domainerr.CodeInvalidRefreshToken, "invalid refresh token: token has been revoked")
+	}
+	if stored.UsedAt != nil {
+		return nil, s.revokeReusedFamily(ctx, stored.FamilyID)
+	}
+	if !stored.IsActive(time.Now()) {
+		return nil, domainerr.Unauthorized(domainerr.CodeInvalidRefreshToken, "invalid refresh token: token has expired")
+	}
+
+	// A concurrent refresh with the same token wins the update; the loser is treated as reuse
+	rotated, err := s.refreshTokenRepo.MarkUsed(ctx, stored.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+	if !rotated {
+		return nil, s.revokeReusedFamily(ctx, stored.FamilyID)
 	}
 
 	// Get user from database
-	user, err := s.userRepo.Get(ctx, claims.UserID)
+	user, err := s.userRepo.Get(ctx, stored.UserID)
 	if err != nil {
-		return nil, fmt.Errorf("user not found: %w", err)
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.IsDisabled() {
+		return nil, domainerr.Unauthorized(domainerr.CodeInvalidRefreshToken, "invalid refresh token: account is disabled")
+	}
+	if user.MustChangePassword {
+		return nil, domainerr.Unauthorized(domainerr.CodeInvalidRefreshToken, "invalid refresh token: password change required")
+	}
+	setupRequired, err := s.twoFactorSetupRequired(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if setupRequired {
+		return nil, domainerr.Unauthorized(domainerr.CodeInvalidRefreshToken, "invalid refresh token: two-factor authentication setup required")
 	}
 
-	// Generate new tokens
+	return s.issueTokens(ctx, user, stored.FamilyID)
+}
+
+// Logout revokes the session the refresh token belongs to
+func (s *AuthService) Logout(ctx context.Context, req *models.LogoutRequest) error {
+	if err := req.Validate(); err != nil {
+		return domainerr.Validation(domainerr.CodeValidationFailed, "invalid logout request").Wrap(err)
+	}
+
+	stored, err := s.findRefreshToken(ctx, req.RefreshToken)
+	if err != nil {
+		return err
+	}
+
+	if err := s.refreshTokenRepo.RevokeFamily(ctx, stored.FamilyID); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	return nil
+}
+
+// ChangePassword changes the password of the current user after verifying the current one.
+// All existing sessions are revoked and a fresh session is returned.
+func (s *AuthService) ChangePassword(
+	ctx context.Context, userID uuid.UUID, req *models.ChangePasswordRequest,
+) (*models.AuthResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, domainerr.Validation(domainerr.CodeValidationFailed, "validation failed").Wrap(err)
+	}
+
+	user, err := s.userRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	valid, err := auth.VerifyPassword(req.CurrentPassword, user.PasswordHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify password: %w", err)
+	}
+	if !valid {
+		return nil, domainerr.Validation(domainerr.CodeCurrentPasswordIncorrect, "validation failed").
+			Wrap(domainerr.InvalidField("/currentPassword", "match", "current password is incorrect"))
+	}
+	if req.NewPassword == req.CurrentPassword {
+		return nil, domainerr.Validation(domainerr.CodePasswordUnchanged, "validation failed").
+			Wrap(domainerr.InvalidField("/newPassword", "nefield", "new password must differ from the current password"))
+	}
+
+	if err := s.setPassword(ctx, user.ID, req.NewPassword); err != nil {
+		return nil, err
+	}
+
+	user.MustChangePassword = false
+	return s.grantSession(ctx, user)
+}
+
+// IssuePasswordReset creates a one-time reset token for a user; the token is returned once and stored hashed.
+// Earlier unused reset tokens and all sessions of the user are revoked.
+func (s *AuthService) IssuePasswordReset(
+	ctx context.Context, userID string, issuedBy uuid.UUID,
+) (*models.PasswordResetResponse, error) {
+	id, err := models.ParseUUID(userID)
+	if err != nil {
+		return nil, domainerr.InvalidInput(domainerr.CodeInvalidID, "invalid user ID").Wrap(err)
+	}
+
+	if _, err := s.userRepo.Get(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	resetToken, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate reset token: %w", err)
+	}
+
+	if err := s.passwordResetRepo.InvalidateForUser(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to invalidate reset tokens: %w", err)
+	}
+
+	stored := &models.PasswordResetToken{
+		UserID:    id,
+		TokenHash: auth.HashOpaqueToken(resetToken),
+		CreatedBy: &issuedBy,
+		ExpiresAt: time.Now().Add(s.passwordResetTTL),
+	}
+	if err := s.passwordResetRepo.Create(ctx, stored); err != nil {
+		return nil, fmt.Errorf("failed to store reset token: %w", err)
+	}
+
+	if err := s.refreshTokenRepo.RevokeAllForUser(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to revoke user sessions: %w", err)
+	}
+
+	return &models.PasswordResetResponse{
+		ResetToken: resetToken,
+		ExpiresAt:  stored.ExpiresAt,
+	}, nil
+}
+
+// ResetPassword sets a new password using a one-time reset token
+func (s *AuthService) ResetPassword(ctx context.Context, req *models.ResetPasswordRequest) error {
+	if err := req.Validate(); err != nil {
+		return domainerr.Validation(domainerr.CodeValidationFailed, "validation failed").Wrap(err)
+	}
+
+	stored, err := s.passwordResetRepo.GetByHash(ctx, auth.HashOpaqueToken(req.Token))
+	if err != nil {
+		return fmt.Errorf("failed to get reset token: %w", err)
+	}
+	if stored == nil || stored.UsedAt != nil || !time.Now().Before(stored.ExpiresAt) {
+		return domainerr.Unauthorized(domainerr.CodeInvalidResetToken, "invalid reset token")
+	}
+
+	consumed, err := s.passwordResetRepo.MarkUsed(ctx, stored.ID)
+	if err != nil {
+		return fmt.Errorf("failed to consume reset token: %w", err)
+	}
+	if !consumed {
+		return domainerr.Unauthorized(domainerr.CodeInvalidResetToken, "invalid reset token")
+	}
+
+	return s.setPassword(ctx, stored.UserID, req.NewPassword)
+}
+
+// setPassword stores a new password, clears the must-change flag and revokes all sessions of the user
+func (s *AuthService) setPassword(ctx context.Context, userID uuid.UUID, password string) error {
+	if err := auth.IsValidPassword(password); err != nil {
+		return domainerr.Validation(domainerr.CodeValidationFailed, "validation failed").Wrap(err)
+	}
+
+	passwordHash, err := auth.HashPassword(password)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	if err := s.userRepo.UpdatePassword(ctx, userID, passwordHash, false); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	if err := s.refreshTokenRepo.RevokeAllForUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to revoke user sessions: %w", err)
+	}
+
+	return nil
+}
+
+// findRefreshToken validates a refresh token and loads its persisted record
+func (s *AuthService) findRefreshToken(ctx context.Context, refreshToken string) (*models.RefreshToken, error) {
+	claims, err := s.jwtManager.ValidateRefreshToken(refreshToken)
+	if err != nil {
+		return nil, domainerr.Unauthorized(domainerr.CodeInvalidRefreshToken, "invalid refresh token").Wrap(err)
+	}
+
+	// Tokens issued before refresh tokens were persisted carry no token ID
+	tokenID, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return nil, domainerr.Unauthorized(domainerr.CodeInvalidRefreshToken, "invalid refresh token: missing token ID")
+	}
+
+	stored, err := s.refreshTokenRepo.Get(ctx, tokenID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	if stored == nil || stored.UserID != claims.UserID {
+		return nil, domainerr.Unauthorized(domainerr.CodeInvalidRefreshToken, "invalid refresh token: unknown token")
+	}
+
+	return stored, nil
+}
+
+// revokeReusedFamily revokes a family whose already rotated token was presented again
+func (s *AuthService) revokeReusedFamily(ctx context.Context, familyID uuid.UUID) error {
+	if err := s.refreshTokenRepo.RevokeFamily(ctx, familyID); err != nil {
+		return fmt.Errorf("failed to revoke refresh token family: %w", err)
+	}
+	return domainerr.Unauthorized(domainerr.CodeInvalidRefreshToken, "invalid refresh token: token reuse detected, session revoked")
+}
+
+// issueTokens generates an access token and a persisted refresh token of the given family
+func (s *AuthService) issueTokens(ctx context.Context, user *models.User, familyID uuid.UUID) (*models.AuthResponse, error) {
 	accessToken, err := s.jwtManager.GenerateAccessToken(user)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate access token: %w", err)
 	}
 
-	refreshToken, err := s.jwtManager.GenerateRefreshToken(user)
+	stored := &models.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		ExpiresAt: time.Now().Add(s.jwtManager.GetTokenExpiration(auth.TokenTypeRefresh)),
+	}
+	refreshToken, err := s.jwtManager.GenerateFamilyRefreshToken(user, stored.ID, familyID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
 	}
+	if err := s.refreshTokenRepo.Create(ctx, stored); err != nil {
+		return nil, fmt.Errorf("failed to store refresh token: %w", err)
+	}
 
 	return &models.AuthResponse{
 		AccessToken:  accessToken,
@@ -105,13 +570,13 @@ func (s *AuthService) Refresh(ctx context.Context, req *models.RefreshRequest) (
 func (s *AuthService) CreateUser(ctx context.Context, req *models.CreateUserRequest) (*models.User, error) {
 	// Validate request
 	if err := req.Validate(); err != nil {
-		return nil, fmt.Errorf("invalid create user request: %w", err)
+		return nil, domainerr.Validation(domainerr.CodeValidationFailed, "invalid create user request").Wrap(err)
 	}
 
 	// Check if user already exists
 	existingUser, err := s.userRepo.FindByEmail(ctx, req.Email)
 	if err == nil && existingUser != nil {
-		return nil, fmt.Errorf("user with email %s already exists", req.Email)
+		return nil, domainerr.Conflict(domainerr.CodeUserEmailExists, "user with email %s already exists", req.Email).With("email", req.Email)
 	}
 
 	// Hash password
@@ -134,7 +599,7 @@ func (s *AuthService) GetUser(ctx context.Context, userID string) (*models.User,
 	// Parse UUID
 	id, err := models.ParseUUID(userID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid user ID: %w", err)
+		return nil, domainerr.InvalidInput(domainerr.CodeInvalidID, "invalid user ID").Wrap(err)
 	}
 
 	user, err := s.userRepo.Get(ctx, id)
@@ -162,12 +627,91 @@ func (s *AuthService) ListUsers(ctx context.Context, page, pageSize int) ([]*mod
 	return users, total, nil
 }
 
+// UpdateUser changes the email, role or disabled state of a user. With replace set (PUT) email and role
+// are required. Disabling an account revokes all of its sessions; admins cannot disable or demote themselves.
+func (s *AuthService) UpdateUser(
+	ctx context.Context, userID string, actorID uuid.UUID, req *models.UpdateUserRequest, replace bool,
+) (*models.User, error) {
+	validate := req.Validate
+	if replace {
+		validate = req.ValidateReplace
+	}
+	if err := validate(); err != nil {
+		return nil, domainerr.Validation(domainerr.CodeValidationFailed, "validation failed").Wrap(err)
+	}
+
+	id, err := models.ParseUUID(userID)
+	if err != nil {
+		return nil, domainerr.InvalidInput(domainerr.CodeInvalidID, "invalid user ID").Wrap(err)
+	}
+
+	user, err := s.userRepo.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if id == actorID {
+		if req.Disabled != nil && *req.Disabled {
+			return nil, domainerr.Validation(domainerr.CodeSelfDisable, "validation failed").
+				Wrap(domainerr.InvalidField("/disabled", "self", "you cannot disable your own account"))
+		}
+		if req.Role != nil && *req.Role != user.Role {
+			return nil, domainerr.Validation(domainerr.CodeSelfRoleChange, "validation failed").
+				Wrap(domainerr.InvalidField("/role", "self", "you cannot change your own role"))
+		}
+	}
+
+	if req.Email != nil && *req.Email != user.Email {
+		exists, err := s.userRepo.ExistsByEmail(ctx, *req.Email, &id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check email uniqueness: %w", err)
+		}
+		if exists {
+			return nil, domainerr.Conflict(domainerr.CodeUserEmailExists, "user with email %s already exists", *req.Email).With("email", *req.Email)
+		}
+		user.Email = *req.Email
+	}
+
+	if req.Role != nil {
+		user.Role = *req.Role
+	}
+
+	disabling := false
+	if req.Disabled != nil {
+		switch {
+		case *req.Disabled && !user.IsDisabled():
+			now := time.Now()
+			user.DisabledAt = &now
+			disabling = true
+		case !*req.Disabled:
+			user.DisabledAt = nil
+		}
+	}
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	if disabling {
+		if err := s.refreshTokenRepo.RevokeAllForUser(ctx, id); err != nil {
+			return nil, fmt.Errorf("failed to revoke user sessions: %w", err)
+		}
+	}
+
+	return user, nil
+}
+
 // DeleteUser removes a user by ID
 func (s *AuthService) DeleteUser(ctx context.Context, userID string) error {
 	// Parse UUID
 	id, err := models.ParseUUID(userID)
 	if err != nil {
-		return fmt.Errorf("invalid user ID: %w", err)
+		return domainerr.InvalidInput(domainerr.CodeInvalidID, "invalid user ID").Wrap(err)
+	}
+
+	// End every session of the user before removing the account
+	if err := s.refreshTokenRepo.RevokeAllForUser(ctx, id); err != nil {
+		return fmt.Errorf("failed to revoke user sessions: %w", err)
 	}
 
 	err = s.userRepo.Delete(ctx, id)
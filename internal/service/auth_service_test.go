@@ -0,0 +1,743 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"eco-van-api/internal/adapter/auth"
+	"eco-van-api/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockRefreshTokenRepository is a mock implementation of RefreshTokenRepository
+type MockRefreshTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockRefreshTokenRepository) Create(ctx context.Context, token *models.RefreshToken) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockRefreshTokenRepository) Get(ctx context.Context, id uuid.UUID) (*models.RefreshToken, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RefreshToken), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) MarkUsed(ctx context.Context, id uuid.UUID) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID uuid.UUID) error {
+	args := m.Called(ctx, familyID)
+	return args.Error(0)
+}
+
+func (m *MockRefreshTokenRepository) RevokeAllForUser(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+// MockPasswordResetRepository is a mock implementation of PasswordResetRepository
+type MockPasswordResetRepository struct {
+	mock.Mock
+}
+
+func (m *MockPasswordResetRepository) Create(ctx context.Context, token *models.PasswordResetToken) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockPasswordResetRepository) GetByHash(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PasswordResetToken), args.Error(1)
+}
+
+func (m *MockPasswordResetRepository) MarkUsed(ctx context.Context, id uuid.UUID) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPasswordResetRepository) InvalidateForUser(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+// MockLoginSecurityRepository is a mock implementation of LoginSecurityRepository
+type MockLoginSecurityRepository struct {
+	mock.Mock
+}
+
+func (m *MockLoginSecurityRepository) GetThrottle(ctx context.Context, key string) (*models.LoginThrottle, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.LoginThrottle), args.Error(1)
+}
+
+func (m *MockLoginSecurityRepository) RegisterFailure(
+	ctx context.Context, key string, maxFailures int, window, lockout time.Duration,
+) (*models.LoginThrottle, error) {
+	args := m.Called(ctx, key, maxFailures, window, lockout)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.LoginThrottle), args.Error(1)
+}
+
+func (m *MockLoginSecurityRepository) ResetThrottle(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockLoginSecurityRepository) RecordAttempt(ctx context.Context, attempt *models.LoginAttempt) error {
+	args := m.Called(ctx, attempt)
+	return args.Error(0)
+}
+
+func (m *MockLoginSecurityRepository) ListAttempts(
+	ctx context.Context, req models.LoginAttemptListRequest,
+) ([]models.LoginAttempt, int64, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]models.LoginAttempt), args.Get(1).(int64), args.Error(2)
+}
+
+// testClient is the client every test login comes from
+var testClient = models.ClientInfo{IP: "192.0.2.10", UserAgent: "test-agent"}
+
+// newAllowingLoginSecurityRepository returns a login security mock for tests that do not exercise throttling
+func newAllowingLoginSecurityRepository() *MockLoginSecurityRepository {
+	repo := new(MockLoginSecurityRepository)
+	repo.On("GetThrottle", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	repo.On("RegisterFailure", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(&models.LoginThrottle{Failures: 1, LastFailureAt: time.Now()}, nil).Maybe()
+	repo.On("ResetThrottle", mock.Anything, mock.Anything).Return(nil).Maybe()
+	repo.On("RecordAttempt", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return repo
+}
+
+// testAuthRepositories fills the repositories a test does not set with empty mocks
+func testAuthRepositories(repos AuthRepositories) AuthRepositories {
+	if repos.Users == nil {
+		repos.Users = new(MockUserRepository)
+	}
+	if repos.RefreshTokens == nil {
+		repos.RefreshTokens = new(MockRefreshTokenRepository)
+	}
+	if repos.PasswordResets == nil {
+		repos.PasswordResets = new(MockPasswordResetRepository)
+	}
+	if repos.LoginSecurity == nil {
+		repos.LoginSecurity = newAllowingLoginSecurityRepository()
+	}
+	if repos.TwoFactor == nil {
+		repos.TwoFactor = newUnenrolledTwoFactorRepository()
+	}
+	return repos
+}
+
+// testAuthSettings returns the auth settings used by the tests
+func testAuthSettings() AuthSettings {
+	return AuthSettings{
+		PasswordResetTTL: time.Hour,
+		LoginThrottle: models.LoginThrottlePolicy{
+			MaxAccountFailures: 3,
+			MaxIPFailures:      10,
+			FailureWindow:      15 * time.Minute,
+			Lockout:            15 * time.Minute,
+			BaseDelay:          time.Second,
+			MaxDelay:           30 * time.Second,
+		},
+	}
+}
+
+// newTestAuthService creates an auth service from the given repositories and test settings
+func newTestAuthService(repos AuthRepositories, jwtManager *auth.JWTManager) *AuthService {
+	return NewAuthService(testAuthRepositories(repos), jwtManager, testAuthSettings())
+}
+
+// issueTestRefreshToken signs a refresh token and returns its matching persisted record
+func issueTestRefreshToken(t *testing.T, jwtManager *auth.JWTManager, user *models.User) (string, *models.RefreshToken) {
+	t.Helper()
+	stored := &models.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		FamilyID:  uuid.New(),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	token, err := jwtManager.GenerateFamilyRefreshToken(user, stored.ID, stored.FamilyID)
+	require.NoError(t, err)
+	return token, stored
+}
+
+func TestAuthService_Login_StartsTokenFamily(t *testing.T) {
+	ctx := context.Background()
+	jwtManager := auth.NewDefaultJWTManager("test-secret")
+	hash, err := auth.HashPassword("password123")
+	require.NoError(t, err)
+	user := &models.User{ID: uuid.New(), Email: "admin@example.com", PasswordHash: hash, Role: models.UserRoleAdmin}
+
+	userRepo := new(MockUserRepository)
+	tokenRepo := new(MockRefreshTokenRepository)
+	userRepo.On("FindByEmail", ctx, user.Email).Return(user, nil)
+	tokenRepo.On("Create", ctx, mock.MatchedBy(func(token *models.RefreshToken) bool {
+		return token.UserID == user.ID && token.FamilyID != uuid.Nil
+	})).Return(nil)
+
+	svc := newTestAuthService(AuthRepositories{Users: userRepo, RefreshTokens: tokenRepo}, jwtManager)
+	resp, err := svc.Login(ctx, &models.LoginRequest{Email: user.Email, Password: "password123"}, testClient)
+	require.NoError(t, err)
+
+	claims, err := jwtManager.ValidateRefreshToken(resp.RefreshToken)
+	require.NoError(t, err)
+	stored := tokenRepo.Calls[0].Arguments.Get(1).(*models.RefreshToken)
+	assert.Equal(t, stored.ID.String(), claims.ID)
+	assert.Equal(t, stored.FamilyID, claims.FamilyID)
+}
+
+func TestAuthService_Refresh(t *testing.T) {
+	ctx := context.Background()
+	jwtManager := auth.NewDefaultJWTManager("test-secret")
+	user := &models.User{ID: uuid.New(), Email: "admin@example.com", Role: models.UserRoleAdmin}
+
+	t.Run("rotates token within the family", func(t *testing.T) {
+		token, stored := issueTestRefreshToken(t, jwtManager, user)
+		userRepo := new(MockUserRepository)
+		tokenRepo := new(MockRefreshTokenRepository)
+		tokenRepo.On("Get", ctx, stored.ID).Return(stored, nil)
+		tokenRepo.On("MarkUsed", ctx, stored.ID).Return(true, nil)
+		userRepo.On("Get", ctx, user.ID).Return(user, nil)
+		tokenRepo.On("Create", ctx, mock.MatchedBy(func(next *models.RefreshToken) bool {
+			return next.FamilyID == stored.FamilyID && next.ID != stored.ID
+		})).Return(nil)
+
+		svc := newTestAuthService(AuthRepositories{Users: userRepo, RefreshTokens: tokenRepo}, jwtManager)
+		resp, err := svc.Refresh(ctx, &models.RefreshRequest{RefreshToken: token})
+		require.NoError(t, err)
+
+		claims, err := jwtManager.ValidateRefreshToken(resp.RefreshToken)
+		require.NoError(t, err)
+		assert.Equal(t, stored.FamilyID, claims.FamilyID)
+		assert.NotEqual(t, stored.ID.String(), claims.ID)
+		tokenRepo.AssertNotCalled(t, "RevokeFamily", mock.Anything, mock.Anything)
+	})
+
+	t.Run("reuse of a rotated token revokes the family", func(t *testing.T) {
+		token, stored := issueTestRefreshToken(t, jwtManager, user)
+		usedAt := time.Now().Add(-time.Minute)
+		stored.UsedAt = &usedAt
+		tokenRepo := new(MockRefreshTokenRepository)
+		tokenRepo.On("Get", ctx, stored.ID).Return(stored, nil)
+		tokenRepo.On("RevokeFamily", ctx, stored.FamilyID).Return(nil)
+
+		svc := newTestAuthService(AuthRepositories{RefreshTokens: tokenRepo}, jwtManager)
+		_, err := svc.Refresh(ctx, &models.RefreshRequest{RefreshToken: token})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "token reuse detected")
+		tokenRepo.AssertExpectations(t)
+		tokenRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("losing a concurrent rotation is treated as reuse", func(t *testing.T) {
+		token, stored := issueTestRefreshToken(t, jwtManager, user)
+		tokenRepo := new(MockRefreshTokenRepository)
+		tokenRepo.On("Get", ctx, stored.ID).Return(stored, nil)
+		tokenRepo.On("MarkUsed", ctx, stored.ID).Return(false, nil)
+		tokenRepo.On("RevokeFamily", ctx, stored.FamilyID).Return(nil)
+
+		svc := newTestAuthService(AuthRepositories{RefreshTokens: tokenRepo}, jwtManager)
+		_, err := svc.Refresh(ctx, &models.RefreshRequest{RefreshToken: token})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "token reuse detected")
+		tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("revoked token is rejected", func(t *testing.T) {
+		token, stored := issueTestRefreshToken(t, jwtManager, user)
+		revokedAt := time.Now()
+		stored.RevokedAt = &revokedAt
+		tokenRepo := new(MockRefreshTokenRepository)
+		tokenRepo.On("Get", ctx, stored.ID).Return(stored, nil)
+
+		svc := newTestAuthService(AuthRepositories{RefreshTokens: tokenRepo}, jwtManager)
+		_, err := svc.Refresh(ctx, &models.RefreshRequest{RefreshToken: token})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "revoked")
+		tokenRepo.AssertNotCalled(t, "MarkUsed", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown token is rejected", func(t *testing.T) {
+		token, stored := issueTestRefreshToken(t, jwtManager, user)
+		tokenRepo := new(MockRefreshTokenRepository)
+		tokenRepo.On("Get", ctx, stored.ID).Return(nil, nil)
+
+		svc := newTestAuthService(AuthRepositories{RefreshTokens: tokenRepo}, jwtManager)
+		_, err := svc.Refresh(ctx, &models.RefreshRequest{RefreshToken: token})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid refresh token")
+	})
+
+	t.Run("access token is rejected", func(t *testing.T) {
+		accessToken, err := jwtManager.GenerateAccessToken(user)
+		require.NoError(t, err)
+
+		svc := newTestAuthService(AuthRepositories{}, jwtManager)
+		_, err = svc.Refresh(ctx, &models.RefreshRequest{RefreshToken: accessToken})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid refresh token")
+	})
+}
+
+func TestAuthService_Logout(t *testing.T) {
+	ctx := context.Background()
+	jwtManager := auth.NewDefaultJWTManager("test-secret")
+	user := &models.User{ID: uuid.New(), Email: "admin@example.com", Role: models.UserRoleAdmin}
+
+	token, stored := issueTestRefreshToken(t, jwtManager, user)
+	tokenRepo := new(MockRefreshTokenRepository)
+	tokenRepo.On("Get", ctx, stored.ID).Return(stored, nil)
+	tokenRepo.On("RevokeFamily", ctx, stored.FamilyID).Return(nil)
+
+	svc := newTestAuthService(AuthRepositories{RefreshTokens: tokenRepo}, jwtManager)
+	err := svc.Logout(ctx, &models.LogoutRequest{RefreshToken: token})
+	require.NoError(t, err)
+	tokenRepo.AssertExpectations(t)
+}
+
+func TestAuthService_DeleteUser_RevokesSessions(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	userRepo := new(MockUserRepository)
+	tokenRepo := new(MockRefreshTokenRepository)
+	tokenRepo.On("RevokeAllForUser", ctx, userID).Return(nil)
+	userRepo.On("Delete", ctx, userID).Return(nil)
+
+	svc := newTestAuthService(AuthRepositories{Users: userRepo, RefreshTokens: tokenRepo}, auth.NewDefaultJWTManager("test-secret"))
+	err := svc.DeleteUser(ctx, userID.String())
+	require.NoError(t, err)
+	tokenRepo.AssertExpectations(t)
+	userRepo.AssertExpectations(t)
+}
+
+func TestAuthService_Login_MustChangePassword(t *testing.T) {
+	ctx := context.Background()
+	jwtManager := auth.NewDefaultJWTManager("test-secret")
+	hash, err := auth.HashPassword("admin123456")
+	require.NoError(t, err)
+	user := &models.User{
+		ID: uuid.New(), Email: "admin@example.com", PasswordHash: hash, Role: models.UserRoleAdmin, MustChangePassword: true,
+	}
+
+	userRepo := new(MockUserRepository)
+	tokenRepo := new(MockRefreshTokenRepository)
+	userRepo.On("FindByEmail", ctx, user.Email).Return(user, nil)
+
+	svc := newTestAuthService(AuthRepositories{Users: userRepo, RefreshTokens: tokenRepo}, jwtManager)
+	resp, err := svc.Login(ctx, &models.LoginRequest{Email: user.Email, Password: "admin123456"}, testClient)
+	require.NoError(t, err)
+
+	assert.True(t, resp.MustChangePassword)
+	assert.Empty(t, resp.RefreshToken)
+	claims, err := jwtManager.ValidateAccessToken(resp.AccessToken)
+	require.NoError(t, err)
+	assert.True(t, claims.PasswordChange)
+	tokenRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_ChangePassword(t *testing.T) {
+	ctx := context.Background()
+	jwtManager := auth.NewDefaultJWTManager("test-secret")
+	hash, err := auth.HashPassword("admin123456")
+	require.NoError(t, err)
+
+	newUser := func() *models.User {
+		return &models.User{
+			ID: uuid.New(), Email: "admin@example.com", PasswordHash: hash, Role: models.UserRoleAdmin, MustChangePassword: true,
+		}
+	}
+
+	t.Run("changes password, clears flag and starts a new session", func(t *testing.T) {
+		user := newUser()
+		userRepo := new(MockUserRepository)
+		tokenRepo := new(MockRefreshTokenRepository)
+		userRepo.On("Get", ctx, user.ID).Return(user, nil)
+		userRepo.On("UpdatePassword", ctx, user.ID, mock.MatchedBy(func(newHash string) bool {
+			ok, _ := auth.VerifyPassword("n3w-secure-pass", newHash)
+			return ok
+		}), false).Return(nil)
+		tokenRepo.On("RevokeAllForUser", ctx, user.ID).Return(nil)
+		tokenRepo.On("Create", ctx, mock.Anything).Return(nil)
+
+		svc := newTestAuthService(AuthRepositories{Users: userRepo, RefreshTokens: tokenRepo}, jwtManager)
+		resp, err := svc.ChangePassword(ctx, user.ID, &models.ChangePasswordRequest{
+			CurrentPassword: "admin123456", NewPassword: "n3w-secure-pass",
+		})
+		require.NoError(t, err)
+
+		assert.False(t, resp.MustChangePassword)
+		assert.NotEmpty(t, resp.RefreshToken)
+		userRepo.AssertExpectations(t)
+		tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("wrong current password", func(t *testing.T) {
+		user := newUser()
+		userRepo := new(MockUserRepository)
+		userRepo.On("Get", ctx, user.ID).Return(user, nil)
+
+		svc := newTestAuthService(AuthRepositories{Users: userRepo}, jwtManager)
+		_, err := svc.ChangePassword(ctx, user.ID, &models.ChangePasswordRequest{
+			CurrentPassword: "wrong-password", NewPassword: "n3w-secure-pass",
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "current password is incorrect")
+		userRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("new password equal to current", func(t *testing.T) {
+		user := newUser()
+		userRepo := new(MockUserRepository)
+		userRepo.On("Get", ctx, user.ID).Return(user, nil)
+
+		svc := newTestAuthService(AuthRepositories{Users: userRepo}, jwtManager)
+		_, err := svc.ChangePassword(ctx, user.ID, &models.ChangePasswordRequest{
+			CurrentPassword: "admin123456", NewPassword: "admin123456",
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must differ")
+	})
+}
+
+func TestAuthService_IssuePasswordReset(t *testing.T) {
+	ctx := context.Background()
+	userID, adminID := uuid.New(), uuid.New()
+
+	userRepo := new(MockUserRepository)
+	tokenRepo := new(MockRefreshTokenRepository)
+	resetRepo := new(MockPasswordResetRepository)
+	userRepo.On("Get", ctx, userID).Return(&models.User{ID: userID, Role: models.UserRoleDispatcher}, nil)
+	resetRepo.On("InvalidateForUser", ctx, userID).Return(nil)
+	resetRepo.On("Create", ctx, mock.MatchedBy(func(token *models.PasswordResetToken) bool {
+		return token.UserID == userID && *token.CreatedBy == adminID && token.TokenHash != ""
+	})).Return(nil)
+	tokenRepo.On("RevokeAllForUser", ctx, userID).Return(nil)
+
+	repos := testAuthRepositories(AuthRepositories{Users: userRepo, RefreshTokens: tokenRepo, PasswordResets: resetRepo})
+	settings := testAuthSettings()
+	settings.PasswordResetTTL = 2 * time.Hour
+	svc := NewAuthService(repos, auth.NewDefaultJWTManager("test-secret"), settings)
+	resp, err := svc.IssuePasswordReset(ctx, userID.String(), adminID)
+	require.NoError(t, err)
+
+	stored := resetRepo.Calls[1].Arguments.Get(1).(*models.PasswordResetToken)
+	assert.Equal(t, auth.HashOpaqueToken(resp.ResetToken), stored.TokenHash, "only the hash of the token is stored")
+	assert.NotEqual(t, resp.ResetToken, stored.TokenHash)
+	assert.WithinDuration(t, time.Now().Add(2*time.Hour), resp.ExpiresAt, time.Minute)
+	resetRepo.AssertExpectations(t)
+	tokenRepo.AssertExpectations(t)
+}
+
+func TestAuthService_ResetPassword(t *testing.T) {
+	ctx := context.Background()
+	jwtManager := auth.NewDefaultJWTManager("test-secret")
+	const resetToken = "one-time-reset-token"
+
+	t.Run("sets new password and consumes token", func(t *testing.T) {
+		stored := &models.PasswordResetToken{
+			ID: uuid.New(), UserID: uuid.New(), TokenHash: auth.HashOpaqueToken(resetToken), ExpiresAt: time.Now().Add(time.Hour),
+		}
+		userRepo := new(MockUserRepository)
+		tokenRepo := new(MockRefreshTokenRepository)
+		resetRepo := new(MockPasswordResetRepository)
+		resetRepo.On("GetByHash", ctx, stored.TokenHash).Return(stored, nil)
+		resetRepo.On("MarkUsed", ctx, stored.ID).Return(true, nil)
+		userRepo.On("UpdatePassword", ctx, stored.UserID, mock.AnythingOfType("string"), false).Return(nil)
+		tokenRepo.On("RevokeAllForUser", ctx, stored.UserID).Return(nil)
+
+		svc := newTestAuthService(AuthRepositories{Users: userRepo, RefreshTokens: tokenRepo, PasswordResets: resetRepo}, jwtManager)
+		err := svc.ResetPassword(ctx, &models.ResetPasswordRequest{Token: resetToken, NewPassword: "n3w-secure-pass"})
+		require.NoError(t, err)
+		userRepo.AssertExpectations(t)
+		tokenRepo.AssertExpectations(t)
+	})
+
+	tests := []struct {
+		name   string
+		stored *models.PasswordResetToken
+	}{
+		{name: "unknown token", stored: nil},
+		{name: "expired token", stored: &models.PasswordResetToken{ID: uuid.New(), ExpiresAt: time.Now().Add(-time.Minute)}},
+		{name: "used token", stored: &models.PasswordResetToken{
+			ID: uuid.New(), ExpiresAt: time.Now().Add(time.Hour), UsedAt: func() *time.Time { now := time.Now(); return &now }(),
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetRepo := new(MockPasswordResetRepository)
+			if tt.stored == nil {
+				resetRepo.On("GetByHash", ctx, mock.Anything).Return(nil, nil)
+			} else {
+				resetRepo.On("GetByHash", ctx, mock.Anything).Return(tt.stored, nil)
+			}
+
+			svc := newTestAuthService(AuthRepositories{PasswordResets: resetRepo}, jwtManager)
+			err := svc.ResetPassword(ctx, &models.ResetPasswordRequest{Token: resetToken, NewPassword: "n3w-secure-pass"})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "invalid reset token")
+			resetRepo.AssertNotCalled(t, "MarkUsed", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestAuthService_UpdateUser(t *testing.T) {
+	ctx := context.Background()
+	jwtManager := auth.NewDefaultJWTManager("test-secret")
+	adminID := uuid.New()
+	role := func(r models.UserRole) *models.UserRole { return &r }
+	boolPtr := func(b bool) *bool { return &b }
+
+	newUser := func() *models.User {
+		return &models.User{ID: uuid.New(), Email: "dispatcher@example.com", Role: models.UserRoleDispatcher}
+	}
+
+	t.Run("patch changes role only", func(t *testing.T) {
+		user := newUser()
+		userRepo := new(MockUserRepository)
+		userRepo.On("Get", ctx, user.ID).Return(user, nil)
+		userRepo.On("Update", ctx, mock.MatchedBy(func(u *models.User) bool {
+			return u.Role == models.UserRoleDriver && u.Email == "dispatcher@example.com" && u.DisabledAt == nil
+		})).Return(nil)
+
+		svc := newTestAuthService(AuthRepositories{Users: userRepo}, jwtManager)
+		updated, err := svc.UpdateUser(ctx, user.ID.String(), adminID,
+			&models.UpdateUserRequest{Role: role(models.UserRoleDriver)}, false)
+		require.NoError(t, err)
+		assert.Equal(t, models.UserRoleDriver, updated.Role)
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("put requires email and role", func(t *testing.T) {
+		svc := newTestAuthService(AuthRepositories{}, jwtManager)
+		_, err := svc.UpdateUser(ctx, uuid.New().String(), adminID,
+			&models.UpdateUserRequest{Role: role(models.UserRoleDriver)}, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "validation failed: email is required")
+	})
+
+	t.Run("email taken by another user", func(t *testing.T) {
+		user := newUser()
+		email := "taken@example.com"
+		userRepo := new(MockUserRepository)
+		userRepo.On("Get", ctx, user.ID).Return(user, nil)
+		userRepo.On("ExistsByEmail", ctx, email, &user.ID).Return(true, nil)
+
+		svc := newTestAuthService(AuthRepositories{Users: userRepo}, jwtManager)
+		_, err := svc.UpdateUser(ctx, user.ID.String(), adminID, &models.UpdateUserRequest{Email: &email}, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already exists")
+		userRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("disabling revokes sessions", func(t *testing.T) {
+		user := newUser()
+		userRepo := new(MockUserRepository)
+		tokenRepo := new(MockRefreshTokenRepository)
+		userRepo.On("Get", ctx, user.ID).Return(user, nil)
+		userRepo.On("Update", ctx, mock.MatchedBy(func(u *models.User) bool { return u.DisabledAt != nil })).Return(nil)
+		tokenRepo.On("RevokeAllForUser", ctx, user.ID).Return(nil)
+
+		svc := newTestAuthService(AuthRepositories{Users: userRepo, RefreshTokens: tokenRepo}, jwtManager)
+		updated, err := svc.UpdateUser(ctx, user.ID.String(), adminID, &models.UpdateUserRequest{Disabled: boolPtr(true)}, false)
+		require.NoError(t, err)
+		assert.True(t, updated.IsDisabled())
+		tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("enabling clears disabled_at", func(t *testing.T) {
+		user := newUser()
+		disabledAt := time.Now().Add(-time.Hour)
+		user.DisabledAt = &disabledAt
+		userRepo := new(MockUserRepository)
+		tokenRepo := new(MockRefreshTokenRepository)
+		userRepo.On("Get", ctx, user.ID).Return(user, nil)
+		userRepo.On("Update", ctx, mock.MatchedBy(func(u *models.User) bool { return u.DisabledAt == nil })).Return(nil)
+
+		svc := newTestAuthService(AuthRepositories{Users: userRepo, RefreshTokens: tokenRepo}, jwtManager)
+		_, err := svc.UpdateUser(ctx, user.ID.String(), adminID, &models.UpdateUserRequest{Disabled: boolPtr(false)}, false)
+		require.NoError(t, err)
+		tokenRepo.AssertNotCalled(t, "RevokeAllForUser", mock.Anything, mock.Anything)
+	})
+
+	t.Run("admin cannot disable or demote themselves", func(t *testing.T) {
+		admin := &models.User{ID: adminID, Email: "admin@example.com", Role: models.UserRoleAdmin}
+		userRepo := new(MockUserRepository)
+		userRepo.On("Get", ctx, adminID).Return(admin, nil)
+
+		svc := newTestAuthService(AuthRepositories{Users: userRepo}, jwtManager)
+		_, err := svc.UpdateUser(ctx, adminID.String(), adminID, &models.UpdateUserRequest{Disabled: boolPtr(true)}, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot disable your own account")
+
+		_, err = svc.UpdateUser(ctx, adminID.String(), adminID, &models.UpdateUserRequest{Role: role(models.UserRoleViewer)}, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot change your own role")
+		userRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthService_Login_DisabledUser(t *testing.T) {
+	ctx := context.Background()
+	hash, err := auth.HashPassword("password123")
+	require.NoError(t, err)
+	disabledAt := time.Now()
+	user := &models.User{ID: uuid.New(), Email: "driver@example.com", PasswordHash: hash, Role: models.UserRoleDriver, DisabledAt: &disabledAt}
+
+	userRepo := new(MockUserRepository)
+	tokenRepo := new(MockRefreshTokenRepository)
+	userRepo.On("FindByEmail", ctx, user.Email).Return(user, nil)
+
+	svc := newTestAuthService(AuthRepositories{Users: userRepo, RefreshTokens: tokenRepo}, auth.NewDefaultJWTManager("test-secret"))
+	_, err = svc.Login(ctx, &models.LoginRequest{Email: user.Email, Password: "password123"}, testClient)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "account is disabled")
+	tokenRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_Login_Throttling(t *testing.T) {
+	ctx := context.Background()
+	jwtManager := auth.NewDefaultJWTManager("test-secret")
+	hash, err := auth.HashPassword("password123")
+	require.NoError(t, err)
+	user := &models.User{ID: uuid.New(), Email: "dispatcher@example.com", PasswordHash: hash, Role: models.UserRoleDispatcher}
+	policy := testAuthSettings().LoginThrottle
+	accountKey := models.AccountThrottleKey(user.Email)
+	ipKey := models.IPThrottleKey(testClient.IP)
+
+	isFailure := func(reason string) interface{} {
+		return mock.MatchedBy(func(attempt *models.LoginAttempt) bool {
+			return !attempt.Success && attempt.FailureReason != nil && *attempt.FailureReason == reason &&
+				attempt.IP == testClient.IP && attempt.UserAgent == testClient.UserAgent
+		})
+	}
+
+	t.Run("locked account is rejected without checking the password", func(t *testing.T) {
+		lockedUntil := time.Now().Add(10 * time.Minute)
+		userRepo := new(MockUserRepository)
+		securityRepo := new(MockLoginSecurityRepository)
+		securityRepo.On("GetThrottle", ctx, accountKey).Return(&models.LoginThrottle{
+			Key: accountKey, Failures: policy.MaxAccountFailures, LastFailureAt: time.Now(), LockedUntil: &lockedUntil,
+		}, nil)
+		securityRepo.On("GetThrottle", ctx, ipKey).Return(nil, nil)
+		securityRepo.On("RecordAttempt", ctx, isFailure(models.LoginFailureThrottled)).Return(nil)
+
+		svc := newTestAuthService(AuthRepositories{Users: userRepo, LoginSecurity: securityRepo}, jwtManager)
+		_, err := svc.Login(ctx, &models.LoginRequest{Email: user.Email, Password: "password123"}, testClient)
+
+		var throttled *models.LoginThrottledError
+		require.ErrorAs(t, err, &throttled)
+		assert.Greater(t, throttled.RetryAfterSeconds(), 500)
+		userRepo.AssertNotCalled(t, "FindByEmail", mock.Anything, mock.Anything)
+		securityRepo.AssertExpectations(t)
+	})
+
+	t.Run("progressive delay applies to the client IP", func(t *testing.T) {
+		securityRepo := new(MockLoginSecurityRepository)
+		securityRepo.On("GetThrottle", ctx, accountKey).Return(nil, nil)
+		securityRepo.On("GetThrottle", ctx, ipKey).Return(&models.LoginThrottle{
+			Key: ipKey, Failures: 3, LastFailureAt: time.Now(),
+		}, nil)
+		securityRepo.On("RecordAttempt", ctx, isFailure(models.LoginFailureThrottled)).Return(nil)
+
+		svc := newTestAuthService(AuthRepositories{LoginSecurity: securityRepo}, jwtManager)
+		_, err := svc.Login(ctx, &models.LoginRequest{Email: user.Email, Password: "password123"}, testClient)
+
+		var throttled *models.LoginThrottledError
+		require.ErrorAs(t, err, &throttled)
+		assert.Equal(t, 4, throttled.RetryAfterSeconds())
+	})
+
+	t.Run("wrong password counts against account and IP", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		securityRepo := new(MockLoginSecurityRepository)
+		userRepo.On("FindByEmail", ctx, user.Email).Return(user, nil)
+		securityRepo.On("GetThrottle", ctx, mock.Anything).Return(nil, nil)
+		securityRepo.On("RegisterFailure", ctx, accountKey, policy.MaxAccountFailures, policy.FailureWindow, policy.Lockout).
+			Return(&models.LoginThrottle{Key: accountKey, Failures: 1, LastFailureAt: time.Now()}, nil)
+		securityRepo.On("RegisterFailure", ctx, ipKey, policy.MaxIPFailures, policy.FailureWindow, policy.Lockout).
+			Return(&models.LoginThrottle{Key: ipKey, Failures: 1, LastFailureAt: time.Now()}, nil)
+		securityRepo.On("RecordAttempt", ctx, isFailure(models.LoginFailureInvalidCredentials)).Return(nil)
+
+		svc := newTestAuthService(AuthRepositories{Users: userRepo, LoginSecurity: securityRepo}, jwtManager)
+		_, err := svc.Login(ctx, &models.LoginRequest{Email: user.Email, Password: "wrong-password"}, testClient)
+
+		require.Error(t, err)
+		assert.Equal(t, "invalid credentials", err.Error())
+		securityRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown email counts as a failure", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		securityRepo := newAllowingLoginSecurityRepository()
+		userRepo.On("FindByEmail", ctx, "nobody@example.com").Return(nil, errors.New("user not found"))
+
+		svc := newTestAuthService(AuthRepositories{Users: userRepo, LoginSecurity: securityRepo}, jwtManager)
+		_, err := svc.Login(ctx, &models.LoginRequest{Email: "nobody@example.com", Password: "password123"}, testClient)
+
+		require.Error(t, err)
+		assert.Equal(t, "invalid credentials", err.Error())
+		securityRepo.AssertCalled(t, "RegisterFailure", ctx, models.AccountThrottleKey("nobody@example.com"),
+			policy.MaxAccountFailures, policy.FailureWindow, policy.Lockout)
+	})
+
+	t.Run("successful login resets the account counter and is recorded", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		tokenRepo := new(MockRefreshTokenRepository)
+		securityRepo := new(MockLoginSecurityRepository)
+		userRepo.On("FindByEmail", ctx, user.Email).Return(user, nil)
+		tokenRepo.On("Create", ctx, mock.Anything).Return(nil)
+		securityRepo.On("GetThrottle", ctx, mock.Anything).Return(nil, nil)
+		securityRepo.On("ResetThrottle", ctx, accountKey).Return(nil)
+		securityRepo.On("RecordAttempt", ctx, mock.MatchedBy(func(attempt *models.LoginAttempt) bool {
+			return attempt.Success && attempt.FailureReason == nil && *attempt.UserID == user.ID
+		})).Return(nil)
+
+		svc := newTestAuthService(AuthRepositories{Users: userRepo, RefreshTokens: tokenRepo, LoginSecurity: securityRepo}, jwtManager)
+		_, err := svc.Login(ctx, &models.LoginRequest{Email: user.Email, Password: "password123"}, testClient)
+
+		require.NoError(t, err)
+		securityRepo.AssertExpectations(t)
+		securityRepo.AssertNotCalled(t, "ResetThrottle", ctx, ipKey)
+	})
+}
+
+func TestAuthService_UnlockUser(t *testing.T) {
+	ctx := context.Background()
+	user := &models.User{ID: uuid.New(), Email: "Driver@Example.com", Role: models.UserRoleDriver}
+
+	userRepo := new(MockUserRepository)
+	securityRepo := new(MockLoginSecurityRepository)
+	userRepo.On("Get", ctx, user.ID).Return(user, nil)
+	securityRepo.On("ResetThrottle", ctx, "account:driver@example.com").Return(nil)
+
+	svc := newTestAuthService(AuthRepositories{Users: userRepo, LoginSecurity: securityRepo}, auth.NewDefaultJWTManager("test-secret"))
+	require.NoError(t, svc.UnlockUser(ctx, user.ID.String()))
+	securityRepo.AssertExpectations(t)
+}
@@ -0,0 +1,347 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"eco-van-api/internal/adapter/auth"
+	"eco-van-api/internal/domainerr"
+	"eco-van-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// LoginTwoFactor completes a login with the challenge token from Login and a TOTP or recovery code.
+// Wrong codes count against the account and client IP like wrong passwords.
+func (s *AuthService) LoginTwoFactor(
+	ctx context.Context, req *models.TwoFactorLoginRequest, client models.ClientInfo,
+) (*models.AuthResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, domainerr.Validation(domainerr.CodeValidationFailed, "invalid two-factor login request").Wrap(err)
+	}
+
+	claims, err := s.jwtManager.ValidateTwoFactorToken(req.TwoFactorToken)
+	if err != nil {
+		return nil, domainerr.Unauthorized(domainerr.CodeInvalidTwoFactorToken, "invalid two-factor token").Wrap(err)
+	}
+
+	user, err := s.userRepo.Get(ctx, claims.UserID)
+	if err != nil {
+		// A user deleted after the password step makes the token invalid; anything else is a server error
+		if domainerr.HasCode(err, domainerr.CodeUserNotFound) {
+			return nil, domainerr.Unauthorized(domainerr.CodeInvalidTwoFactorToken, "invalid two-factor token").Wrap(err)
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := s.checkLoginThrottle(ctx, models.AccountThrottleKey(user.Email), models.IPThrottleKey(client.IP)); err != nil {
+		var throttled *models.LoginThrottledError
+		if errors.As(err, &throttled) {
+			return nil, s.recordLoginFailure(ctx, user.Email, &user.ID, client, models.LoginFailureThrottled, err)
+		}
+		return nil, err
+	}
+
+	if user.IsDisabled() {
+		return nil, s.recordLoginFailure(ctx, user.Email, &user.ID, client, models.LoginFailureAccountDisabled,
+			domainerr.Unauthorized(domainerr.CodeAccountDisabled, "invalid credentials: account is disabled"))
+	}
+
+	valid, err := s.verifyTwoFactorCode(ctx, user.ID, req.Code, true)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, s.registerLoginFailure(ctx, user.Email, &user.ID, client, models.LoginFailureInvalidTwoFactorCode,
+			domainerr.Unauthorized(domainerr.CodeInvalidTwoFactorCode, "invalid two-factor code"))
+	}
+
+	if err := s.completeLogin(ctx, user, client); err != nil {
+		return nil, err
+	}
+
+	return s.grantSession(ctx, user)
+}
+
+// GetTwoFactorStatus reports whether the current user has two-factor authentication enabled or required
+func (s *AuthService) GetTwoFactorStatus(ctx context.Context, userID uuid.UUID) (*models.TwoFactorStatusResponse, error) {
+	user, err := s.userRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	status := &models.TwoFactorStatusResponse{Required: s.twoFactor.Required(user.Role)}
+	if status.Enabled, err = s.twoFactorEnabled(ctx, userID); err != nil {
+		return nil, err
+	}
+	if status.Enabled {
+		if status.RecoveryCodesRemaining, err = s.twoFactorRepo.CountRecoveryCodes(ctx, userID); err != nil {
+			return nil, fmt.Errorf("failed to count recovery codes: %w", err)
+		}
+	}
+
+	return status, nil
+}
+
+// EnrollTwoFactor starts a TOTP enrollment and returns the secret to add to an authenticator app.
+// Two-factor authentication is enabled once a code is confirmed with ConfirmTwoFactor.
+func (s *AuthService) EnrollTwoFactor(ctx context.Context, userID uuid.UUID) (*models.TwoFactorEnrollResponse, error) {
+	user, err := s.userRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	enabled, err := s.twoFactorEnabled(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return nil, domainerr.Conflict(domainerr.CodeTwoFactorAlreadyEnabled, "two-factor authentication is already enabled")
+	}
+
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.twoFactorRepo.SavePending(ctx, userID, secret); err != nil {
+		return nil, fmt.Errorf("failed to start two-factor enrollment: %w", err)
+	}
+
+	return &models.TwoFactorEnrollResponse{
+		Secret:          secret,
+		ProvisioningURI: auth.TOTPProvisioningURI(s.twoFactor.Issuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmTwoFactor enables two-factor authentication with a code of the pending enrollment and returns
+// the recovery codes. Sessions started without the second factor are revoked and a new session is returned.
+func (s *AuthService) ConfirmTwoFactor(
+	ctx context.Context, userID uuid.UUID, req *models.TwoFactorCodeRequest,
+) (*models.TwoFactorConfirmResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, domainerr.Validation(domainerr.CodeValidationFailed, "validation failed").Wrap(err)
+	}
+
+	user, err := s.userRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	totp, err := s.twoFactorRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get two-factor settings: %w", err)
+	}
+	if totp.IsEnabled() {
+		return nil, domainerr.Conflict(domainerr.CodeTwoFactorAlreadyEnabled, "two-factor authentication is already enabled")
+	}
+	if totp == nil {
+		return nil, domainerr.Validation(
+			domainerr.CodeTwoFactorEnrollmentRequired, "validation failed: two-factor enrollment has not been started",
+		)
+	}
+
+	step, valid := auth.VerifyTOTP(totp.Secret, req.Code, time.Now())
+	if !valid {
+		return nil, domainerr.Validation(domainerr.CodeInvalidTwoFactorCode, "validation failed").
+			Wrap(domainerr.InvalidField("/code", "totp", "invalid two-factor code"))
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	confirmed, err := s.twoFactorRepo.Confirm(ctx, userID, step, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enable two-factor authentication: %w", err)
+	}
+	if !confirmed {
+		return nil, domainerr.Conflict(domainerr.CodeTwoFactorAlreadyEnabled, "two-factor authentication is already enabled")
+	}
+
+	if err := s.refreshTokenRepo.RevokeAllForUser(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to revoke user sessions: %w", err)
+	}
+
+	session, err := s.grantSession(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TwoFactorConfirmResponse{RecoveryCodes: codes, AuthResponse: *session}, nil
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the current user after checking a TOTP code
+func (s *AuthService) RegenerateRecoveryCodes(
+	ctx context.Context, userID uuid.UUID, req *models.TwoFactorCodeRequest,
+) (*models.RecoveryCodesResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, domainerr.Validation(domainerr.CodeValidationFailed, "validation failed").Wrap(err)
+	}
+
+	if err := s.checkTwoFactorCode(ctx, userID, req.Code, false); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.twoFactorRepo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, fmt.Errorf("failed to replace recovery codes: %w", err)
+	}
+
+	return &models.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// DisableTwoFactor turns off two-factor authentication of the current user after checking a TOTP or
+// recovery code; users whose role requires it cannot turn it off
+func (s *AuthService) DisableTwoFactor(ctx context.Context, userID uuid.UUID, req *models.TwoFactorCodeRequest) error {
+	if err := req.Validate(); err != nil {
+		return domainerr.Validation(domainerr.CodeValidationFailed, "validation failed").Wrap(err)
+	}
+
+	user, err := s.userRepo.Get(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if s.twoFactor.Required(user.Role) {
+		return domainerr.Forbidden(domainerr.CodeTwoFactorRequired, "forbidden: two-factor authentication is required for role %s", user.Role).
+			With("role", user.Role)
+	}
+
+	if err := s.checkTwoFactorCode(ctx, userID, req.Code, true); err != nil {
+		return err
+	}
+
+	if err := s.twoFactorRepo.Delete(ctx, userID); err != nil {
+		return fmt.Errorf("failed to disable two-factor authentication: %w", err)
+	}
+
+	return nil
+}
+
+// ResetTwoFactor lets an admin remove the two-factor authentication of a user who lost their authenticator
+// and recovery codes. All sessions of the user are revoked; if their role requires two-factor
+// authentication they have to enroll again on their next login.
+func (s *AuthService) ResetTwoFactor(ctx context.Context, userID string) error {
+	id, err := models.ParseUUID(userID)
+	if err != nil {
+		return domainerr.InvalidInput(domainerr.CodeInvalidID, "invalid user ID").Wrap(err)
+	}
+
+	if _, err := s.userRepo.Get(ctx, id); err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := s.twoFactorRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to reset two-factor authentication: %w", err)
+	}
+
+	if err := s.refreshTokenRepo.RevokeAllForUser(ctx, id); err != nil {
+		return fmt.Errorf("failed to revoke user sessions: %w", err)
+	}
+
+	return nil
+}
+
+// twoFactorChallenge answers the password step of a user with two-factor authentication
+func (s *AuthService) twoFactorChallenge(user *models.User) (*models.AuthResponse, error) {
+	token, err := s.jwtManager.GenerateTwoFactorToken(user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate two-factor token: %w", err)
+	}
+
+	return &models.AuthResponse{
+		TwoFactorRequired: true,
+		TwoFactorToken:    token,
+		ExpiresIn:         int(auth.TwoFactorTokenTTL.Seconds()),
+	}, nil
+}
+
+// twoFactorEnabled reports whether the user confirmed a TOTP enrollment
+func (s *AuthService) twoFactorEnabled(ctx context.Context, userID uuid.UUID) (bool, error) {
+	totp, err := s.twoFactorRepo.Get(ctx, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get two-factor settings: %w", err)
+	}
+	return totp.IsEnabled(), nil
+}
+
+// twoFactorSetupRequired reports whether the user's role requires two-factor authentication they have not enabled
+func (s *AuthService) twoFactorSetupRequired(ctx context.Context, user *models.User) (bool, error) {
+	if !s.twoFactor.Required(user.Role) {
+		return false, nil
+	}
+	enabled, err := s.twoFactorEnabled(ctx, user.ID)
+	if err != nil {
+		return false, err
+	}
+	return !enabled, nil
+}
+
+// checkTwoFactorCode verifies a code of a user with two-factor authentication for a settings change
+func (s *AuthService) checkTwoFactorCode(ctx context.Context, userID uuid.UUID, code string, allowRecoveryCode bool) error {
+	enabled, err := s.twoFactorEnabled(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return domainerr.Validation(domainerr.CodeTwoFactorNotEnabled, "validation failed: two-factor authentication is not enabled")
+	}
+
+	valid, err := s.verifyTwoFactorCode(ctx, userID, code, allowRecoveryCode)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return domainerr.Validation(domainerr.CodeInvalidTwoFactorCode, "validation failed").
+			Wrap(domainerr.InvalidField("/code", "totp", "invalid two-factor code"))
+	}
+	return nil
+}
+
+// verifyTwoFactorCode accepts a TOTP code whose time step was not used yet or, if allowed, an unused recovery code.
+// Both are consumed, so a code works only once.
+func (s *AuthService) verifyTwoFactorCode(
+	ctx context.Context, userID uuid.UUID, code string, allowRecoveryCode bool,
+) (bool, error) {
+	totp, err := s.twoFactorRepo.Get(ctx, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get two-factor settings: %w", err)
+	}
+	if !totp.IsEnabled() {
+		return false, nil
+	}
+
+	if step, valid := auth.VerifyTOTP(totp.Secret, code, time.Now()); valid {
+		used, err := s.twoFactorRepo.UseStep(ctx, userID, step)
+		if err != nil {
+			return false, fmt.Errorf("failed to record two-factor code: %w", err)
+		}
+		return used, nil
+	}
+
+	if !allowRecoveryCode {
+		return false, nil
+	}
+	used, err := s.twoFactorRepo.UseRecoveryCode(ctx, userID, auth.HashRecoveryCode(code))
+	if err != nil {
+		return false, fmt.Errorf("failed to use recovery code: %w", err)
+	}
+	return used, nil
+}
+
+// generateRecoveryCodes returns new recovery codes together with the hashes to store
+func generateRecoveryCodes() (codes, hashes []string, err error) {
+	codes, err = auth.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, nil, err
+	}
+	hashes = make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = auth.HashRecoveryCode(code)
+	}
+	return codes, hashes, nil
+}
@@ -0,0 +1,471 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"eco-van-api/internal/adapter/auth"
+	"eco-van-api/internal/domainerr"
+	"eco-van-api/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockTwoFactorRepository is a mock implementation of TwoFactorRepository
+type MockTwoFactorRepository struct {
+	mock.Mock
+}
+
+func (m *MockTwoFactorRepository) Get(ctx context.Context, userID uuid.UUID) (*models.UserTOTP, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UserTOTP), args.Error(1)
+}
+
+func (m *MockTwoFactorRepository) SavePending(ctx context.Context, userID uuid.UUID, secret string) error {
+	args := m.Called(ctx, userID, secret)
+	return args.Error(0)
+}
+
+func (m *MockTwoFactorRepository) Confirm(
+	ctx context.Context, userID uuid.UUID, step int64, recoveryCodeHashes []string,
+) (bool, error) {
+	args := m.Called(ctx, userID, step, recoveryCodeHashes)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTwoFactorRepository) UseStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	args := m.Called(ctx, userID, step)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTwoFactorRepository) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error {
+	args := m.Called(ctx, userID, codeHashes)
+	return args.Error(0)
+}
+
+func (m *MockTwoFactorRepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error) {
+	args := m.Called(ctx, userID, codeHash)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTwoFactorRepository) CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTwoFactorRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+// newUnenrolledTwoFactorRepository returns a two-factor mock for tests of users without two-factor authentication
+func newUnenrolledTwoFactorRepository() *MockTwoFactorRepository {
+	repo := new(MockTwoFactorRepository)
+	repo.On("Get", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	return repo
+}
+
+// newTwoFactorAuthService creates an auth service whose policy makes two-factor authentication mandatory for ADMIN
+func newTwoFactorAuthService(repos AuthRepositories, jwtManager *auth.JWTManager) *AuthService {
+	settings := testAuthSettings()
+	settings.TwoFactor = models.TwoFactorPolicy{Issuer: "Eco-Van", RequiredForAdmin: true}
+	return NewAuthService(testAuthRepositories(repos), jwtManager, settings)
+}
+
+// enabledTOTP returns the confirmed TOTP enrollment of a user with a fresh secret
+func enabledTOTP(t *testing.T, userID uuid.UUID) *models.UserTOTP {
+	t.Helper()
+	secret, err := auth.GenerateTOTPSecret()
+	require.NoError(t, err)
+	confirmedAt := time.Now().Add(-time.Hour)
+	return &models.UserTOTP{UserID: userID, Secret: secret, ConfirmedAt: &confirmedAt}
+}
+
+// currentTOTPCode returns the code of the secret for now together with its time step
+func currentTOTPCode(t *testing.T, secret string) (string, int64) {
+	t.Helper()
+	step := auth.TOTPStep(time.Now())
+	code, err := auth.TOTPCode(secret, step)
+	require.NoError(t, err)
+	return code, step
+}
+
+func TestAuthService_LoginTwoFactor(t *testing.T) {
+	ctx := context.Background()
+	jwtManager := auth.NewDefaultJWTManager("test-secret")
+	hash, err := auth.HashPassword("password123")
+	require.NoError(t, err)
+	user := &models.User{ID: uuid.New(), Email: "admin@example.com", PasswordHash: hash, Role: models.UserRoleAdmin}
+	totp := enabledTOTP(t, user.ID)
+	accountKey := models.AccountThrottleKey(user.Email)
+
+	// passwordStep logs in with the password and returns the challenge token
+	passwordStep := func(t *testing.T, svc *AuthService) string {
+		resp, err := svc.Login(ctx, &models.LoginRequest{Email: user.Email, Password: "password123"}, testClient)
+		require.NoError(t, err)
+		require.True(t, resp.TwoFactorRequired)
+		assert.Empty(t, resp.AccessToken)
+		assert.Empty(t, resp.RefreshToken)
+		return resp.TwoFactorToken
+	}
+
+	newRepos := func() (*MockUserRepository, *MockRefreshTokenRepository, *MockLoginSecurityRepository, *MockTwoFactorRepository) {
+		userRepo := new(MockUserRepository)
+		tokenRepo := new(MockRefreshTokenRepository)
+		twoFactorRepo := new(MockTwoFactorRepository)
+		userRepo.On("FindByEmail", ctx, user.Email).Return(user, nil)
+		userRepo.On("Get", ctx, user.ID).Return(user, nil)
+		tokenRepo.On("Create", ctx, mock.Anything).Return(nil)
+		twoFactorRepo.On("Get", ctx, user.ID).Return(totp, nil)
+		return userRepo, tokenRepo, newAllowingLoginSecurityRepository(), twoFactorRepo
+	}
+
+	t.Run("password step only returns a challenge and keeps the account counter", func(t *testing.T) {
+		userRepo, tokenRepo, securityRepo, twoFactorRepo := newRepos()
+		svc := newTestAuthService(AuthRepositories{
+			Users: userRepo, RefreshTokens: tokenRepo, LoginSecurity: securityRepo, TwoFactor: twoFactorRepo,
+		}, jwtManager)
+
+		token := passwordStep(t, svc)
+
+		claims, err := jwtManager.ValidateTwoFactorToken(token)
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, claims.UserID)
+		_, err = jwtManager.ValidateAccessToken(token)
+		assert.Error(t, err)
+		tokenRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		securityRepo.AssertNotCalled(t, "ResetThrottle", mock.Anything, mock.Anything)
+	})
+
+	t.Run("TOTP code completes the login", func(t *testing.T) {
+		userRepo, tokenRepo, securityRepo, twoFactorRepo := newRepos()
+		code, step := currentTOTPCode(t, totp.Secret)
+		twoFactorRepo.On("UseStep", ctx, user.ID, step).Return(true, nil)
+		svc := newTestAuthService(AuthRepositories{
+			Users: userRepo, RefreshTokens: tokenRepo, LoginSecurity: securityRepo, TwoFactor: twoFactorRepo,
+		}, jwtManager)
+
+		resp, err := svc.LoginTwoFactor(ctx, &models.TwoFactorLoginRequest{TwoFactorToken: passwordStep(t, svc), Code: code}, testClient)
+		require.NoError(t, err)
+
+		assert.NotEmpty(t, resp.AccessToken)
+		assert.NotEmpty(t, resp.RefreshToken)
+		securityRepo.AssertCalled(t, "ResetThrottle", ctx, accountKey)
+		twoFactorRepo.AssertExpectations(t)
+	})
+
+	t.Run("recovery code completes the login", func(t *testing.T) {
+		userRepo, tokenRepo, securityRepo, twoFactorRepo := newRepos()
+		twoFactorRepo.On("UseRecoveryCode", ctx, user.ID, auth.HashRecoveryCode("abcde-fghjk")).Return(true, nil)
+		svc := newTestAuthService(AuthRepositories{
+			Users: userRepo, RefreshTokens: tokenRepo, LoginSecurity: securityRepo, TwoFactor: twoFactorRepo,
+		}, jwtManager)
+
+		resp, err := svc.LoginTwoFactor(ctx, &models.TwoFactorLoginRequest{
+			TwoFactorToken: passwordStep(t, svc), Code: "ABCDE FGHJK",
+		}, testClient)
+		require.NoError(t, err)
+		assert.NotEmpty(t, resp.RefreshToken)
+	})
+
+	t.Run("wrong or replayed code counts as a failure", func(t *testing.T) {
+		userRepo, tokenRepo, securityRepo, twoFactorRepo := newRepos()
+		code, step := currentTOTPCode(t, totp.Secret)
+		twoFactorRepo.On("UseStep", ctx, user.ID, step).Return(false, nil)
+		twoFactorRepo.On("UseRecoveryCode", ctx, user.ID, mock.Anything).Return(false, nil)
+		svc := newTestAuthService(AuthRepositories{
+			Users: userRepo, RefreshTokens: tokenRepo, LoginSecurity: securityRepo, TwoFactor: twoFactorRepo,
+		}, jwtManager)
+
+		_, err := svc.LoginTwoFactor(ctx, &models.TwoFactorLoginRequest{TwoFactorToken: passwordStep(t, svc), Code: code}, testClient)
+		require.Error(t, err)
+		assert.Equal(t, "invalid two-factor code", err.Error())
+		securityRepo.AssertCalled(t, "RegisterFailure", ctx, accountKey, mock.Anything, mock.Anything, mock.Anything)
+		securityRepo.AssertCalled(t, "RecordAttempt", ctx, mock.MatchedBy(func(attempt *models.LoginAttempt) bool {
+			return attempt.FailureReason != nil && *attempt.FailureReason == models.LoginFailureInvalidTwoFactorCode
+		}))
+		tokenRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("locked account is rejected before the code is checked", func(t *testing.T) {
+		userRepo, tokenRepo, _, twoFactorRepo := newRepos()
+		lockedUntil := time.Now().Add(10 * time.Minute)
+		securityRepo := new(MockLoginSecurityRepository)
+		securityRepo.On("GetThrottle", ctx, accountKey).Return(&models.LoginThrottle{
+			Key: accountKey, Failures: 3, LastFailureAt: time.Now(), LockedUntil: &lockedUntil,
+		}, nil)
+		securityRepo.On("GetThrottle", ctx, mock.Anything).Return(nil, nil)
+		securityRepo.On("RecordAttempt", ctx, mock.Anything).Return(nil)
+		svc := newTestAuthService(AuthRepositories{
+			Users: userRepo, RefreshTokens: tokenRepo, LoginSecurity: securityRepo, TwoFactor: twoFactorRepo,
+		}, jwtManager)
+
+		token, err := jwtManager.GenerateTwoFactorToken(user)
+		require.NoError(t, err)
+		code, _ := currentTOTPCode(t, totp.Secret)
+		_, err = svc.LoginTwoFactor(ctx, &models.TwoFactorLoginRequest{TwoFactorToken: token, Code: code}, testClient)
+
+		var throttled *models.LoginThrottledError
+		require.ErrorAs(t, err, &throttled)
+		twoFactorRepo.AssertNotCalled(t, "UseStep", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("deleted user makes the challenge token invalid", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		userRepo.On("Get", ctx, user.ID).Return(nil, domainerr.NotFound(domainerr.CodeUserNotFound, "user not found"))
+		svc := newTestAuthService(AuthRepositories{Users: userRepo}, jwtManager)
+
+		token, err := jwtManager.GenerateTwoFactorToken(user)
+		require.NoError(t, err)
+		_, err = svc.LoginTwoFactor(ctx, &models.TwoFactorLoginRequest{TwoFactorToken: token, Code: "123456"}, testClient)
+		assert.True(t, domainerr.HasCode(err, domainerr.CodeInvalidTwoFactorToken))
+	})
+
+	t.Run("user lookup failure is not an invalid token", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		userRepo.On("Get", ctx, user.ID).Return(nil, errors.New("connection refused"))
+		svc := newTestAuthService(AuthRepositories{Users: userRepo}, jwtManager)
+
+		token, err := jwtManager.GenerateTwoFactorToken(user)
+		require.NoError(t, err)
+		_, err = svc.LoginTwoFactor(ctx, &models.TwoFactorLoginRequest{TwoFactorToken: token, Code: "123456"}, testClient)
+		require.Error(t, err)
+		_, isDomainErr := domainerr.As(err)
+		assert.False(t, isDomainErr)
+		assert.Contains(t, err.Error(), "failed to get user")
+	})
+
+	t.Run("access token is not a challenge token", func(t *testing.T) {
+		svc := newTestAuthService(AuthRepositories{}, jwtManager)
+		accessToken, err := jwtManager.GenerateAccessToken(user)
+		require.NoError(t, err)
+
+		_, err = svc.LoginTwoFactor(ctx, &models.TwoFactorLoginRequest{TwoFactorToken: accessToken, Code: "123456"}, testClient)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid two-factor token")
+	})
+}
+
+func TestAuthService_TwoFactorRequiredForAdmin(t *testing.T) {
+	ctx := context.Background()
+	jwtManager := auth.NewDefaultJWTManager("test-secret")
+	hash, err := auth.HashPassword("password123")
+	require.NoError(t, err)
+
+	t.Run("admin without 2FA gets a setup token only", func(t *testing.T) {
+		user := &models.User{ID: uuid.New(), Email: "admin@example.com", PasswordHash: hash, Role: models.UserRoleAdmin}
+		userRepo := new(MockUserRepository)
+		tokenRepo := new(MockRefreshTokenRepository)
+		userRepo.On("FindByEmail", ctx, user.Email).Return(user, nil)
+
+		svc := newTwoFactorAuthService(AuthRepositories{Users: userRepo, RefreshTokens: tokenRepo}, jwtManager)
+		resp, err := svc.Login(ctx, &models.LoginRequest{Email: user.Email, Password: "password123"}, testClient)
+		require.NoError(t, err)
+
+		assert.True(t, resp.MustEnrollTwoFactor)
+		assert.Empty(t, resp.RefreshToken)
+		claims, err := jwtManager.ValidateAccessToken(resp.AccessToken)
+		require.NoError(t, err)
+		assert.True(t, claims.TwoFactorSetup)
+		tokenRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("other roles are not affected", func(t *testing.T) {
+		user := &models.User{ID: uuid.New(), Email: "dispatcher@example.com", PasswordHash: hash, Role: models.UserRoleDispatcher}
+		userRepo := new(MockUserRepository)
+		tokenRepo := new(MockRefreshTokenRepository)
+		userRepo.On("FindByEmail", ctx, user.Email).Return(user, nil)
+		tokenRepo.On("Create", ctx, mock.Anything).Return(nil)
+
+		svc := newTwoFactorAuthService(AuthRepositories{Users: userRepo, RefreshTokens: tokenRepo}, jwtManager)
+		resp, err := svc.Login(ctx, &models.LoginRequest{Email: user.Email, Password: "password123"}, testClient)
+		require.NoError(t, err)
+		assert.False(t, resp.MustEnrollTwoFactor)
+		assert.NotEmpty(t, resp.RefreshToken)
+	})
+
+	t.Run("refresh is refused until the admin enrolls", func(t *testing.T) {
+		user := &models.User{ID: uuid.New(), Email: "admin@example.com", Role: models.UserRoleAdmin}
+		refreshToken, stored := issueTestRefreshToken(t, jwtManager, user)
+		userRepo := new(MockUserRepository)
+		tokenRepo := new(MockRefreshTokenRepository)
+		userRepo.On("Get", ctx, user.ID).Return(user, nil)
+		tokenRepo.On("Get", ctx, stored.ID).Return(stored, nil)
+		tokenRepo.On("MarkUsed", ctx, stored.ID).Return(true, nil)
+
+		svc := newTwoFactorAuthService(AuthRepositories{Users: userRepo, RefreshTokens: tokenRepo}, jwtManager)
+		_, err := svc.Refresh(ctx, &models.RefreshRequest{RefreshToken: refreshToken})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "two-factor authentication setup required")
+	})
+
+	t.Run("admin cannot disable required 2FA", func(t *testing.T) {
+		user := &models.User{ID: uuid.New(), Email: "admin@example.com", Role: models.UserRoleAdmin}
+		userRepo := new(MockUserRepository)
+		twoFactorRepo := new(MockTwoFactorRepository)
+		userRepo.On("Get", ctx, user.ID).Return(user, nil)
+
+		svc := newTwoFactorAuthService(AuthRepositories{Users: userRepo, TwoFactor: twoFactorRepo}, jwtManager)
+		err := svc.DisableTwoFactor(ctx, user.ID, &models.TwoFactorCodeRequest{Code: "123456"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "forbidden")
+		twoFactorRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthService_StartSession_TwoFactor(t *testing.T) {
+	ctx := context.Background()
+	jwtManager := auth.NewDefaultJWTManager("test-secret")
+
+	t.Run("user with 2FA only gets a challenge", func(t *testing.T) {
+		user := &models.User{ID: uuid.New(), Email: "dispatcher@example.com", Role: models.UserRoleDispatcher}
+		tokenRepo := new(MockRefreshTokenRepository)
+		securityRepo := newAllowingLoginSecurityRepository()
+		twoFactorRepo := new(MockTwoFactorRepository)
+		twoFactorRepo.On("Get", ctx, user.ID).Return(enabledTOTP(t, user.ID), nil)
+
+		svc := newTestAuthService(AuthRepositories{
+			RefreshTokens: tokenRepo, LoginSecurity: securityRepo, TwoFactor: twoFactorRepo,
+		}, jwtManager)
+		resp, err := svc.StartSession(ctx, user, testClient)
+		require.NoError(t, err)
+
+		assert.True(t, resp.TwoFactorRequired)
+		assert.Empty(t, resp.AccessToken)
+		assert.Empty(t, resp.RefreshToken)
+		claims, err := jwtManager.ValidateTwoFactorToken(resp.TwoFactorToken)
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, claims.UserID)
+		tokenRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		securityRepo.AssertNotCalled(t, "RecordAttempt", mock.Anything, mock.Anything)
+	})
+
+	t.Run("admin without 2FA gets a setup token only", func(t *testing.T) {
+		user := &models.User{ID: uuid.New(), Email: "admin@example.com", Role: models.UserRoleAdmin}
+		tokenRepo := new(MockRefreshTokenRepository)
+
+		svc := newTwoFactorAuthService(AuthRepositories{RefreshTokens: tokenRepo}, jwtManager)
+		resp, err := svc.StartSession(ctx, user, testClient)
+		require.NoError(t, err)
+
+		assert.True(t, resp.MustEnrollTwoFactor)
+		assert.Empty(t, resp.RefreshToken)
+		claims, err := jwtManager.ValidateAccessToken(resp.AccessToken)
+		require.NoError(t, err)
+		assert.True(t, claims.TwoFactorSetup)
+		tokenRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("user without 2FA gets a session", func(t *testing.T) {
+		user := &models.User{ID: uuid.New(), Email: "dispatcher@example.com", Role: models.UserRoleDispatcher}
+		tokenRepo := new(MockRefreshTokenRepository)
+		tokenRepo.On("Create", ctx, mock.Anything).Return(nil)
+
+		svc := newTwoFactorAuthService(AuthRepositories{RefreshTokens: tokenRepo}, jwtManager)
+		resp, err := svc.StartSession(ctx, user, testClient)
+		require.NoError(t, err)
+		assert.NotEmpty(t, resp.AccessToken)
+		assert.NotEmpty(t, resp.RefreshToken)
+	})
+}
+
+func TestAuthService_EnrollAndConfirmTwoFactor(t *testing.T) {
+	ctx := context.Background()
+	jwtManager := auth.NewDefaultJWTManager("test-secret")
+	user := &models.User{ID: uuid.New(), Email: "admin@example.com", Role: models.UserRoleAdmin}
+
+	t.Run("enroll returns a provisioning URI for the pending secret", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		twoFactorRepo := new(MockTwoFactorRepository)
+		userRepo.On("Get", ctx, user.ID).Return(user, nil)
+		twoFactorRepo.On("Get", ctx, user.ID).Return(nil, nil)
+		twoFactorRepo.On("SavePending", ctx, user.ID, mock.Anything).Return(nil)
+
+		svc := newTwoFactorAuthService(AuthRepositories{Users: userRepo, TwoFactor: twoFactorRepo}, jwtManager)
+		resp, err := svc.EnrollTwoFactor(ctx, user.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, twoFactorRepo.Calls[1].Arguments.String(2), resp.Secret)
+		assert.Contains(t, resp.ProvisioningURI, "otpauth://totp/Eco-Van:admin@example.com?")
+		assert.Contains(t, resp.ProvisioningURI, "secret="+resp.Secret)
+	})
+
+	t.Run("enroll is refused while 2FA is enabled", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		twoFactorRepo := new(MockTwoFactorRepository)
+		userRepo.On("Get", ctx, user.ID).Return(user, nil)
+		twoFactorRepo.On("Get", ctx, user.ID).Return(enabledTOTP(t, user.ID), nil)
+
+		svc := newTwoFactorAuthService(AuthRepositories{Users: userRepo, TwoFactor: twoFactorRepo}, jwtManager)
+		_, err := svc.EnrollTwoFactor(ctx, user.ID)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already enabled")
+	})
+
+	t.Run("confirm enables 2FA, returns recovery codes and a new session", func(t *testing.T) {
+		pending := enabledTOTP(t, user.ID)
+		pending.ConfirmedAt = nil
+		code, step := currentTOTPCode(t, pending.Secret)
+
+		userRepo := new(MockUserRepository)
+		tokenRepo := new(MockRefreshTokenRepository)
+		twoFactorRepo := new(MockTwoFactorRepository)
+		userRepo.On("Get", ctx, user.ID).Return(user, nil)
+		tokenRepo.On("RevokeAllForUser", ctx, user.ID).Return(nil)
+		tokenRepo.On("Create", ctx, mock.Anything).Return(nil)
+		twoFactorRepo.On("Get", ctx, user.ID).Return(pending, nil).Once()
+		twoFactorRepo.On("Confirm", ctx, user.ID, step, mock.Anything).Return(true, nil)
+		twoFactorRepo.On("Get", ctx, user.ID).Return(enabledTOTP(t, user.ID), nil)
+
+		svc := newTwoFactorAuthService(AuthRepositories{Users: userRepo, RefreshTokens: tokenRepo, TwoFactor: twoFactorRepo}, jwtManager)
+		resp, err := svc.ConfirmTwoFactor(ctx, user.ID, &models.TwoFactorCodeRequest{Code: code})
+		require.NoError(t, err)
+
+		require.Len(t, resp.RecoveryCodes, auth.RecoveryCodeCount)
+		storedHashes := twoFactorRepo.Calls[1].Arguments.Get(3).([]string)
+		assert.Equal(t, auth.HashRecoveryCode(resp.RecoveryCodes[0]), storedHashes[0])
+		assert.NotEmpty(t, resp.RefreshToken)
+		assert.False(t, resp.MustEnrollTwoFactor)
+		tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("confirm rejects a wrong code", func(t *testing.T) {
+		pending := enabledTOTP(t, user.ID)
+		pending.ConfirmedAt = nil
+		userRepo := new(MockUserRepository)
+		twoFactorRepo := new(MockTwoFactorRepository)
+		userRepo.On("Get", ctx, user.ID).Return(user, nil)
+		twoFactorRepo.On("Get", ctx, user.ID).Return(pending, nil)
+
+		svc := newTwoFactorAuthService(AuthRepositories{Users: userRepo, TwoFactor: twoFactorRepo}, jwtManager)
+		_, err := svc.ConfirmTwoFactor(ctx, user.ID, &models.TwoFactorCodeRequest{Code: "000000x"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "validation failed: invalid two-factor code")
+		twoFactorRepo.AssertNotCalled(t, "Confirm", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthService_DisableTwoFactor(t *testing.T) {
+	ctx := context.Background()
+	jwtManager := auth.NewDefaultJWTManager("test-secret")
+	user := &models.User{ID: uuid.New(), Email: "dispatcher@example.com", Role: models.UserRoleDispatcher}
+
+	userRepo := new(MockUserRepository)
+	twoFactorRepo := new(MockTwoFactorRepository)
+	userRepo.On("Get", ctx, user.ID).Return(user, nil)
+	twoFactorRepo.On("Get", ctx, user.ID).Return(enabledTOTP(t, user.ID), nil)
+	twoFactorRepo.On("UseRecoveryCode", ctx, user.ID, auth.HashRecoveryCode("abcde-fghjk")).Return(true, nil)
+	twoFactorRepo.On("Delete", ctx, user.ID).Return(nil)
+
+	svc := newTwoFactorAuthService(AuthRepositories{Users: userRepo, TwoFactor: twoFactorRepo}, jwtManager)
+	require.NoError(t, svc.DisableTwoFactor(ctx, user.ID, &models.TwoFactorCodeRequest{Code: "abcde-fghjk"}))
+	twoFactorRepo.AssertExpectations(t)
+}
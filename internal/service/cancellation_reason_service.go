@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"eco-van-api/internal/domainerr"
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/port"
+)
+
+// cancellationReasonCodePattern matches the CHECK constraint on cancellation_reasons.code
+var cancellationReasonCodePattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// cancellationReasonService implements port.CancellationReasonService
+type cancellationReasonService struct {
+	reasonRepo port.CancellationReasonRepository
+}
+
+// NewCancellationReasonService creates a new cancellation reason service
+func NewCancellationReasonService(reasonRepo port.CancellationReasonRepository) port.CancellationReasonService {
+	return &cancellationReasonService{
+		reasonRepo: reasonRepo,
+	}
+}
+
+// Create creates a new cancellation reason
+func (s *cancellationReasonService) Create(
+	ctx context.Context, req models.CreateCancellationReasonRequest,
+) (*models.CancellationReason, error) {
+	code := strings.ToUpper(strings.TrimSpace(req.Code))
+	if !cancellationReasonCodePattern.MatchString(code) {
+		return nil, domainerr.Validation(domainerr.CodeValidationFailed, "validation failed").
+			Wrap(domainerr.InvalidField("/code", "format", "code must start with a letter and contain only A-Z, 0-9 and _"))
+	}
+
+	existing, err := s.reasonRepo.GetByCode(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check cancellation reason existence: %w", err)
+	}
+	if existing != nil {
+		return nil, domainerr.Conflict(domainerr.CodeCancellationReasonExists, "cancellation reason '%s' already exists", code).With("code", code)
+	}
+
+	reason := &models.CancellationReason{
+		Code:     code,
+		Label:    strings.TrimSpace(req.Label),
+		IsActive: true,
+	}
+	if err := s.reasonRepo.Create(ctx, reason); err != nil {
+		return nil, fmt.Errorf("failed to create cancellation reason: %w", err)
+	}
+
+	return reason, nil
+}
+
+// List retrieves cancellation reasons, optionally including inactive ones
+func (s *cancellationReasonService) List(ctx context.Context, includeInactive bool) (*models.CancellationReasonListResponse, error) {
+	reasons, err := s.reasonRepo.List(ctx, includeInactive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cancellation reasons: %w", err)
+	}
+
+	return &models.CancellationReasonListResponse{Items: reasons}, nil
+}
+
+// Update updates the label or active flag of a cancellation reason.
+// Reasons are deactivated rather than deleted because canceled orders keep referencing them.
+func (s *cancellationReasonService) Update(
+	ctx context.Context, code string, req models.UpdateCancellationReasonRequest,
+) (*models.CancellationReason, error) {
+	reason, err := s.reasonRepo.GetByCode(ctx, strings.ToUpper(code))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cancellation reason: %w", err)
+	}
+	if reason == nil {
+		return nil, domainerr.NotFound(domainerr.CodeCancellationReasonNotFound, "cancellation reason not found")
+	}
+
+	reason.UpdateFromRequest(req)
+
+	if err := s.reasonRepo.Update(ctx, reason); err != nil {
+		return nil, fmt.Errorf("failed to update cancellation reason: %w", err)
+	}
+
+	return reason, nil
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"eco-van-api/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockCancellationReasonRepository is a mock implementation of CancellationReasonRepository
+type MockCancellationReasonRepository struct {
+	mock.Mock
+}
+
+func (m *MockCancellationReasonRepository) Create(ctx context.Context, reason *models.CancellationReason) error {
+	args := m.Called(ctx, reason)
+	return args.Error(0)
+}
+
+func (m *MockCancellationReasonRepository) GetByCode(ctx context.Context, code string) (*models.CancellationReason, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CancellationReason), args.Error(1)
+}
+
+func (m *MockCancellationReasonRepository) List(ctx context.Context, includeInactive bool) ([]models.CancellationReason, error) {
+	args := m.Called(ctx, includeInactive)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CancellationReason), args.Error(1)
+}
+
+func (m *MockCancellationReasonRepository) Update(ctx context.Context, reason *models.CancellationReason) error {
+	args := m.Called(ctx, reason)
+	return args.Error(0)
+}
+
+func TestCancellationReasonService_Create(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("normalizes code and creates active reason", func(t *testing.T) {
+		mockRepo := new(MockCancellationReasonRepository)
+		svc := NewCancellationReasonService(mockRepo)
+
+		mockRepo.On("GetByCode", ctx, "ROAD_CLOSED").Return(nil, nil)
+		mockRepo.On("Create", ctx, mock.AnythingOfType("*models.CancellationReason")).Return(nil)
+
+		result, err := svc.Create(ctx, models.CreateCancellationReasonRequest{Code: " road_closed ", Label: "Road closed"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "ROAD_CLOSED", result.Code)
+		assert.True(t, result.IsActive)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("duplicate code", func(t *testing.T) {
+		mockRepo := new(MockCancellationReasonRepository)
+		svc := NewCancellationReasonService(mockRepo)
+
+		mockRepo.On("GetByCode", ctx, models.CancellationReasonWeather).
+			Return(&models.CancellationReason{Code: models.CancellationReasonWeather}, nil)
+
+		result, err := svc.Create(ctx, models.CreateCancellationReasonRequest{Code: "WEATHER", Label: "Weather"})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "already exists")
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid code format", func(t *testing.T) {
+		mockRepo := new(MockCancellationReasonRepository)
+		svc := NewCancellationReasonService(mockRepo)
+
+		result, err := svc.Create(ctx, models.CreateCancellationReasonRequest{Code: "1-bad code", Label: "Bad"})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "validation failed")
+	})
+}
+
+func TestCancellationReasonService_Update(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("deactivates reason", func(t *testing.T) {
+		mockRepo := new(MockCancellationReasonRepository)
+		svc := NewCancellationReasonService(mockRepo)
+		reason := &models.CancellationReason{Code: models.CancellationReasonDuplicate, Label: "Duplicate", IsActive: true}
+		inactive := false
+
+		mockRepo.On("GetByCode", ctx, models.CancellationReasonDuplicate).Return(reason, nil)
+		mockRepo.On("Update", ctx, reason).Return(nil)
+
+		result, err := svc.Update(ctx, "duplicate", models.UpdateCancellationReasonRequest{IsActive: &inactive})
+
+		require.NoError(t, err)
+		assert.False(t, result.IsActive)
+		assert.Equal(t, "Duplicate", result.Label)
+	})
+
+	t.Run("reason not found", func(t *testing.T) {
+		mockRepo := new(MockCancellationReasonRepository)
+		svc := NewCancellationReasonService(mockRepo)
+
+		mockRepo.On("GetByCode", ctx, "MISSING").Return(nil, nil)
+
+		result, err := svc.Update(ctx, "MISSING", models.UpdateCancellationReasonRequest{})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "not found")
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"eco-van-api/internal/domainerr"
 	"eco-van-api/internal/models"
 	"eco-van-api/internal/port"
 
@@ -38,7 +39,7 @@ func (s *clientObjectService) Create(
 		return nil, fmt.Errorf("failed to get client: %w", err)
 	}
 	if client == nil {
-		return nil, fmt.Errorf("client not found")
+		return nil, domainerr.NotFound(domainerr.CodeClientNotFound, "client not found")
 	}
 
 	// Check if name already exists for this client
@@ -47,7 +48,9 @@ func (s *clientObjectService) Create(
 		return nil, fmt.Errorf("failed to check name existence: %w", err)
 	}
 	if exists {
-		return nil, fmt.Errorf("client object with name %s already exists for this client", req.Name)
+		return nil, domainerr.Conflict(
+			domainerr.CodeClientObjectNameExists, "client object with name %s already exists for this client", req.Name,
+		).With("name", req.Name)
 	}
 
 	// Create client object
@@ -73,12 +76,12 @@ func (s *clientObjectService) GetByID(
 		return nil, fmt.Errorf("failed to get client object: %w", err)
 	}
 	if clientObject == nil {
-		return nil, fmt.Errorf("client object not found")
+		return nil, domainerr.NotFound(domainerr.CodeClientObjectNotFound, "client object not found")
 	}
 
 	// Verify it belongs to the specified client
 	if clientObject.ClientID != clientID {
-		return nil, fmt.Errorf("client object not found")
+		return nil, domainerr.NotFound(domainerr.CodeClientObjectNotFound, "client object not found")
 	}
 
 	response := clientObject.ToResponse()
@@ -97,7 +100,7 @@ func (s *clientObjectService) List(
 		return nil, fmt.Errorf("failed to get client: %w", err)
 	}
 	if client == nil {
-		return nil, fmt.Errorf("client not found")
+		return nil, domainerr.NotFound(domainerr.CodeClientNotFound, "client not found")
 	}
 
 	// Set defaults
@@ -134,12 +137,12 @@ func (s *clientObjectService) Update(
 		return nil, fmt.Errorf("failed to get client object: %w", err)
 	}
 	if clientObject == nil {
-		return nil, fmt.Errorf("client object not found")
+		return nil, domainerr.NotFound(domainerr.CodeClientObjectNotFound, "client object not found")
 	}
 
 	// Verify it belongs to the specified client
 	if clientObject.ClientID != clientID {
-		return nil, fmt.Errorf("client object not found")
+		return nil, domainerr.NotFound(domainerr.CodeClientObjectNotFound, "client object not found")
 	}
 
 	// Check if name already exists for this client (excluding current object)
@@ -148,7 +151,9 @@ func (s *clientObjectService) Update(
 		return nil, fmt.Errorf("failed to check name existence: %w", err)
 	}
 	if exists {
-		return nil, fmt.Errorf("client object with name %s already exists for this client", req.Name)
+		return nil, domainerr.Conflict(
+			domainerr.CodeClientObjectNameExists, "client object with name %s already exists for this client", req.Name,
+		).With("name", req.Name)
 	}
 
 	// Update client object
@@ -166,18 +171,18 @@ func (s *clientObjectService) Delete(ctx context.Context, clientID, id uuid.UUID
 	// Verify client exists
 	_, err := s.clientRepo.GetByID(ctx, clientID, false)
 	if err != nil {
-		return fmt.Errorf("client not found: %w", err)
+		return fmt.Errorf("failed to get client: %w", err)
 	}
 
 	// Get existing client object
 	clientObject, err := s.clientObjectRepo.GetByID(ctx, id, false)
 	if err != nil {
-		return fmt.Errorf("client object not found: %w", err)
+		return fmt.Errorf("failed to get client object: %w", err)
 	}
 
 	// Verify the client object belongs to the specified client
 	if clientObject.ClientID != clientID {
-		return fmt.Errorf("client object not found")
+		return domainerr.NotFound(domainerr.CodeClientObjectNotFound, "client object not found")
 	}
 
 	// Check for conflicts before deletion
@@ -187,7 +192,7 @@ func (s *clientObjectService) Delete(ctx context.Context, clientID, id uuid.UUID
 	}
 
 	if conflicts.HasActiveOrders || conflicts.HasActiveEquipment {
-		return fmt.Errorf("cannot delete client object: %s", conflicts.Message)
+		return domainerr.Conflict(domainerr.CodeClientObjectInUse, "cannot delete client object: %s", conflicts.Message)
 	}
 
 	// Soft delete client object
@@ -203,18 +208,18 @@ func (s *clientObjectService) Restore(ctx context.Context, clientID, id uuid.UUI
 	// Verify client exists
 	_, err := s.clientRepo.GetByID(ctx, clientID, false)
 	if err != nil {
-		return nil, fmt.Errorf("client not found: %w", err)
+		return nil, fmt.Errorf("failed to get client: %w", err)
 	}
 
 	// Get existing client object (including deleted)
 	clientObject, err := s.clientObjectRepo.GetByID(ctx, id, true)
 	if err != nil {
-		return nil, fmt.Errorf("client object not found: %w", err)
+		return nil, fmt.Errorf("failed to get client object: %w", err)
 	}
 
 	// Verify the client object belongs to the specified client
 	if clientObject.ClientID != clientID {
-		return nil, fmt.Errorf("client object not found")
+		return nil, domainerr.NotFound(domainerr.CodeClientObjectNotFound, "client object not found")
 	}
 
 	// Check if name already exists for this client (among non-deleted objects)
@@ -223,7 +228,9 @@ func (s *clientObjectService) Restore(ctx context.Context, clientID, id uuid.UUI
 		return nil, fmt.Errorf("failed to check name existence: %w", err)
 	}
 	if exists {
-		return nil, fmt.Errorf("client object with name '%s' already exists for this client", clientObject.Name)
+		return nil, domainerr.Conflict(
+			domainerr.CodeClientObjectNameExists, "client object with name '%s' already exists for this client", clientObject.Name,
+		).With("name", clientObject.Name)
 	}
 
 	// Restore client object
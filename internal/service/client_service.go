@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"eco-van-api/internal/domainerr"
 	"eco-van-api/internal/models"
 	"eco-van-api/internal/port"
 
@@ -32,7 +33,7 @@ func (s *clientService) Create(ctx context.Context, req models.CreateClientReque
 		return nil, fmt.Errorf("failed to check client name existence: %w", err)
 	}
 	if exists {
-		return nil, fmt.Errorf("client with name '%s' already exists", req.Name)
+		return nil, domainerr.Conflict(domainerr.CodeClientNameExists, "client with name '%s' already exists", req.Name).With("name", req.Name)
 	}
 
 	// Create client from request
@@ -57,7 +58,7 @@ func (s *clientService) GetByID(ctx context.Context, id uuid.UUID) (*models.Clie
 	}
 
 	if client == nil {
-		return nil, fmt.Errorf("client not found")
+		return nil, domainerr.NotFound(domainerr.CodeClientNotFound, "client not found")
 	}
 
 	response := client.ToResponse()
@@ -95,7 +96,7 @@ func (s *clientService) Update(ctx context.Context, id uuid.UUID, req models.Upd
 	}
 
 	if client == nil {
-		return nil, fmt.Errorf("client not found")
+		return nil, domainerr.NotFound(domainerr.CodeClientNotFound, "client not found")
 	}
 
 	// Check if new name conflicts with existing client (excluding current one)
@@ -104,7 +105,7 @@ func (s *clientService) Update(ctx context.Context, id uuid.UUID, req models.Upd
 		return nil, fmt.Errorf("failed to check client name existence: %w", err)
 	}
 	if exists {
-		return nil, fmt.Errorf("client with name '%s' already exists", req.Name)
+		return nil, domainerr.Conflict(domainerr.CodeClientNameExists, "client with name '%s' already exists", req.Name).With("name", req.Name)
 	}
 
 	// Update client from request
@@ -130,7 +131,7 @@ func (s *clientService) Delete(ctx context.Context, id uuid.UUID) error {
 	}
 
 	if client == nil {
-		return fmt.Errorf("client not found")
+		return domainerr.NotFound(domainerr.CodeClientNotFound, "client not found")
 	}
 
 	// Soft delete the client
@@ -151,11 +152,11 @@ func (s *clientService) Restore(ctx context.Context, id uuid.UUID) (*models.Clie
 	}
 
 	if client == nil {
-		return nil, fmt.Errorf("client not found")
+		return nil, domainerr.NotFound(domainerr.CodeClientNotFound, "client not found")
 	}
 
 	if client.DeletedAt == nil {
-		return nil, fmt.Errorf("client is not deleted")
+		return nil, domainerr.InvalidTransition(domainerr.CodeClientNotDeleted, "client is not deleted")
 	}
 
 	// Check if name conflicts with existing active client
@@ -164,7 +165,9 @@ func (s *clientService) Restore(ctx context.Context, id uuid.UUID) (*models.Clie
 		return nil, fmt.Errorf("failed to check client name existence: %w", err)
 	}
 	if exists {
-		return nil, fmt.Errorf("cannot restore client: name '%s' is already taken by another client", client.Name)
+		return nil, domainerr.Conflict(
+			domainerr.CodeClientNameExists, "cannot restore client: name '%s' is already taken by another client", client.Name,
+		).With("name", client.Name)
 	}
 
 	// Restore the client
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/port"
+)
+
+// dispatchService implements port.DispatchService
+type dispatchService struct {
+	dispatchRepo port.DispatchRepository
+}
+
+// NewDispatchService creates a new dispatch board service
+func NewDispatchService(dispatchRepo port.DispatchRepository) port.DispatchService {
+	return &dispatchService{
+		dispatchRepo: dispatchRepo,
+	}
+}
+
+// GetBoard returns every transport with its crew, equipment and orders for the date, plus unassigned orders
+func (s *dispatchService) GetBoard(ctx context.Context, date time.Time) (*models.DispatchBoard, error) {
+	transports, err := s.dispatchRepo.ListTransports(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transport: %w", err)
+	}
+
+	orders, err := s.dispatchRepo.ListDayOrders(ctx, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orders: %w", err)
+	}
+
+	return models.NewDispatchBoard(date.Format("2006-01-02"), transports, orders), nil
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"eco-van-api/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockDispatchRepository is a mock implementation of port.DispatchRepository
+type MockDispatchRepository struct {
+	mock.Mock
+}
+
+func (m *MockDispatchRepository) ListTransports(ctx context.Context) ([]models.DispatchTransport, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.DispatchTransport), args.Error(1)
+}
+
+func (m *MockDispatchRepository) ListDayOrders(ctx context.Context, date time.Time) ([]models.DispatchOrder, error) {
+	args := m.Called(ctx, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.DispatchOrder), args.Error(1)
+}
+
+func TestDispatchService_GetBoard(t *testing.T) {
+	ctx := context.Background()
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	truckA := uuid.New()
+	truckB := uuid.New()
+	deletedTruck := uuid.New()
+
+	t.Run("groups orders by transport", func(t *testing.T) {
+		repo := new(MockDispatchRepository)
+		svc := NewDispatchService(repo)
+
+		transports := []models.DispatchTransport{
+			{ID: truckA, PlateNo: "A001AA", Driver: &models.DispatchDriver{ID: uuid.New(), FullName: "Ivan"}},
+			{ID: truckB, PlateNo: "B002BB"},
+		}
+		first := models.DispatchOrder{ID: uuid.New(), TransportID: &truckA}
+		second := models.DispatchOrder{ID: uuid.New(), TransportID: &truckA}
+		unassigned := models.DispatchOrder{ID: uuid.New()}
+		orphaned := models.DispatchOrder{ID: uuid.New(), TransportID: &deletedTruck}
+
+		repo.On("ListTransports", ctx).Return(transports, nil)
+		repo.On("ListDayOrders", ctx, date).Return([]models.DispatchOrder{first, second, unassigned, orphaned}, nil)
+
+		board, err := svc.GetBoard(ctx, date)
+
+		require.NoError(t, err)
+		assert.Equal(t, "2024-03-15", board.Date)
+		require.Len(t, board.Transports, 2)
+		assert.Equal(t, []uuid.UUID{first.ID, second.ID}, dispatchOrderIDs(board.Transports[0].Orders))
+		assert.Equal(t, "Ivan", board.Transports[0].Driver.FullName)
+		assert.NotNil(t, board.Transports[1].Orders)
+		assert.Empty(t, board.Transports[1].Orders)
+		assert.Equal(t, []uuid.UUID{unassigned.ID, orphaned.ID}, dispatchOrderIDs(board.Unassigned))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := new(MockDispatchRepository)
+		svc := NewDispatchService(repo)
+
+		repo.On("ListTransports", ctx).Return(nil, errors.New("db down"))
+
+		board, err := svc.GetBoard(ctx, date)
+
+		assert.Error(t, err)
+		assert.Nil(t, board)
+		repo.AssertNotCalled(t, "ListDayOrders", mock.Anything, mock.Anything)
+	})
+}
+
+func dispatchOrderIDs(orders []models.DispatchOrder) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(orders))
+	for _, o := range orders {
+		ids = append(ids, o.ID)
+	}
+	return ids
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"eco-van-api/internal/domainerr"
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/port"
+
+	"github.com/google/uuid"
+)
+
+// maxDriverDayOrders bounds the orders listed for a transport and day
+const maxDriverDayOrders = 100
+
+// driverSelfService implements port.DriverSelfService
+type driverSelfService struct {
+	driverRepo    port.DriverRepository
+	transportRepo port.TransportRepository
+	orderService  port.OrderService
+}
+
+// NewDriverSelfService creates a new driver self-service
+func NewDriverSelfService(
+	driverRepo port.DriverRepository, transportRepo port.TransportRepository, orderService port.OrderService,
+) port.DriverSelfService {
+	return &driverSelfService{
+		driverRepo:    driverRepo,
+		transportRepo: transportRepo,
+		orderService:  orderService,
+	}
+}
+
+// GetProfile returns the driver linked to the user and the transport the driver is assigned to
+func (s *driverSelfService) GetProfile(ctx context.Context, userID uuid.UUID) (*models.DriverSelfResponse, error) {
+	driver, transport, err := s.resolve(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.DriverSelfResponse{Driver: driver.ToResponse()}
+	if transport != nil {
+		transportResponse := transport.ToResponse()
+		response.Transport = &transportResponse
+	}
+	return response, nil
+}
+
+// ListOrders returns the non-canceled orders of the driver's transport for a date, earliest window first
+func (s *driverSelfService) ListOrders(ctx context.Context, userID uuid.UUID, date time.Time) (*models.DriverOrdersResponse, error) {
+	_, transport, err := s.resolve(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.DriverOrdersResponse{
+		Date:  date.Format("2006-01-02"),
+		Items: make([]models.OrderResponse, 0),
+	}
+	if transport == nil {
+		return response, nil
+	}
+	response.TransportID = &transport.ID
+
+	orders, err := s.orderService.List(ctx, models.OrderListRequest{
+		Page:        1,
+		PageSize:    maxDriverDayOrders,
+		Date:        &date,
+		TransportID: &transport.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, order := range orders.Items {
+		if order.Status != string(models.OrderStatusCanceled) {
+			response.Items = append(response.Items, order)
+		}
+	}
+	sort.SliceStable(response.Items, func(i, j int) bool {
+		return windowSortKey(response.Items[i]) < windowSortKey(response.Items[j])
+	})
+
+	return response, nil
+}
+
+// StartOrder moves an order of the driver's transport to IN_PROGRESS
+func (s *driverSelfService) StartOrder(ctx context.Context, userID, orderID uuid.UUID) (*models.OrderResponse, error) {
+	if err := s.checkOwnOrder(ctx, userID, orderID); err != nil {
+		return nil, err
+	}
+
+	return s.orderService.UpdateStatus(ctx, orderID, models.UpdateOrderStatusRequest{
+		Status: models.OrderStatusInProgress,
+	}, &userID, nil)
+}
+
+// CompleteOrder moves an order of the driver's transport to COMPLETED with the collected amounts
+func (s *driverSelfService) CompleteOrder(
+	ctx context.Context, userID, orderID uuid.UUID, completion models.OrderCompletionRequest,
+) (*models.OrderResponse, error) {
+	if err := s.checkOwnOrder(ctx, userID, orderID); err != nil {
+		return nil, err
+	}
+
+	return s.orderService.UpdateStatus(ctx, orderID, models.UpdateOrderStatusRequest{
+		Status:     models.OrderStatusCompleted,
+		Completion: &completion,
+	}, &userID, nil)
+}
+
+// resolve loads the driver linked to the user and the transport the driver is assigned to, if any
+func (s *driverSelfService) resolve(ctx context.Context, userID uuid.UUID) (*models.Driver, *models.Transport, error) {
+	driver, err := s.driverRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get driver: %w", err)
+	}
+	if driver == nil {
+		return nil, nil, domainerr.Forbidden(domainerr.CodeDriverNotLinked, "forbidden: user is not linked to a driver")
+	}
+
+	transport, err := s.transportRepo.GetByDriverID(ctx, driver.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get transport: %w", err)
+	}
+
+	return driver, transport, nil
+}
+
+// checkOwnOrder ensures the order is assigned to the transport the user's driver currently drives
+func (s *driverSelfService) checkOwnOrder(ctx context.Context, userID, orderID uuid.UUID) error {
+	_, transport, err := s.resolve(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if transport == nil {
+		return domainerr.Forbidden(domainerr.CodeDriverWithoutTransport, "forbidden: driver is not assigned to a transport")
+	}
+
+	order, err := s.orderService.GetByID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	if order.TransportID == nil || *order.TransportID != transport.ID {
+		return domainerr.Forbidden(domainerr.CodeOrderNotOnDriverTransport, "forbidden: order is not assigned to your transport")
+	}
+
+	return nil
+}
+
+// windowSortKey orders by window start with orders without a window last
+func windowSortKey(order models.OrderResponse) string {
+	if order.ScheduledWindowFrom == nil {
+		return "~"
+	}
+	return *order.ScheduledWindowFrom
+}
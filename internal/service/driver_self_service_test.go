@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eco-van-api/internal/domainerr"
+	"eco-van-api/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDriverSelfService(
+	driverRepo *MockDriverRepository, transportRepo *MockTransportRepository, orderRepo *MockOrderRepository,
+) *driverSelfService {
+	orderSvc := NewOrderService(orderRepo, new(MockClientRepository), new(MockClientObjectRepository),
+		transportRepo, new(MockCancellationReasonRepository))
+	return NewDriverSelfService(driverRepo, transportRepo, orderSvc).(*driverSelfService)
+}
+
+func TestDriverSelfService_StartOrder(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	driver := &models.Driver{ID: uuid.New(), FullName: "John Doe", UserID: &userID}
+	transport := &models.Transport{ID: uuid.New(), CurrentDriverID: &driver.ID}
+
+	t.Run("starts own order", func(t *testing.T) {
+		driverRepo, transportRepo, orderRepo := new(MockDriverRepository), new(MockTransportRepository), new(MockOrderRepository)
+		svc := newTestDriverSelfService(driverRepo, transportRepo, orderRepo)
+		order := newTestOrder(models.OrderStatusScheduled)
+		order.TransportID = &transport.ID
+
+		driverRepo.On("GetByUserID", ctx, userID).Return(driver, nil)
+		transportRepo.On("GetByDriverID", ctx, driver.ID).Return(transport, nil)
+		orderRepo.On("GetByID", ctx, order.ID, false).Return(order, nil)
+		orderRepo.On("UpdateStatus", ctx, order, mock.MatchedBy(func(entry *models.OrderStatusHistory) bool {
+			return entry.ToStatus == models.OrderStatusInProgress && entry.ChangedBy != nil && *entry.ChangedBy == userID
+		}), []models.EquipmentMove(nil)).Run(func(args mock.Arguments) {
+			args.Get(1).(*models.Order).Status = string(models.OrderStatusInProgress)
+		}).Return(nil)
+
+		result, err := svc.StartOrder(ctx, userID, order.ID)
+
+		require.NoError(t, err)
+		assert.Equal(t, string(models.OrderStatusInProgress), result.Status)
+		orderRepo.AssertExpectations(t)
+	})
+
+	t.Run("order of another transport is forbidden", func(t *testing.T) {
+		driverRepo, transportRepo, orderRepo := new(MockDriverRepository), new(MockTransportRepository), new(MockOrderRepository)
+		svc := newTestDriverSelfService(driverRepo, transportRepo, orderRepo)
+		otherTransportID := uuid.New()
+		order := newTestOrder(models.OrderStatusScheduled)
+		order.TransportID = &otherTransportID
+
+		driverRepo.On("GetByUserID", ctx, userID).Return(driver, nil)
+		transportRepo.On("GetByDriverID", ctx, driver.ID).Return(transport, nil)
+		orderRepo.On("GetByID", ctx, order.ID, false).Return(order, nil)
+
+		result, err := svc.StartOrder(ctx, userID, order.ID)
+
+		assert.EqualError(t, err, "forbidden: order is not assigned to your transport")
+		assert.True(t, domainerr.IsKind(err, domainerr.KindForbidden))
+		assert.Nil(t, result)
+		orderRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("user without driver link is forbidden", func(t *testing.T) {
+		driverRepo, transportRepo, orderRepo := new(MockDriverRepository), new(MockTransportRepository), new(MockOrderRepository)
+		svc := newTestDriverSelfService(driverRepo, transportRepo, orderRepo)
+
+		driverRepo.On("GetByUserID", ctx, userID).Return(nil, nil)
+
+		result, err := svc.StartOrder(ctx, userID, uuid.New())
+
+		assert.EqualError(t, err, "forbidden: user is not linked to a driver")
+		assert.Nil(t, result)
+	})
+
+	t.Run("driver without transport is forbidden", func(t *testing.T) {
+		driverRepo, transportRepo, orderRepo := new(MockDriverRepository), new(MockTransportRepository), new(MockOrderRepository)
+		svc := newTestDriverSelfService(driverRepo, transportRepo, orderRepo)
+
+		driverRepo.On("GetByUserID", ctx, userID).Return(driver, nil)
+		transportRepo.On("GetByDriverID", ctx, driver.ID).Return(nil, nil)
+
+		result, err := svc.CompleteOrder(ctx, userID, uuid.New(), *newTestCompletion())
+
+		assert.EqualError(t, err, "forbidden: driver is not assigned to a transport")
+		assert.Nil(t, result)
+	})
+}
+
+func TestDriverSelfService_ListOrders(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	driver := &models.Driver{ID: uuid.New(), FullName: "John Doe", UserID: &userID}
+	transport := &models.Transport{ID: uuid.New(), CurrentDriverID: &driver.ID}
+
+	driverRepo, transportRepo, orderRepo := new(MockDriverRepository), new(MockTransportRepository), new(MockOrderRepository)
+	svc := newTestDriverSelfService(driverRepo, transportRepo, orderRepo)
+
+	late, early := "14:00", "08:00"
+	noWindow := models.OrderResponse{ID: uuid.New(), Status: string(models.OrderStatusScheduled)}
+	afternoon := models.OrderResponse{ID: uuid.New(), Status: string(models.OrderStatusScheduled), ScheduledWindowFrom: &late}
+	morning := models.OrderResponse{ID: uuid.New(), Status: string(models.OrderStatusInProgress), ScheduledWindowFrom: &early}
+	canceled := models.OrderResponse{ID: uuid.New(), Status: string(models.OrderStatusCanceled), ScheduledWindowFrom: &early}
+
+	driverRepo.On("GetByUserID", ctx, userID).Return(driver, nil)
+	transportRepo.On("GetByDriverID", ctx, driver.ID).Return(transport, nil)
+	orderRepo.On("List", ctx, mock.MatchedBy(func(req models.OrderListRequest) bool {
+		return req.TransportID != nil && *req.TransportID == transport.ID && req.Date != nil && req.Date.Equal(date)
+	})).Return(&models.OrderListResponse{
+		Items: []models.OrderResponse{noWindow, afternoon, canceled, morning},
+	}, nil)
+
+	result, err := svc.ListOrders(ctx, userID, date)
+
+	require.NoError(t, err)
+	assert.Equal(t, "2024-03-15", result.Date)
+	assert.Equal(t, &transport.ID, result.TransportID)
+	require.Len(t, result.Items, 3)
+	assert.Equal(t, morning.ID, result.Items[0].ID)
+	assert.Equal(t, afternoon.ID, result.Items[1].ID)
+	assert.Equal(t, noWindow.ID, result.Items[2].ID)
+}
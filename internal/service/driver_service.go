@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"eco-van-api/internal/domainerr"
 	"eco-van-api/internal/models"
 	"eco-van-api/internal/port"
 	"fmt"
@@ -12,11 +13,12 @@ import (
 
 type driverService struct {
 	driverRepo port.DriverRepository
+	userRepo   port.UserRepository
 }
 
 // NewDriverService creates a new driver service
-func NewDriverService(driverRepo port.DriverRepository) port.DriverService {
-	return &driverService{driverRepo: driverRepo}
+func NewDriverService(driverRepo port.DriverRepository, userRepo port.UserRepository) port.DriverService {
+	return &driverService{driverRepo: driverRepo, userRepo: userRepo}
 }
 
 // Create creates a new driver with validation
@@ -28,7 +30,8 @@ func (s *driverService) Create(ctx context.Context, req models.CreateDriverReque
 			return nil, fmt.Errorf("failed to check driver license existence: %w", err)
 		}
 		if exists {
-			return nil, fmt.Errorf("driver with license number '%s' already exists", *req.LicenseNo)
+			return nil, domainerr.Conflict(domainerr.CodeDriverLicenseExists, "driver with license number '%s' already exists", *req.LicenseNo).
+				With("licenseNo", *req.LicenseNo)
 		}
 	}
 
@@ -53,7 +56,7 @@ func (s *driverService) GetByID(ctx context.Context, id uuid.UUID) (*models.Driv
 	}
 
 	if driver == nil {
-		return nil, fmt.Errorf("driver not found")
+		return nil, domainerr.NotFound(domainerr.CodeDriverNotFound, "driver not found")
 	}
 
 	// Return response
@@ -80,7 +83,7 @@ func (s *driverService) Update(ctx context.Context, id uuid.UUID, req models.Upd
 	}
 
 	if driver == nil {
-		return nil, fmt.Errorf("driver not found")
+		return nil, domainerr.NotFound(domainerr.CodeDriverNotFound, "driver not found")
 	}
 
 	// Check if license number already exists (if being changed)
@@ -90,7 +93,8 @@ func (s *driverService) Update(ctx context.Context, id uuid.UUID, req models.Upd
 			return nil, fmt.Errorf("failed to check driver license existence: %w", err)
 		}
 		if exists {
-			return nil, fmt.Errorf("driver with license number '%s' already exists", *req.LicenseNo)
+			return nil, domainerr.Conflict(domainerr.CodeDriverLicenseExists, "driver with license number '%s' already exists", *req.LicenseNo).
+				With("licenseNo", *req.LicenseNo)
 		}
 	}
 
@@ -116,7 +120,7 @@ func (s *driverService) Delete(ctx context.Context, id uuid.UUID) error {
 	}
 
 	if isAssigned {
-		return fmt.Errorf("cannot delete driver while assigned to transport")
+		return domainerr.Conflict(domainerr.CodeDriverAssigned, "cannot delete driver while assigned to transport")
 	}
 
 	// Soft delete driver
@@ -136,11 +140,11 @@ func (s *driverService) Restore(ctx context.Context, id uuid.UUID) (*models.Driv
 	}
 
 	if driver == nil {
-		return nil, fmt.Errorf("driver not found")
+		return nil, domainerr.NotFound(domainerr.CodeDriverNotFound, "driver not found")
 	}
 
 	if driver.DeletedAt == nil {
-		return nil, fmt.Errorf("driver is not soft-deleted")
+		return nil, domainerr.InvalidTransition(domainerr.CodeDriverNotDeleted, "driver is not soft-deleted")
 	}
 
 	// Restore driver
@@ -168,3 +172,64 @@ func (s *driverService) ListAvailable(ctx context.Context, req models.DriverList
 
 	return response, nil
 }
+
+// LinkUser links a DRIVER user account to a driver so the user can use the driver self-service API
+func (s *driverService) LinkUser(ctx context.Context, id uuid.UUID, req models.LinkDriverUserRequest) (*models.DriverResponse, error) {
+	driver, err := s.driverRepo.GetByID(ctx, id, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get driver: %w", err)
+	}
+	if driver == nil {
+		return nil, domainerr.NotFound(domainerr.CodeDriverNotFound, "driver not found")
+	}
+
+	user, err := s.userRepo.Get(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return nil, domainerr.Validation(domainerr.CodeUserNotFound, "validation failed").
+			Wrap(domainerr.InvalidField("/userId", "exists", "user not found"))
+	}
+	if user.Role != models.UserRoleDriver {
+		return nil, domainerr.Validation(domainerr.CodeDriverUserRoleRequired, "validation failed").
+			Wrap(domainerr.InvalidField("/userId", "role", "user must have the %s role", models.UserRoleDriver).
+				WithMessageID("role", string(models.UserRoleDriver))).
+			With("role", models.UserRoleDriver)
+	}
+
+	linked, err := s.driverRepo.GetByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check user driver link: %w", err)
+	}
+	if linked != nil && linked.ID != id {
+		return nil, domainerr.Conflict(domainerr.CodeDriverUserAlreadyLinked, "user is already linked to another driver")
+	}
+
+	if err := s.driverRepo.SetUser(ctx, id, &req.UserID); err != nil {
+		return nil, fmt.Errorf("failed to link user: %w", err)
+	}
+
+	driver.UserID = &req.UserID
+	response := driver.ToResponse()
+	return &response, nil
+}
+
+// UnlinkUser removes the user account link of a driver
+func (s *driverService) UnlinkUser(ctx context.Context, id uuid.UUID) (*models.DriverResponse, error) {
+	driver, err := s.driverRepo.GetByID(ctx, id, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get driver: %w", err)
+	}
+	if driver == nil {
+		return nil, domainerr.NotFound(domainerr.CodeDriverNotFound, "driver not found")
+	}
+
+	if err := s.driverRepo.SetUser(ctx, id, nil); err != nil {
+		return nil, fmt.Errorf("failed to unlink user: %w", err)
+	}
+
+	driver.UserID = nil
+	response := driver.ToResponse()
+	return &response, nil
+}
@@ -70,6 +70,84 @@ func (m *MockDriverRepository) ListAvailable(ctx context.Context, req models.Dri
 	return args.Get(0).(*models.DriverListResponse), args.Error(1)
 }
 
+func (m *MockDriverRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*models.Driver, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Driver), args.Error(1)
+}
+
+func (m *MockDriverRepository) SetUser(ctx context.Context, driverID uuid.UUID, userID *uuid.UUID) error {
+	args := m.Called(ctx, driverID, userID)
+	return args.Error(0)
+}
+
+// MockUserRepository is a mock implementation of UserRepository
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) Create(ctx context.Context, email, passwordHash, role string) (*models.User, error) {
+	args := m.Called(ctx, email, passwordHash, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) Get(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) List(ctx context.Context, page, pageSize int) ([]*models.User, int, error) {
+	args := m.Called(ctx, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*models.User), args.Int(1), args.Error(2)
+}
+
+func (m *MockUserRepository) Update(ctx context.Context, user *models.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string, mustChange bool) error {
+	args := m.Called(ctx, id, passwordHash, mustChange)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) ExistsByEmail(ctx context.Context, email string, excludeID *uuid.UUID) (bool, error) {
+	args := m.Called(ctx, email, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
 func TestDriverService_Create(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -113,7 +191,7 @@ func TestDriverService_Create(t *testing.T) {
 			mockRepo := &MockDriverRepository{}
 			tt.setupMock(mockRepo)
 
-			service := NewDriverService(mockRepo)
+			service := NewDriverService(mockRepo, new(MockUserRepository))
 			result, err := service.Create(context.Background(), tt.req)
 
 			if tt.expectError {
@@ -208,7 +286,7 @@ func TestDriverService_Update(t *testing.T) {
 			mockRepo := &MockDriverRepository{}
 			tt.setupMock(mockRepo)
 
-			service := NewDriverService(mockRepo)
+			service := NewDriverService(mockRepo, new(MockUserRepository))
 			result, err := service.Update(context.Background(), tt.id, tt.req)
 
 			if tt.expectError {
@@ -258,7 +336,7 @@ func TestDriverService_Delete(t *testing.T) {
 			mockRepo := &MockDriverRepository{}
 			tt.setupMock(mockRepo)
 
-			service := NewDriverService(mockRepo)
+			service := NewDriverService(mockRepo, new(MockUserRepository))
 			err := service.Delete(context.Background(), tt.id)
 
 			if tt.expectError {
@@ -322,7 +400,7 @@ func TestDriverService_Restore(t *testing.T) {
 			mockRepo := &MockDriverRepository{}
 			tt.setupMock(mockRepo)
 
-			service := NewDriverService(mockRepo)
+			service := NewDriverService(mockRepo, new(MockUserRepository))
 			result, err := service.Restore(context.Background(), tt.id)
 
 			if tt.expectError {
@@ -338,3 +416,73 @@ func TestDriverService_Restore(t *testing.T) {
 		})
 	}
 }
+
+func TestDriverService_LinkUser(t *testing.T) {
+	ctx := context.Background()
+	driverID := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name          string
+		user          *models.User
+		linkedDriver  *models.Driver
+		expectError   bool
+		expectedError string
+	}{
+		{
+			name: "links driver user",
+			user: &models.User{ID: userID, Role: models.UserRoleDriver},
+		},
+		{
+			name:          "user not found",
+			expectError:   true,
+			expectedError: "validation failed: user not found",
+		},
+		{
+			name:          "user without driver role",
+			user:          &models.User{ID: userID, Role: models.UserRoleDispatcher},
+			expectError:   true,
+			expectedError: "validation failed: user must have the DRIVER role",
+		},
+		{
+			name:          "user linked to another driver",
+			user:          &models.User{ID: userID, Role: models.UserRoleDriver},
+			linkedDriver:  &models.Driver{ID: uuid.New()},
+			expectError:   true,
+			expectedError: "user is already linked to another driver",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			driverRepo := new(MockDriverRepository)
+			userRepo := new(MockUserRepository)
+			svc := NewDriverService(driverRepo, userRepo)
+
+			driverRepo.On("GetByID", ctx, driverID, false).Return(&models.Driver{ID: driverID, FullName: "John Doe"}, nil)
+			if tt.user != nil {
+				userRepo.On("Get", ctx, userID).Return(tt.user, nil)
+			} else {
+				userRepo.On("Get", ctx, userID).Return(nil, nil)
+			}
+			if tt.linkedDriver != nil {
+				driverRepo.On("GetByUserID", ctx, userID).Return(tt.linkedDriver, nil)
+			} else {
+				driverRepo.On("GetByUserID", ctx, userID).Return(nil, nil)
+			}
+			driverRepo.On("SetUser", ctx, driverID, &userID).Return(nil)
+
+			result, err := svc.LinkUser(ctx, driverID, models.LinkDriverUserRequest{UserID: userID})
+
+			if tt.expectError {
+				assert.EqualError(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				driverRepo.AssertNotCalled(t, "SetUser", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, &userID, result.UserID)
+			driverRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"eco-van-api/internal/domainerr"
 	"eco-van-api/internal/models"
 	"eco-van-api/internal/port"
 
@@ -29,7 +30,7 @@ func NewEquipmentService(equipmentRepo port.EquipmentRepository) port.EquipmentS
 func (s *equipmentService) Create(ctx context.Context, req models.CreateEquipmentRequest) (*models.EquipmentResponse, error) {
 	// Validate placement (exactly one of client_object_id or warehouse_id)
 	if err := req.ValidatePlacement(); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+		return nil, domainerr.Validation(domainerr.CodeEquipmentInvalidPlacement, "validation failed").Wrap(err)
 	}
 
 	// Check if number already exists (if provided)
@@ -39,7 +40,8 @@ func (s *equipmentService) Create(ctx context.Context, req models.CreateEquipmen
 			return nil, fmt.Errorf("failed to check equipment number existence: %w", err)
 		}
 		if exists {
-			return nil, fmt.Errorf("equipment with number '%s' already exists", *req.Number)
+			return nil, domainerr.Conflict(domainerr.CodeEquipmentNumberExists, "equipment with number '%s' already exists", *req.Number).
+				With("number", *req.Number)
 		}
 	}
 
@@ -66,7 +68,7 @@ func (s *equipmentService) GetByID(ctx context.Context, id uuid.UUID) (*models.E
 	}
 
 	if equipment == nil {
-		return nil, fmt.Errorf("equipment not found")
+		return nil, domainerr.NotFound(domainerr.CodeEquipmentNotFound, "equipment not found")
 	}
 
 	response := equipment.ToResponse()
@@ -102,7 +104,7 @@ func (s *equipmentService) Update(ctx context.Context, id uuid.UUID, req models.
 	}
 
 	if equipment == nil {
-		return nil, fmt.Errorf("equipment not found")
+		return nil, domainerr.NotFound(domainerr.CodeEquipmentNotFound, "equipment not found")
 	}
 
 	// Validate update request
@@ -146,7 +148,7 @@ func (s *equipmentService) validateUpdateRequest(
 
 	// If placement is being changed and equipment is attached to transport, reject
 	if isAttached && s.isPlacementChanging(req, equipment) {
-		return fmt.Errorf("cannot change equipment placement while attached to transport")
+		return domainerr.Conflict(domainerr.CodeEquipmentAttachedToTransport, "cannot change equipment placement while attached to transport")
 	}
 
 	// Check if number already exists (if being changed)
@@ -156,7 +158,8 @@ func (s *equipmentService) validateUpdateRequest(
 			return fmt.Errorf("failed to check equipment number existence: %w", err)
 		}
 		if exists {
-			return fmt.Errorf("equipment with number '%s' already exists", *req.Number)
+			return domainerr.Conflict(domainerr.CodeEquipmentNumberExists, "equipment with number '%s' already exists", *req.Number).
+				With("number", *req.Number)
 		}
 	}
 
@@ -183,7 +186,7 @@ func (s *equipmentService) validatePlacementUpdate(req models.UpdateEquipmentReq
 
 	// Placement was specified, ensure it's valid
 	if err := req.ValidatePlacement(); err != nil {
-		return fmt.Errorf("validation failed: %w", err)
+		return domainerr.Validation(domainerr.CodeEquipmentInvalidPlacement, "validation failed").Wrap(err)
 	}
 	return nil
 }
@@ -199,7 +202,7 @@ func (s *equipmentService) Delete(ctx context.Context, id uuid.UUID) error {
 	}
 
 	if equipment == nil {
-		return fmt.Errorf("equipment not found")
+		return domainerr.NotFound(domainerr.CodeEquipmentNotFound, "equipment not found")
 	}
 
 	// Check if equipment is attached to transport
@@ -209,7 +212,7 @@ func (s *equipmentService) Delete(ctx context.Context, id uuid.UUID) error {
 	}
 
 	if isAttached {
-		return fmt.Errorf("cannot delete equipment while attached to transport")
+		return domainerr.Conflict(domainerr.CodeEquipmentAttachedToTransport, "cannot delete equipment while attached to transport")
 	}
 
 	// Soft delete equipment
@@ -229,11 +232,11 @@ func (s *equipmentService) Restore(ctx context.Context, id uuid.UUID) (*models.E
 	}
 
 	if equipment == nil {
-		return nil, fmt.Errorf("equipment not found")
+		return nil, domainerr.NotFound(domainerr.CodeEquipmentNotFound, "equipment not found")
 	}
 
 	if equipment.DeletedAt == nil {
-		return nil, fmt.Errorf("equipment is not deleted")
+		return nil, domainerr.InvalidTransition(domainerr.CodeEquipmentNotDeleted, "equipment is not deleted")
 	}
 
 	// Check if number conflicts with existing equipment
@@ -243,7 +246,9 @@ func (s *equipmentService) Restore(ctx context.Context, id uuid.UUID) (*models.E
 			return nil, fmt.Errorf("failed to check equipment number existence: %w", err)
 		}
 		if exists {
-			return nil, fmt.Errorf("cannot restore equipment: number '%s' conflicts with existing equipment", *equipment.Number)
+			return nil, domainerr.Conflict(
+				domainerr.CodeEquipmentNumberExists, "cannot restore equipment: number '%s' conflicts with existing equipment", *equipment.Number,
+			).With("number", *equipment.Number)
 		}
 	}
 
@@ -258,8 +263,9 @@ func (s *equipmentService) Restore(ctx context.Context, id uuid.UUID) (*models.E
 		return nil, fmt.Errorf("failed to get restored equipment: %w", err)
 	}
 
+	// Deleted again before it could be read back
 	if restoredEquipment == nil {
-		return nil, fmt.Errorf("failed to get restored equipment")
+		return nil, domainerr.NotFound(domainerr.CodeEquipmentNotFound, "equipment not found")
 	}
 
 	// Return response
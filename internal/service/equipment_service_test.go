@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"eco-van-api/internal/domainerr"
 	"eco-van-api/internal/models"
 
 	"github.com/google/uuid"
@@ -55,7 +56,7 @@ func (m *MockEquipmentRepository) SoftDelete(ctx context.Context, id uuid.UUID)
 
 func (m *MockEquipmentRepository) Restore(ctx context.Context, id uuid.UUID) error {
 	args := m.Called(ctx, id)
-	return args.Error(1)
+	return args.Error(0)
 }
 
 func (m *MockEquipmentRepository) IsAttachedToTransport(ctx context.Context, equipmentID uuid.UUID) (bool, error) {
@@ -345,3 +346,17 @@ func TestEquipmentService_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestEquipmentService_Restore_DeletedAgain(t *testing.T) {
+	equipmentID := uuid.New()
+	deletedAt := time.Now()
+	mockRepo := &MockEquipmentRepository{}
+	mockRepo.On("GetByID", mock.Anything, equipmentID, true).Return(&models.Equipment{ID: equipmentID, DeletedAt: &deletedAt}, nil)
+	mockRepo.On("Restore", mock.Anything, equipmentID).Return(nil)
+	mockRepo.On("GetByID", mock.Anything, equipmentID, false).Return(nil, nil)
+
+	_, err := NewEquipmentService(mockRepo).Restore(context.Background(), equipmentID)
+
+	assert.True(t, domainerr.HasCode(err, domainerr.CodeEquipmentNotFound))
+	mockRepo.AssertExpectations(t)
+}
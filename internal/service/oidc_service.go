@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"eco-van-api/internal/adapter/auth"
+	"eco-van-api/internal/domainerr"
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/port"
+)
+
+// OIDCSettings holds the configurable behaviour of single sign-on
+type OIDCSettings struct {
+	// Roles maps identity provider group or role claim values to user roles
+	Roles models.OIDCRoleMapping
+	// DefaultRole is given when no claim value is mapped; empty rejects such users
+	DefaultRole models.UserRole
+	// StateTTL is how long a started login can be completed
+	StateTTL time.Duration
+}
+
+// OIDCService implements OpenID Connect single sign-on with the authorization code flow and PKCE.
+// Users are created on their first login and their role follows the identity provider groups.
+type OIDCService struct {
+	provider    port.OIDCProvider
+	oidcRepo    port.OIDCRepository
+	userRepo    port.UserRepository
+	authService *AuthService
+	settings    OIDCSettings
+}
+
+// NewOIDCService creates a new single sign-on service; sessions are issued by the authentication service
+func NewOIDCService(
+	provider port.OIDCProvider, oidcRepo port.OIDCRepository, userRepo port.UserRepository,
+	authService *AuthService, settings OIDCSettings,
+) *OIDCService {
+	return &OIDCService{
+		provider:    provider,
+		oidcRepo:    oidcRepo,
+		userRepo:    userRepo,
+		authService: authService,
+		settings:    settings,
+	}
+}
+
+// Authorize starts a login and returns the identity provider URL to send the user to
+func (s *OIDCService) Authorize(ctx context.Context) (*models.OIDCAuthorizeResponse, error) {
+	state, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	codeVerifier, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	loginState := &models.OIDCLoginState{
+		StateHash:    auth.HashOpaqueToken(state),
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		ExpiresAt:    time.Now().Add(s.settings.StateTTL),
+	}
+	if err := s.oidcRepo.CreateLoginState(ctx, loginState); err != nil {
+		return nil, fmt.Errorf("failed to store OIDC login state: %w", err)
+	}
+
+	challenge := sha256.Sum256([]byte(codeVerifier))
+	authorizationURL, err := s.provider.AuthCodeURL(ctx, state, nonce, base64.RawURLEncoding.EncodeToString(challenge[:]))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build authorization URL: %w", err)
+	}
+
+	return &models.OIDCAuthorizeResponse{AuthorizationURL: authorizationURL, State: state}, nil
+}
+
+// Callback completes a login: the code is redeemed for a verified ID token, whose claims select
+// or create the user and determine their role, and a session is started
+func (s *OIDCService) Callback(
+	ctx context.Context, req *models.OIDCCallbackRequest, client models.ClientInfo,
+) (*models.AuthResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, domainerr.Validation(domainerr.CodeValidationFailed, "invalid OIDC callback request").Wrap(err)
+	}
+
+	loginState, err := s.oidcRepo.ConsumeLoginState(ctx, auth.HashOpaqueToken(req.State), time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get OIDC login state: %w", err)
+	}
+	if loginState == nil {
+		return nil, domainerr.Unauthorized(domainerr.CodeInvalidOIDCLogin, "invalid OIDC login: unknown or expired state")
+	}
+
+	claims, err := s.provider.Exchange(ctx, req.Code, loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		// The provider error can carry the IdP response body; it is kept as the cause for the
+		// server log only, the client sees the stable message
+		return nil, domainerr.Unauthorized(domainerr.CodeInvalidOIDCLogin, "invalid OIDC login").Wrap(err)
+	}
+
+	role, ok := s.settings.Roles.Resolve(claims.Groups)
+	if !ok {
+		if s.settings.DefaultRole == "" {
+			return nil, domainerr.Forbidden(
+				domainerr.CodeOIDCRoleNotMapped, "forbidden: no role is mapped to the identity provider groups of %s", claims.Email,
+			)
+		}
+		role = s.settings.DefaultRole
+	}
+
+	user, err := s.resolveUser(ctx, claims, role)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.authService.StartSession(ctx, user, client)
+}
+
+// resolveUser finds the user linked to the identity, links an existing account with the same verified email
+// or creates a new one, and keeps the user's role in sync with the identity provider
+func (s *OIDCService) resolveUser(ctx context.Context, claims *models.OIDCClaims, role models.UserRole) (*models.User, error) {
+	identity, err := s.oidcRepo.GetIdentity(ctx, claims.Issuer, claims.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user identity: %w", err)
+	}
+
+	var user *models.User
+	if identity != nil {
+		if user, err = s.userRepo.Get(ctx, identity.UserID); err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+	} else {
+		if user, err = s.linkUser(ctx, claims, role); err != nil {
+			return nil, err
+		}
+	}
+
+	if user.Role != role {
+		user.Role = role
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return nil, fmt.Errorf("failed to update user role: %w", err)
+		}
+	}
+
+	return user, nil
+}
+
+// linkUser links the identity to the account with the same email or creates the account on first login
+func (s *OIDCService) linkUser(ctx context.Context, claims *models.OIDCClaims, role models.UserRole) (*models.User, error) {
+	email := strings.TrimSpace(claims.Email)
+	if email == "" {
+		return nil, domainerr.Unauthorized(domainerr.CodeInvalidOIDCLogin, "invalid OIDC login: the identity provider did not return an email")
+	}
+
+	exists, err := s.userRepo.ExistsByEmail(ctx, email, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check email uniqueness: %w", err)
+	}
+
+	var user *models.User
+	if exists {
+		// Only a provider-verified email proves ownership of an existing account
+		if !claims.EmailVerified {
+			return nil, domainerr.Unauthorized(
+				domainerr.CodeInvalidOIDCLogin, "invalid OIDC login: email %s is not verified by the identity provider", email,
+			)
+		}
+		if user, err = s.userRepo.GetByEmail(ctx, email); err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+	} else {
+		if user, err = s.createUser(ctx, email, role); err != nil {
+			return nil, err
+		}
+	}
+
+	identity := &models.OIDCIdentity{Issuer: claims.Issuer, Subject: claims.Subject, UserID: user.ID}
+	if err := s.oidcRepo.CreateIdentity(ctx, identity); err != nil {
+		return nil, fmt.Errorf("failed to link user identity: %w", err)
+	}
+
+	return user, nil
+}
+
+// createUser creates a single sign-on user; the random password is never revealed, so the account
+// has no usable password until an admin issues a password reset
+func (s *OIDCService) createUser(ctx context.Context, email string, role models.UserRole) (*models.User, error) {
+	password, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	passwordHash, err := auth.HashPassword(password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	user, err := s.userRepo.Create(ctx, email, passwordHash, role.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return user, nil
+}
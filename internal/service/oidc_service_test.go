@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"eco-van-api/internal/adapter/auth"
+	"eco-van-api/internal/domainerr"
+	"eco-van-api/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testOIDCIssuer = "https://idp.example.com"
+
+// MockOIDCProvider is a mock implementation of OIDCProvider
+type MockOIDCProvider struct {
+	mock.Mock
+}
+
+func (m *MockOIDCProvider) Issuer() string {
+	return testOIDCIssuer
+}
+
+func (m *MockOIDCProvider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	args := m.Called(ctx, state, nonce, codeChallenge)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockOIDCProvider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*models.OIDCClaims, error) {
+	args := m.Called(ctx, code, codeVerifier, nonce)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.OIDCClaims), args.Error(1)
+}
+
+// MockOIDCRepository is a mock implementation of OIDCRepository
+type MockOIDCRepository struct {
+	mock.Mock
+}
+
+func (m *MockOIDCRepository) CreateLoginState(ctx context.Context, state *models.OIDCLoginState) error {
+	args := m.Called(ctx, state)
+	return args.Error(0)
+}
+
+func (m *MockOIDCRepository) ConsumeLoginState(ctx context.Context, stateHash string, now time.Time) (*models.OIDCLoginState, error) {
+	args := m.Called(ctx, stateHash, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.OIDCLoginState), args.Error(1)
+}
+
+func (m *MockOIDCRepository) GetIdentity(ctx context.Context, issuer, subject string) (*models.OIDCIdentity, error) {
+	args := m.Called(ctx, issuer, subject)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.OIDCIdentity), args.Error(1)
+}
+
+func (m *MockOIDCRepository) CreateIdentity(ctx context.Context, identity *models.OIDCIdentity) error {
+	args := m.Called(ctx, identity)
+	return args.Error(0)
+}
+
+// testOIDCSettings maps the admins group to ADMIN and the office group to DISPATCHER
+func testOIDCSettings() OIDCSettings {
+	return OIDCSettings{
+		Roles:    models.OIDCRoleMapping{"eco-admins": models.UserRoleAdmin, "office": models.UserRoleDispatcher},
+		StateTTL: 10 * time.Minute,
+	}
+}
+
+// oidcTestFixture wires an OIDC service whose provider accepts the code "code" for a pending state "state"
+type oidcTestFixture struct {
+	provider  *MockOIDCProvider
+	oidcRepo  *MockOIDCRepository
+	userRepo  *MockUserRepository
+	tokenRepo *MockRefreshTokenRepository
+	service   *OIDCService
+}
+
+func newOIDCTestFixture(settings OIDCSettings, claims *models.OIDCClaims) *oidcTestFixture {
+	f := &oidcTestFixture{
+		provider:  new(MockOIDCProvider),
+		oidcRepo:  new(MockOIDCRepository),
+		userRepo:  new(MockUserRepository),
+		tokenRepo: new(MockRefreshTokenRepository),
+	}
+	loginState := &models.OIDCLoginState{StateHash: auth.HashOpaqueToken("state"), Nonce: "nonce", CodeVerifier: "verifier"}
+	f.oidcRepo.On("ConsumeLoginState", mock.Anything, loginState.StateHash, mock.AnythingOfType("time.Time")).
+		Return(loginState, nil)
+	f.provider.On("Exchange", mock.Anything, "code", "verifier", "nonce").Return(claims, nil)
+	f.tokenRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.RefreshToken")).Return(nil).Maybe()
+
+	authService := newTestAuthService(AuthRepositories{Users: f.userRepo, RefreshTokens: f.tokenRepo},
+		auth.NewDefaultJWTManager("test-secret"))
+	f.service = NewOIDCService(f.provider, f.oidcRepo, f.userRepo, authService, settings)
+	return f
+}
+
+func (f *oidcTestFixture) callback() (*models.AuthResponse, error) {
+	return f.service.Callback(context.Background(), &models.OIDCCallbackRequest{Code: "code", State: "state"}, testClient)
+}
+
+func TestOIDCService_Authorize(t *testing.T) {
+	ctx := context.Background()
+	provider := new(MockOIDCProvider)
+	oidcRepo := new(MockOIDCRepository)
+	oidcRepo.On("CreateLoginState", ctx, mock.AnythingOfType("*models.OIDCLoginState")).Return(nil)
+	provider.On("AuthCodeURL", ctx, mock.Anything, mock.Anything, mock.Anything).Return(testOIDCIssuer+"/authorize?x=1", nil)
+
+	svc := NewOIDCService(provider, oidcRepo, new(MockUserRepository), nil, testOIDCSettings())
+	resp, err := svc.Authorize(ctx)
+	require.NoError(t, err)
+
+	stored := oidcRepo.Calls[0].Arguments.Get(1).(*models.OIDCLoginState)
+	urlArgs := provider.Calls[0].Arguments
+	challenge := sha256.Sum256([]byte(stored.CodeVerifier))
+
+	assert.Equal(t, testOIDCIssuer+"/authorize?x=1", resp.AuthorizationURL)
+	assert.Equal(t, auth.HashOpaqueToken(resp.State), stored.StateHash)
+	assert.Equal(t, resp.State, urlArgs.String(1))
+	assert.Equal(t, stored.Nonce, urlArgs.String(2))
+	assert.Equal(t, base64.RawURLEncoding.EncodeToString(challenge[:]), urlArgs.String(3))
+	assert.WithinDuration(t, time.Now().Add(10*time.Minute), stored.ExpiresAt, time.Minute)
+}
+
+func TestOIDCService_Callback(t *testing.T) {
+	claims := func(groups ...string) *models.OIDCClaims {
+		return &models.OIDCClaims{
+			Issuer: testOIDCIssuer, Subject: "sub-1", Email: "jane@example.com", EmailVerified: true, Groups: groups,
+		}
+	}
+
+	t.Run("first login creates the user with the mapped role", func(t *testing.T) {
+		user := &models.User{ID: uuid.New(), Email: "jane@example.com", Role: models.UserRoleDispatcher}
+		f := newOIDCTestFixture(testOIDCSettings(), claims("office", "staff"))
+		f.oidcRepo.On("GetIdentity", mock.Anything, testOIDCIssuer, "sub-1").Return(nil, nil)
+		f.userRepo.On("ExistsByEmail", mock.Anything, "jane@example.com", (*uuid.UUID)(nil)).Return(false, nil)
+		f.userRepo.On("Create", mock.Anything, "jane@example.com", mock.AnythingOfType("string"), "DISPATCHER").Return(user, nil)
+		f.oidcRepo.On("CreateIdentity", mock.Anything, &models.OIDCIdentity{
+			Issuer: testOIDCIssuer, Subject: "sub-1", UserID: user.ID,
+		}).Return(nil)
+
+		resp, err := f.callback()
+		require.NoError(t, err)
+		assert.NotEmpty(t, resp.AccessToken)
+		assert.NotEmpty(t, resp.RefreshToken)
+		f.userRepo.AssertExpectations(t)
+		f.oidcRepo.AssertExpectations(t)
+	})
+
+	t.Run("linked user gets the role of their groups", func(t *testing.T) {
+		user := &models.User{ID: uuid.New(), Email: "jane@example.com", Role: models.UserRoleViewer}
+		f := newOIDCTestFixture(testOIDCSettings(), claims("office", "eco-admins"))
+		f.oidcRepo.On("GetIdentity", mock.Anything, testOIDCIssuer, "sub-1").
+			Return(&models.OIDCIdentity{Issuer: testOIDCIssuer, Subject: "sub-1", UserID: user.ID}, nil)
+		f.userRepo.On("Get", mock.Anything, user.ID).Return(user, nil)
+		f.userRepo.On("Update", mock.Anything, user).Return(nil)
+
+		_, err := f.callback()
+		require.NoError(t, err)
+		assert.Equal(t, models.UserRoleAdmin, user.Role)
+	})
+
+	t.Run("existing account is linked by verified email", func(t *testing.T) {
+		user := &models.User{ID: uuid.New(), Email: "jane@example.com", Role: models.UserRoleDispatcher}
+		f := newOIDCTestFixture(testOIDCSettings(), claims("office"))
+		f.oidcRepo.On("GetIdentity", mock.Anything, testOIDCIssuer, "sub-1").Return(nil, nil)
+		f.userRepo.On("ExistsByEmail", mock.Anything, "jane@example.com", (*uuid.UUID)(nil)).Return(true, nil)
+		f.userRepo.On("GetByEmail", mock.Anything, "jane@example.com").Return(user, nil)
+		f.oidcRepo.On("CreateIdentity", mock.Anything, mock.AnythingOfType("*models.OIDCIdentity")).Return(nil)
+
+		_, err := f.callback()
+		require.NoError(t, err)
+		f.userRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("unverified email does not take over an existing account", func(t *testing.T) {
+		unverified := claims("office")
+		unverified.EmailVerified = false
+		f := newOIDCTestFixture(testOIDCSettings(), unverified)
+		f.oidcRepo.On("GetIdentity", mock.Anything, testOIDCIssuer, "sub-1").Return(nil, nil)
+		f.userRepo.On("ExistsByEmail", mock.Anything, "jane@example.com", (*uuid.UUID)(nil)).Return(true, nil)
+
+		_, err := f.callback()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid OIDC login")
+		f.oidcRepo.AssertNotCalled(t, "CreateIdentity", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unmapped groups are forbidden without a default role", func(t *testing.T) {
+		f := newOIDCTestFixture(testOIDCSettings(), claims("staff"))
+
+		_, err := f.callback()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "forbidden")
+		f.oidcRepo.AssertNotCalled(t, "GetIdentity", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("default role applies to unmapped groups", func(t *testing.T) {
+		settings := testOIDCSettings()
+		settings.DefaultRole = models.UserRoleViewer
+		user := &models.User{ID: uuid.New(), Email: "jane@example.com", Role: models.UserRoleViewer}
+		f := newOIDCTestFixture(settings, claims())
+		f.oidcRepo.On("GetIdentity", mock.Anything, testOIDCIssuer, "sub-1").
+			Return(&models.OIDCIdentity{UserID: user.ID}, nil)
+		f.userRepo.On("Get", mock.Anything, user.ID).Return(user, nil)
+
+		_, err := f.callback()
+		require.NoError(t, err)
+		f.userRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("disabled user gets no session", func(t *testing.T) {
+		disabledAt := time.Now()
+		user := &models.User{ID: uuid.New(), Role: models.UserRoleDispatcher, DisabledAt: &disabledAt}
+		f := newOIDCTestFixture(testOIDCSettings(), claims("office"))
+		f.oidcRepo.On("GetIdentity", mock.Anything, testOIDCIssuer, "sub-1").
+			Return(&models.OIDCIdentity{UserID: user.ID}, nil)
+		f.userRepo.On("Get", mock.Anything, user.ID).Return(user, nil)
+
+		_, err := f.callback()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid credentials")
+		f.tokenRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown state is rejected before the code is redeemed", func(t *testing.T) {
+		oidcRepo := new(MockOIDCRepository)
+		oidcRepo.On("ConsumeLoginState", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+		provider := new(MockOIDCProvider)
+
+		svc := NewOIDCService(provider, oidcRepo, new(MockUserRepository), nil, testOIDCSettings())
+		_, err := svc.Callback(context.Background(), &models.OIDCCallbackRequest{Code: "code", State: "forged"}, testClient)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid OIDC login")
+		provider.AssertNotCalled(t, "Exchange", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejected code is an invalid login", func(t *testing.T) {
+		oidcRepo := new(MockOIDCRepository)
+		oidcRepo.On("ConsumeLoginState", mock.Anything, mock.Anything, mock.Anything).
+			Return(&models.OIDCLoginState{Nonce: "nonce", CodeVerifier: "verifier"}, nil)
+		provider := new(MockOIDCProvider)
+		providerErr := errors.New(`token endpoint: {"error":"invalid_grant","error_description":"code expired"}`)
+		provider.On("Exchange", mock.Anything, "code", "verifier", "nonce").Return(nil, providerErr)
+
+		svc := NewOIDCService(provider, oidcRepo, new(MockUserRepository), nil, testOIDCSettings())
+		_, err := svc.Callback(context.Background(), &models.OIDCCallbackRequest{Code: "code", State: "state"}, testClient)
+		domainErr, ok := domainerr.As(err)
+		require.True(t, ok)
+		assert.Equal(t, domainerr.CodeInvalidOIDCLogin, domainErr.Code)
+		assert.Equal(t, "invalid OIDC login", domainErr.Message)
+		assert.ErrorIs(t, err, providerErr)
+	})
+}
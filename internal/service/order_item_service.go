@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"eco-van-api/internal/domainerr"
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/port"
+
+	"github.com/google/uuid"
+)
+
+// orderItemService implements port.OrderItemService
+type orderItemService struct {
+	orderRepo     port.OrderRepository
+	equipmentRepo port.EquipmentRepository
+	warehouseRepo port.WarehouseRepository
+	transportRepo port.TransportRepository
+}
+
+// NewOrderItemService creates a new order item service
+func NewOrderItemService(
+	orderRepo port.OrderRepository,
+	equipmentRepo port.EquipmentRepository,
+	warehouseRepo port.WarehouseRepository,
+	transportRepo port.TransportRepository,
+) port.OrderItemService {
+	return &orderItemService{
+		orderRepo:     orderRepo,
+		equipmentRepo: equipmentRepo,
+		warehouseRepo: warehouseRepo,
+		transportRepo: transportRepo,
+	}
+}
+
+// List retrieves the equipment items of an order
+func (s *orderItemService) List(ctx context.Context, orderID uuid.UUID) (*models.OrderItemListResponse, error) {
+	order, err := s.orderRepo.GetByID(ctx, orderID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if order == nil {
+		return nil, domainerr.NotFound(domainerr.CodeOrderNotFound, "order not found")
+	}
+
+	items, err := s.orderRepo.ListItems(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list order items: %w", err)
+	}
+
+	return &models.OrderItemListResponse{OrderID: orderID, OrderVersion: order.Version, Items: items}, nil
+}
+
+// Replace validates and replaces the full list of items of an order. Items are part of the order, so the
+// replacement is checked against and bumps the order version. The items make up the volume the order's
+// transport carries, so the new list must fit the transport on the scheduled date.
+func (s *orderItemService) Replace(
+	ctx context.Context, orderID uuid.UUID, req models.ReplaceOrderItemsRequest, ifMatch *models.VersionMatch,
+) (*models.OrderItemListResponse, error) {
+	order, err := s.orderRepo.GetByID(ctx, orderID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if order == nil {
+		return nil, domainerr.NotFound(domainerr.CodeOrderNotFound, "order not found")
+	}
+	if err := checkOrderVersion(order, ifMatch); err != nil {
+		return nil, err
+	}
+
+	// Items of finished orders are part of the record and cannot change
+	if order.Status == string(models.OrderStatusCompleted) || order.Status == string(models.OrderStatusCanceled) {
+		return nil, domainerr.InvalidTransition(domainerr.CodeOrderItemsLocked, "order in %s status cannot change items", order.Status).
+			With("status", order.Status)
+	}
+
+	items := make([]models.OrderItem, 0, len(req.Items))
+	seen := make(map[uuid.UUID]bool, len(req.Items))
+	volumeL := 0
+	for i := range req.Items {
+		itemReq := &req.Items[i]
+		if err := itemReq.Validate(); err != nil {
+			return nil, domainerr.Validation(domainerr.CodeValidationFailed, "validation failed: items[%d]", i).
+				WrapAt(fmt.Sprintf("/items/%d", i), err)
+		}
+
+		pointer := fmt.Sprintf("/items/%d", i)
+		refs := []equipmentRef{{field: pointer + "/equipmentId", id: itemReq.EquipmentID}}
+		if itemReq.ReplacementEquipmentID != nil {
+			refs = append(refs, equipmentRef{field: pointer + "/replacementEquipmentId", id: *itemReq.ReplacementEquipmentID})
+		}
+		for _, ref := range refs {
+			if seen[ref.id] {
+				return nil, domainerr.Validation(domainerr.CodeValidationFailed, "validation failed").
+					Wrap(domainerr.InvalidField(ref.field, "unique", "equipment %s is listed more than once", ref.id)).
+					With("equipmentId", ref.id)
+			}
+			seen[ref.id] = true
+
+			equipment, err := s.validateEquipment(ctx, ref.field, ref.id)
+			if err != nil {
+				return nil, err
+			}
+			// Delivered equipment is not counted, matching the volume the repository reports for the order
+			if ref.id == itemReq.EquipmentID && itemReq.Operation != models.OrderItemDeliver {
+				volumeL += equipment.VolumeL
+			}
+		}
+
+		if itemReq.WarehouseID != nil {
+			if err := s.validateWarehouse(ctx, pointer+"/warehouseId", *itemReq.WarehouseID); err != nil {
+				return nil, err
+			}
+		}
+
+		items = append(items, models.OrderItem{
+			EquipmentID:            itemReq.EquipmentID,
+			Operation:              itemReq.Operation,
+			ReplacementEquipmentID: itemReq.ReplacementEquipmentID,
+			WarehouseID:            itemReq.WarehouseID,
+		})
+	}
+
+	if order.TransportID != nil && !req.OverrideConflicts {
+		if err := s.checkTransportCapacity(ctx, order, volumeL); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.orderRepo.ReplaceItems(ctx, order, items); err != nil {
+		return nil, fmt.Errorf("failed to replace order items: %w", err)
+	}
+
+	return &models.OrderItemListResponse{OrderID: orderID, OrderVersion: order.Version, Items: items}, nil
+}
+
+// equipmentRef is a piece of equipment referenced by an order item, with the request field that names it
+type equipmentRef struct {
+	field string
+	id    uuid.UUID
+}
+
+// checkTransportCapacity checks the order with its new volume against the other orders booked on its transport
+func (s *orderItemService) checkTransportCapacity(ctx context.Context, order *models.Order, volumeL int) error {
+	transport, err := s.transportRepo.GetByID(ctx, *order.TransportID, false)
+	if err != nil {
+		return fmt.Errorf("failed to get transport: %w", err)
+	}
+	if transport == nil {
+		return nil
+	}
+	return checkTransportDay(ctx, s.orderRepo, transport, order, volumeL)
+}
+
+// validateEquipment checks that the equipment at the given request field exists and is not deleted
+func (s *orderItemService) validateEquipment(ctx context.Context, field string, equipmentID uuid.UUID) (*models.Equipment, error) {
+	equipment, err := s.equipmentRepo.GetByID(ctx, equipmentID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get equipment: %w", err)
+	}
+	if equipment == nil {
+		return nil, domainerr.Validation(domainerr.CodeEquipmentNotFound, "validation failed").
+			Wrap(domainerr.InvalidField(field, "exists", "equipment %s not found", equipmentID)).
+			With("equipmentId", equipmentID)
+	}
+	return equipment, nil
+}
+
+// validateWarehouse checks that the warehouse at the given request field exists and is not deleted
+func (s *orderItemService) validateWarehouse(ctx context.Context, field string, warehouseID uuid.UUID) error {
+	warehouse, err := s.warehouseRepo.GetByID(ctx, warehouseID, false)
+	if err != nil {
+		return fmt.Errorf("failed to get warehouse: %w", err)
+	}
+	if warehouse == nil {
+		return domainerr.Validation(domainerr.CodeWarehouseNotFound, "validation failed").
+			Wrap(domainerr.InvalidField(field, "exists", "warehouse %s not found", warehouseID)).
+			With("warehouseId", warehouseID)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"eco-van-api/internal/domainerr"
+	"eco-van-api/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestOrderItemService(
+	orderRepo *MockOrderRepository, equipmentRepo *MockEquipmentRepository, warehouseRepo *MockWarehouseRepository,
+) *orderItemService {
+	return NewOrderItemService(orderRepo, equipmentRepo, warehouseRepo, new(MockTransportRepository)).(*orderItemService)
+}
+
+func TestOrderItemService_Replace(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("replaces items", func(t *testing.T) {
+		orderRepo := new(MockOrderRepository)
+		equipmentRepo := new(MockEquipmentRepository)
+		warehouseRepo := new(MockWarehouseRepository)
+		svc := newTestOrderItemService(orderRepo, equipmentRepo, warehouseRepo)
+		order := newTestOrder(models.OrderStatusScheduled)
+		order.Version = 3
+		oldID, newID, warehouseID := uuid.New(), uuid.New(), uuid.New()
+
+		orderRepo.On("GetByID", ctx, order.ID, false).Return(order, nil)
+		equipmentRepo.On("GetByID", ctx, mock.AnythingOfType("uuid.UUID"), false).Return(&models.Equipment{}, nil)
+		warehouseRepo.On("GetByID", ctx, warehouseID, false).Return(&models.Warehouse{ID: warehouseID}, nil)
+		orderRepo.On("ReplaceItems", ctx, order, mock.MatchedBy(func(items []models.OrderItem) bool {
+			return len(items) == 1 && items[0].Operation == models.OrderItemSwap
+		})).Run(func(args mock.Arguments) {
+			args.Get(1).(*models.Order).Version++
+		}).Return(nil)
+
+		req := models.ReplaceOrderItemsRequest{Items: []models.OrderItemRequest{{
+			EquipmentID: oldID, Operation: models.OrderItemSwap, ReplacementEquipmentID: &newID, WarehouseID: &warehouseID,
+		}}}
+		result, err := svc.Replace(ctx, order.ID, req, &models.VersionMatch{Versions: []int{3}})
+
+		require.NoError(t, err)
+		assert.Len(t, result.Items, 1)
+		assert.Equal(t, 4, result.OrderVersion)
+		equipmentRepo.AssertNumberOfCalls(t, "GetByID", 2)
+		orderRepo.AssertExpectations(t)
+	})
+
+	t.Run("stale version is rejected", func(t *testing.T) {
+		orderRepo := new(MockOrderRepository)
+		svc := newTestOrderItemService(orderRepo, new(MockEquipmentRepository), new(MockWarehouseRepository))
+		order := newTestOrder(models.OrderStatusScheduled)
+		order.Version = 3
+
+		orderRepo.On("GetByID", ctx, order.ID, false).Return(order, nil)
+
+		_, err := svc.Replace(ctx, order.ID, models.ReplaceOrderItemsRequest{}, &models.VersionMatch{Versions: []int{2}})
+
+		assert.True(t, domainerr.HasCode(err, domainerr.CodeOrderVersionMismatch))
+		orderRepo.AssertNotCalled(t, "ReplaceItems", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("swap without replacement is rejected", func(t *testing.T) {
+		orderRepo := new(MockOrderRepository)
+		svc := newTestOrderItemService(orderRepo, new(MockEquipmentRepository), new(MockWarehouseRepository))
+		order := newTestOrder(models.OrderStatusDraft)
+
+		orderRepo.On("GetByID", ctx, order.ID, false).Return(order, nil)
+
+		req := models.ReplaceOrderItemsRequest{Items: []models.OrderItemRequest{
+			{EquipmentID: uuid.New(), Operation: models.OrderItemSwap},
+		}}
+		_, err := svc.Replace(ctx, order.ID, req, nil)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "validation failed")
+		domainErr, ok := domainerr.As(err)
+		require.True(t, ok)
+		assert.Equal(t, []domainerr.FieldError{{
+			Pointer: "/items/0/replacementEquipmentId",
+			Rule:    "required_if",
+			Message: "replacementEquipmentId is required for SWAP",
+		}}, domainErr.FieldErrors)
+		orderRepo.AssertNotCalled(t, "ReplaceItems", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("duplicate equipment is rejected", func(t *testing.T) {
+		orderRepo := new(MockOrderRepository)
+		equipmentRepo := new(MockEquipmentRepository)
+		svc := newTestOrderItemService(orderRepo, equipmentRepo, new(MockWarehouseRepository))
+		order := newTestOrder(models.OrderStatusDraft)
+		equipmentID := uuid.New()
+
+		orderRepo.On("GetByID", ctx, order.ID, false).Return(order, nil)
+		equipmentRepo.On("GetByID", ctx, equipmentID, false).Return(&models.Equipment{ID: equipmentID}, nil)
+
+		req := models.ReplaceOrderItemsRequest{Items: []models.OrderItemRequest{
+			{EquipmentID: equipmentID, Operation: models.OrderItemDeliver},
+			{EquipmentID: equipmentID, Operation: models.OrderItemEmpty},
+		}}
+		_, err := svc.Replace(ctx, order.ID, req, nil)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "listed more than once")
+		domainErr, ok := domainerr.As(err)
+		require.True(t, ok)
+		require.Len(t, domainErr.FieldErrors, 1)
+		assert.Equal(t, "/items/1/equipmentId", domainErr.FieldErrors[0].Pointer)
+	})
+
+	t.Run("items exceeding transport capacity are rejected", func(t *testing.T) {
+		orderRepo := new(MockOrderRepository)
+		equipmentRepo := new(MockEquipmentRepository)
+		transportRepo := new(MockTransportRepository)
+		svc := NewOrderItemService(orderRepo, equipmentRepo, new(MockWarehouseRepository), transportRepo).(*orderItemService)
+		transport := &models.Transport{ID: uuid.New(), CapacityL: 1000, Status: "IN_WORK"}
+		order := newTestOrder(models.OrderStatusScheduled)
+		order.TransportID = &transport.ID
+		pickupID, deliverID := uuid.New(), uuid.New()
+		other := models.TransportDayOrder{OrderID: uuid.New(), VolumeL: 800}
+
+		orderRepo.On("GetByID", ctx, order.ID, false).Return(order, nil)
+		equipmentRepo.On("GetByID", ctx, pickupID, false).Return(&models.Equipment{ID: pickupID, VolumeL: 240}, nil)
+		equipmentRepo.On("GetByID", ctx, deliverID, false).Return(&models.Equipment{ID: deliverID, VolumeL: 1100}, nil)
+		transportRepo.On("GetByID", ctx, transport.ID, false).Return(transport, nil)
+		orderRepo.On("ListTransportDayOrders", ctx, transport.ID, order.ScheduledDate).
+			Return([]models.TransportDayOrder{other, {OrderID: order.ID, VolumeL: 0}}, nil)
+
+		req := models.ReplaceOrderItemsRequest{Items: []models.OrderItemRequest{
+			{EquipmentID: pickupID, Operation: models.OrderItemPickup},
+			{EquipmentID: deliverID, Operation: models.OrderItemDeliver},
+		}}
+		_, err := svc.Replace(ctx, order.ID, req, nil)
+
+		var conflict *models.TransportConflictError
+		require.ErrorAs(t, err, &conflict)
+		assert.Equal(t, 1040, conflict.TotalVolumeL)
+		assert.Equal(t, []uuid.UUID{other.OrderID}, conflict.CapacityOrderIDs)
+		orderRepo.AssertNotCalled(t, "ReplaceItems", mock.Anything, mock.Anything, mock.Anything)
+
+		// A dispatcher can keep the items anyway
+		orderRepo.On("ReplaceItems", ctx, order, mock.Anything).Return(nil)
+		req.OverrideConflicts = true
+		_, err = svc.Replace(ctx, order.ID, req, nil)
+
+		require.NoError(t, err)
+		orderRepo.AssertNumberOfCalls(t, "ListTransportDayOrders", 1)
+	})
+
+	t.Run("completed order cannot change items", func(t *testing.T) {
+		orderRepo := new(MockOrderRepository)
+		svc := newTestOrderItemService(orderRepo, new(MockEquipmentRepository), new(MockWarehouseRepository))
+		order := newTestOrder(models.OrderStatusCompleted)
+
+		orderRepo.On("GetByID", ctx, order.ID, false).Return(order, nil)
+
+		_, err := svc.Replace(ctx, order.ID, models.ReplaceOrderItemsRequest{}, nil)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot change items")
+	})
+}
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"eco-van-api/internal/domainerr"
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/port"
+
+	"github.com/google/uuid"
+)
+
+// timeWindowLayout is the HH:MM format of schedule time windows
+const timeWindowLayout = "15:04"
+
+// orderScheduleService implements port.OrderScheduleService
+type orderScheduleService struct {
+	scheduleRepo  port.OrderScheduleRepository
+	clientObjRepo port.ClientObjectRepository
+	transportRepo port.TransportRepository
+	aheadDays     int
+}
+
+// NewOrderScheduleService creates a new order schedule service that generates orders aheadDays in advance
+func NewOrderScheduleService(
+	scheduleRepo port.OrderScheduleRepository,
+	clientObjRepo port.ClientObjectRepository,
+	transportRepo port.TransportRepository,
+	aheadDays int,
+) port.OrderScheduleService {
+	return &orderScheduleService{
+		scheduleRepo:  scheduleRepo,
+		clientObjRepo: clientObjRepo,
+		transportRepo: transportRepo,
+		aheadDays:     aheadDays,
+	}
+}
+
+// Create creates a new order schedule with validation
+func (s *orderScheduleService) Create(
+	ctx context.Context, req *models.CreateOrderScheduleRequest, createdBy *uuid.UUID,
+) (*models.OrderSchedule, error) {
+	clientObj, err := s.clientObjRepo.GetByID(ctx, req.ObjectID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client object: %w", err)
+	}
+	if clientObj == nil {
+		return nil, domainerr.NotFound(domainerr.CodeClientObjectNotFound, "client object not found")
+	}
+
+	schedule := models.FromOrderScheduleCreateRequest(req)
+	schedule.CreatedBy = createdBy
+
+	if err := s.validateSchedule(ctx, &schedule); err != nil {
+		return nil, err
+	}
+
+	if err := s.scheduleRepo.Create(ctx, &schedule); err != nil {
+		return nil, fmt.Errorf("failed to create order schedule: %w", err)
+	}
+
+	return &schedule, nil
+}
+
+// GetByID retrieves an order schedule by ID
+func (s *orderScheduleService) GetByID(ctx context.Context, id uuid.UUID) (*models.OrderSchedule, error) {
+	schedule, err := s.scheduleRepo.GetByID(ctx, id, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order schedule: %w", err)
+	}
+	if schedule == nil {
+		return nil, domainerr.NotFound(domainerr.CodeOrderScheduleNotFound, "order schedule not found")
+	}
+	return schedule, nil
+}
+
+// List retrieves order schedules with pagination and filtering
+func (s *orderScheduleService) List(
+	ctx context.Context, req models.OrderScheduleListRequest,
+) (*models.OrderScheduleListResponse, error) {
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 20
+	}
+	const maxPageSize = 100
+	if req.PageSize > maxPageSize {
+		req.PageSize = maxPageSize
+	}
+
+	response, err := s.scheduleRepo.List(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list order schedules: %w", err)
+	}
+	return response, nil
+}
+
+// Update updates an order schedule. Future DRAFT and SCHEDULED orders generated from it are
+// discarded so that the next generator run recreates them from the new settings.
+func (s *orderScheduleService) Update(
+	ctx context.Context, id uuid.UUID, req models.UpdateOrderScheduleRequest,
+) (*models.OrderSchedule, error) {
+	schedule, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule.UpdateFromRequest(req)
+	if err := s.validateSchedule(ctx, schedule); err != nil {
+		return nil, err
+	}
+
+	if err := s.scheduleRepo.Update(ctx, schedule); err != nil {
+		return nil, fmt.Errorf("failed to update order schedule: %w", err)
+	}
+
+	if _, err := s.scheduleRepo.DiscardFutureOrders(ctx, schedule.ID, today()); err != nil {
+		return nil, fmt.Errorf("failed to discard generated orders: %w", err)
+	}
+
+	return s.GetByID(ctx, id)
+}
+
+// Delete soft-deletes an order schedule together with its future generated orders
+func (s *orderScheduleService) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.scheduleRepo.SoftDelete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete order schedule: %w", err)
+	}
+
+	if _, err := s.scheduleRepo.DiscardFutureOrders(ctx, id, today()); err != nil {
+		return fmt.Errorf("failed to discard generated orders: %w", err)
+	}
+
+	return nil
+}
+
+// GenerateOrders materializes orders for all due schedules up to aheadDays after now.
+// A failing schedule does not stop the others; the first error is returned after the run.
+func (s *orderScheduleService) GenerateOrders(ctx context.Context, now time.Time) (int, error) {
+	horizon := now.AddDate(0, 0, s.aheadDays)
+
+	schedules, err := s.scheduleRepo.ListDue(ctx, horizon)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list due order schedules: %w", err)
+	}
+
+	created := 0
+	var firstErr error
+	for i := range schedules {
+		schedule := &schedules[i]
+
+		rule, err := models.ParseRecurrence(schedule.RRule)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("order schedule %s has an invalid rrule: %w", schedule.ID, err)
+			}
+			continue
+		}
+
+		dates := schedule.NextDates(rule, now, horizon)
+		n, err := s.scheduleRepo.GenerateOrders(ctx, schedule, dates, time.Date(
+			horizon.Year(), horizon.Month(), horizon.Day(), 0, 0, 0, 0, time.UTC))
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to generate orders for schedule %s: %w", schedule.ID, err)
+			}
+			continue
+		}
+		created += n
+	}
+
+	return created, firstErr
+}
+
+// validateSchedule validates the recurrence rule, date range, time window and default transport
+func (s *orderScheduleService) validateSchedule(ctx context.Context, schedule *models.OrderSchedule) error {
+	if _, err := models.ParseRecurrence(schedule.RRule); err != nil {
+		return domainerr.Validation(domainerr.CodeValidationFailed, "validation failed").
+			Wrap(domainerr.InvalidField("/rrule", "rrule", "invalid rrule: %v", err))
+	}
+
+	if schedule.EndDate != nil && schedule.EndDate.Before(schedule.StartDate) {
+		return domainerr.Validation(domainerr.CodeValidationFailed, "validation failed").
+			Wrap(domainerr.InvalidField("/endDate", "gtefield", "endDate must not be before startDate"))
+	}
+
+	if err := validateTimeWindow(schedule.WindowFrom, schedule.WindowTo); err != nil {
+		return domainerr.Validation(domainerr.CodeValidationFailed, "validation failed").Wrap(err)
+	}
+
+	if schedule.TransportID != nil {
+		transport, err := s.transportRepo.GetByID(ctx, *schedule.TransportID, false)
+		if err != nil {
+			return fmt.Errorf("failed to get transport: %w", err)
+		}
+		if transport == nil {
+			return domainerr.NotFound(domainerr.CodeTransportNotFound, "transport not found")
+		}
+	}
+
+	return nil
+}
+
+// validateTimeWindow checks that both window bounds are HH:MM and from is before to
+func validateTimeWindow(from, to *string) error {
+	var fromTime, toTime time.Time
+	var err error
+	if from != nil {
+		if fromTime, err = time.Parse(timeWindowLayout, *from); err != nil {
+			return domainerr.InvalidField("/windowFrom", "datetime", "windowFrom must be in HH:MM format")
+		}
+	}
+	if to != nil {
+		if toTime, err = time.Parse(timeWindowLayout, *to); err != nil {
+			return domainerr.InvalidField("/windowTo", "datetime", "windowTo must be in HH:MM format")
+		}
+	}
+	if from != nil && to != nil && !fromTime.Before(toTime) {
+		return domainerr.InvalidField("/windowFrom", "ltfield", "windowFrom must be before windowTo")
+	}
+	return nil
+}
+
+// today returns the current date at midnight UTC, matching how DATE columns are compared
+func today() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"eco-van-api/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockOrderScheduleRepository is a mock implementation of OrderScheduleRepository
+type MockOrderScheduleRepository struct {
+	mock.Mock
+}
+
+func (m *MockOrderScheduleRepository) Create(ctx context.Context, schedule *models.OrderSchedule) error {
+	args := m.Called(ctx, schedule)
+	return args.Error(0)
+}
+
+func (m *MockOrderScheduleRepository) GetByID(ctx context.Context, id uuid.UUID, includeDeleted bool) (*models.OrderSchedule, error) {
+	args := m.Called(ctx, id, includeDeleted)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.OrderSchedule), args.Error(1)
+}
+
+func (m *MockOrderScheduleRepository) Update(ctx context.Context, schedule *models.OrderSchedule) error {
+	args := m.Called(ctx, schedule)
+	return args.Error(0)
+}
+
+func (m *MockOrderScheduleRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockOrderScheduleRepository) List(
+	ctx context.Context, req models.OrderScheduleListRequest,
+) (*models.OrderScheduleListResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.OrderScheduleListResponse), args.Error(1)
+}
+
+func (m *MockOrderScheduleRepository) ListDue(ctx context.Context, horizon time.Time) ([]models.OrderSchedule, error) {
+	args := m.Called(ctx, horizon)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.OrderSchedule), args.Error(1)
+}
+
+func (m *MockOrderScheduleRepository) GenerateOrders(
+	ctx context.Context, schedule *models.OrderSchedule, dates []time.Time, generatedUntil time.Time,
+) (int, error) {
+	args := m.Called(ctx, schedule, dates, generatedUntil)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockOrderScheduleRepository) DiscardFutureOrders(ctx context.Context, scheduleID uuid.UUID, from time.Time) (int64, error) {
+	args := m.Called(ctx, scheduleID, from)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+const testScheduleAheadDays = 7
+
+func newTestOrderScheduleService(
+	scheduleRepo *MockOrderScheduleRepository, clientObjRepo *MockClientObjectRepository,
+) *orderScheduleService {
+	return NewOrderScheduleService(scheduleRepo, clientObjRepo, new(MockTransportRepository),
+		testScheduleAheadDays).(*orderScheduleService)
+}
+
+func TestOrderScheduleService_Create(t *testing.T) {
+	ctx := context.Background()
+	objectID := uuid.New()
+
+	t.Run("creates schedule with defaults", func(t *testing.T) {
+		scheduleRepo := new(MockOrderScheduleRepository)
+		clientObjRepo := new(MockClientObjectRepository)
+		svc := newTestOrderScheduleService(scheduleRepo, clientObjRepo)
+		from, to := "08:00", "12:00"
+
+		clientObjRepo.On("GetByID", ctx, objectID, false).Return(&models.ClientObject{ID: objectID}, nil)
+		scheduleRepo.On("Create", ctx, mock.AnythingOfType("*models.OrderSchedule")).Return(nil)
+
+		req := &models.CreateOrderScheduleRequest{
+			ObjectID:   objectID,
+			RRule:      "FREQ=WEEKLY;BYDAY=MO,TH",
+			StartDate:  time.Date(2025, time.March, 3, 15, 30, 0, 0, time.UTC),
+			WindowFrom: &from,
+			WindowTo:   &to,
+		}
+		result, err := svc.Create(ctx, req, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, string(models.OrderStatusDraft), result.OrderStatus)
+		assert.Equal(t, string(models.OrderPriorityMedium), result.Priority)
+		assert.True(t, result.IsActive)
+		assert.Equal(t, time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC), result.StartDate)
+		scheduleRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid rrule", func(t *testing.T) {
+		scheduleRepo := new(MockOrderScheduleRepository)
+		clientObjRepo := new(MockClientObjectRepository)
+		svc := newTestOrderScheduleService(scheduleRepo, clientObjRepo)
+
+		clientObjRepo.On("GetByID", ctx, objectID, false).Return(&models.ClientObject{ID: objectID}, nil)
+
+		req := &models.CreateOrderScheduleRequest{ObjectID: objectID, RRule: "FREQ=HOURLY", StartDate: time.Now()}
+		result, err := svc.Create(ctx, req, nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "validation failed")
+		scheduleRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("inverted time window", func(t *testing.T) {
+		scheduleRepo := new(MockOrderScheduleRepository)
+		clientObjRepo := new(MockClientObjectRepository)
+		svc := newTestOrderScheduleService(scheduleRepo, clientObjRepo)
+		from, to := "14:00", "09:00"
+
+		clientObjRepo.On("GetByID", ctx, objectID, false).Return(&models.ClientObject{ID: objectID}, nil)
+
+		req := &models.CreateOrderScheduleRequest{
+			ObjectID: objectID, RRule: "FREQ=DAILY", StartDate: time.Now(), WindowFrom: &from, WindowTo: &to,
+		}
+		_, err := svc.Create(ctx, req, nil)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "windowFrom must be before windowTo")
+	})
+
+	t.Run("client object not found", func(t *testing.T) {
+		scheduleRepo := new(MockOrderScheduleRepository)
+		clientObjRepo := new(MockClientObjectRepository)
+		svc := newTestOrderScheduleService(scheduleRepo, clientObjRepo)
+
+		clientObjRepo.On("GetByID", ctx, objectID, false).Return(nil, nil)
+
+		req := &models.CreateOrderScheduleRequest{ObjectID: objectID, RRule: "FREQ=DAILY", StartDate: time.Now()}
+		_, err := svc.Create(ctx, req, nil)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+}
+
+func TestOrderScheduleService_GenerateOrders(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, time.March, 1, 6, 0, 0, 0, time.UTC) // Saturday
+	horizon := now.AddDate(0, 0, testScheduleAheadDays)
+	generatedUntil := time.Date(2025, time.March, 8, 0, 0, 0, 0, time.UTC)
+
+	t.Run("materializes dates within horizon", func(t *testing.T) {
+		scheduleRepo := new(MockOrderScheduleRepository)
+		svc := newTestOrderScheduleService(scheduleRepo, new(MockClientObjectRepository))
+		schedule := models.OrderSchedule{
+			ID:        uuid.New(),
+			RRule:     "FREQ=WEEKLY;BYDAY=MO,TH",
+			StartDate: time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+		}
+		wantDates := []time.Time{
+			time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC),
+			time.Date(2025, time.March, 6, 0, 0, 0, 0, time.UTC),
+		}
+
+		scheduleRepo.On("ListDue", ctx, horizon).Return([]models.OrderSchedule{schedule}, nil)
+		scheduleRepo.On("GenerateOrders", ctx, mock.AnythingOfType("*models.OrderSchedule"), wantDates, generatedUntil).
+			Return(2, nil)
+
+		created, err := svc.GenerateOrders(ctx, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, created)
+		scheduleRepo.AssertExpectations(t)
+	})
+
+	t.Run("failing schedule does not stop the others", func(t *testing.T) {
+		scheduleRepo := new(MockOrderScheduleRepository)
+		svc := newTestOrderScheduleService(scheduleRepo, new(MockClientObjectRepository))
+		broken := models.OrderSchedule{ID: uuid.New(), RRule: "FREQ=NEVER", StartDate: now}
+		daily := models.OrderSchedule{ID: uuid.New(), RRule: "FREQ=DAILY", StartDate: now}
+
+		scheduleRepo.On("ListDue", ctx, horizon).Return([]models.OrderSchedule{broken, daily}, nil)
+		scheduleRepo.On("GenerateOrders", ctx, mock.MatchedBy(func(s *models.OrderSchedule) bool {
+			return s.ID == daily.ID
+		}), mock.Anything, generatedUntil).Return(8, nil)
+
+		created, err := svc.GenerateOrders(ctx, now)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), broken.ID.String())
+		assert.Equal(t, 8, created)
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		scheduleRepo := new(MockOrderScheduleRepository)
+		svc := newTestOrderScheduleService(scheduleRepo, new(MockClientObjectRepository))
+
+		scheduleRepo.On("ListDue", ctx, horizon).Return(nil, errors.New("db down"))
+
+		created, err := svc.GenerateOrders(ctx, now)
+
+		assert.Error(t, err)
+		assert.Zero(t, created)
+	})
+}
+
+func TestOrderScheduleService_Update(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("discards future generated orders", func(t *testing.T) {
+		scheduleRepo := new(MockOrderScheduleRepository)
+		svc := newTestOrderScheduleService(scheduleRepo, new(MockClientObjectRepository))
+		schedule := &models.OrderSchedule{ID: uuid.New(), RRule: "FREQ=DAILY", StartDate: time.Now(), IsActive: true}
+		newRule := "FREQ=WEEKLY;BYDAY=FR"
+
+		scheduleRepo.On("GetByID", ctx, schedule.ID, false).Return(schedule, nil)
+		scheduleRepo.On("Update", ctx, schedule).Return(nil)
+		scheduleRepo.On("DiscardFutureOrders", ctx, schedule.ID, mock.AnythingOfType("time.Time")).Return(int64(3), nil)
+
+		result, err := svc.Update(ctx, schedule.ID, models.UpdateOrderScheduleRequest{RRule: &newRule})
+
+		require.NoError(t, err)
+		assert.Equal(t, newRule, result.RRule)
+		scheduleRepo.AssertExpectations(t)
+	})
+
+	t.Run("schedule not found", func(t *testing.T) {
+		scheduleRepo := new(MockOrderScheduleRepository)
+		svc := newTestOrderScheduleService(scheduleRepo, new(MockClientObjectRepository))
+		id := uuid.New()
+
+		scheduleRepo.On("GetByID", ctx, id, false).Return(nil, nil)
+
+		_, err := svc.Update(ctx, id, models.UpdateOrderScheduleRequest{})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+}
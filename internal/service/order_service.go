@@ -3,7 +3,9 @@ package service
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"eco-van-api/internal/domainerr"
 	"eco-van-api/internal/models"
 	"eco-van-api/internal/port"
 
@@ -16,6 +18,7 @@ type orderService struct {
 	clientRepo    port.ClientRepository
 	clientObjRepo port.ClientObjectRepository
 	transportRepo port.TransportRepository
+	reasonRepo    port.CancellationReasonRepository
 }
 
 // NewOrderService creates a new order service
@@ -24,12 +27,14 @@ func NewOrderService(
 	clientRepo port.ClientRepository,
 	clientObjRepo port.ClientObjectRepository,
 	transportRepo port.TransportRepository,
+	reasonRepo port.CancellationReasonRepository,
 ) port.OrderService {
 	return &orderService{
 		orderRepo:     orderRepo,
 		clientRepo:    clientRepo,
 		clientObjRepo: clientObjRepo,
 		transportRepo: transportRepo,
+		reasonRepo:    reasonRepo,
 	}
 }
 
@@ -41,7 +46,7 @@ func (s *orderService) Create(ctx context.Context, req *models.CreateOrderReques
 		return nil, fmt.Errorf("failed to get client: %w", err)
 	}
 	if client == nil {
-		return nil, fmt.Errorf("client not found")
+		return nil, domainerr.NotFound(domainerr.CodeClientNotFound, "client not found")
 	}
 
 	// Validate that client object exists and belongs to the client
@@ -50,31 +55,30 @@ func (s *orderService) Create(ctx context.Context, req *models.CreateOrderReques
 		return nil, fmt.Errorf("failed to get client object: %w", err)
 	}
 	if clientObj == nil {
-		return nil, fmt.Errorf("client object not found")
+		return nil, domainerr.NotFound(domainerr.CodeClientObjectNotFound, "client object not found")
 	}
 	if clientObj.ClientID != req.ClientID {
-		return nil, fmt.Errorf("client object does not belong to the specified client")
+		return nil, domainerr.Validation(domainerr.CodeClientObjectClientMismatch, "client object does not belong to the specified client").
+			At("/clientObjectId", "belongs_to")
 	}
 
 	// Validate transport if being assigned
-	if req.TransportID != nil {
-		transport, err := s.transportRepo.GetByID(ctx, *req.TransportID, false)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get transport: %w", err)
-		}
-		if transport == nil {
-			return nil, fmt.Errorf("transport not found")
-		}
-		// Check if transport is available (has IN_WORK status)
-		if transport.Status != "IN_WORK" {
-			return nil, fmt.Errorf("transport is not available (status: %s)", transport.Status)
-		}
+	transport, err := s.validateTransportUpdate(ctx, req.TransportID)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create order from request
 	order := models.FromOrderCreateRequest(req)
 	order.CreatedBy = createdBy
 
+	// A new order has no items yet, so only its time window can conflict
+	if transport != nil && !req.OverrideConflicts {
+		if err := s.checkTransportConflicts(ctx, transport, &order); err != nil {
+			return nil, err
+		}
+	}
+
 	// Save to repository
 	err = s.orderRepo.Create(ctx, &order)
 	if err != nil {
@@ -94,7 +98,15 @@ func (s *orderService) GetByID(ctx context.Context, id uuid.UUID) (*models.Order
 	}
 
 	if order == nil {
-		return nil, fmt.Errorf("order not found")
+		return nil, domainerr.NotFound(domainerr.CodeOrderNotFound, "order not found")
+	}
+
+	// Completed orders carry what was actually collected
+	if order.Status == string(models.OrderStatusCompleted) {
+		order.Completion, err = s.orderRepo.GetCompletion(ctx, order.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get order completion: %w", err)
+		}
 	}
 
 	response := order.ToResponse()
@@ -134,7 +146,7 @@ func (s *orderService) validateClientUpdate(ctx context.Context, clientID *uuid.
 		return fmt.Errorf("failed to get client: %w", err)
 	}
 	if client == nil {
-		return fmt.Errorf("client not found")
+		return domainerr.NotFound(domainerr.CodeClientNotFound, "client not found")
 	}
 	return nil
 }
@@ -157,45 +169,109 @@ func (s *orderService) validateClientObjectUpdate(ctx context.Context, params cl
 		return fmt.Errorf("failed to get client object: %w", err)
 	}
 	if clientObj == nil {
-		return fmt.Errorf("client object not found")
+		return domainerr.NotFound(domainerr.CodeClientObjectNotFound, "client object not found")
 	}
 
 	// If client is also being updated, validate the relationship
 	if params.clientID != nil {
 		if clientObj.ClientID != *params.clientID {
-			return fmt.Errorf("client object does not belong to the specified client")
+			return domainerr.Validation(domainerr.CodeClientObjectClientMismatch, "client object does not belong to the specified client").
+				At("/clientObjectId", "belongs_to")
 		}
 	} else {
 		// Use existing client ID for validation
 		if clientObj.ClientID != params.existingClientID {
-			return fmt.Errorf("client object does not belong to the order's client")
+			return domainerr.Validation(domainerr.CodeClientObjectClientMismatch, "client object does not belong to the order's client").
+				At("/clientObjectId", "belongs_to")
 		}
 	}
 	return nil
 }
 
-// validateTransportUpdate validates transport update if provided
-func (s *orderService) validateTransportUpdate(ctx context.Context, transportID *uuid.UUID) error {
+// validateTransportUpdate validates transport update if provided and returns the transport
+func (s *orderService) validateTransportUpdate(ctx context.Context, transportID *uuid.UUID) (*models.Transport, error) {
 	if transportID == nil {
-		return nil
+		return nil, nil
 	}
 
 	transport, err := s.transportRepo.GetByID(ctx, *transportID, false)
 	if err != nil {
-		return fmt.Errorf("failed to get transport: %w", err)
+		return nil, fmt.Errorf("failed to get transport: %w", err)
 	}
 	if transport == nil {
-		return fmt.Errorf("transport not found")
+		return nil, domainerr.NotFound(domainerr.CodeTransportNotFound, "transport not found")
 	}
 	// Check if transport is available (has IN_WORK status)
 	if transport.Status != "IN_WORK" {
-		return fmt.Errorf("transport is not available (status: %s)", transport.Status)
+		return nil, domainerr.Validation(domainerr.CodeTransportNotAvailable, "transport is not available (status: %s)", transport.Status).
+			At("/transportId", "available").
+			With("status", transport.Status)
+	}
+	return transport, nil
+}
+
+// checkTransportConflicts rejects double-booked time windows and capacity overruns of the order's transport
+func (s *orderService) checkTransportConflicts(ctx context.Context, transport *models.Transport, order *models.Order) error {
+	// Finished orders no longer compete for the transport
+	if order.Status == string(models.OrderStatusCompleted) || order.Status == string(models.OrderStatusCanceled) {
+		return nil
+	}
+
+	var volumeL int
+	if order.ID != uuid.Nil {
+		var err error
+		volumeL, err = s.orderRepo.GetVolume(ctx, order.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get order volume: %w", err)
+		}
+	}
+	return checkTransportDay(ctx, s.orderRepo, transport, order, volumeL)
+}
+
+// checkTransportDay checks the order with the given volume against the other orders booked on the transport that day
+func checkTransportDay(
+	ctx context.Context, orderRepo port.OrderRepository, transport *models.Transport, order *models.Order, volumeL int,
+) error {
+	dayOrders, err := orderRepo.ListTransportDayOrders(ctx, transport.ID, order.ScheduledDate)
+	if err != nil {
+		return fmt.Errorf("failed to list transport orders: %w", err)
+	}
+
+	candidate := models.TransportDayOrder{
+		OrderID:    order.ID,
+		WindowFrom: order.ScheduledWindowFrom,
+		WindowTo:   order.ScheduledWindowTo,
+		VolumeL:    volumeL,
+	}
+
+	others := make([]models.TransportDayOrder, 0, len(dayOrders))
+	for _, dayOrder := range dayOrders {
+		if dayOrder.OrderID != order.ID {
+			others = append(others, dayOrder)
+		}
+	}
+
+	if conflict := models.CheckTransportDay(transport, order.ScheduledDate, candidate, others); conflict != nil {
+		return conflict
 	}
 	return nil
 }
 
+// checkOrderVersion rejects a write the client based on another version of the order than the stored one;
+// without a precondition the write applies to whatever version is current
+func checkOrderVersion(order *models.Order, ifMatch *models.VersionMatch) error {
+	if ifMatch.Matches(order.Version) {
+		return nil
+	}
+	return domainerr.PreconditionFailed(
+		domainerr.CodeOrderVersionMismatch, "order was modified: current version is %d", order.Version,
+	).With("version", order.Version)
+}
+
 // Update updates an existing order with validation
-func (s *orderService) Update(ctx context.Context, id uuid.UUID, req models.UpdateOrderRequest) (*models.OrderResponse, error) {
+func (s *orderService) Update(
+	ctx context.Context, id uuid.UUID, req models.UpdateOrderRequest, ifMatch *models.VersionMatch,
+) (*models.OrderResponse, error) {
 	// Get existing order
 	order, err := s.orderRepo.GetByID(ctx, id, false)
 	if err != nil {
@@ -203,7 +279,11 @@ func (s *orderService) Update(ctx context.Context, id uuid.UUID, req models.Upda
 	}
 
 	if order == nil {
-		return nil, fmt.Errorf("order not found")
+		return nil, domainerr.NotFound(domainerr.CodeOrderNotFound, "order not found")
+	}
+
+	if err := checkOrderVersion(order, ifMatch); err != nil {
+		return nil, err
 	}
 
 	// Validate updates
@@ -219,13 +299,31 @@ func (s *orderService) Update(ctx context.Context, id uuid.UUID, req models.Upda
 		return nil, err
 	}
 
-	if err := s.validateTransportUpdate(ctx, req.TransportID); err != nil {
+	transport, err := s.validateTransportUpdate(ctx, req.TransportID)
+	if err != nil {
 		return nil, err
 	}
 
 	// Update order from request
 	order.UpdateFromRequest(req)
 
+	// Rebooking the transport, date or time window must not double-book the transport
+	rebooked := req.TransportID != nil || req.ScheduledDate != nil ||
+		req.ScheduledWindowFrom != nil || req.ScheduledWindowTo != nil
+	if rebooked && order.TransportID != nil && !req.OverrideConflicts {
+		if transport == nil {
+			transport, err = s.transportRepo.GetByID(ctx, *order.TransportID, false)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get transport: %w", err)
+			}
+		}
+		if transport != nil {
+			if err := s.checkTransportConflicts(ctx, transport, order); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// Save to repository
 	err = s.orderRepo.Update(ctx, order)
 	if err != nil {
@@ -237,8 +335,10 @@ func (s *orderService) Update(ctx context.Context, id uuid.UUID, req models.Upda
 	return &response, nil
 }
 
-// UpdateStatus updates the order status with transition validation
-func (s *orderService) UpdateStatus(ctx context.Context, id uuid.UUID, req models.UpdateOrderStatusRequest) (*models.OrderResponse, error) {
+// UpdateStatus updates the order status with transition validation and records who changed it
+func (s *orderService) UpdateStatus(
+	ctx context.Context, id uuid.UUID, req models.UpdateOrderStatusRequest, changedBy *uuid.UUID, ifMatch *models.VersionMatch,
+) (*models.OrderResponse, error) {
 	// Get existing order
 	order, err := s.orderRepo.GetByID(ctx, id, false)
 	if err != nil {
@@ -246,20 +346,58 @@ func (s *orderService) UpdateStatus(ctx context.Context, id uuid.UUID, req model
 	}
 
 	if order == nil {
-		return nil, fmt.Errorf("order not found")
+		return nil, domainerr.NotFound(domainerr.CodeOrderNotFound, "order not found")
+	}
+
+	if err := checkOrderVersion(order, ifMatch); err != nil {
+		return nil, err
 	}
 
 	// Validate status transition
 	err = order.CanTransitionTo(req.Status)
 	if err != nil {
-		return nil, fmt.Errorf("invalid status transition: %w", err)
+		return nil, domainerr.InvalidTransition(domainerr.CodeOrderStatusTransition, "invalid status transition: %v", err)
 	}
 
-	// Update status
-	order.Status = string(req.Status)
+	// Cancellations must carry an active reason code from the taxonomy
+	if err := req.ValidateCancellation(); err != nil {
+		return nil, domainerr.Validation(domainerr.CodeValidationFailed, "validation failed").Wrap(err)
+	}
+	// Completions must record the collected volume and waste category
+	if err := req.ValidateCompletion(); err != nil {
+		return nil, domainerr.Validation(domainerr.CodeValidationFailed, "validation failed").Wrap(err)
+	}
+	if req.Status == models.OrderStatusCompleted {
+		order.Completion = req.Completion.ToCompletion(order.ID, changedBy)
+	}
+	if req.Status == models.OrderStatusCanceled {
+		if err := s.validateCancellationReason(ctx, *req.ReasonCode); err != nil {
+			return nil, err
+		}
+		code := strings.ToUpper(strings.TrimSpace(*req.ReasonCode))
+		order.CancellationReasonCode = &code
+		order.CancellationNote = req.Reason
+	}
 
-	// Save to repository
-	err = s.orderRepo.Update(ctx, order)
+	entry := &models.OrderStatusHistory{
+		OrderID:    order.ID,
+		FromStatus: models.OrderStatus(order.Status),
+		ToStatus:   req.Status,
+		ChangedBy:  changedBy,
+		Reason:     req.Reason,
+	}
+
+	// Completing an order moves the equipment listed in its items
+	var moves []models.EquipmentMove
+	if req.Status == models.OrderStatusCompleted {
+		moves, err = s.planEquipmentMoves(ctx, order)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Save status, history entry and equipment moves together
+	err = s.orderRepo.UpdateStatus(ctx, order, entry, moves)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update order status: %w", err)
 	}
@@ -269,8 +407,79 @@ func (s *orderService) UpdateStatus(ctx context.Context, id uuid.UUID, req model
 	return &response, nil
 }
 
+// planEquipmentMoves translates the order items into equipment placement changes
+func (s *orderService) planEquipmentMoves(ctx context.Context, order *models.Order) ([]models.EquipmentMove, error) {
+	items, err := s.orderRepo.ListItems(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list order items: %w", err)
+	}
+
+	moves, err := models.PlanEquipmentMoves(order, items)
+	if err != nil {
+		return nil, domainerr.Conflict(domainerr.CodeEquipmentPlacementConflict, "equipment placement conflict: %v", err)
+	}
+
+	return moves, nil
+}
+
+// validateCancellationReason checks that the reason code exists and is active
+func (s *orderService) validateCancellationReason(ctx context.Context, code string) error {
+	reason, err := s.reasonRepo.GetByCode(ctx, strings.ToUpper(strings.TrimSpace(code)))
+	if err != nil {
+		return fmt.Errorf("failed to get cancellation reason: %w", err)
+	}
+	if reason == nil || !reason.IsActive {
+		return domainerr.Validation(domainerr.CodeCancellationReasonNotFound, "validation failed").
+			Wrap(domainerr.InvalidField("/reasonCode", "exists", "unknown or inactive cancellation reason code"))
+	}
+	return nil
+}
+
+// GetCancellationStats returns monthly cancellation counts grouped by reason
+func (s *orderService) GetCancellationStats(
+	ctx context.Context, req models.CancellationStatsRequest,
+) (*models.CancellationStatsResponse, error) {
+	if !req.To.After(req.From) {
+		return nil, domainerr.Validation(domainerr.CodeInvalidDateRange, "validation failed: 'to' must not be before 'from'")
+	}
+
+	stats, err := s.orderRepo.CancellationStats(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cancellation stats: %w", err)
+	}
+
+	return &models.CancellationStatsResponse{
+		From:  req.From.Format(models.MonthLayout),
+		To:    req.To.AddDate(0, -1, 0).Format(models.MonthLayout),
+		Items: stats,
+	}, nil
+}
+
+// GetStatusHistory returns the status transitions of an order
+func (s *orderService) GetStatusHistory(ctx context.Context, id uuid.UUID) (*models.OrderStatusHistoryResponse, error) {
+	// History stays available for soft-deleted orders
+	order, err := s.orderRepo.GetByID(ctx, id, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	if order == nil {
+		return nil, domainerr.NotFound(domainerr.CodeOrderNotFound, "order not found")
+	}
+
+	entries, err := s.orderRepo.ListStatusHistory(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order status history: %w", err)
+	}
+
+	return &models.OrderStatusHistoryResponse{
+		OrderID: id,
+		Items:   entries,
+	}, nil
+}
+
 // Delete soft-deletes an order (only if status allows)
-func (s *orderService) Delete(ctx context.Context, id uuid.UUID) error {
+func (s *orderService) Delete(ctx context.Context, id uuid.UUID, ifMatch *models.VersionMatch) error {
 	// Get existing order
 	order, err := s.orderRepo.GetByID(ctx, id, false)
 	if err != nil {
@@ -278,17 +487,21 @@ func (s *orderService) Delete(ctx context.Context, id uuid.UUID) error {
 	}
 
 	if order == nil {
-		return fmt.Errorf("order not found")
+		return domainerr.NotFound(domainerr.CodeOrderNotFound, "order not found")
+	}
+
+	if err := checkOrderVersion(order, ifMatch); err != nil {
+		return err
 	}
 
 	// Check if order can be deleted
 	err = order.CanBeDeleted()
 	if err != nil {
-		return fmt.Errorf("order cannot be deleted: %w", err)
+		return domainerr.InvalidTransition(domainerr.CodeOrderNotDeletable, "order cannot be deleted: %v", err)
 	}
 
-	// Soft delete the order
-	err = s.orderRepo.SoftDelete(ctx, id)
+	// Soft delete the order unless it changed since it was checked
+	err = s.orderRepo.SoftDelete(ctx, id, order.Version)
 	if err != nil {
 		return fmt.Errorf("failed to delete order: %w", err)
 	}
@@ -305,11 +518,11 @@ func (s *orderService) Restore(ctx context.Context, id uuid.UUID) (*models.Order
 	}
 
 	if order == nil {
-		return nil, fmt.Errorf("order not found")
+		return nil, domainerr.NotFound(domainerr.CodeOrderNotFound, "order not found")
 	}
 
 	if order.DeletedAt == nil {
-		return nil, fmt.Errorf("order is not deleted")
+		return nil, domainerr.InvalidTransition(domainerr.CodeOrderNotDeleted, "order is not deleted")
 	}
 
 	// Restore the order
@@ -330,14 +543,19 @@ func (s *orderService) Restore(ctx context.Context, id uuid.UUID) (*models.Order
 }
 
 // AssignTransport assigns transport to an order
-func (s *orderService) AssignTransport(ctx context.Context, orderID uuid.UUID, req models.AssignTransportRequest) error {
+func (s *orderService) AssignTransport(
+	ctx context.Context, orderID uuid.UUID, req models.AssignTransportRequest, ifMatch *models.VersionMatch,
+) error {
 	// Check if order exists and is not deleted
 	order, err := s.orderRepo.GetByID(ctx, orderID, false)
 	if err != nil {
 		return fmt.Errorf("failed to get order: %w", err)
 	}
 	if order == nil {
-		return fmt.Errorf("order not found")
+		return domainerr.NotFound(domainerr.CodeOrderNotFound, "order not found")
+	}
+	if err := checkOrderVersion(order, ifMatch); err != nil {
+		return err
 	}
 
 	// Check if transport exists and is not deleted
@@ -346,12 +564,18 @@ func (s *orderService) AssignTransport(ctx context.Context, orderID uuid.UUID, r
 		return fmt.Errorf("failed to get transport: %w", err)
 	}
 	if transport == nil {
-		return fmt.Errorf("transport not found")
+		return domainerr.NotFound(domainerr.CodeTransportNotFound, "transport not found")
 	}
 
 	// Assign transport to order
 	order.AssignTransport(req.TransportID)
 
+	if !req.OverrideConflicts {
+		if err := s.checkTransportConflicts(ctx, transport, order); err != nil {
+			return err
+		}
+	}
+
 	// Save to repository
 	err = s.orderRepo.Update(ctx, order)
 	if err != nil {
@@ -0,0 +1,579 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"eco-van-api/internal/domainerr"
+	"eco-van-api/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockOrderRepository is a mock implementation of OrderRepository
+type MockOrderRepository struct {
+	mock.Mock
+}
+
+func (m *MockOrderRepository) Create(ctx context.Context, order *models.Order) error {
+	args := m.Called(ctx, order)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) GetByID(ctx context.Context, id uuid.UUID, includeDeleted bool) (*models.Order, error) {
+	args := m.Called(ctx, id, includeDeleted)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) Update(ctx context.Context, order *models.Order) error {
+	args := m.Called(ctx, order)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) UpdateStatus(
+	ctx context.Context, order *models.Order, entry *models.OrderStatusHistory, moves []models.EquipmentMove,
+) error {
+	args := m.Called(ctx, order, entry, moves)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) GetCompletion(ctx context.Context, orderID uuid.UUID) (*models.OrderCompletion, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.OrderCompletion), args.Error(1)
+}
+
+func (m *MockOrderRepository) ListTransportDayOrders(
+	ctx context.Context, transportID uuid.UUID, date time.Time,
+) ([]models.TransportDayOrder, error) {
+	args := m.Called(ctx, transportID, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TransportDayOrder), args.Error(1)
+}
+
+func (m *MockOrderRepository) GetVolume(ctx context.Context, orderID uuid.UUID) (int, error) {
+	args := m.Called(ctx, orderID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockOrderRepository) ListItems(ctx context.Context, orderID uuid.UUID) ([]models.OrderItem, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.OrderItem), args.Error(1)
+}
+
+func (m *MockOrderRepository) ReplaceItems(ctx context.Context, order *models.Order, items []models.OrderItem) error {
+	args := m.Called(ctx, order, items)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) ListStatusHistory(ctx context.Context, orderID uuid.UUID) ([]models.OrderStatusHistory, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.OrderStatusHistory), args.Error(1)
+}
+
+func (m *MockOrderRepository) SoftDelete(ctx context.Context, id uuid.UUID, version int) error {
+	args := m.Called(ctx, id, version)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) List(ctx context.Context, req models.OrderListRequest) (*models.OrderListResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.OrderListResponse), args.Error(1)
+}
+
+func (m *MockOrderRepository) ExistsByClientAndObject(
+	ctx context.Context, clientID, objectID uuid.UUID, excludeID *uuid.UUID,
+) (bool, error) {
+	args := m.Called(ctx, clientID, objectID, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockOrderRepository) HasActiveOrders(ctx context.Context, objectID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, objectID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockOrderRepository) GetActiveOrdersByObject(ctx context.Context, objectID uuid.UUID) ([]models.Order, error) {
+	args := m.Called(ctx, objectID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) CancellationStats(
+	ctx context.Context, req models.CancellationStatsRequest,
+) ([]models.CancellationStat, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CancellationStat), args.Error(1)
+}
+
+func newTestOrderService(orderRepo *MockOrderRepository) *orderService {
+	return newTestOrderServiceWithReasons(orderRepo, new(MockCancellationReasonRepository))
+}
+
+func newTestOrderServiceWithReasons(orderRepo *MockOrderRepository, reasonRepo *MockCancellationReasonRepository) *orderService {
+	return NewOrderService(orderRepo, new(MockClientRepository), new(MockClientObjectRepository),
+		new(MockTransportRepository), reasonRepo).(*orderService)
+}
+
+func newTestCompletion() *models.OrderCompletionRequest {
+	return &models.OrderCompletionRequest{VolumeL: 240, WasteCategory: models.WasteCategoryMixed}
+}
+
+func newTestOrder(status models.OrderStatus) *models.Order {
+	return &models.Order{
+		ID:            uuid.New(),
+		ClientID:      uuid.New(),
+		ObjectID:      uuid.New(),
+		ScheduledDate: time.Now(),
+		Status:        string(status),
+		Priority:      string(models.OrderPriorityMedium),
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+}
+
+func TestOrderService_UpdateStatus(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("records transition with actor and reason", func(t *testing.T) {
+		mockRepo := new(MockOrderRepository)
+		reasonRepo := new(MockCancellationReasonRepository)
+		svc := newTestOrderServiceWithReasons(mockRepo, reasonRepo)
+		order := newTestOrder(models.OrderStatusScheduled)
+		actorID := uuid.New()
+		reason := "client asked to postpone"
+		code := "client_request"
+
+		mockRepo.On("GetByID", ctx, order.ID, false).Return(order, nil)
+		reasonRepo.On("GetByCode", ctx, models.CancellationReasonClientRequest).
+			Return(&models.CancellationReason{Code: models.CancellationReasonClientRequest, IsActive: true}, nil)
+		mockRepo.On("UpdateStatus", ctx, order, mock.MatchedBy(func(entry *models.OrderStatusHistory) bool {
+			return entry.OrderID == order.ID &&
+				entry.FromStatus == models.OrderStatusScheduled &&
+				entry.ToStatus == models.OrderStatusCanceled &&
+				entry.ChangedBy != nil && *entry.ChangedBy == actorID &&
+				entry.Reason != nil && *entry.Reason == reason
+		}), []models.EquipmentMove(nil)).Run(func(args mock.Arguments) {
+			args.Get(1).(*models.Order).Status = string(models.OrderStatusCanceled)
+		}).Return(nil)
+
+		req := models.UpdateOrderStatusRequest{Status: models.OrderStatusCanceled, Reason: &reason, ReasonCode: &code}
+		result, err := svc.UpdateStatus(ctx, order.ID, req, &actorID, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, string(models.OrderStatusCanceled), result.Status)
+		require.NotNil(t, result.CancellationReasonCode)
+		assert.Equal(t, models.CancellationReasonClientRequest, *result.CancellationReasonCode)
+		assert.Equal(t, &reason, result.CancellationNote)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("cancellation without reason code is rejected", func(t *testing.T) {
+		mockRepo := new(MockOrderRepository)
+		svc := newTestOrderService(mockRepo)
+		order := newTestOrder(models.OrderStatusScheduled)
+		reason := "no longer needed"
+
+		mockRepo.On("GetByID", ctx, order.ID, false).Return(order, nil)
+
+		req := models.UpdateOrderStatusRequest{Status: models.OrderStatusCanceled, Reason: &reason}
+		result, err := svc.UpdateStatus(ctx, order.ID, req, nil, nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "validation failed")
+		mockRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("cancellation with inactive reason code is rejected", func(t *testing.T) {
+		mockRepo := new(MockOrderRepository)
+		reasonRepo := new(MockCancellationReasonRepository)
+		svc := newTestOrderServiceWithReasons(mockRepo, reasonRepo)
+		order := newTestOrder(models.OrderStatusDraft)
+		reason := "bad weather"
+		code := models.CancellationReasonWeather
+
+		mockRepo.On("GetByID", ctx, order.ID, false).Return(order, nil)
+		reasonRepo.On("GetByCode", ctx, code).Return(&models.CancellationReason{Code: code, IsActive: false}, nil)
+
+		req := models.UpdateOrderStatusRequest{Status: models.OrderStatusCanceled, Reason: &reason, ReasonCode: &code}
+		result, err := svc.UpdateStatus(ctx, order.ID, req, nil, nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "inactive cancellation reason")
+		mockRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid transition is not recorded", func(t *testing.T) {
+		mockRepo := new(MockOrderRepository)
+		svc := newTestOrderService(mockRepo)
+		order := newTestOrder(models.OrderStatusCompleted)
+
+		mockRepo.On("GetByID", ctx, order.ID, false).Return(order, nil)
+
+		req := models.UpdateOrderStatusRequest{Status: models.OrderStatusScheduled}
+		result, err := svc.UpdateStatus(ctx, order.ID, req, nil, nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "invalid status transition")
+		mockRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("completion moves item equipment", func(t *testing.T) {
+		mockRepo := new(MockOrderRepository)
+		svc := newTestOrderService(mockRepo)
+		order := newTestOrder(models.OrderStatusInProgress)
+		warehouseID := uuid.New()
+		items := []models.OrderItem{
+			{EquipmentID: uuid.New(), Operation: models.OrderItemDeliver},
+			{EquipmentID: uuid.New(), Operation: models.OrderItemPickup, WarehouseID: &warehouseID},
+		}
+
+		mockRepo.On("GetByID", ctx, order.ID, false).Return(order, nil)
+		mockRepo.On("ListItems", ctx, order.ID).Return(items, nil)
+		mockRepo.On("UpdateStatus", ctx, order, mock.Anything, mock.MatchedBy(func(moves []models.EquipmentMove) bool {
+			return len(moves) == 2 &&
+				moves[0].EquipmentID == items[1].EquipmentID && *moves[0].Target.WarehouseID == warehouseID &&
+				moves[1].EquipmentID == items[0].EquipmentID && *moves[1].Target.ClientObjectID == order.ObjectID
+		})).Return(nil)
+
+		req := models.UpdateOrderStatusRequest{Status: models.OrderStatusCompleted, Completion: newTestCompletion()}
+		_, err := svc.UpdateStatus(ctx, order.ID, req, nil, nil)
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("completion records collected volume", func(t *testing.T) {
+		mockRepo := new(MockOrderRepository)
+		svc := newTestOrderService(mockRepo)
+		order := newTestOrder(models.OrderStatusInProgress)
+		actorID := uuid.New()
+		weight := 412.5
+
+		mockRepo.On("GetByID", ctx, order.ID, false).Return(order, nil)
+		mockRepo.On("ListItems", ctx, order.ID).Return([]models.OrderItem{}, nil)
+		mockRepo.On("UpdateStatus", ctx, mock.MatchedBy(func(o *models.Order) bool {
+			return o.Completion != nil && o.Completion.OrderID == order.ID && o.Completion.VolumeL == 1100 &&
+				*o.Completion.WeightKg == weight && o.Completion.WasteCategory == models.WasteCategoryPaper &&
+				*o.Completion.CompletedBy == actorID
+		}), mock.Anything, mock.Anything).Return(nil)
+
+		completion := &models.OrderCompletionRequest{VolumeL: 1100, WeightKg: &weight, WasteCategory: models.WasteCategoryPaper}
+		req := models.UpdateOrderStatusRequest{Status: models.OrderStatusCompleted, Completion: completion}
+		result, err := svc.UpdateStatus(ctx, order.ID, req, &actorID, nil)
+
+		require.NoError(t, err)
+		require.NotNil(t, result.Completion)
+		assert.Equal(t, 1100, result.Completion.VolumeL)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("completion without completion record is rejected", func(t *testing.T) {
+		mockRepo := new(MockOrderRepository)
+		svc := newTestOrderService(mockRepo)
+		order := newTestOrder(models.OrderStatusInProgress)
+
+		mockRepo.On("GetByID", ctx, order.ID, false).Return(order, nil)
+
+		req := models.UpdateOrderStatusRequest{Status: models.OrderStatusCompleted}
+		result, err := svc.UpdateStatus(ctx, order.ID, req, nil, nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "validation failed")
+		mockRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("completion without pickup destination is rejected", func(t *testing.T) {
+		mockRepo := new(MockOrderRepository)
+		svc := newTestOrderService(mockRepo)
+		order := newTestOrder(models.OrderStatusInProgress)
+		items := []models.OrderItem{{EquipmentID: uuid.New(), Operation: models.OrderItemPickup}}
+
+		mockRepo.On("GetByID", ctx, order.ID, false).Return(order, nil)
+		mockRepo.On("ListItems", ctx, order.ID).Return(items, nil)
+
+		req := models.UpdateOrderStatusRequest{Status: models.OrderStatusCompleted, Completion: newTestCompletion()}
+		result, err := svc.UpdateStatus(ctx, order.ID, req, nil, nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "placement conflict")
+		mockRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("order not found", func(t *testing.T) {
+		mockRepo := new(MockOrderRepository)
+		svc := newTestOrderService(mockRepo)
+		orderID := uuid.New()
+
+		mockRepo.On("GetByID", ctx, orderID, false).Return(nil, nil)
+
+		req := models.UpdateOrderStatusRequest{Status: models.OrderStatusScheduled}
+		result, err := svc.UpdateStatus(ctx, orderID, req, nil, nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "not found")
+	})
+}
+
+func TestOrderService_AssignTransport(t *testing.T) {
+	ctx := context.Background()
+	from, to := "09:00", "11:00"
+
+	newFixture := func() (*orderService, *MockOrderRepository, *MockTransportRepository, *models.Order, *models.Transport) {
+		orderRepo := new(MockOrderRepository)
+		transportRepo := new(MockTransportRepository)
+		svc := NewOrderService(orderRepo, new(MockClientRepository), new(MockClientObjectRepository),
+			transportRepo, new(MockCancellationReasonRepository)).(*orderService)
+		order := newTestOrder(models.OrderStatusScheduled)
+		order.ScheduledWindowFrom, order.ScheduledWindowTo = &from, &to
+		transport := &models.Transport{ID: uuid.New(), CapacityL: 1000, Status: "IN_WORK"}
+
+		orderRepo.On("GetByID", ctx, order.ID, false).Return(order, nil)
+		transportRepo.On("GetByID", ctx, transport.ID, false).Return(transport, nil)
+		return svc, orderRepo, transportRepo, order, transport
+	}
+
+	t.Run("overlapping window is rejected with conflicting orders", func(t *testing.T) {
+		svc, orderRepo, _, order, transport := newFixture()
+		busyFrom, busyTo := "10:30", "12:00"
+		busy := models.TransportDayOrder{OrderID: uuid.New(), WindowFrom: &busyFrom, WindowTo: &busyTo}
+
+		orderRepo.On("ListTransportDayOrders", ctx, transport.ID, order.ScheduledDate).
+			Return([]models.TransportDayOrder{busy}, nil)
+		orderRepo.On("GetVolume", ctx, order.ID).Return(0, nil)
+
+		err := svc.AssignTransport(ctx, order.ID, models.AssignTransportRequest{TransportID: transport.ID}, nil)
+
+		var conflict *models.TransportConflictError
+		require.ErrorAs(t, err, &conflict)
+		assert.Equal(t, []uuid.UUID{busy.OrderID}, conflict.ConflictingOrderIDs())
+		orderRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("exceeded capacity is rejected", func(t *testing.T) {
+		svc, orderRepo, _, order, transport := newFixture()
+		other := models.TransportDayOrder{OrderID: uuid.New(), VolumeL: 800}
+
+		orderRepo.On("ListTransportDayOrders", ctx, transport.ID, order.ScheduledDate).
+			Return([]models.TransportDayOrder{other}, nil)
+		orderRepo.On("GetVolume", ctx, order.ID).Return(240, nil)
+
+		err := svc.AssignTransport(ctx, order.ID, models.AssignTransportRequest{TransportID: transport.ID}, nil)
+
+		var conflict *models.TransportConflictError
+		require.ErrorAs(t, err, &conflict)
+		assert.Equal(t, 1040, conflict.TotalVolumeL)
+		assert.Equal(t, []uuid.UUID{other.OrderID}, conflict.CapacityOrderIDs)
+	})
+
+	t.Run("override skips conflict checks", func(t *testing.T) {
+		svc, orderRepo, _, order, transport := newFixture()
+
+		orderRepo.On("Update", ctx, order).Return(nil)
+
+		req := models.AssignTransportRequest{TransportID: transport.ID, OverrideConflicts: true}
+		err := svc.AssignTransport(ctx, order.ID, req, nil)
+
+		require.NoError(t, err)
+		orderRepo.AssertNotCalled(t, "ListTransportDayOrders", mock.Anything, mock.Anything, mock.Anything)
+		orderRepo.AssertExpectations(t)
+	})
+}
+
+func TestOrderService_ExpectedVersion(t *testing.T) {
+	ctx := context.Background()
+	stale := &models.VersionMatch{Versions: []int{2}}
+
+	newFixture := func() (*orderService, *MockOrderRepository, *models.Order) {
+		orderRepo := new(MockOrderRepository)
+		svc := newTestOrderService(orderRepo)
+		order := newTestOrder(models.OrderStatusScheduled)
+		order.Version = 3
+
+		orderRepo.On("GetByID", ctx, order.ID, false).Return(order, nil)
+		return svc, orderRepo, order
+	}
+
+	assertVersionMismatch := func(t *testing.T, err error) {
+		t.Helper()
+		require.Error(t, err)
+		assert.True(t, domainerr.IsKind(err, domainerr.KindPreconditionFailed))
+		assert.True(t, domainerr.HasCode(err, domainerr.CodeOrderVersionMismatch))
+	}
+
+	t.Run("stale update is rejected", func(t *testing.T) {
+		svc, orderRepo, order := newFixture()
+		notes := "call before arrival"
+
+		result, err := svc.Update(ctx, order.ID, models.UpdateOrderRequest{Notes: &notes}, stale)
+
+		assertVersionMismatch(t, err)
+		assert.Nil(t, result)
+		orderRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("stale status change is rejected", func(t *testing.T) {
+		svc, orderRepo, order := newFixture()
+
+		req := models.UpdateOrderStatusRequest{Status: models.OrderStatusInProgress}
+		result, err := svc.UpdateStatus(ctx, order.ID, req, nil, stale)
+
+		assertVersionMismatch(t, err)
+		assert.Nil(t, result)
+		orderRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("stale delete is rejected", func(t *testing.T) {
+		svc, orderRepo, order := newFixture()
+
+		err := svc.Delete(ctx, order.ID, stale)
+
+		assertVersionMismatch(t, err)
+		orderRepo.AssertNotCalled(t, "SoftDelete", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("weak or unknown tags never match", func(t *testing.T) {
+		svc, _, order := newFixture()
+		notes := "call before arrival"
+
+		_, err := svc.Update(ctx, order.ID, models.UpdateOrderRequest{Notes: &notes}, &models.VersionMatch{})
+
+		assertVersionMismatch(t, err)
+	})
+
+	t.Run("current version is accepted", func(t *testing.T) {
+		svc, orderRepo, order := newFixture()
+		notes := "call before arrival"
+
+		orderRepo.On("Update", ctx, order).Return(nil)
+
+		ifMatch := &models.VersionMatch{Versions: []int{2, order.Version}}
+		result, err := svc.Update(ctx, order.ID, models.UpdateOrderRequest{Notes: &notes}, ifMatch)
+
+		require.NoError(t, err)
+		assert.Equal(t, &notes, result.Notes)
+		orderRepo.AssertExpectations(t)
+	})
+
+	t.Run("delete is guarded by the checked version", func(t *testing.T) {
+		svc, orderRepo, order := newFixture()
+		order.Status = string(models.OrderStatusDraft)
+		orderRepo.On("SoftDelete", ctx, order.ID, order.Version).
+			Return(domainerr.Conflict(domainerr.CodeOrderChangedConcurrently, "order was changed concurrently"))
+
+		err := svc.Delete(ctx, order.ID, nil)
+
+		assert.True(t, domainerr.HasCode(err, domainerr.CodeOrderChangedConcurrently))
+		orderRepo.AssertExpectations(t)
+	})
+}
+
+func TestOrderService_GetStatusHistory(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("returns history for soft-deleted order", func(t *testing.T) {
+		mockRepo := new(MockOrderRepository)
+		svc := newTestOrderService(mockRepo)
+		order := newTestOrder(models.OrderStatusCanceled)
+		deletedAt := time.Now()
+		order.DeletedAt = &deletedAt
+		entries := []models.OrderStatusHistory{
+			{ID: uuid.New(), OrderID: order.ID, FromStatus: models.OrderStatusDraft, ToStatus: models.OrderStatusCanceled},
+		}
+
+		mockRepo.On("GetByID", ctx, order.ID, true).Return(order, nil)
+		mockRepo.On("ListStatusHistory", ctx, order.ID).Return(entries, nil)
+
+		result, err := svc.GetStatusHistory(ctx, order.ID)
+
+		require.NoError(t, err)
+		assert.Equal(t, order.ID, result.OrderID)
+		assert.Len(t, result.Items, 1)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockRepo := new(MockOrderRepository)
+		svc := newTestOrderService(mockRepo)
+		order := newTestOrder(models.OrderStatusDraft)
+
+		mockRepo.On("GetByID", ctx, order.ID, true).Return(order, nil)
+		mockRepo.On("ListStatusHistory", ctx, order.ID).Return(nil, errors.New("db down"))
+
+		result, err := svc.GetStatusHistory(ctx, order.ID)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestOrderService_GetCancellationStats(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("returns stats for inclusive month range", func(t *testing.T) {
+		mockRepo := new(MockOrderRepository)
+		svc := newTestOrderService(mockRepo)
+		code := models.CancellationReasonNoAccess
+		req := models.CancellationStatsRequest{From: from, To: to}
+		stats := []models.CancellationStat{{Month: "2025-02", ReasonCode: &code, Count: 3}}
+
+		mockRepo.On("CancellationStats", ctx, req).Return(stats, nil)
+
+		result, err := svc.GetCancellationStats(ctx, req)
+
+		require.NoError(t, err)
+		assert.Equal(t, "2025-01", result.From)
+		assert.Equal(t, "2025-03", result.To)
+		assert.Equal(t, stats, result.Items)
+	})
+
+	t.Run("reversed range is rejected", func(t *testing.T) {
+		mockRepo := new(MockOrderRepository)
+		svc := newTestOrderService(mockRepo)
+
+		result, err := svc.GetCancellationStats(ctx, models.CancellationStatsRequest{From: to, To: from})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "validation failed")
+		mockRepo.AssertNotCalled(t, "CancellationStats", mock.Anything, mock.Anything)
+	})
+}
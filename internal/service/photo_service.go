@@ -0,0 +1,207 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"eco-van-api/internal/domainerr"
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/port"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// sniffLen is the number of bytes http.DetectContentType looks at
+	sniffLen = 512
+	// maxOriginalNameLen bounds the client-supplied filename kept in metadata
+	maxOriginalNameLen = 255
+)
+
+// photoService implements port.PhotoService
+type photoService struct {
+	photoRepo port.PhotoRepository
+	store     port.BlobStore
+}
+
+// NewPhotoService creates a new photo service keeping file content in store
+func NewPhotoService(photoRepo port.PhotoRepository, store port.BlobStore) port.PhotoService {
+	return &photoService{
+		photoRepo: photoRepo,
+		store:     store,
+	}
+}
+
+// Upload validates and stores a photo for the given entity
+func (s *photoService) Upload(
+	ctx context.Context, entityType models.PhotoEntityType, entityID uuid.UUID,
+	originalName string, content io.Reader,
+) (*models.PhotoResponse, error) {
+	if err := s.ensureEntity(ctx, entityType, entityID); err != nil {
+		return nil, err
+	}
+
+	// Detect the MIME type from the content rather than trusting the client
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(content, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read photo: %w", err)
+	}
+	if n == 0 {
+		return nil, domainerr.Validation(domainerr.CodePhotoEmpty, "validation failed: photo is empty")
+	}
+	head = head[:n]
+
+	mimeType := http.DetectContentType(head)
+	ext, ok := models.AllowedPhotoMimeTypes[mimeType]
+	if !ok {
+		return nil, domainerr.Validation(domainerr.CodePhotoUnsupportedType, "unsupported photo type: %s", mimeType).With("contentType", mimeType)
+	}
+
+	photo := &models.Photo{
+		ID:           uuid.New(),
+		EntityType:   entityType,
+		EntityID:     entityID,
+		OriginalName: sanitizeOriginalName(originalName, ext),
+		MimeType:     mimeType,
+		CreatedAt:    time.Now(),
+	}
+	photo.Filename = path.Join(string(entityType), entityID.String(), photo.ID.String()+ext)
+
+	size, err := s.store.Put(ctx, photo.Filename, io.MultiReader(bytes.NewReader(head), content), mimeType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store photo: %w", err)
+	}
+	photo.Size = size
+
+	if err := s.photoRepo.Create(ctx, photo); err != nil {
+		_ = s.store.Delete(ctx, photo.Filename)
+		return nil, fmt.Errorf("failed to create photo: %w", err)
+	}
+
+	response := photo.ToResponse()
+	return &response, nil
+}
+
+// List retrieves all photos attached to an entity
+func (s *photoService) List(
+	ctx context.Context, entityType models.PhotoEntityType, entityID uuid.UUID,
+) (*models.PhotoListResponse, error) {
+	if err := s.ensureEntity(ctx, entityType, entityID); err != nil {
+		return nil, err
+	}
+
+	photos, err := s.photoRepo.ListByEntity(ctx, entityType, entityID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list photos: %w", err)
+	}
+
+	items := make([]models.PhotoResponse, 0, len(photos))
+	for i := range photos {
+		items = append(items, photos[i].ToResponse())
+	}
+
+	return &models.PhotoListResponse{
+		Items: items,
+		Total: len(items),
+	}, nil
+}
+
+// Open returns photo metadata and its content; the caller must close the reader
+func (s *photoService) Open(
+	ctx context.Context, entityType models.PhotoEntityType, entityID, photoID uuid.UUID,
+) (*models.Photo, io.ReadCloser, error) {
+	photo, err := s.getPhoto(ctx, entityType, entityID, photoID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.store.Get(ctx, photo.Filename)
+	if err != nil {
+		if errors.Is(err, port.ErrBlobNotFound) {
+			return nil, nil, domainerr.NotFound(domainerr.CodePhotoNotFound, "photo file not found")
+		}
+		return nil, nil, fmt.Errorf("failed to open photo: %w", err)
+	}
+
+	return photo, content, nil
+}
+
+// Delete removes a photo and its stored file
+func (s *photoService) Delete(ctx context.Context, entityType models.PhotoEntityType, entityID, photoID uuid.UUID) error {
+	photo, err := s.getPhoto(ctx, entityType, entityID, photoID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.photoRepo.Delete(ctx, photo.ID); err != nil {
+		return fmt.Errorf("failed to delete photo: %w", err)
+	}
+
+	// Metadata is the source of truth; a missing blob is not an error
+	if err := s.store.Delete(ctx, photo.Filename); err != nil {
+		return fmt.Errorf("failed to remove photo file: %w", err)
+	}
+
+	return nil
+}
+
+// ensureEntity validates the entity type and checks that the entity exists
+func (s *photoService) ensureEntity(ctx context.Context, entityType models.PhotoEntityType, entityID uuid.UUID) error {
+	if !entityType.IsValid() {
+		return domainerr.InvalidInput(domainerr.CodePhotoInvalidEntity, "invalid entity type: %s", entityType).
+			With("entityType", entityType)
+	}
+
+	exists, err := s.photoRepo.EntityExists(ctx, entityType, entityID)
+	if err != nil {
+		return fmt.Errorf("failed to check entity existence: %w", err)
+	}
+	if !exists {
+		return domainerr.NotFound(domainerr.CodePhotoEntityNotFound, "entity not found")
+	}
+	return nil
+}
+
+// getPhoto loads photo metadata and checks that it belongs to the given entity
+func (s *photoService) getPhoto(
+	ctx context.Context, entityType models.PhotoEntityType, entityID, photoID uuid.UUID,
+) (*models.Photo, error) {
+	if !entityType.IsValid() {
+		return nil, domainerr.InvalidInput(domainerr.CodePhotoInvalidEntity, "invalid entity type: %s", entityType).
+			With("entityType", entityType)
+	}
+
+	photo, err := s.photoRepo.GetByID(ctx, photoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get photo: %w", err)
+	}
+
+	if photo == nil || photo.EntityType != entityType || photo.EntityID != entityID {
+		return nil, domainerr.NotFound(domainerr.CodePhotoNotFound, "photo not found")
+	}
+
+	return photo, nil
+}
+
+// sanitizeOriginalName strips any directory components from a client-supplied filename
+func sanitizeOriginalName(name, ext string) string {
+	name = strings.TrimSpace(path.Base(strings.ReplaceAll(name, "\\", "/")))
+	if name == "" || name == "." || name == "/" {
+		return "photo" + ext
+	}
+	// Trim whole runes so non-ASCII names stay valid UTF-8
+	for len(name) > maxOriginalNameLen {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	return name
+}
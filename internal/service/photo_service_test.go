@@ -0,0 +1,211 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/port"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockPhotoRepository is a mock implementation of PhotoRepository
+type MockPhotoRepository struct {
+	mock.Mock
+}
+
+func (m *MockPhotoRepository) Create(ctx context.Context, photo *models.Photo) error {
+	args := m.Called(ctx, photo)
+	return args.Error(0)
+}
+
+func (m *MockPhotoRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Photo, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Photo), args.Error(1)
+}
+
+func (m *MockPhotoRepository) ListByEntity(
+	ctx context.Context, entityType models.PhotoEntityType, entityID uuid.UUID,
+) ([]models.Photo, error) {
+	args := m.Called(ctx, entityType, entityID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Photo), args.Error(1)
+}
+
+func (m *MockPhotoRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockPhotoRepository) EntityExists(ctx context.Context, entityType models.PhotoEntityType, entityID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, entityType, entityID)
+	return args.Bool(0), args.Error(1)
+}
+
+// memoryBlobStore is an in-memory port.BlobStore for tests
+type memoryBlobStore struct {
+	blobs map[string][]byte
+}
+
+func newMemoryBlobStore() *memoryBlobStore {
+	return &memoryBlobStore{blobs: map[string][]byte{}}
+}
+
+func (s *memoryBlobStore) Put(_ context.Context, key string, content io.Reader, _ string) (int64, error) {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return 0, err
+	}
+	s.blobs[key] = data
+	return int64(len(data)), nil
+}
+
+func (s *memoryBlobStore) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	data, ok := s.blobs[key]
+	if !ok {
+		return nil, port.ErrBlobNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *memoryBlobStore) Delete(_ context.Context, key string) error {
+	delete(s.blobs, key)
+	return nil
+}
+
+// pngBytes is a minimal payload that http.DetectContentType recognises as image/png
+var pngBytes = append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 64)...)
+
+func TestPhotoService_Upload(t *testing.T) {
+	ctx := context.Background()
+	entityID := uuid.New()
+
+	t.Run("successful upload", func(t *testing.T) {
+		store := newMemoryBlobStore()
+		mockRepo := new(MockPhotoRepository)
+		svc := NewPhotoService(mockRepo, store)
+
+		mockRepo.On("EntityExists", ctx, models.PhotoEntityOrders, entityID).Return(true, nil)
+		mockRepo.On("Create", ctx, mock.AnythingOfType("*models.Photo")).Return(nil)
+
+		result, err := svc.Upload(ctx, models.PhotoEntityOrders, entityID, "../../proof.png", bytes.NewReader(pngBytes))
+
+		require.NoError(t, err)
+		assert.Equal(t, "image/png", result.MimeType)
+		assert.Equal(t, int64(len(pngBytes)), result.Size)
+		assert.Equal(t, "proof.png", result.OriginalName)
+
+		stored := mockRepo.Calls[1].Arguments.Get(1).(*models.Photo)
+		assert.Equal(t, pngBytes, store.blobs[stored.Filename])
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("unsupported content type", func(t *testing.T) {
+		store := newMemoryBlobStore()
+		mockRepo := new(MockPhotoRepository)
+		svc := NewPhotoService(mockRepo, store)
+
+		mockRepo.On("EntityExists", ctx, models.PhotoEntityOrders, entityID).Return(true, nil)
+
+		result, err := svc.Upload(ctx, models.PhotoEntityOrders, entityID, "fake.png", strings.NewReader("not an image"))
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "unsupported photo type")
+		assert.Empty(t, store.blobs)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("entity not found", func(t *testing.T) {
+		mockRepo := new(MockPhotoRepository)
+		svc := NewPhotoService(mockRepo, newMemoryBlobStore())
+
+		mockRepo.On("EntityExists", ctx, models.PhotoEntityDrivers, entityID).Return(false, nil)
+
+		result, err := svc.Upload(ctx, models.PhotoEntityDrivers, entityID, "a.png", bytes.NewReader(pngBytes))
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "not found")
+	})
+
+	t.Run("invalid entity type", func(t *testing.T) {
+		mockRepo := new(MockPhotoRepository)
+		svc := NewPhotoService(mockRepo, newMemoryBlobStore())
+
+		result, err := svc.Upload(ctx, models.PhotoEntityType("users"), entityID, "a.png", bytes.NewReader(pngBytes))
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "invalid entity type")
+		mockRepo.AssertNotCalled(t, "EntityExists", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestPhotoService_OpenAndDelete(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryBlobStore()
+	entityID := uuid.New()
+	photo := &models.Photo{
+		ID:           uuid.New(),
+		EntityType:   models.PhotoEntityEquipment,
+		EntityID:     entityID,
+		OriginalName: "bin.png",
+		MimeType:     "image/png",
+		Size:         int64(len(pngBytes)),
+		CreatedAt:    time.Now(),
+	}
+	photo.Filename = "equipment/" + entityID.String() + "/" + photo.ID.String() + ".png"
+	store.blobs[photo.Filename] = pngBytes
+
+	t.Run("open returns stored content", func(t *testing.T) {
+		mockRepo := new(MockPhotoRepository)
+		svc := NewPhotoService(mockRepo, store)
+		mockRepo.On("GetByID", ctx, photo.ID).Return(photo, nil)
+
+		meta, content, err := svc.Open(ctx, models.PhotoEntityEquipment, entityID, photo.ID)
+		require.NoError(t, err)
+		defer content.Close()
+
+		data, err := io.ReadAll(content)
+		require.NoError(t, err)
+		assert.Equal(t, pngBytes, data)
+		assert.Equal(t, "image/png", meta.MimeType)
+	})
+
+	t.Run("photo of another entity is not found", func(t *testing.T) {
+		mockRepo := new(MockPhotoRepository)
+		svc := NewPhotoService(mockRepo, store)
+		mockRepo.On("GetByID", ctx, photo.ID).Return(photo, nil)
+
+		_, _, err := svc.Open(ctx, models.PhotoEntityEquipment, uuid.New(), photo.ID)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "photo not found")
+	})
+
+	t.Run("delete removes metadata and file", func(t *testing.T) {
+		mockRepo := new(MockPhotoRepository)
+		svc := NewPhotoService(mockRepo, store)
+		mockRepo.On("GetByID", ctx, photo.ID).Return(photo, nil)
+		mockRepo.On("Delete", ctx, photo.ID).Return(nil)
+
+		err := svc.Delete(ctx, models.PhotoEntityEquipment, entityID, photo.ID)
+		require.NoError(t, err)
+
+		assert.NotContains(t, store.blobs, photo.Filename)
+		mockRepo.AssertExpectations(t)
+	})
+}
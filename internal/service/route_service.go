@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"eco-van-api/internal/domainerr"
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/port"
+
+	"github.com/google/uuid"
+)
+
+// routeService implements port.RouteService
+type routeService struct {
+	routeRepo     port.RouteRepository
+	transportRepo port.TransportRepository
+}
+
+// NewRouteService creates a new route planning service
+func NewRouteService(routeRepo port.RouteRepository, transportRepo port.TransportRepository) port.RouteService {
+	return &routeService{
+		routeRepo:     routeRepo,
+		transportRepo: transportRepo,
+	}
+}
+
+// Optimize produces an ordered stop sequence per vehicle for the scheduled orders of a date
+func (s *routeService) Optimize(ctx context.Context, req models.RoutePlanRequest) (*models.RoutePlan, error) {
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return nil, domainerr.Validation(domainerr.CodeValidationFailed, "validation failed").
+			Wrap(domainerr.InvalidField("/date", "datetime", "date must be in YYYY-MM-DD format"))
+	}
+
+	opts, err := routingOptions(req)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := s.routeRepo.ListPlannableOrders(ctx, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orders: %w", err)
+	}
+
+	vehicleIDs := make(map[uuid.UUID]bool, len(req.Vehicles))
+	for i, v := range req.Vehicles {
+		if vehicleIDs[v.TransportID] {
+			return nil, domainerr.Validation(domainerr.CodeValidationFailed, "validation failed").
+				Wrap(domainerr.InvalidField(vehicleField(i, "transportId"), "unique", "transport %s is listed more than once", v.TransportID)).
+				With("transportId", v.TransportID)
+		}
+		vehicleIDs[v.TransportID] = true
+	}
+
+	// Orders without coordinates or bound to another transport cannot be planned here
+	routable := make([]models.RoutingOrder, 0, len(orders))
+	unassigned := make([]models.UnroutedOrder, 0)
+	for _, order := range orders {
+		switch {
+		case order.GeoLat == nil || order.GeoLng == nil:
+			unassigned = append(unassigned, models.UnroutedOrder{
+				OrderID: order.OrderID, Reason: "client object has no coordinates",
+			})
+		case order.TransportID != nil && !vehicleIDs[*order.TransportID]:
+			unassigned = append(unassigned, models.UnroutedOrder{
+				OrderID: order.OrderID, Reason: "order is assigned to a transport that is not part of the plan",
+			})
+		default:
+			routable = append(routable, order)
+		}
+	}
+
+	vehicles, err := s.buildVehicles(ctx, req.Vehicles, routable)
+	if err != nil {
+		return nil, err
+	}
+
+	routes, unrouted := models.SolveRoutes(vehicles, routable, opts)
+
+	return &models.RoutePlan{
+		Date:       req.Date,
+		Routes:     routes,
+		Unassigned: append(unassigned, unrouted...),
+	}, nil
+}
+
+// routingOptions applies defaults to the optional tuning parameters
+func routingOptions(req models.RoutePlanRequest) (models.RoutingOptions, error) {
+	dayStart := models.DefaultRouteDayStart
+	if req.DayStart != nil {
+		dayStart = *req.DayStart
+	}
+	startMinutes, err := models.ParseClock(dayStart)
+	if err != nil {
+		return models.RoutingOptions{}, domainerr.Validation(domainerr.CodeValidationFailed, "validation failed").
+			Wrap(domainerr.InvalidField("/dayStart", "datetime", "dayStart: %v", err))
+	}
+
+	opts := models.RoutingOptions{
+		DayStartMinutes: startMinutes,
+		SpeedKmh:        models.DefaultRouteSpeedKmh,
+		ServiceMinutes:  models.DefaultRouteServiceMinutes,
+	}
+	if req.AverageSpeedKmh != nil {
+		opts.SpeedKmh = *req.AverageSpeedKmh
+	}
+	if req.ServiceMinutes != nil {
+		opts.ServiceMinutes = *req.ServiceMinutes
+	}
+	return opts, nil
+}
+
+// vehicleField returns the JSON pointer of a field of the i-th requested vehicle
+func vehicleField(i int, name string) string {
+	return fmt.Sprintf("/vehicles/%d/%s", i, name)
+}
+
+// buildVehicles loads the requested transport and resolves each vehicle's depot
+func (s *routeService) buildVehicles(
+	ctx context.Context, requested []models.RouteVehicleRequest, orders []models.RoutingOrder,
+) ([]models.RoutingVehicle, error) {
+	depots, err := s.routeRepo.ListDepots(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list depots: %w", err)
+	}
+	if len(depots) == 0 {
+		return nil, domainerr.Validation(domainerr.CodeRouteNoDepot, "validation failed: no warehouse with coordinates to use as a depot")
+	}
+	depotByID := make(map[uuid.UUID]*models.Warehouse, len(depots))
+	for i := range depots {
+		depotByID[depots[i].ID] = &depots[i]
+	}
+	defaultDepot := nearestDepot(depots, orders)
+
+	vehicles := make([]models.RoutingVehicle, 0, len(requested))
+	for i, v := range requested {
+		transport, err := s.transportRepo.GetByID(ctx, v.TransportID, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transport: %w", err)
+		}
+		if transport == nil {
+			return nil, domainerr.Validation(domainerr.CodeTransportNotFound, "validation failed").
+				Wrap(domainerr.InvalidField(vehicleField(i, "transportId"), "exists", "transport %s not found", v.TransportID)).
+				With("transportId", v.TransportID)
+		}
+		if transport.Status != "IN_WORK" {
+			return nil, domainerr.Validation(domainerr.CodeTransportNotAvailable, "validation failed").
+				Wrap(domainerr.InvalidField(vehicleField(i, "transportId"), "available",
+					"transport %s is not available (status: %s)", v.TransportID, transport.Status)).
+				With("transportId", v.TransportID).
+				With("status", transport.Status)
+		}
+
+		depot := defaultDepot
+		if v.DepotWarehouseID != nil {
+			depot = depotByID[*v.DepotWarehouseID]
+			if depot == nil {
+				return nil, domainerr.Validation(domainerr.CodeWarehouseNotFound, "validation failed").
+					Wrap(domainerr.InvalidField(vehicleField(i, "depotWarehouseId"), "exists",
+						"warehouse %s not found or has no coordinates", *v.DepotWarehouseID)).
+					With("warehouseId", *v.DepotWarehouseID)
+			}
+		}
+
+		vehicles = append(vehicles, models.RoutingVehicle{
+			TransportID: transport.ID,
+			DepotID:     depot.ID,
+			Depot:       models.GeoPoint{Lat: *depot.GeoLat, Lng: *depot.GeoLng},
+			CapacityL:   transport.CapacityL,
+		})
+	}
+
+	return vehicles, nil
+}
+
+// nearestDepot picks the depot closest to the centroid of the orders, or the first depot if there are none
+func nearestDepot(depots []models.Warehouse, orders []models.RoutingOrder) *models.Warehouse {
+	if len(orders) == 0 {
+		return &depots[0]
+	}
+
+	var centroid models.GeoPoint
+	for _, o := range orders {
+		centroid.Lat += *o.GeoLat
+		centroid.Lng += *o.GeoLng
+	}
+	centroid.Lat /= float64(len(orders))
+	centroid.Lng /= float64(len(orders))
+
+	best, bestDistance := &depots[0], math.Inf(1)
+	for i := range depots {
+		distance := models.HaversineKm(centroid, models.GeoPoint{Lat: *depots[i].GeoLat, Lng: *depots[i].GeoLng})
+		if distance < bestDistance {
+			best, bestDistance = &depots[i], distance
+		}
+	}
+	return best
+}
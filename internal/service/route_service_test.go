@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eco-van-api/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockRouteRepository is a mock implementation of port.RouteRepository
+type MockRouteRepository struct {
+	mock.Mock
+}
+
+func (m *MockRouteRepository) ListPlannableOrders(ctx context.Context, date time.Time) ([]models.RoutingOrder, error) {
+	args := m.Called(ctx, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.RoutingOrder), args.Error(1)
+}
+
+func (m *MockRouteRepository) ListDepots(ctx context.Context) ([]models.Warehouse, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Warehouse), args.Error(1)
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+func TestRouteService_Optimize(t *testing.T) {
+	ctx := context.Background()
+	date := time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC)
+	transport := &models.Transport{ID: uuid.New(), CapacityL: 5000, Status: "IN_WORK"}
+	nearDepot := models.Warehouse{ID: uuid.New(), GeoLat: floatPtr(55.0), GeoLng: floatPtr(37.0)}
+	farDepot := models.Warehouse{ID: uuid.New(), GeoLat: floatPtr(59.9), GeoLng: floatPtr(30.3)}
+
+	t.Run("plans routable orders from the nearest depot", func(t *testing.T) {
+		routeRepo := new(MockRouteRepository)
+		transportRepo := new(MockTransportRepository)
+		svc := NewRouteService(routeRepo, transportRepo)
+		routable := models.RoutingOrder{OrderID: uuid.New(), GeoLat: floatPtr(55.1), GeoLng: floatPtr(37.1), Priority: "MEDIUM"}
+		noCoordinates := models.RoutingOrder{OrderID: uuid.New(), Priority: "MEDIUM"}
+
+		routeRepo.On("ListPlannableOrders", ctx, date).Return([]models.RoutingOrder{routable, noCoordinates}, nil)
+		routeRepo.On("ListDepots", ctx).Return([]models.Warehouse{farDepot, nearDepot}, nil)
+		transportRepo.On("GetByID", ctx, transport.ID, false).Return(transport, nil)
+
+		req := models.RoutePlanRequest{Date: "2025-03-03", Vehicles: []models.RouteVehicleRequest{{TransportID: transport.ID}}}
+		plan, err := svc.Optimize(ctx, req)
+
+		require.NoError(t, err)
+		require.Len(t, plan.Routes, 1)
+		assert.Equal(t, nearDepot.ID, plan.Routes[0].DepotWarehouseID)
+		require.Len(t, plan.Routes[0].Stops, 1)
+		assert.Equal(t, routable.OrderID, plan.Routes[0].Stops[0].OrderID)
+		require.Len(t, plan.Unassigned, 1)
+		assert.Equal(t, noCoordinates.OrderID, plan.Unassigned[0].OrderID)
+	})
+
+	t.Run("unavailable transport is rejected", func(t *testing.T) {
+		routeRepo := new(MockRouteRepository)
+		transportRepo := new(MockTransportRepository)
+		svc := NewRouteService(routeRepo, transportRepo)
+		repair := &models.Transport{ID: uuid.New(), Status: string(models.TransportStatusRepair)}
+
+		routeRepo.On("ListPlannableOrders", ctx, date).Return([]models.RoutingOrder{}, nil)
+		routeRepo.On("ListDepots", ctx).Return([]models.Warehouse{nearDepot}, nil)
+		transportRepo.On("GetByID", ctx, repair.ID, false).Return(repair, nil)
+
+		req := models.RoutePlanRequest{Date: "2025-03-03", Vehicles: []models.RouteVehicleRequest{{TransportID: repair.ID}}}
+		_, err := svc.Optimize(ctx, req)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "validation failed")
+	})
+
+	t.Run("no depots", func(t *testing.T) {
+		routeRepo := new(MockRouteRepository)
+		svc := NewRouteService(routeRepo, new(MockTransportRepository))
+
+		routeRepo.On("ListPlannableOrders", ctx, date).Return([]models.RoutingOrder{}, nil)
+		routeRepo.On("ListDepots", ctx).Return([]models.Warehouse{}, nil)
+
+		req := models.RoutePlanRequest{Date: "2025-03-03", Vehicles: []models.RouteVehicleRequest{{TransportID: transport.ID}}}
+		_, err := svc.Optimize(ctx, req)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "no warehouse with coordinates")
+	})
+}
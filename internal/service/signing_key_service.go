@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"eco-van-api/internal/adapter/auth"
+	"eco-van-api/internal/models"
+	"eco-van-api/internal/port"
+)
+
+// SigningKeySettings configures JWT signing key rotation
+type SigningKeySettings struct {
+	Algorithm string
+	// RotationInterval is how long a key signs tokens before a successor takes over
+	RotationInterval time.Duration
+	// PublishAhead is how long a new key is published in the JWKS before it signs, so verifiers can fetch it
+	PublishAhead time.Duration
+	// VerifyFor is how long a replaced key keeps verifying tokens; it covers the longest token lifetime
+	VerifyFor time.Duration
+}
+
+// SigningKeyService rotates the JWT signing keys stored in the database and loads them into the key set
+type SigningKeyService struct {
+	signingKeyRepo port.SigningKeyRepository
+	keys           *auth.KeySet
+	settings       SigningKeySettings
+}
+
+// NewSigningKeyService creates a new signing key service
+func NewSigningKeyService(signingKeyRepo port.SigningKeyRepository, keys *auth.KeySet, settings SigningKeySettings) *SigningKeyService {
+	return &SigningKeyService{
+		signingKeyRepo: signingKeyRepo,
+		keys:           keys,
+		settings:       settings,
+	}
+}
+
+// Refresh creates a new key when the newest one is due for rotation and reloads the key set
+func (s *SigningKeyService) Refresh(ctx context.Context, now time.Time) error {
+	stored, err := s.signingKeyRepo.List(ctx, now)
+	if err != nil {
+		return fmt.Errorf("failed to list signing keys: %w", err)
+	}
+
+	if s.rotationDue(stored, now) {
+		if err := s.rotate(ctx, stored, now); err != nil {
+			return err
+		}
+		if stored, err = s.signingKeyRepo.List(ctx, now); err != nil {
+			return fmt.Errorf("failed to list signing keys: %w", err)
+		}
+	}
+
+	keys := make([]*auth.SigningKey, 0, len(stored))
+	for i := range stored {
+		key, err := auth.ParseSigningKey(stored[i].KID, stored[i].Algorithm, stored[i].PrivateKeyPEM, stored[i].ActivatesAt)
+		if err != nil {
+			return fmt.Errorf("failed to load signing key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	s.keys.Replace(keys)
+
+	return nil
+}
+
+// rotationDue reports whether the newest key is missing, uses another algorithm or has signed for a full interval
+func (s *SigningKeyService) rotationDue(stored []models.JWTSigningKey, now time.Time) bool {
+	if len(stored) == 0 {
+		return true
+	}
+	newest := stored[len(stored)-1]
+	return newest.Algorithm != s.settings.Algorithm || !now.Before(newest.ActivatesAt.Add(s.settings.RotationInterval))
+}
+
+// rotate creates the successor of the newest key; it activates right away only when no key can sign yet
+func (s *SigningKeyService) rotate(ctx context.Context, stored []models.JWTSigningKey, now time.Time) error {
+	activatesAt := now
+	previousKID := ""
+	if len(stored) > 0 {
+		previousKID = stored[len(stored)-1].KID
+		if !stored[0].ActivatesAt.After(now) {
+			activatesAt = now.Add(s.settings.PublishAhead)
+		}
+	}
+
+	key, err := auth.GenerateSigningKey(s.settings.Algorithm, activatesAt)
+	if err != nil {
+		return err
+	}
+	privateKeyPEM, err := key.MarshalPrivateKey()
+	if err != nil {
+		return err
+	}
+
+	record := models.JWTSigningKey{
+		KID:           key.KID,
+		Algorithm:     key.Algorithm,
+		PrivateKeyPEM: privateKeyPEM,
+		ActivatesAt:   activatesAt,
+	}
+	if _, err := s.signingKeyRepo.Rotate(ctx, &record, previousKID, activatesAt.Add(s.settings.VerifyFor)); err != nil {
+		return fmt.Errorf("failed to rotate signing key: %w", err)
+	}
+
+	return nil
+}
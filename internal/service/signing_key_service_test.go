@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eco-van-api/internal/adapter/auth"
+	"eco-van-api/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockSigningKeyRepository is a mock implementation of SigningKeyRepository
+type MockSigningKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockSigningKeyRepository) List(ctx context.Context, now time.Time) ([]models.JWTSigningKey, error) {
+	args := m.Called(ctx, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.JWTSigningKey), args.Error(1)
+}
+
+func (m *MockSigningKeyRepository) Rotate(
+	ctx context.Context, key *models.JWTSigningKey, previousKID string, retireAt time.Time,
+) (bool, error) {
+	args := m.Called(ctx, key, previousKID, retireAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func testSigningKeySettings() SigningKeySettings {
+	return SigningKeySettings{
+		Algorithm:        models.JWTAlgorithmEdDSA,
+		RotationInterval: 30 * 24 * time.Hour,
+		PublishAhead:     time.Hour,
+		VerifyFor:        30 * 24 * time.Hour,
+	}
+}
+
+// storedSigningKey generates a key pair in its persisted form
+func storedSigningKey(t *testing.T, algorithm string, activatesAt time.Time) models.JWTSigningKey {
+	t.Helper()
+	key, err := auth.GenerateSigningKey(algorithm, activatesAt)
+	require.NoError(t, err)
+	privateKeyPEM, err := key.MarshalPrivateKey()
+	require.NoError(t, err)
+	return models.JWTSigningKey{KID: key.KID, Algorithm: algorithm, PrivateKeyPEM: privateKeyPEM, ActivatesAt: activatesAt}
+}
+
+func TestSigningKeyService_Refresh(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	settings := testSigningKeySettings()
+
+	t.Run("first start creates a key that signs immediately", func(t *testing.T) {
+		// The key stored by the rotation is what the reload returns
+		stored := storedSigningKey(t, models.JWTAlgorithmEdDSA, now)
+		repo := new(MockSigningKeyRepository)
+		repo.On("List", ctx, now).Return([]models.JWTSigningKey{}, nil).Once()
+		repo.On("Rotate", ctx, mock.MatchedBy(func(key *models.JWTSigningKey) bool {
+			return key.Algorithm == models.JWTAlgorithmEdDSA && key.ActivatesAt.Equal(now)
+		}), "", now.Add(settings.VerifyFor)).Return(true, nil)
+		repo.On("List", ctx, now).Return([]models.JWTSigningKey{stored}, nil).Once()
+
+		keys := auth.NewKeySet()
+		require.NoError(t, NewSigningKeyService(repo, keys, settings).Refresh(ctx, now))
+
+		signing, err := keys.SigningKey(now)
+		require.NoError(t, err)
+		assert.Equal(t, stored.KID, signing.KID)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("current key is loaded without rotation", func(t *testing.T) {
+		current := storedSigningKey(t, models.JWTAlgorithmEdDSA, now.Add(-24*time.Hour))
+		repo := new(MockSigningKeyRepository)
+		repo.On("List", ctx, now).Return([]models.JWTSigningKey{current}, nil)
+
+		keys := auth.NewKeySet()
+		require.NoError(t, NewSigningKeyService(repo, keys, settings).Refresh(ctx, now))
+
+		_, ok := keys.VerificationKey(current.KID)
+		assert.True(t, ok)
+		repo.AssertNotCalled(t, "Rotate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("due key gets a successor published ahead of activation", func(t *testing.T) {
+		current := storedSigningKey(t, models.JWTAlgorithmEdDSA, now.Add(-settings.RotationInterval))
+		activatesAt := now.Add(settings.PublishAhead)
+		repo := new(MockSigningKeyRepository)
+		repo.On("List", ctx, now).Return([]models.JWTSigningKey{current}, nil)
+		repo.On("Rotate", ctx, mock.MatchedBy(func(key *models.JWTSigningKey) bool {
+			return key.ActivatesAt.Equal(activatesAt)
+		}), current.KID, activatesAt.Add(settings.VerifyFor)).Return(true, nil)
+
+		require.NoError(t, NewSigningKeyService(repo, auth.NewKeySet(), settings).Refresh(ctx, now))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("algorithm change rotates", func(t *testing.T) {
+		current := storedSigningKey(t, models.JWTAlgorithmRS256, now.Add(-time.Hour))
+		repo := new(MockSigningKeyRepository)
+		repo.On("List", ctx, now).Return([]models.JWTSigningKey{current}, nil)
+		repo.On("Rotate", ctx, mock.MatchedBy(func(key *models.JWTSigningKey) bool {
+			return key.Algorithm == models.JWTAlgorithmEdDSA
+		}), current.KID, mock.AnythingOfType("time.Time")).Return(false, nil)
+
+		require.NoError(t, NewSigningKeyService(repo, auth.NewKeySet(), settings).Refresh(ctx, now))
+		repo.AssertExpectations(t)
+	})
+}
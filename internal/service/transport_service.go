@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"eco-van-api/internal/domainerr"
 	"eco-van-api/internal/models"
 	"eco-van-api/internal/port"
 
@@ -41,7 +42,8 @@ func (s *TransportService) Create(ctx context.Context, req *models.CreateTranspo
 		return nil, fmt.Errorf("failed to check plate number uniqueness: %w", err)
 	}
 	if exists {
-		return nil, fmt.Errorf("transport with plate number %s already exists", req.PlateNo)
+		return nil, domainerr.Conflict(domainerr.CodeTransportPlateExists, "transport with plate number %s already exists", req.PlateNo).
+			With("plateNo", req.PlateNo)
 	}
 
 	// If driver ID is provided, validate it exists and is available
@@ -52,7 +54,7 @@ func (s *TransportService) Create(ctx context.Context, req *models.CreateTranspo
 			return nil, fmt.Errorf("failed to get driver: %w", err)
 		}
 		if driver == nil {
-			return nil, fmt.Errorf("driver not found")
+			return nil, domainerr.NotFound(domainerr.CodeDriverNotFound, "driver not found")
 		}
 
 		// Check if driver is already assigned to another transport
@@ -61,7 +63,7 @@ func (s *TransportService) Create(ctx context.Context, req *models.CreateTranspo
 			return nil, fmt.Errorf("failed to check driver assignment: %w", err)
 		}
 		if isAssigned {
-			return nil, fmt.Errorf("driver is already assigned to another transport")
+			return nil, domainerr.Conflict(domainerr.CodeDriverAlreadyAssigned, "driver is already assigned to another transport")
 		}
 	}
 
@@ -86,7 +88,7 @@ func (s *TransportService) GetByID(ctx context.Context, id uuid.UUID) (*models.T
 		return nil, fmt.Errorf("failed to get transport: %w", err)
 	}
 	if transport == nil {
-		return nil, fmt.Errorf("transport not found")
+		return nil, domainerr.NotFound(domainerr.CodeTransportNotFound, "transport not found")
 	}
 
 	response := transport.ToResponse()
@@ -101,7 +103,7 @@ func (s *TransportService) Update(ctx context.Context, id uuid.UUID, req models.
 		return nil, fmt.Errorf("failed to get transport: %w", err)
 	}
 	if transport == nil {
-		return nil, fmt.Errorf("transport not found")
+		return nil, domainerr.NotFound(domainerr.CodeTransportNotFound, "transport not found")
 	}
 
 	// Check plate number uniqueness if updating
@@ -111,7 +113,8 @@ func (s *TransportService) Update(ctx context.Context, id uuid.UUID, req models.
 			return nil, fmt.Errorf("failed to check plate number uniqueness: %w", err)
 		}
 		if exists {
-			return nil, fmt.Errorf("transport with plate number %s already exists", *req.PlateNo)
+			return nil, domainerr.Conflict(domainerr.CodeTransportPlateExists, "transport with plate number %s already exists", *req.PlateNo).
+				With("plateNo", *req.PlateNo)
 		}
 	}
 
@@ -128,7 +131,7 @@ func (s *TransportService) Update(ctx context.Context, id uuid.UUID, req models.
 				return nil, fmt.Errorf("failed to get driver: %w", err)
 			}
 			if driver == nil {
-				return nil, fmt.Errorf("driver not found")
+				return nil, domainerr.NotFound(domainerr.CodeDriverNotFound, "driver not found")
 			}
 
 			// Check if driver is already assigned to another transport
@@ -137,7 +140,7 @@ func (s *TransportService) Update(ctx context.Context, id uuid.UUID, req models.
 				return nil, fmt.Errorf("failed to check driver assignment: %w", err)
 			}
 			if isAssigned {
-				return nil, fmt.Errorf("driver is already assigned to another transport")
+				return nil, domainerr.Conflict(domainerr.CodeDriverAlreadyAssigned, "driver is already assigned to another transport")
 			}
 		}
 	}
@@ -164,7 +167,7 @@ func (s *TransportService) Delete(ctx context.Context, id uuid.UUID) error {
 		return fmt.Errorf("failed to check driver assignment: %w", err)
 	}
 	if hasDriver {
-		return fmt.Errorf("cannot delete transport: driver is currently assigned")
+		return domainerr.Conflict(domainerr.CodeTransportHasDriver, "cannot delete transport: driver is currently assigned")
 	}
 
 	// Check if transport has active equipment
@@ -173,7 +176,7 @@ func (s *TransportService) Delete(ctx context.Context, id uuid.UUID) error {
 		return fmt.Errorf("failed to check equipment assignment: %w", err)
 	}
 	if hasEquipment {
-		return fmt.Errorf("cannot delete transport: equipment is currently assigned")
+		return domainerr.Conflict(domainerr.CodeTransportHasEquipment, "cannot delete transport: equipment is currently assigned")
 	}
 
 	// Check if transport has active orders
@@ -182,7 +185,7 @@ func (s *TransportService) Delete(ctx context.Context, id uuid.UUID) error {
 		return fmt.Errorf("failed to check active orders: %w", err)
 	}
 	if hasOrders {
-		return fmt.Errorf("cannot delete transport: has active orders")
+		return domainerr.Conflict(domainerr.CodeTransportHasActiveOrders, "cannot delete transport: has active orders")
 	}
 
 	// Soft delete transport
@@ -237,7 +240,7 @@ func (s *TransportService) AssignDriver(ctx context.Context, transportID string,
 	// Parse transport ID
 	tID, err := uuid.Parse(transportID)
 	if err != nil {
-		return fmt.Errorf("invalid transport ID: %w", err)
+		return domainerr.InvalidInput(domainerr.CodeInvalidID, "invalid transport ID").Wrap(err)
 	}
 
 	// Check if transport exists and is not deleted
@@ -246,7 +249,7 @@ func (s *TransportService) AssignDriver(ctx context.Context, transportID string,
 		return fmt.Errorf("failed to get transport: %w", err)
 	}
 	if transport == nil {
-		return fmt.Errorf("transport not found")
+		return domainerr.NotFound(domainerr.CodeTransportNotFound, "transport not found")
 	}
 
 	// Check if driver exists and is not deleted
@@ -255,7 +258,7 @@ func (s *TransportService) AssignDriver(ctx context.Context, transportID string,
 		return fmt.Errorf("failed to get driver: %w", err)
 	}
 	if driver == nil {
-		return fmt.Errorf("driver not found")
+		return domainerr.NotFound(domainerr.CodeDriverNotFound, "driver not found")
 	}
 
 	// Check if driver is already assigned to another transport
@@ -264,7 +267,7 @@ func (s *TransportService) AssignDriver(ctx context.Context, transportID string,
 		return fmt.Errorf("failed to check driver assignment: %w", err)
 	}
 	if isAssigned {
-		return fmt.Errorf("driver is already assigned to another transport")
+		return domainerr.Conflict(domainerr.CodeDriverAlreadyAssigned, "driver is already assigned to another transport")
 	}
 
 	// Assign driver to transport
@@ -281,7 +284,7 @@ func (s *TransportService) AssignEquipment(ctx context.Context, transportID stri
 	// Parse transport ID
 	tID, err := uuid.Parse(transportID)
 	if err != nil {
-		return fmt.Errorf("invalid transport ID: %w", err)
+		return domainerr.InvalidInput(domainerr.CodeInvalidID, "invalid transport ID").Wrap(err)
 	}
 
 	// Check if transport exists and is not deleted
@@ -290,7 +293,7 @@ func (s *TransportService) AssignEquipment(ctx context.Context, transportID stri
 		return fmt.Errorf("failed to get transport: %w", err)
 	}
 	if transport == nil {
-		return fmt.Errorf("transport not found")
+		return domainerr.NotFound(domainerr.CodeTransportNotFound, "transport not found")
 	}
 
 	// Check if equipment exists and is not deleted
@@ -299,7 +302,7 @@ func (s *TransportService) AssignEquipment(ctx context.Context, transportID stri
 		return fmt.Errorf("failed to get equipment: %w", err)
 	}
 	if equipment == nil {
-		return fmt.Errorf("equipment not found")
+		return domainerr.NotFound(domainerr.CodeEquipmentNotFound, "equipment not found")
 	}
 
 	// Check if equipment is available for assignment (no client_object_id or warehouse_id)
@@ -308,7 +311,9 @@ func (s *TransportService) AssignEquipment(ctx context.Context, transportID stri
 		return fmt.Errorf("failed to check equipment availability: %w", err)
 	}
 	if !isAvailable {
-		return fmt.Errorf("equipment is not available for assignment (already placed at client object or warehouse)")
+		return domainerr.Conflict(
+			domainerr.CodeEquipmentNotAvailable, "equipment is not available for assignment (already placed at client object or warehouse)",
+		)
 	}
 
 	// Check if equipment is already assigned to another transport
@@ -317,7 +322,7 @@ func (s *TransportService) AssignEquipment(ctx context.Context, transportID stri
 		return fmt.Errorf("failed to check equipment assignment: %w", err)
 	}
 	if isAssigned {
-		return fmt.Errorf("equipment is already assigned to another transport")
+		return domainerr.Conflict(domainerr.CodeEquipmentAlreadyAssigned, "equipment is already assigned to another transport")
 	}
 
 	// Assign equipment to transport
@@ -334,7 +339,7 @@ func (s *TransportService) UnassignDriver(ctx context.Context, transportID strin
 	// Parse transport ID
 	tID, err := uuid.Parse(transportID)
 	if err != nil {
-		return fmt.Errorf("invalid transport ID: %w", err)
+		return domainerr.InvalidInput(domainerr.CodeInvalidID, "invalid transport ID").Wrap(err)
 	}
 
 	// Check if transport exists and is not deleted
@@ -343,12 +348,12 @@ func (s *TransportService) UnassignDriver(ctx context.Context, transportID strin
 		return fmt.Errorf("failed to get transport: %w", err)
 	}
 	if transport == nil {
-		return fmt.Errorf("transport not found")
+		return domainerr.NotFound(domainerr.CodeTransportNotFound, "transport not found")
 	}
 
 	// Check if transport has a driver assigned
 	if transport.CurrentDriverID == nil {
-		return fmt.Errorf("transport has no driver assigned")
+		return domainerr.Conflict(domainerr.CodeTransportHasNoDriver, "transport has no driver assigned")
 	}
 
 	// Unassign driver from transport
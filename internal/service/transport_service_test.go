@@ -102,6 +102,14 @@ func (m *MockTransportRepository) GetAvailable(ctx context.Context, req models.T
 	return args.Get(0).(*models.TransportListResponse), args.Error(1)
 }
 
+func (m *MockTransportRepository) GetByDriverID(ctx context.Context, driverID uuid.UUID) (*models.Transport, error) {
+	args := m.Called(ctx, driverID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Transport), args.Error(1)
+}
+
 func (m *MockTransportRepository) IsDriverAssignedToOtherTransport(ctx context.Context, driverID, excludeTransportID uuid.UUID) (
 	bool, error) {
 	args := m.Called(ctx, driverID, excludeTransportID)
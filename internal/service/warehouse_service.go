@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"eco-van-api/internal/domainerr"
 	"eco-van-api/internal/models"
 	"eco-van-api/internal/port"
 
@@ -31,7 +32,8 @@ func (s *warehouseService) Create(ctx context.Context, req models.CreateWarehous
 		return nil, fmt.Errorf("failed to check warehouse name: %w", err)
 	}
 	if exists {
-		return nil, fmt.Errorf("warehouse with name '%s' already exists", req.Name)
+		return nil, domainerr.Conflict(domainerr.CodeWarehouseNameExists, "warehouse with name '%s' already exists", req.Name).
+			With("name", req.Name)
 	}
 
 	// Create warehouse model
@@ -57,7 +59,7 @@ func (s *warehouseService) GetByID(ctx context.Context, id uuid.UUID) (*models.W
 		return nil, fmt.Errorf("failed to get warehouse: %w", err)
 	}
 	if warehouse == nil {
-		return nil, fmt.Errorf("warehouse not found")
+		return nil, domainerr.NotFound(domainerr.CodeWarehouseNotFound, "warehouse not found")
 	}
 
 	response := warehouse.ToResponse()
@@ -90,7 +92,7 @@ func (s *warehouseService) Update(ctx context.Context, id uuid.UUID, req models.
 		return nil, fmt.Errorf("failed to get warehouse: %w", err)
 	}
 	if warehouse == nil {
-		return nil, fmt.Errorf("warehouse not found")
+		return nil, domainerr.NotFound(domainerr.CodeWarehouseNotFound, "warehouse not found")
 	}
 
 	// Check if new name conflicts with existing warehouse
@@ -99,7 +101,8 @@ func (s *warehouseService) Update(ctx context.Context, id uuid.UUID, req models.
 		return nil, fmt.Errorf("failed to check warehouse name: %w", err)
 	}
 	if exists {
-		return nil, fmt.Errorf("warehouse with name '%s' already exists", req.Name)
+		return nil, domainerr.Conflict(domainerr.CodeWarehouseNameExists, "warehouse with name '%s' already exists", req.Name).
+			With("name", req.Name)
 	}
 
 	// Update warehouse
@@ -125,7 +128,7 @@ func (s *warehouseService) Delete(ctx context.Context, id uuid.UUID) error {
 		return fmt.Errorf("failed to get warehouse: %w", err)
 	}
 	if warehouse == nil {
-		return fmt.Errorf("warehouse not found")
+		return domainerr.NotFound(domainerr.CodeWarehouseNotFound, "warehouse not found")
 	}
 
 	// Check if warehouse has active equipment
@@ -134,7 +137,7 @@ func (s *warehouseService) Delete(ctx context.Context, id uuid.UUID) error {
 		return fmt.Errorf("failed to check warehouse equipment: %w", err)
 	}
 	if hasEquipment {
-		return fmt.Errorf("cannot delete warehouse: equipment is still present")
+		return domainerr.Conflict(domainerr.CodeWarehouseHasEquipment, "cannot delete warehouse: equipment is still present")
 	}
 
 	// Soft delete warehouse
@@ -153,12 +156,12 @@ func (s *warehouseService) Restore(ctx context.Context, id uuid.UUID) (*models.W
 		return nil, fmt.Errorf("failed to get warehouse: %w", err)
 	}
 	if warehouse == nil {
-		return nil, fmt.Errorf("warehouse not found")
+		return nil, domainerr.NotFound(domainerr.CodeWarehouseNotFound, "warehouse not found")
 	}
 
 	// Check if warehouse is already restored
 	if warehouse.DeletedAt == nil {
-		return nil, fmt.Errorf("warehouse is not deleted")
+		return nil, domainerr.InvalidTransition(domainerr.CodeWarehouseNotDeleted, "warehouse is not deleted")
 	}
 
 	// Check if restored name conflicts with existing warehouse
@@ -167,7 +170,9 @@ func (s *warehouseService) Restore(ctx context.Context, id uuid.UUID) (*models.W
 		return nil, fmt.Errorf("failed to check warehouse name: %w", err)
 	}
 	if exists {
-		return nil, fmt.Errorf("cannot restore warehouse: name '%s' conflicts with existing warehouse", warehouse.Name)
+		return nil, domainerr.Conflict(
+			domainerr.CodeWarehouseNameExists, "cannot restore warehouse: name '%s' conflicts with existing warehouse", warehouse.Name,
+		).With("name", warehouse.Name)
 	}
 
 	// Restore warehouse